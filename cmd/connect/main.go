@@ -5,14 +5,39 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/chat"
+	"github.com/melg8/connect/internal/connect/config"
 	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/console"
+	"github.com/melg8/connect/internal/connect/control"
+	"github.com/melg8/connect/internal/connect/dashboard"
+	"github.com/melg8/connect/internal/connect/dedup"
+	"github.com/melg8/connect/internal/connect/eyes"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/healer"
+	"github.com/melg8/connect/internal/connect/lease"
+	"github.com/melg8/connect/internal/connect/party"
+	"github.com/melg8/connect/internal/connect/picture"
 )
 
-func connectAndAuthenticate() error {
-	connector, err := connection.ServerConnector("127.0.0.1:2106")
+const dedupReportInterval = time.Minute
+
+func connectAndAuthenticate(address string) error {
+	connector, err := connection.ServerConnector(address)
 	if err != nil {
 		return fmt.Errorf("failed to create server connector: %w", err)
 	}
@@ -41,9 +66,556 @@ func Test() error {
 	return MyError("Retruning error without any packages used")
 }
 
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		return config.Default(), nil
+	}
+
+	return config.Load(path)
+}
+
+func showLeases(manager *lease.Manager) error {
+	holders, err := manager.Holders()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Leases held: %d\n", len(holders))
+	for _, holder := range holders {
+		log.Println(holder.String())
+	}
+
+	return nil
+}
+
+// acquireLeases makes sure no other instance runs same config and leases all
+// accounts of config. Accounts held by other instances are skipped.
+func acquireLeases(
+	manager *lease.Manager,
+	cfg *config.Config,
+) ([]config.Account, error) {
+	if _, err := manager.AcquireInstance(cfg.Name); err != nil {
+		return nil, fmt.Errorf("config %s is already running: %w", cfg.Name, err)
+	}
+
+	accounts := make([]config.Account, 0, len(cfg.Accounts))
+	for _, account := range cfg.Accounts {
+		_, err := manager.AcquireAccount(account.Login)
+		if errors.Is(err, lease.ErrHeld) {
+			log.Printf("Skipping account %s: %v\n", account.Login, err)
+
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+func reconnectPolicy(reconnect config.Reconnect) bot.ReconnectPolicy {
+	if reconnect.Disabled {
+		return bot.NoReconnect()
+	}
+
+	return bot.ReconnectPolicy{
+		Enabled:     true,
+		MinDelay:    reconnect.MinDelay.Duration,
+		MaxDelay:    reconnect.MaxDelay.Duration,
+		MaxAttempts: reconnect.MaxAttempts,
+	}
+}
+
+// loginRoster orders bots of leased accounts. Dependencies on accounts leased
+// by other instances are dropped.
+func loginRoster(accounts []config.Account) []bot.LoginEntry {
+	leased := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		leased[account.Login] = true
+	}
+
+	roster := make([]bot.LoginEntry, 0, len(accounts))
+	for _, account := range accounts {
+		after := make([]string, 0, len(account.After))
+		for _, login := range account.After {
+			if leased[login] {
+				after = append(after, login)
+			}
+		}
+		roster = append(roster, bot.LoginEntry{
+			Name:     account.Login,
+			Priority: account.Priority,
+			After:    after,
+		})
+	}
+
+	return roster
+}
+
+// sharing connects world models of bots, so objects seen by many bots are
+// decoded once. Eyes and dedup are alternative ways to do it. Board always
+// shares states of bots.
+type sharing struct {
+	coordinator *eyes.Coordinator
+	stage       *dedup.Stage
+	states      *board.Board
+}
+
+func newSharing(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	cfg *config.Config,
+) *sharing {
+	result := &sharing{coordinator: nil, stage: nil, states: board.New()}
+	if cfg.Eyes.Enabled {
+		result.coordinator = eyes.NewCoordinator(cfg.Eyes.Radius)
+		spawn(wg, func() {
+			result.coordinator.Run(ctx, eyes.DefaultInterval)
+		})
+	}
+	if cfg.Dedup.Enabled {
+		result.stage = dedup.New(cfg.Dedup.Window.Duration)
+		spawn(wg, func() { result.reportDedup(ctx) })
+	}
+
+	return result
+}
+
+// join connects bot to shared observation. Objects which bot doesn't decode
+// itself are seen through view of agent.
+func (s *sharing) join(b *bot.Bot, a *agent.Agent) {
+	if s.coordinator != nil {
+		member := s.coordinator.Join(b.Name(), a.World)
+		a.View = member
+		a.Dispatcher.AddFilter(member.Keep)
+		b.OnStateChange(func(status bot.Status) {
+			member.SetOnline(status.State == bot.InWorld)
+		})
+	}
+	if s.stage != nil {
+		member := s.stage.Join(a.World)
+		a.View = member
+		a.Dispatcher.AddFilter(member.Keep)
+		b.OnStateChange(func(status bot.Status) {
+			if status.State != bot.InWorld {
+				member.Leave()
+			}
+		})
+	}
+
+	state := s.states.Join(b.Name(), a.View, a.Combat)
+	state.Register(a.Dispatcher)
+	b.OnStateChange(func(status bot.Status) {
+		state.SetOnline(status.State == bot.InWorld)
+	})
+}
+
+// reportDedup logs counters of dedup stage periodically and on finish.
+func (s *sharing) reportDedup(ctx context.Context) {
+	ticker := time.NewTicker(dedupReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Dedup: %s\n", s.stage.Stats())
+
+			return
+		case <-ticker.C:
+			log.Printf("Dedup: %s\n", s.stage.Stats())
+		}
+	}
+}
+
+// newOrganizer forms parties of config, logins of config are turned into
+// character names used in game. It returns nil when config has no parties.
+func newOrganizer(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	cfg *config.Config,
+) *party.Organizer {
+	if len(cfg.Parties) == 0 {
+		return nil
+	}
+
+	characters := make(map[string]string, len(cfg.Accounts))
+	for _, account := range cfg.Accounts {
+		characters[account.Login] = account.Character
+	}
+	compositions := make([]party.Composition, 0, len(cfg.Parties))
+	for _, group := range cfg.Parties {
+		members := make([]string, 0, len(group.Members))
+		for _, login := range group.Members {
+			members = append(members, characters[login])
+		}
+		compositions = append(compositions, party.Composition{
+			Leader:  characters[group.Leader],
+			Members: members,
+			Loot:    group.LootMode(),
+		})
+	}
+
+	organizer := party.NewOrganizer(compositions)
+	spawn(wg, func() { organizer.Run(ctx, party.DefaultInterval) })
+
+	return organizer
+}
+
+// openGeodata opens geodata of directory and loads all its regions, empty
+// directory means no geodata at all.
+func openGeodata(path string) (*geodata.Geodata, error) {
+	geo := geodata.Open(path)
+	if path == "" {
+		return geo, nil
+	}
+
+	regions, err := geo.Preload()
+	if err != nil {
+		closeGeodata(geo)
+
+		return nil, err
+	}
+	log.Printf("Loaded %d geodata regions\n", regions)
+
+	return geo, nil
+}
+
+func closeGeodata(geo *geodata.Geodata) {
+	if err := geo.Close(); err != nil {
+		log.Printf("Error closing geodata: %v\n", err)
+	}
+}
+
+// newAgent creates agent which logs in with account.
+func newAgent(account config.Account, geo *geodata.Geodata) *agent.Agent {
+	a := agent.New(account.Login, geo)
+	a.SetCredentials(connection.Credentials{
+		Login:     account.Login,
+		Password:  account.Password,
+		Character: account.Character,
+	})
+
+	return a
+}
+
+// runBots runs bot for every account until context is done. Goroutines of
+// bots are done before it returns.
+func runBots(
+	ctx context.Context,
+	cfg *config.Config,
+	accounts []config.Account,
+	withConsole bool,
+) error {
+	connector, err := connection.ServerConnector(cfg.Server)
+	if err != nil {
+		return fmt.Errorf("failed to create server connector: %w", err)
+	}
+
+	geo, err := openGeodata(cfg.Geodata)
+	if err != nil {
+		return err
+	}
+	defer closeGeodata(geo)
+
+	// Goroutines are done before geodata they use is closed.
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	shared := newSharing(ctx, &wg, cfg)
+	organizer := newOrganizer(ctx, &wg, cfg)
+	policy := reconnectPolicy(cfg.Reconnect)
+	supervisor := bot.NewSupervisor()
+	agents := make(map[string]*agent.Agent, len(accounts))
+	for _, account := range accounts {
+		a := newAgent(account, geo)
+		agents[account.Login] = a
+		spawn(&wg, func() {
+			a.Run(ctx)
+			// Commands to agent get same context, following stops with it.
+			a.Wait()
+		})
+
+		b := bot.New(account.Login, connector, a.NewSession)
+		b.SetReconnectPolicy(policy)
+		a.Track(b)
+		shared.join(b, a)
+		joinOrganizer(organizer, account, a, b)
+		startRoles(ctx, &wg, cfg, account, a, shared.states)
+		if err := supervisor.Add(b); err != nil {
+			return err
+		}
+	}
+
+	scheduler, err := bot.NewLoginScheduler(
+		loginRoster(accounts), bot.DefaultLoginSettle)
+	if err != nil {
+		return fmt.Errorf("invalid login order: %w", err)
+	}
+	scheduler.Attach(supervisor)
+	log.Printf("Login order: %v\n", scheduler.Order())
+	controller := control.New(supervisor, agents, shared.states, cfg)
+	startControl(ctx, &wg, cfg, controller, withConsole)
+	runPictures(ctx, &wg, cfg, agents)
+
+	if err := supervisor.StartAll(ctx); err != nil {
+		return err
+	}
+
+	return supervisor.Wait(ctx)
+}
+
+// joinOrganizer hands party of agent to organizer, character of account
+// is invited while bot is in world.
+func joinOrganizer(
+	organizer *party.Organizer,
+	account config.Account,
+	a *agent.Agent,
+	b *bot.Bot,
+) {
+	if organizer == nil || account.Character == "" {
+		return
+	}
+
+	character := account.Character
+	organizer.Join(character, a.Party)
+	b.OnStateChange(func(status bot.Status) {
+		organizer.SetOnline(character, status.State == bot.InWorld)
+	})
+}
+
+// startRoles starts background roles of bot: following commands of
+// commanders and looking after team as healer.
+func startRoles(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	cfg *config.Config,
+	account config.Account,
+	a *agent.Agent,
+	states *board.Board,
+) {
+	if len(cfg.Commanders) > 0 {
+		spawn(wg, func() { runCommander(ctx, cfg.Commanders, a) })
+	}
+	if account.Healer != nil {
+		support := healer.New(account.Login, states, a.Combat,
+			healerSettings(*account.Healer))
+		support.SetClock(a.World.Now)
+		spawn(wg, func() { support.Run(ctx, healer.DefaultInterval) })
+	}
+}
+
+// healerSettings turns healer config into settings, zero thresholds are
+// replaced by defaults.
+func healerSettings(cfg config.Healer) healer.Settings {
+	settings := healer.Settings{
+		Heals:     make([]healer.Heal, 0, len(cfg.Heals)),
+		Buffs:     make([]healer.Buff, 0, len(cfg.Buffs)),
+		Threshold: cfg.Threshold,
+		Emergency: cfg.Emergency,
+		Tanks:     cfg.Tanks,
+		Team:      cfg.Team,
+	}
+	for _, heal := range cfg.Heals {
+		settings.Heals = append(settings.Heals, healer.Heal{
+			SkillID:  heal.SkillID,
+			Power:    heal.Power,
+			MP:       heal.MP,
+			CastTime: heal.CastTime.Duration,
+		})
+	}
+	for _, buff := range cfg.Buffs {
+		settings.Buffs = append(settings.Buffs, healer.Buff{
+			SkillID: buff.SkillID,
+			MP:      buff.MP,
+			Refresh: buff.Refresh.Duration,
+		})
+	}
+	if settings.Threshold == 0 {
+		settings.Threshold = healer.DefaultThreshold
+	}
+	if settings.Emergency == 0 {
+		settings.Emergency = healer.DefaultEmergency
+	}
+
+	return settings
+}
+
+// runCommander runs commands whispered to bot by commanders until context
+// is done.
+func runCommander(ctx context.Context, commanders []string, a *agent.Agent) {
+	commander := chat.NewCommander(a.Chat, commanders)
+	commander.Run(ctx, func(command chat.Command) error {
+		log.Printf("Command %v from %s to %s\n",
+			command.Kind, command.From, a.Name)
+
+		return a.Execute(ctx, command)
+	})
+}
+
+// startControl runs scenarios of config through control API, serves web
+// dashboard when it is enabled and reads commands from terminal when
+// console is on.
+func startControl(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	cfg *config.Config,
+	controller *control.Controller,
+	withConsole bool,
+) {
+	controller.RunScenarios(ctx)
+	// Console may run scenarios until shutdown, so they are waited for
+	// after it.
+	spawn(wg, func() {
+		<-ctx.Done()
+		controller.Wait()
+	})
+	if cfg.Dashboard.Enabled {
+		spawn(wg, func() {
+			runDashboard(ctx, cfg.Dashboard.Address, controller)
+		})
+	}
+	if !withConsole {
+		return
+	}
+
+	spawn(wg, func() {
+		if err := console.New(controller, os.Stdout).Run(
+			ctx, os.Stdin); err != nil {
+			log.Printf("Error reading console: %v\n", err)
+
+			return
+		}
+		if ctx.Err() == nil {
+			log.Println("Console closed, bots keep running")
+		}
+	})
+}
+
+// runDashboard serves web dashboard until context is done.
+func runDashboard(
+	ctx context.Context,
+	address string,
+	controller *control.Controller,
+) {
+	if err := dashboard.New(controller).Run(ctx, address); err != nil {
+		log.Printf("Error serving dashboard: %v\n", err)
+	}
+}
+
+// runPictures paints pictures of config by gold drops. Artists leased by
+// other instances are left out, pictures without artists are skipped.
+func runPictures(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	cfg *config.Config,
+	agents map[string]*agent.Agent,
+) {
+	for _, settings := range cfg.Pictures {
+		painter, err := picture.FromConfig(settings, agents)
+		if err != nil {
+			log.Printf("Skipping picture %s: %v\n", settings.Name, err)
+
+			continue
+		}
+		spawn(wg, func() {
+			if err := painter.Run(ctx); err != nil {
+				log.Printf("Picture %s stopped: %v\n", settings.Name, err)
+
+				return
+			}
+			log.Printf("Picture %s finished\n", settings.Name)
+		})
+	}
+}
+
+// spawn runs task in goroutine which wait group waits for.
+func spawn(wg *sync.WaitGroup, task func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		task()
+	}()
+}
+
+// handleSignals cancels context on first interrupt, so bots log out and
+// finish cleanly. Second interrupt quits at once.
+func handleSignals(ctx context.Context, cancel context.CancelFunc) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-signals:
+	}
+	log.Println("Shutting down, repeat signal to quit without logout")
+	cancel()
+
+	<-signals
+	log.Println("Forced quit")
+	os.Exit(1)
+}
+
+func run(configPath string, listLeases, withConsole bool) error {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	manager, err := lease.NewManager(cfg.LockDir, cfg.Name, lease.DefaultTTL)
+	if err != nil {
+		return err
+	}
+	if listLeases {
+		return showLeases(manager)
+	}
+
+	defer func() {
+		if err := manager.ReleaseAll(); err != nil {
+			log.Printf("Error releasing leases: %v\n", err)
+		}
+	}()
+
+	accounts, err := acquireLeases(manager, cfg)
+	if err != nil {
+		return err
+	}
+	log.Printf("Leased %d of %d accounts\n", len(accounts), len(cfg.Accounts))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	spawn(&wg, func() { manager.KeepAlive(ctx) })
+	// Signals are handled until process quits, nobody waits for it.
+	go handleSignals(ctx, cancel)
+
+	if len(accounts) == 0 {
+		log.Println("No accounts to run, checking auth server only")
+
+		return connectAndAuthenticate(cfg.Server)
+	}
+
+	return runBots(ctx, cfg, accounts, withConsole)
+}
+
 func main() {
+	configPath := flag.String("config", "", "path to json config file")
+	listLeases := flag.Bool("leases", false, "show leased accounts and exit")
+	withConsole := flag.Bool("console", false, "read commands from terminal")
+	flag.Parse()
+
 	log.Println("Starting connect bot...")
-	if err := connectAndAuthenticate(); err != nil {
-		log.Fatal(err)
+	// Deferred cleanup of run must finish before exit, so no log.Fatal here.
+	if err := run(*configPath, *listLeases, *withConsole); err != nil {
+		log.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
+	log.Println("Stopped")
 }
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/chat"
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/dialog"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/inventory"
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/melg8/connect/internal/connect/party"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Agent is everything single bot knows and can do in game world. It lives
+// longer than sessions of bot, so subsystems keep working after reconnect.
+type Agent struct {
+	Name  string
+	World *world.World
+	// View is world seen by bot. It is World unless bot shares observation
+	// with other bots, then objects decoded by them are seen through it.
+	// View is replaced only before bot starts.
+	View       world.View
+	Dispatcher *dispatch.Dispatcher
+	Link       *connection.GameLink
+	Mover      *movement.Mover
+	Party      *party.Party
+	Combat     *combat.Combat
+	Inventory  *inventory.Inventory
+	Chat       *chat.Chat
+	Dialog     *dialog.Window
+	// Blackboard is memory of behavior tree of bot.
+	Blackboard *behavior.Blackboard
+	// Geodata is shared by all agents of process.
+	Geodata *geodata.Geodata
+
+	mutex       sync.Mutex
+	credentials connection.Credentials
+	recorder    Recorder
+	task        string
+	// stopFollow cancels following of character, it is nil when bot
+	// doesn't follow anyone.
+	stopFollow context.CancelFunc
+	// following are goroutines of following which were started.
+	following sync.WaitGroup
+}
+
+func New(name string, geo *geodata.Geodata) *Agent {
+	model := world.New()
+	dispatcher := dispatch.NewDispatcher()
+	link := connection.NewGameLink()
+	mover := movement.New(model, link)
+	group := party.New(link)
+	fight := combat.New(model, link)
+	bag := inventory.New(link)
+	talk := chat.New(model, link)
+	window := dialog.New(link)
+
+	// World goes first, other handlers rely on updated world model.
+	model.Register(dispatcher)
+	mover.Register(dispatcher)
+	group.Register(dispatcher)
+	fight.Register(dispatcher)
+	bag.Register(dispatcher)
+	talk.Register(dispatcher)
+	window.Register(dispatcher)
+
+	return &Agent{
+		Name:       name,
+		World:      model,
+		View:       model,
+		Dispatcher: dispatcher,
+		Link:       link,
+		Mover:      mover,
+		Party:      group,
+		Combat:     fight,
+		Inventory:  bag,
+		Chat:       talk,
+		Dialog:     window,
+		Blackboard: behavior.NewBlackboard(),
+		Geodata:    geo,
+		mutex:      sync.Mutex{},
+		credentials: connection.Credentials{
+			Login:     name,
+			Password:  "",
+			Character: "",
+		},
+		recorder:   nil,
+		task:       "",
+		stopFollow: nil,
+		following:  sync.WaitGroup{},
+	}
+}
+
+// NewSession is bot.SessionFactory of agent. World, party, combat, inventory
+// and dialog state are cleared for every new session, server sends them
+// again after entering world. State of previous session is recorded into
+// snapshot of tracking bot first.
+func (a *Agent) NewSession() bot.Session {
+	a.record()
+	a.World.Clear()
+	a.Party.Clear()
+	a.Combat.Clear()
+	a.Inventory.Clear()
+	a.Dialog.Clear()
+
+	a.mutex.Lock()
+	credentials := a.credentials
+	a.mutex.Unlock()
+
+	return &session{
+		GameSession: connection.NewGameSession(a.Dispatcher, a.Link,
+			credentials),
+		agent: a,
+	}
+}
+
+// SetCredentials sets account and character used by next sessions.
+func (a *Agent) SetCredentials(credentials connection.Credentials) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.credentials = credentials
+}
+
+// WalkTo moves character to destination around obstacles known from
+// geodata.
+func (a *Agent) WalkTo(ctx context.Context, destination world.Position) error {
+	return a.Mover.Walk(ctx, a.Geodata, destination)
+}
+
+// DropGold drops count of adena on ground at position.
+func (a *Agent) DropGold(count int32, at world.Position) error {
+	return a.Inventory.DropByItemID(inventory.AdenaID, count, at)
+}
+
+// Talk selects npc and talks to it, first page of its dialog is returned.
+func (a *Agent) Talk(
+	ctx context.Context,
+	npcID int32,
+) (*dialog.Dialog, error) {
+	if err := a.Combat.Select(ctx, npcID); err != nil {
+		return nil, err
+	}
+
+	return a.Dialog.Await(ctx, func() error {
+		return a.Combat.Interact(npcID)
+	})
+}
+
+// Behave ticks behavior tree of bot by world clock until context is done.
+func (a *Agent) Behave(ctx context.Context, root behavior.Node) {
+	tree := behavior.NewTree(root, a.Blackboard)
+	tree.SetClock(a.World.Now)
+	tree.Run(ctx, behavior.DefaultTickInterval)
+}
+
+// Run does background work of agent until context is done.
+func (a *Agent) Run(ctx context.Context) {
+	a.Mover.Run(ctx)
+}
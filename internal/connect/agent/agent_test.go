@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/inventory"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ behavior.Walker   = (*Agent)(nil)
+	_ behavior.Caster   = (*combat.Combat)(nil)
+	_ behavior.Attacker = (*combat.Combat)(nil)
+	_ behavior.ItemUser = (*inventory.Inventory)(nil)
+)
+
+func TestAgent(t *testing.T) {
+	geo := geodata.Open("")
+	agent := New("tank", geo)
+	require.Equal(t, "tank", agent.Name)
+	require.Same(t, geo, agent.Geodata)
+	require.False(t, agent.Party.InParty())
+	require.Zero(t, agent.Combat.Target())
+	require.Empty(t, agent.Inventory.Items())
+	_, open := agent.Dialog.Current()
+	require.False(t, open)
+
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = 5
+	writer := packet.NewWriter()
+	require.NoError(t, info.ToBytes(writer))
+	require.NoError(t, agent.Dispatcher.Dispatch(fromgameserver.UserInfoID,
+		writer.Bytes()))
+
+	_, ok := agent.World.Self()
+	require.True(t, ok)
+
+	err := agent.Mover.MoveTo(world.Position{X: 1, Y: 2, Z: 3})
+	require.True(t, errors.Is(err, connection.ErrNotInGame))
+	err = agent.WalkTo(context.Background(), world.Position{X: 1, Y: 2, Z: 3})
+	require.True(t, errors.Is(err, connection.ErrNotInGame))
+	_, err = agent.Talk(context.Background(), 300)
+	require.True(t, errors.Is(err, connection.ErrNotInGame))
+	err = agent.DropGold(1, world.Position{X: 1, Y: 2, Z: 3})
+	require.True(t, errors.Is(err, inventory.ErrNoItem))
+
+	session := agent.NewSession()
+	require.NotNil(t, session)
+	_, ok = agent.World.Self()
+	require.False(t, ok, "new session starts with empty world")
+	require.NoError(t, session.Close())
+}
+
+func TestAgent_Behave(t *testing.T) {
+	agent := New("tank", geodata.Open(""))
+	ctx, cancel := context.WithCancel(context.Background())
+	root := behavior.NewAction(
+		func(_ context.Context, tick *behavior.Tick) behavior.Status {
+			tick.Blackboard.Set("ticked", true)
+			cancel()
+
+			return behavior.Success
+		})
+
+	agent.Behave(ctx, root)
+	_, ok := agent.Blackboard.Get("ticked")
+	require.True(t, ok)
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/chat"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/movement"
+)
+
+const (
+	// followInterval is how often position of followed character is
+	// checked.
+	followInterval = 500 * time.Millisecond
+	// followDistance is how close bot keeps to followed character.
+	followDistance = 150
+)
+
+var (
+	ErrUnknownPlayer = errors.New("player is not seen by bot")
+	ErrNoTarget      = errors.New("player has no target")
+)
+
+// Execute does command given in chat. Following goes on in background
+// until stop command or next follow command.
+func (a *Agent) Execute(ctx context.Context, command chat.Command) error {
+	switch command.Kind {
+	case chat.Follow:
+		return a.Follow(ctx, command.From)
+	case chat.Stop:
+		return a.Stop()
+	case chat.Assist:
+		return a.Assist(ctx, command.Target)
+	default:
+		return fmt.Errorf("%w: %v", chat.ErrUnknownCommand, command.Kind)
+	}
+}
+
+// Follow keeps bot near character with name until Stop is called or
+// context is done.
+func (a *Agent) Follow(ctx context.Context, name string) error {
+	if _, ok := a.View.PlayerByName(name); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPlayer, name)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// Following doesn't start after shutdown, so Wait doesn't miss it.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	followCtx, cancel := context.WithCancel(ctx)
+	if a.stopFollow != nil {
+		a.stopFollow()
+	}
+	a.stopFollow = cancel
+
+	a.following.Add(1)
+	go func() {
+		defer a.following.Done()
+		a.follow(followCtx, name)
+	}()
+
+	return nil
+}
+
+// Wait returns when all following which was started is done. It is called
+// after context of commands is done.
+func (a *Agent) Wait() {
+	// Following which is starting now sees done context or is added before
+	// mutex is released.
+	a.mutex.Lock()
+	a.mutex.Unlock() //nolint:staticcheck
+
+	a.following.Wait()
+}
+
+func (a *Agent) follow(ctx context.Context, name string) {
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+
+	for {
+		err := a.approach(name)
+		if err != nil && !errors.Is(err, connection.ErrNotInGame) {
+			log.Printf("Error following %s: %v\n", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// approach moves bot toward character when it is too far. Character out of
+// sight is waited where it was seen last.
+func (a *Agent) approach(name string) error {
+	leader, ok := a.View.PlayerByName(name)
+	if !ok {
+		return nil
+	}
+	own, ok := a.Mover.Position()
+	if !ok {
+		return movement.ErrUnknownPosition
+	}
+	target := leader.At(a.View.Now())
+	if own.Distance(target) <= followDistance {
+		return nil
+	}
+	if destination, ok := a.Mover.Destination(); ok &&
+		destination.Distance(target) <= followDistance {
+		return nil
+	}
+
+	path, err := a.Geodata.FindPath(own, target)
+	if err != nil {
+		return err
+	}
+
+	return a.Mover.MoveTo(path[0])
+}
+
+// Stop stops following and movement of bot.
+func (a *Agent) Stop() error {
+	a.mutex.Lock()
+	if a.stopFollow != nil {
+		a.stopFollow()
+		a.stopFollow = nil
+	}
+	a.mutex.Unlock()
+
+	if !a.Mover.Moving() {
+		return nil
+	}
+	position, ok := a.Mover.Position()
+	if !ok {
+		return movement.ErrUnknownPosition
+	}
+
+	return a.Mover.MoveTo(position)
+}
+
+// Assist selects and attacks target of character with name.
+func (a *Agent) Assist(ctx context.Context, name string) error {
+	player, ok := a.View.PlayerByName(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPlayer, name)
+	}
+	target := a.Combat.TargetOf(player.ObjectID)
+	if target == 0 {
+		return fmt.Errorf("%w: %s", ErrNoTarget, name)
+	}
+
+	if err := a.Combat.Select(ctx, target); err != nil {
+		return err
+	}
+
+	return a.Combat.Attack(target, false)
+}
+
+// Following reports if bot follows someone.
+func (a *Agent) Following() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.stopFollow != nil
+}
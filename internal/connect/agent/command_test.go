@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/chat"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/geodata"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const humanID = 50
+
+func feed(t *testing.T, a *Agent, id byte, p crypt.Serializable) {
+	t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(t, p.ToBytes(writer))
+	require.NoError(t, a.Dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func newCommanded(t *testing.T) *Agent {
+	t.Helper()
+
+	a := New("healer", geodata.Open(""))
+	self := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	self.ObjectID = 5
+	feed(t, a, fromgameserver.UserInfoID, self)
+	human := &fromgameserver.CharInfo{} //nolint:exhaustruct
+	human.ObjectID = humanID
+	human.Name = "Human"
+	human.X = 1000
+	feed(t, a, fromgameserver.CharInfoID, human)
+
+	return a
+}
+
+func command(kind chat.CommandKind, target string) chat.Command {
+	return chat.Command{Kind: kind, From: "Human", Target: target}
+}
+
+func TestAgent_FollowAndStop(t *testing.T) {
+	a := newCommanded(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := a.Follow(ctx, "Stranger")
+	require.True(t, errors.Is(err, ErrUnknownPlayer))
+	require.False(t, a.Following())
+
+	require.NoError(t, a.Execute(ctx, command(chat.Follow, "")))
+	require.True(t, a.Following())
+	require.NoError(t, a.Execute(ctx, command(chat.Follow, "")),
+		"following again replaces old following")
+
+	require.NoError(t, a.Execute(ctx, command(chat.Stop, "")))
+	require.False(t, a.Following())
+}
+
+func TestAgent_WaitForFollowing(t *testing.T) {
+	a := newCommanded(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, a.Follow(ctx, "Human"))
+	cancel()
+	a.Wait()
+
+	err := a.Follow(ctx, "Human")
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestAgent_FollowThroughView(t *testing.T) {
+	shared := newCommanded(t)
+	a := New("healer", geodata.Open(""))
+	self := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	self.ObjectID = 6
+	feed(t, a, fromgameserver.UserInfoID, self)
+	a.View = world.NewOverlay(a.World, func() *world.World {
+		return shared.World
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, ok := a.World.PlayerByName("Human")
+	require.False(t, ok, "other bot decodes packets about human")
+	require.NoError(t, a.Follow(ctx, "Human"))
+	require.True(t, a.Following())
+}
+
+func TestAgent_Assist(t *testing.T) {
+	a := newCommanded(t)
+	ctx := context.Background()
+
+	err := a.Execute(ctx, command(chat.Assist, "Stranger"))
+	require.True(t, errors.Is(err, ErrUnknownPlayer))
+	err = a.Execute(ctx, command(chat.Assist, "Human"))
+	require.True(t, errors.Is(err, ErrNoTarget))
+
+	feed(t, a, fromgameserver.TargetSelectedID,
+		&fromgameserver.TargetSelected{
+			ObjectID: humanID,
+			TargetID: 300,
+			X:        0,
+			Y:        0,
+			Z:        0,
+		})
+	err = a.Execute(ctx, command(chat.Assist, "Human"))
+	require.True(t, errors.Is(err, connection.ErrNotInGame))
+}
+
+func TestAgent_ExecuteUnknown(t *testing.T) {
+	a := newCommanded(t)
+	err := a.Execute(context.Background(), command(chat.CommandKind(9), ""))
+	require.True(t, errors.Is(err, chat.ErrUnknownCommand))
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/connection"
+)
+
+// Party roles of snapshot.
+const (
+	RoleLeader = "leader"
+	RoleMember = "member"
+)
+
+// Recorder keeps snapshot of bot over reconnects, bot.Bot is one.
+type Recorder interface {
+	UpdateSnapshot(update func(snapshot *bot.Snapshot))
+}
+
+var _ Recorder = (*bot.Bot)(nil)
+
+// Track makes agent record its target, task and party role into snapshot
+// of recorder before state of lost session is cleared.
+func (a *Agent) Track(recorder Recorder) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.recorder = recorder
+}
+
+// SetTask names work agent does now, like phase of scenario. Empty task
+// means agent is idle.
+func (a *Agent) SetTask(task string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.task = task
+}
+
+// Task returns work agent does now.
+func (a *Agent) Task() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.task
+}
+
+// PartyRole returns role of character in its party, empty out of party.
+func (a *Agent) PartyRole() string {
+	switch {
+	case !a.Party.InParty():
+		return ""
+	case a.Party.LeaderID() == a.World.SelfID():
+		return RoleLeader
+	default:
+		return RoleMember
+	}
+}
+
+// record saves state of session into snapshot. Sessions which never
+// entered world have nothing to save and keep snapshot of earlier one.
+func (a *Agent) record() {
+	a.mutex.Lock()
+	recorder, task := a.recorder, a.task
+	a.mutex.Unlock()
+
+	if _, ok := a.World.Self(); recorder == nil || !ok {
+		return
+	}
+	targetID, role := a.Combat.Target(), a.PartyRole()
+	recorder.UpdateSnapshot(func(snapshot *bot.Snapshot) {
+		snapshot.TargetID = targetID
+		snapshot.Task = task
+		snapshot.PartyRole = role
+	})
+}
+
+// session is game session of agent which restores snapshot after
+// reconnect.
+type session struct {
+	*connection.GameSession
+	agent *Agent
+}
+
+var _ bot.Resumer = (*session)(nil)
+
+// Resume selects target character had before reconnect. Packets aren't read
+// yet, so selection isn't waited for. Party and scenario don't need it,
+// organizer and scenario group bring bot back on their own.
+func (s *session) Resume(_ context.Context, snapshot bot.Snapshot) error {
+	if snapshot.TargetID == 0 {
+		return nil
+	}
+
+	return s.agent.Combat.Aim(snapshot.TargetID)
+}
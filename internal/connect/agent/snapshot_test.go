@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/connection"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	snapshot bot.Snapshot
+}
+
+func (r *recorder) UpdateSnapshot(update func(snapshot *bot.Snapshot)) {
+	update(&r.snapshot)
+}
+
+func TestAgent_RecordsSnapshot(t *testing.T) {
+	a := newCommanded(t)
+	var saved recorder
+	a.Track(&saved)
+	a.SetTask("farm fight")
+	require.Equal(t, "farm fight", a.Task())
+	require.Empty(t, a.PartyRole())
+
+	feed(t, a, fromgameserver.MyTargetSelectedID,
+		&fromgameserver.MyTargetSelected{ObjectID: humanID, Color: 0})
+	feed(t, a, fromgameserver.PartySmallWindowAllID,
+		&fromgameserver.PartySmallWindowAll{
+			LeaderID: 5, ItemDistribution: 0,
+			Members: []fromgameserver.PartyMember{{
+				ObjectID: humanID, Name: "Human", CurCP: 0, MaxCP: 0,
+				CurHP: 1, MaxHP: 1, CurMP: 1, MaxMP: 1, Level: 1,
+				ClassID: 0, Race: 0,
+			}},
+		})
+	require.Equal(t, RoleLeader, a.PartyRole())
+
+	a.NewSession()
+	expected := bot.Snapshot{
+		TargetID:  humanID,
+		Task:      "farm fight",
+		PartyRole: RoleLeader,
+		Values:    nil,
+	}
+	require.Equal(t, expected, saved.snapshot)
+
+	// Session which never entered world doesn't wipe snapshot.
+	a.SetTask("")
+	a.NewSession()
+	require.Equal(t, expected, saved.snapshot)
+}
+
+func TestSession_Resume(t *testing.T) {
+	a := New("tank", nil)
+	resumer, ok := a.NewSession().(bot.Resumer)
+	require.True(t, ok)
+
+	ctx := context.Background()
+	require.NoError(t, resumer.Resume(ctx, bot.Snapshot{})) //nolint:exhaustruct
+
+	snapshot := bot.Snapshot{
+		TargetID: humanID, Task: "", PartyRole: "", Values: nil,
+	}
+	err := resumer.Resume(ctx, snapshot)
+	require.True(t, errors.Is(err, connection.ErrNotInGame), err)
+}
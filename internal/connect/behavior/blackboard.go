@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Blackboard is memory of bot shared by nodes of its tree, like target or
+// place to go. It is safe for concurrent use, so packet handlers and other
+// bots can leave notes on it.
+type Blackboard struct {
+	mutex  sync.RWMutex
+	values map[string]any
+}
+
+func NewBlackboard() *Blackboard {
+	return &Blackboard{mutex: sync.RWMutex{}, values: make(map[string]any)}
+}
+
+func (b *Blackboard) Set(key string, value any) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.values[key] = value
+}
+
+func (b *Blackboard) Get(key string) (any, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	value, ok := b.values[key]
+
+	return value, ok
+}
+
+func (b *Blackboard) Delete(key string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.values, key)
+}
+
+// Keys returns sorted keys of blackboard.
+func (b *Blackboard) Keys() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return slices.Sorted(maps.Keys(b.values))
+}
+
+// Value returns value of key if it has type T.
+func Value[T any](blackboard *Blackboard, key string) (T, bool) {
+	value, ok := blackboard.Get(key)
+	if !ok {
+		var none T
+
+		return none, false
+	}
+	typed, ok := value.(T)
+
+	return typed, ok
+}
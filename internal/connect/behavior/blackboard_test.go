@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlackboard(t *testing.T) {
+	blackboard := NewBlackboard()
+	_, ok := blackboard.Get("target")
+	require.False(t, ok)
+
+	blackboard.Set("target", int32(300))
+	blackboard.Set("leader", "Tank")
+	require.Equal(t, []string{"leader", "target"}, blackboard.Keys())
+
+	target, ok := Value[int32](blackboard, "target")
+	require.True(t, ok)
+	require.Equal(t, int32(300), target)
+
+	_, ok = Value[string](blackboard, "target")
+	require.False(t, ok, "value of other type")
+	_, ok = Value[string](blackboard, "missing")
+	require.False(t, ok)
+
+	blackboard.Delete("target")
+	require.Equal(t, []string{"leader"}, blackboard.Keys())
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import "context"
+
+// Sequence ticks children one by one while they succeed. It fails on first
+// failed child and continues running child on next tick.
+type Sequence struct {
+	children []Node
+	current  int
+}
+
+func NewSequence(children ...Node) *Sequence {
+	return &Sequence{children: children, current: 0}
+}
+
+func (s *Sequence) Tick(ctx context.Context, tick *Tick) Status {
+	return tickInOrder(ctx, tick, s.children, &s.current, Success)
+}
+
+func (s *Sequence) Reset() {
+	resetFrom(s.children, &s.current)
+}
+
+// Selector ticks children one by one until one of them succeeds. It fails
+// when all children fail and continues running child on next tick.
+type Selector struct {
+	children []Node
+	current  int
+}
+
+func NewSelector(children ...Node) *Selector {
+	return &Selector{children: children, current: 0}
+}
+
+func (s *Selector) Tick(ctx context.Context, tick *Tick) Status {
+	return tickInOrder(ctx, tick, s.children, &s.current, Failure)
+}
+
+func (s *Selector) Reset() {
+	resetFrom(s.children, &s.current)
+}
+
+// tickInOrder goes over children from current one while they finish with
+// status which lets to go on.
+func tickInOrder(
+	ctx context.Context,
+	tick *Tick,
+	children []Node,
+	current *int,
+	goOn Status,
+) Status {
+	for *current < len(children) {
+		child := children[*current]
+		status := child.Tick(ctx, tick)
+		if status == Running {
+			return Running
+		}
+		child.Reset()
+		if status != goOn {
+			*current = 0
+
+			return status
+		}
+		*current++
+	}
+	*current = 0
+
+	return goOn
+}
+
+// resetFrom resets child which may be running and starts from first child.
+func resetFrom(children []Node, current *int) {
+	if *current < len(children) {
+		children[*current].Reset()
+	}
+	*current = 0
+}
+
+// Parallel ticks all unfinished children on every tick in order of
+// declaration. It succeeds when needed number of children succeed and
+// fails when so many children fail that it can't succeed anymore.
+// Children still running then are abandoned.
+type Parallel struct {
+	children []Node
+	needed   int
+	results  []Status
+}
+
+func NewParallel(needed int, children ...Node) *Parallel {
+	return &Parallel{
+		children: children,
+		needed:   min(needed, len(children)),
+		results:  make([]Status, len(children)),
+	}
+}
+
+func (p *Parallel) Tick(ctx context.Context, tick *Tick) Status {
+	successes, failures := 0, 0
+	for i, child := range p.children {
+		if p.results[i] == Running {
+			p.results[i] = child.Tick(ctx, tick)
+			if p.results[i] != Running {
+				child.Reset()
+			}
+		}
+
+		switch p.results[i] {
+		case Success:
+			successes++
+		case Failure:
+			failures++
+		case Running:
+		}
+	}
+
+	switch {
+	case successes >= p.needed:
+		p.Reset()
+
+		return Success
+	case len(p.children)-failures < p.needed:
+		p.Reset()
+
+		return Failure
+	default:
+		return Running
+	}
+}
+
+func (p *Parallel) Reset() {
+	for i, child := range p.children {
+		if p.results[i] == Running {
+			child.Reset()
+		}
+		p.results[i] = Running
+	}
+}
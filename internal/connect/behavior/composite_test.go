@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequence(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	sequence := NewSequence(
+		newStub(j, "a", Success),
+		newStub(j, "b", Running, Success, Failure),
+		newStub(j, "c", Success),
+	)
+	tick := newClock().tick()
+
+	require.Equal(t, Running, sequence.Tick(ctx, tick))
+	require.Equal(t, []string{"a:Success", "a:reset", "b:Running"}, j.take())
+
+	require.Equal(t, Success, sequence.Tick(ctx, tick))
+	require.Equal(t, []string{
+		"b:Success", "b:reset", "c:Success", "c:reset",
+	}, j.take(), "running child continues")
+
+	require.Equal(t, Failure, sequence.Tick(ctx, tick))
+	require.Equal(t, []string{
+		"a:Success", "a:reset", "b:Failure", "b:reset",
+	}, j.take())
+
+	require.Equal(t, Success, NewSequence().Tick(ctx, tick))
+}
+
+func TestSelector(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	selector := NewSelector(
+		newStub(j, "a", Failure),
+		newStub(j, "b", Running, Failure, Success),
+		newStub(j, "c", Failure),
+	)
+	tick := newClock().tick()
+
+	require.Equal(t, Running, selector.Tick(ctx, tick))
+	require.Equal(t, []string{"a:Failure", "a:reset", "b:Running"}, j.take())
+
+	require.Equal(t, Failure, selector.Tick(ctx, tick))
+	require.Equal(t, []string{
+		"b:Failure", "b:reset", "c:Failure", "c:reset",
+	}, j.take())
+
+	require.Equal(t, Success, selector.Tick(ctx, tick))
+	require.Equal(t, []string{
+		"a:Failure", "a:reset", "b:Success", "b:reset",
+	}, j.take())
+
+	require.Equal(t, Failure, NewSelector().Tick(ctx, tick))
+}
+
+func TestSequence_Reset(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	sequence := NewSequence(
+		newStub(j, "a", Success),
+		newStub(j, "b", Running),
+	)
+	tick := newClock().tick()
+
+	require.Equal(t, Running, sequence.Tick(ctx, tick))
+	j.take()
+	sequence.Reset()
+	require.Equal(t, []string{"b:reset"}, j.take())
+
+	require.Equal(t, Running, sequence.Tick(ctx, tick))
+	require.Equal(t, []string{"a:Success", "a:reset", "b:Running"}, j.take(),
+		"reset sequence starts from first child")
+}
+
+func TestParallel(t *testing.T) {
+	tests := []struct {
+		name     string
+		needed   int
+		statuses [][]Status
+		results  []Status
+	}{
+		{
+			name:     "all succeed",
+			needed:   2,
+			statuses: [][]Status{{Running, Success}, {Success}},
+			results:  []Status{Running, Success},
+		},
+		{
+			name:     "one is enough",
+			needed:   1,
+			statuses: [][]Status{{Running}, {Success}},
+			results:  []Status{Success},
+		},
+		{
+			name:     "can't succeed anymore",
+			needed:   2,
+			statuses: [][]Status{{Running}, {Running, Failure}},
+			results:  []Status{Running, Failure},
+		},
+		{
+			name:     "needed above children",
+			needed:   5,
+			statuses: [][]Status{{Success}},
+			results:  []Status{Success},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			j := &journal{entries: nil}
+			children := make([]Node, 0, len(test.statuses))
+			for i, statuses := range test.statuses {
+				children = append(children,
+					newStub(j, string(rune('a'+i)), statuses...))
+			}
+			parallel := NewParallel(test.needed, children...)
+			tick := newClock().tick()
+
+			for _, expected := range test.results {
+				require.Equal(t, expected,
+					parallel.Tick(context.Background(), tick))
+			}
+		})
+	}
+}
+
+func TestParallel_Order(t *testing.T) {
+	j := &journal{entries: nil}
+	parallel := NewParallel(2,
+		newStub(j, "a", Running, Success),
+		newStub(j, "b", Success),
+		newStub(j, "c", Running),
+	)
+	tick := newClock().tick()
+
+	require.Equal(t, Running, parallel.Tick(context.Background(), tick))
+	require.Equal(t, []string{
+		"a:Running", "b:Success", "b:reset", "c:Running",
+	}, j.take())
+
+	require.Equal(t, Success, parallel.Tick(context.Background(), tick))
+	require.Equal(t, []string{
+		"a:Success", "a:reset", "c:Running", "c:reset",
+	}, j.take(), "finished child isn't ticked, running one is abandoned")
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"time"
+)
+
+// Retry starts failed child again until it succeeds or fails attempts
+// times. Next attempt starts on next tick.
+type Retry struct {
+	child    Node
+	attempts int
+	failures int
+}
+
+func NewRetry(attempts int, child Node) *Retry {
+	return &Retry{child: child, attempts: attempts, failures: 0}
+}
+
+func (r *Retry) Tick(ctx context.Context, tick *Tick) Status {
+	status := r.child.Tick(ctx, tick)
+	if status != Failure {
+		return status
+	}
+
+	r.child.Reset()
+	r.failures++
+	if r.failures >= r.attempts {
+		r.failures = 0
+
+		return Failure
+	}
+
+	return Running
+}
+
+func (r *Retry) Reset() {
+	r.child.Reset()
+	r.failures = 0
+}
+
+// Timeout fails child which runs longer than limit.
+type Timeout struct {
+	child   Node
+	limit   time.Duration
+	started time.Time
+}
+
+func NewTimeout(limit time.Duration, child Node) *Timeout {
+	return &Timeout{child: child, limit: limit, started: time.Time{}}
+}
+
+func (t *Timeout) Tick(ctx context.Context, tick *Tick) Status {
+	if t.started.IsZero() {
+		t.started = tick.Now
+	}
+	if tick.Now.Sub(t.started) >= t.limit {
+		t.Reset()
+
+		return Failure
+	}
+
+	status := t.child.Tick(ctx, tick)
+	if status != Running {
+		t.started = time.Time{}
+	}
+
+	return status
+}
+
+func (t *Timeout) Reset() {
+	t.child.Reset()
+	t.started = time.Time{}
+}
+
+// Cooldown doesn't let child start again sooner than period after it
+// finished, it fails while child cools down.
+type Cooldown struct {
+	child    Node
+	period   time.Duration
+	running  bool
+	finished time.Time
+}
+
+func NewCooldown(period time.Duration, child Node) *Cooldown {
+	return &Cooldown{
+		child:    child,
+		period:   period,
+		running:  false,
+		finished: time.Time{},
+	}
+}
+
+func (c *Cooldown) Tick(ctx context.Context, tick *Tick) Status {
+	if !c.running && !c.finished.IsZero() &&
+		tick.Now.Sub(c.finished) < c.period {
+		return Failure
+	}
+
+	status := c.child.Tick(ctx, tick)
+	c.running = status == Running
+	if !c.running {
+		c.finished = tick.Now
+	}
+
+	return status
+}
+
+// Reset abandons child, cooldown period isn't forgotten so abandoned
+// parents can't bypass it.
+func (c *Cooldown) Reset() {
+	c.child.Reset()
+	c.running = false
+}
+
+// Invert turns success of child into failure and failure into success.
+type Invert struct {
+	child Node
+}
+
+func NewInvert(child Node) *Invert {
+	return &Invert{child: child}
+}
+
+func (i *Invert) Tick(ctx context.Context, tick *Tick) Status {
+	switch status := i.child.Tick(ctx, tick); status {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	default:
+		return status
+	}
+}
+
+func (i *Invert) Reset() {
+	i.child.Reset()
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	tick := newClock().tick()
+	retry := NewRetry(2, newStub(j, "a", Failure, Failure, Failure, Success))
+
+	require.Equal(t, Running, retry.Tick(ctx, tick))
+	require.Equal(t, Failure, retry.Tick(ctx, tick))
+	require.Equal(t, []string{
+		"a:Failure", "a:reset", "a:Failure", "a:reset",
+	}, j.take())
+
+	require.Equal(t, Running, retry.Tick(ctx, tick))
+	require.Equal(t, Success, retry.Tick(ctx, tick))
+
+	retry.Reset()
+	require.Equal(t, []string{
+		"a:Failure", "a:reset", "a:Success", "a:reset",
+	}, j.take())
+}
+
+func TestTimeout(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	clock := newClock()
+	timeout := NewTimeout(time.Second,
+		newStub(j, "a", Running, Running, Running, Success))
+
+	require.Equal(t, Running, timeout.Tick(ctx, clock.tick()))
+	clock.advance(900 * time.Millisecond)
+	require.Equal(t, Running, timeout.Tick(ctx, clock.tick()))
+	clock.advance(100 * time.Millisecond)
+	require.Equal(t, Failure, timeout.Tick(ctx, clock.tick()))
+	require.Equal(t, []string{"a:Running", "a:Running", "a:reset"}, j.take())
+
+	clock.advance(time.Hour)
+	require.Equal(t, Running, timeout.Tick(ctx, clock.tick()),
+		"timer starts again")
+	require.Equal(t, Success, timeout.Tick(ctx, clock.tick()))
+
+	timeout.Reset()
+	clock.advance(time.Hour)
+	require.Equal(t, Success, timeout.Tick(ctx, clock.tick()))
+}
+
+func TestCooldown(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	clock := newClock()
+	cooldown := NewCooldown(time.Second,
+		newStub(j, "a", Running, Success, Failure))
+
+	require.Equal(t, Running, cooldown.Tick(ctx, clock.tick()))
+	clock.advance(time.Hour)
+	require.Equal(t, Success, cooldown.Tick(ctx, clock.tick()),
+		"running child isn't cooling down")
+	clock.advance(999 * time.Millisecond)
+	require.Equal(t, Failure, cooldown.Tick(ctx, clock.tick()))
+	require.Equal(t, []string{"a:Running", "a:Success"}, j.take())
+
+	cooldown.Reset()
+	clock.advance(time.Millisecond)
+	require.Equal(t, Failure, cooldown.Tick(ctx, clock.tick()))
+	require.Equal(t, []string{"a:reset", "a:Failure"}, j.take())
+}
+
+func TestInvert(t *testing.T) {
+	j := &journal{entries: nil}
+	ctx := context.Background()
+	tick := newClock().tick()
+	invert := NewInvert(newStub(j, "a", Success, Failure, Running))
+
+	require.Equal(t, Failure, invert.Tick(ctx, tick))
+	require.Equal(t, Success, invert.Tick(ctx, tick))
+	require.Equal(t, Running, invert.Tick(ctx, tick))
+
+	invert.Reset()
+	require.Equal(t, []string{
+		"a:Success", "a:Failure", "a:Running", "a:reset",
+	}, j.take())
+}
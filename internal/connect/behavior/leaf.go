@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"sync"
+)
+
+// Action is leaf which does its work within single tick.
+type Action struct {
+	act func(ctx context.Context, tick *Tick) Status
+}
+
+func NewAction(act func(ctx context.Context, tick *Tick) Status) *Action {
+	return &Action{act: act}
+}
+
+func (a *Action) Tick(ctx context.Context, tick *Tick) Status {
+	return a.act(ctx, tick)
+}
+
+func (a *Action) Reset() {}
+
+// Condition is leaf which succeeds when check is true.
+type Condition struct {
+	check func(tick *Tick) bool
+}
+
+func NewCondition(check func(tick *Tick) bool) *Condition {
+	return &Condition{check: check}
+}
+
+func (c *Condition) Tick(_ context.Context, tick *Tick) Status {
+	if c.check(tick) {
+		return Success
+	}
+
+	return Failure
+}
+
+func (c *Condition) Reset() {}
+
+// Task is leaf which runs blocking work, like walk or cast, in background.
+// It is running until work returns and succeeds if work has no error. Reset
+// cancels work which is still running and waits for it, so work must return
+// soon after its context is done.
+type Task struct {
+	work   func(ctx context.Context, tick Tick) error
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+func NewTask(work func(ctx context.Context, tick Tick) error) *Task {
+	return &Task{
+		work:   work,
+		mutex:  sync.Mutex{},
+		cancel: nil,
+		done:   nil,
+		err:    nil,
+	}
+}
+
+func (t *Task) Tick(ctx context.Context, tick *Tick) Status {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.done == nil {
+		workCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		t.cancel, t.done = cancel, done
+		go func(tick Tick) {
+			err := t.work(workCtx, tick)
+
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+
+			if t.done == done {
+				t.err = err
+			}
+			close(done)
+		}(*tick)
+
+		return Running
+	}
+
+	select {
+	case <-t.done:
+	default:
+		return Running
+	}
+
+	if t.err != nil {
+		return Failure
+	}
+
+	return Success
+}
+
+// Err returns error of last finished work.
+func (t *Task) Err() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.err
+}
+
+func (t *Task) Reset() {
+	t.mutex.Lock()
+	cancel, done := t.cancel, t.done
+	t.cancel, t.done = nil, nil
+	t.mutex.Unlock()
+
+	// Work doesn't outlive tree, things it uses may be closed after tree
+	// stops.
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// settle ticks node until it stops running.
+func settle(t *testing.T, node Node, tick *Tick) Status {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if status := node.Tick(context.Background(), tick); status != Running {
+			return status
+		}
+		time.Sleep(time.Millisecond)
+	}
+	require.Fail(t, "node is still running")
+
+	return Running
+}
+
+func TestCondition(t *testing.T) {
+	tick := newClock().tick()
+	tick.Blackboard.Set("hp", 10)
+	low := NewCondition(func(tick *Tick) bool {
+		hp, _ := Value[int](tick.Blackboard, "hp")
+
+		return hp < 50
+	})
+
+	require.Equal(t, Success, low.Tick(context.Background(), tick))
+	tick.Blackboard.Set("hp", 90)
+	require.Equal(t, Failure, low.Tick(context.Background(), tick))
+	low.Reset()
+}
+
+func TestTask(t *testing.T) {
+	errBroken := errors.New("broken")
+	results := make(chan error, 2)
+	task := NewTask(func(context.Context, Tick) error { return <-results })
+	tick := newClock().tick()
+
+	require.Equal(t, Running, task.Tick(context.Background(), tick))
+	require.Equal(t, Running, task.Tick(context.Background(), tick))
+	results <- nil
+	require.Equal(t, Success, settle(t, task, tick))
+	task.Reset()
+
+	results <- errBroken
+	require.Equal(t, Failure, settle(t, task, tick))
+	require.True(t, errors.Is(task.Err(), errBroken))
+}
+
+func TestTask_ResetCancelsWork(t *testing.T) {
+	canceled := make(chan struct{})
+	task := NewTask(func(ctx context.Context, _ Tick) error {
+		<-ctx.Done()
+		close(canceled)
+
+		return ctx.Err()
+	})
+	tick := newClock().tick()
+
+	require.Equal(t, Running, task.Tick(context.Background(), tick))
+	task.Reset()
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("reset returned before work finished")
+	}
+	require.NoError(t, task.Err(), "result of abandoned work is ignored")
+}
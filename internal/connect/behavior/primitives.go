@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// ErrNoValue is returned by leaves which don't find their input on
+// blackboard.
+var ErrNoValue = errors.New("blackboard has no value")
+
+// Walker moves character around obstacles, it is implemented by agent.
+type Walker interface {
+	WalkTo(ctx context.Context, destination world.Position) error
+}
+
+// Caster casts skills, it is implemented by combat.
+type Caster interface {
+	Cast(ctx context.Context, request combat.Request) (combat.Cast, error)
+}
+
+// Attacker starts auto attack, it is implemented by combat.
+type Attacker interface {
+	Attack(objectID int32, shift bool) error
+}
+
+// ItemUser uses items of inventory, it is implemented by inventory.
+type ItemUser interface {
+	UseByItemID(itemID int32) error
+}
+
+func value[T any](tick Tick, key string) (T, error) {
+	result, ok := Value[T](tick.Blackboard, key)
+	if !ok {
+		return result, fmt.Errorf("%w: %s", ErrNoValue, key)
+	}
+
+	return result, nil
+}
+
+// WalkTo walks to position found on blackboard by key.
+func WalkTo(walker Walker, key string) *Task {
+	return NewTask(func(ctx context.Context, tick Tick) error {
+		destination, err := value[world.Position](tick, key)
+		if err != nil {
+			return err
+		}
+
+		return walker.WalkTo(ctx, destination)
+	})
+}
+
+// Cast casts skill at object which id is found on blackboard by key. Empty
+// key casts at current target.
+func Cast(caster Caster, skillID int32, key string) *Task {
+	return NewTask(func(ctx context.Context, tick Tick) error {
+		var target int32
+		if key != "" {
+			var err error
+			if target, err = value[int32](tick, key); err != nil {
+				return err
+			}
+		}
+
+		_, err := caster.Cast(ctx, combat.Request{
+			SkillID:  skillID,
+			TargetID: target,
+			Ctrl:     false,
+			Shift:    false,
+		})
+
+		return err
+	})
+}
+
+// Attack starts auto attack of object which id is found on blackboard by
+// key. It succeeds once attack is requested.
+func Attack(attacker Attacker, key string) *Action {
+	return NewAction(func(_ context.Context, tick *Tick) Status {
+		target, err := value[int32](*tick, key)
+		if err != nil || attacker.Attack(target, false) != nil {
+			return Failure
+		}
+
+		return Success
+	})
+}
+
+// UseItem uses any item with item id, like potion.
+func UseItem(user ItemUser, itemID int32) *Action {
+	return NewAction(func(context.Context, *Tick) Status {
+		if user.UseByItemID(itemID) != nil {
+			return Failure
+		}
+
+		return Success
+	})
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var errRefused = errors.New("refused")
+
+// bot records calls of primitives.
+type bot struct {
+	mutex   sync.Mutex
+	calls   []any
+	refuses bool
+}
+
+func (b *bot) record(call any) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.calls = append(b.calls, call)
+	if b.refuses {
+		return errRefused
+	}
+
+	return nil
+}
+
+func (b *bot) WalkTo(_ context.Context, destination world.Position) error {
+	return b.record(destination)
+}
+
+func (b *bot) Cast(
+	_ context.Context,
+	request combat.Request,
+) (combat.Cast, error) {
+	var cast combat.Cast
+
+	return cast, b.record(request)
+}
+
+func (b *bot) Attack(objectID int32, _ bool) error {
+	return b.record(objectID)
+}
+
+func (b *bot) UseByItemID(itemID int32) error {
+	return b.record(itemID)
+}
+
+func TestPrimitives(t *testing.T) {
+	player := &bot{mutex: sync.Mutex{}, calls: nil, refuses: false}
+	tick := newClock().tick()
+	tick.Blackboard.Set("spot", world.Position{X: 1, Y: 2, Z: 3})
+	tick.Blackboard.Set("target", int32(300))
+
+	require.Equal(t, Success, settle(t, WalkTo(player, "spot"), tick))
+	require.Equal(t, Success, settle(t, Cast(player, 1011, "target"), tick))
+	require.Equal(t, Success, settle(t, Cast(player, 1011, ""), tick))
+	require.Equal(t, Success, settle(t, Attack(player, "target"), tick))
+	require.Equal(t, Success, settle(t, UseItem(player, 1060), tick))
+
+	require.Equal(t, []any{
+		world.Position{X: 1, Y: 2, Z: 3},
+		combat.Request{SkillID: 1011, TargetID: 300, Ctrl: false, Shift: false},
+		combat.Request{SkillID: 1011, TargetID: 0, Ctrl: false, Shift: false},
+		int32(300),
+		int32(1060),
+	}, player.calls)
+}
+
+func TestPrimitives_Fail(t *testing.T) {
+	player := &bot{mutex: sync.Mutex{}, calls: nil, refuses: true}
+	tick := newClock().tick()
+	tick.Blackboard.Set("target", int32(300))
+
+	walk := WalkTo(player, "spot")
+	require.Equal(t, Failure, settle(t, walk, tick))
+	require.True(t, errors.Is(walk.Err(), ErrNoValue))
+	require.Equal(t, Failure, settle(t, Cast(player, 1011, "spot"), tick))
+	require.Equal(t, Failure, settle(t, Attack(player, "spot"), tick))
+
+	cast := Cast(player, 1011, "target")
+	require.Equal(t, Failure, settle(t, cast, tick))
+	require.True(t, errors.Is(cast.Err(), errRefused))
+	require.Equal(t, Failure, settle(t, Attack(player, "target"), tick))
+	require.Equal(t, Failure, settle(t, UseItem(player, 1060), tick))
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultTickInterval is how often tree of bot is ticked.
+const DefaultTickInterval = 100 * time.Millisecond
+
+// Status is result of node tick.
+type Status int
+
+const (
+	// Running node needs more ticks to finish.
+	Running Status = iota
+	Success
+	Failure
+)
+
+func (s Status) String() string {
+	switch s {
+	case Running:
+		return "Running"
+	case Success:
+		return "Success"
+	case Failure:
+		return "Failure"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Tick is state of single pass over tree shared by all its nodes.
+type Tick struct {
+	// Now is time of tick, nodes use it instead of clock so trees can be
+	// tested with fake time.
+	Now        time.Time
+	Blackboard *Blackboard
+}
+
+// Node is part of behavior tree. Nodes are ticked by their parents in
+// order of declaration, so same events give same decisions.
+type Node interface {
+	// Tick advances node by one step.
+	Tick(ctx context.Context, tick *Tick) Status
+	// Reset forgets progress of node, it is called when node finishes or
+	// when running node is abandoned by its parent.
+	Reset()
+}
+
+// Tree ticks root node with blackboard of bot. Root is started again after
+// it finishes.
+type Tree struct {
+	root       Node
+	blackboard *Blackboard
+	now        func() time.Time
+}
+
+func NewTree(root Node, blackboard *Blackboard) *Tree {
+	return &Tree{root: root, blackboard: blackboard, now: time.Now}
+}
+
+// SetClock replaces clock of tree.
+func (t *Tree) SetClock(now func() time.Time) {
+	t.now = now
+}
+
+// Tick ticks root node once.
+func (t *Tree) Tick(ctx context.Context) Status {
+	tick := &Tick{Now: t.now(), Blackboard: t.blackboard}
+	status := t.root.Tick(ctx, tick)
+	if status != Running {
+		t.root.Reset()
+	}
+
+	return status
+}
+
+// Run ticks tree with interval until context is done. Running nodes are
+// abandoned at the end.
+func (t *Tree) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer t.root.Reset()
+
+	for {
+		t.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package behavior
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// journal records ticks and resets of stubs in order they happen.
+type journal struct {
+	entries []string
+}
+
+func (j *journal) take() []string {
+	entries := j.entries
+	j.entries = nil
+
+	return entries
+}
+
+// stub is node which returns scripted statuses, last one repeats.
+type stub struct {
+	name     string
+	journal  *journal
+	statuses []Status
+	ticks    int
+}
+
+func newStub(j *journal, name string, statuses ...Status) *stub {
+	return &stub{name: name, journal: j, statuses: statuses, ticks: 0}
+}
+
+func (s *stub) Tick(context.Context, *Tick) Status {
+	status := s.statuses[min(s.ticks, len(s.statuses)-1)]
+	s.ticks++
+	s.journal.entries = append(s.journal.entries,
+		fmt.Sprintf("%s:%v", s.name, status))
+
+	return status
+}
+
+func (s *stub) Reset() {
+	s.journal.entries = append(s.journal.entries, s.name+":reset")
+}
+
+// clock is fake time of tests.
+type clock struct {
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Unix(1000, 0)}
+}
+
+func (c *clock) advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+func (c *clock) tick() *Tick {
+	return &Tick{Now: c.now, Blackboard: NewBlackboard()}
+}
+
+func TestStatus_String(t *testing.T) {
+	require.Equal(t, "Running", Running.String())
+	require.Equal(t, "Success", Success.String())
+	require.Equal(t, "Failure", Failure.String())
+	require.Equal(t, "Status(7)", Status(7).String())
+}
+
+func TestTree_Tick(t *testing.T) {
+	j := &journal{entries: nil}
+	blackboard := NewBlackboard()
+	var seen []time.Time
+	root := NewSequence(
+		NewAction(func(_ context.Context, tick *Tick) Status {
+			seen = append(seen, tick.Now)
+			tick.Blackboard.Set("ticked", true)
+
+			return Success
+		}),
+		newStub(j, "a", Running, Success),
+	)
+	tree := NewTree(root, blackboard)
+	tree.SetClock(func() time.Time { return time.Unix(5, 0) })
+
+	require.Equal(t, Running, tree.Tick(context.Background()))
+	require.Equal(t, Success, tree.Tick(context.Background()))
+	require.Equal(t, []string{"a:Running", "a:Success", "a:reset"}, j.take())
+	require.Equal(t, []time.Time{time.Unix(5, 0)}, seen)
+	ticked, ok := Value[bool](blackboard, "ticked")
+	require.True(t, ok)
+	require.True(t, ticked)
+}
+
+func TestTree_Run(t *testing.T) {
+	j := &journal{entries: nil}
+	ticks := make(chan struct{}, 100)
+	root := NewSequence(
+		NewAction(func(context.Context, *Tick) Status {
+			ticks <- struct{}{}
+
+			return Success
+		}),
+		newStub(j, "a", Running),
+	)
+	tree := NewTree(root, NewBlackboard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tree.Run(ctx, time.Millisecond)
+	}()
+
+	<-ticks
+	cancel()
+	<-done
+	entries := j.take()
+	require.Equal(t, "a:reset", entries[len(entries)-1],
+		"running node is abandoned when tree stops")
+}
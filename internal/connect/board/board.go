@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package board
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const changeBuffer = 64
+
+// State is what all bots of process know about one bot. States are never
+// changed after they are put on board, so readers share them without
+// copying.
+type State struct {
+	// Name is name of bot, Character is name of its character in game.
+	Name      string
+	Character string
+	ObjectID  int32
+	Online    bool
+	Position  world.Position
+	CurHP     int32
+	MaxHP     int32
+	CurMP     int32
+	MaxMP     int32
+	CurCP     int32
+	MaxCP     int32
+	Target    int32
+	// Aggro are sorted ids of npcs which target bot.
+	Aggro []int32
+	// Casting is id of skill bot casts now at CastTarget, zero if it
+	// doesn't cast.
+	Casting    int32
+	CastTarget int32
+	// Effects are buffs and debuffs on character of bot.
+	Effects []combat.Effect
+	Updated time.Time
+}
+
+// HP returns share of health points left, from zero to one.
+func (s State) HP() float64 {
+	return ratio(s.CurHP, s.MaxHP)
+}
+
+// MP returns share of mana points left, from zero to one.
+func (s State) MP() float64 {
+	return ratio(s.CurMP, s.MaxMP)
+}
+
+func ratio(current, maximum int32) float64 {
+	if maximum <= 0 {
+		return 0
+	}
+
+	return float64(current) / float64(maximum)
+}
+
+// same reports if states differ only by update time.
+func (s State) same(other State) bool {
+	s.Updated, other.Updated = time.Time{}, time.Time{}
+
+	return reflect.DeepEqual(s, other)
+}
+
+// Change is new state of bot with state it replaced. Old state of bot which
+// just joined board is zero.
+type Change struct {
+	Old State
+	New State
+}
+
+// Board is process wide blackboard of bot states, bots of all parties use
+// it to look at each other. Readers don't wait for writers: each bot keeps
+// its state in atomic pointer, lock guards only list of bots.
+type Board struct {
+	mutex       sync.RWMutex
+	states      map[string]*atomic.Pointer[State]
+	subscribers map[chan Change]struct{}
+}
+
+func New() *Board {
+	return &Board{
+		mutex:       sync.RWMutex{},
+		states:      make(map[string]*atomic.Pointer[State]),
+		subscribers: make(map[chan Change]struct{}),
+	}
+}
+
+// Get returns state of bot by its name.
+func (b *Board) Get(name string) (State, bool) {
+	b.mutex.RLock()
+	pointer, ok := b.states[name]
+	b.mutex.RUnlock()
+	if !ok {
+		var none State
+
+		return none, false
+	}
+
+	return *pointer.Load(), true
+}
+
+// ByObjectID returns state of bot which character has object id.
+func (b *Board) ByObjectID(objectID int32) (State, bool) {
+	for _, state := range b.All() {
+		if state.ObjectID == objectID && state.Online {
+			return state, true
+		}
+	}
+
+	var none State
+
+	return none, false
+}
+
+// All returns states of all bots sorted by name.
+func (b *Board) All() []State {
+	b.mutex.RLock()
+	result := make([]State, 0, len(b.states))
+	for _, pointer := range b.states {
+		result = append(result, *pointer.Load())
+	}
+	b.mutex.RUnlock()
+
+	slices.SortFunc(result, func(a, b State) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
+// Subscribe returns channel of state changes of all bots and function which
+// cancels subscription. Changes are dropped for subscriber which doesn't
+// keep up, so bots never wait for it.
+func (b *Board) Subscribe() (<-chan Change, func()) {
+	changes := make(chan Change, changeBuffer)
+
+	b.mutex.Lock()
+	b.subscribers[changes] = struct{}{}
+	b.mutex.Unlock()
+
+	return changes, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		delete(b.subscribers, changes)
+	}
+}
+
+// put replaces state of bot and tells subscribers about change. States
+// which differ only by update time are not put.
+func (b *Board) put(pointer *atomic.Pointer[State], state State) {
+	old := pointer.Load()
+	if old != nil && old.same(state) {
+		return
+	}
+	pointer.Store(&state)
+
+	var previous State
+	if old != nil {
+		previous = *old
+	}
+	change := Change{Old: previous, New: state}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- change:
+		default:
+		}
+	}
+}
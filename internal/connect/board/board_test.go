@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package board
+
+import (
+	"sync"
+	"testing"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Ratios(t *testing.T) {
+	state := State{CurHP: 30, MaxHP: 120, CurMP: 10, MaxMP: 0} //nolint:exhaustruct
+	require.InDelta(t, 0.25, state.HP(), 1e-9)
+	require.Zero(t, state.MP(), "unknown maximum")
+}
+
+func TestBoard_All(t *testing.T) {
+	b := New()
+	newTestBot(t, b, "tank")
+	healer := newTestBot(t, b, "healer")
+	healer.enter()
+	healer.member.SetOnline(true)
+
+	all := b.All()
+	require.Len(t, all, 2)
+	require.Equal(t, "healer", all[0].Name)
+	require.Equal(t, "tank", all[1].Name)
+
+	state, ok := b.ByObjectID(selfID)
+	require.True(t, ok)
+	require.Equal(t, "healer", state.Name)
+	healer.member.SetOnline(false)
+	_, ok = b.ByObjectID(selfID)
+	require.False(t, ok, "offline bots aren't found by object id")
+
+	_, ok = b.Get("dps")
+	require.False(t, ok)
+}
+
+func TestBoard_Subscribe(t *testing.T) {
+	b := New()
+	changes, cancel := b.Subscribe()
+	bot := newTestBot(t, b, "tank")
+	bot.enter()
+	bot.member.SetOnline(true)
+
+	change := <-changes
+	require.False(t, change.Old.Online)
+	require.True(t, change.New.Online)
+	require.Equal(t, int32(50), change.New.CurHP)
+
+	bot.member.Refresh()
+	require.Empty(t, changes, "same state isn't put again")
+
+	bot.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: selfID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurHP, Value: 40},
+		},
+	})
+	change = <-changes
+	require.Equal(t, int32(50), change.Old.CurHP)
+	require.Equal(t, int32(40), change.New.CurHP)
+
+	cancel()
+	bot.targeted(monsterID, selfID)
+	require.Empty(t, changes)
+}
+
+func TestBoard_Concurrent(t *testing.T) {
+	b := New()
+	bot := newTestBot(t, b, "tank")
+	bot.enter()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			bot.member.SetOnline(i%2 == 0)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			b.All()
+			b.Get("tank")
+		}
+	}()
+	wg.Wait()
+
+	state, ok := b.Get("tank")
+	require.True(t, ok)
+	require.False(t, state.Online)
+}
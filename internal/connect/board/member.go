@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package board
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// refreshIDs are packets which change state of bot on board.
+var refreshIDs = []byte{
+	fromgameserver.UserInfoID,
+	fromgameserver.StatusUpdateID,
+	fromgameserver.MoveToLocationID,
+	fromgameserver.StopMoveID,
+	fromgameserver.ValidateLocationID,
+	fromgameserver.MyTargetSelectedID,
+	fromgameserver.TargetSelectedID,
+	fromgameserver.TargetUnselectedID,
+	fromgameserver.MagicSkillUseID,
+	fromgameserver.MagicSkillLaunchedID,
+	fromgameserver.MagicSkillCanceldID,
+	fromgameserver.DeleteObjectID,
+	fromgameserver.AbnormalStatusUpdateID,
+}
+
+// Member puts state of one bot on board. State is read from world view
+// and combat of bot after they handle packets.
+type Member struct {
+	// mutex orders refreshes of bot, it isn't shared with other bots.
+	mutex  sync.Mutex
+	name   string
+	board  *Board
+	world  world.View
+	combat *combat.Combat
+	state  *atomic.Pointer[State]
+	online bool
+}
+
+// Join adds bot to board. Bot is offline until it is told otherwise.
+func (b *Board) Join(
+	name string,
+	model world.View,
+	fight *combat.Combat,
+) *Member {
+	state := &atomic.Pointer[State]{}
+	state.Store(&State{Name: name}) //nolint:exhaustruct
+
+	b.mutex.Lock()
+	b.states[name] = state
+	b.mutex.Unlock()
+
+	return &Member{
+		mutex:  sync.Mutex{},
+		name:   name,
+		board:  b,
+		world:  model,
+		combat: fight,
+		state:  state,
+		online: false,
+	}
+}
+
+// Register refreshes state of bot after packets which change it. It must be
+// called after world and combat are registered.
+func (m *Member) Register(dispatcher *dispatch.Dispatcher) {
+	for _, id := range refreshIDs {
+		dispatcher.Handle(id, m.handle)
+	}
+}
+
+func (m *Member) handle([]byte) error {
+	m.Refresh()
+
+	return nil
+}
+
+// SetOnline tells if bot is in game world. Offline bot keeps its last
+// known state.
+func (m *Member) SetOnline(online bool) {
+	m.mutex.Lock()
+	m.online = online
+	m.mutex.Unlock()
+
+	m.Refresh()
+}
+
+// Refresh puts current state of bot on board.
+func (m *Member) Refresh() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state := *m.state.Load()
+	state.Online = m.online
+	state.Updated = m.world.Now()
+
+	self, ok := m.world.Self()
+	if m.online && ok {
+		state.Character = self.Name
+		state.ObjectID = self.ObjectID
+		state.Position = self.At(state.Updated)
+		state.CurHP, state.MaxHP = self.CurHP, self.MaxHP
+		state.CurMP, state.MaxMP = self.CurMP, self.MaxMP
+		state.CurCP, state.MaxCP = self.CurCP, self.MaxCP
+		state.Target = m.combat.Target()
+		state.Aggro = m.aggro(self.ObjectID)
+		state.Casting, state.CastTarget = 0, 0
+		if cast, casting := m.combat.Casting(); casting {
+			state.Casting, state.CastTarget = cast.SkillID, cast.TargetID
+		}
+		state.Effects = m.combat.Effects()
+	}
+
+	m.board.put(m.state, state)
+}
+
+// aggro returns npcs which target character.
+func (m *Member) aggro(objectID int32) []int32 {
+	var result []int32
+	for _, id := range m.combat.Targeting(objectID) {
+		if entity, ok := m.world.ByObjectID(id); ok && isNpc(entity) {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// Leave removes bot from board.
+func (m *Member) Leave() {
+	m.board.mutex.Lock()
+	defer m.board.mutex.Unlock()
+
+	if m.board.states[m.name] == m.state {
+		delete(m.board.states, m.name)
+	}
+}
+
+func isNpc(entity world.Entity) bool {
+	_, ok := entity.(world.Npc)
+
+	return ok
+}
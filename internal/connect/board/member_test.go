@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package board
+
+import (
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	selfID    = 5
+	monsterID = 300
+	playerID  = 400
+	healID    = 1011
+	mightID   = 1068
+)
+
+// testBot is bot with world model and combat fed by packets.
+type testBot struct {
+	t          *testing.T
+	dispatcher *dispatch.Dispatcher
+	member     *Member
+}
+
+func newTestBot(t *testing.T, b *Board, name string) *testBot {
+	t.Helper()
+
+	model := world.New()
+	model.SetClock(func() time.Time { return time.Unix(1000, 0) })
+	fight := combat.New(model, nil)
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	fight.Register(dispatcher)
+	member := b.Join(name, model, fight)
+	member.Register(dispatcher)
+
+	return &testBot{t: t, dispatcher: dispatcher, member: member}
+}
+
+func (b *testBot) feed(id byte, p crypt.Serializable) {
+	b.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(b.t, p.ToBytes(writer))
+	require.NoError(b.t, b.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (b *testBot) enter() {
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = selfID
+	info.Name = "Tank"
+	info.X, info.Y, info.Z = 10, 20, 30
+	info.CurHP, info.MaxHP = 50, 100
+	info.CurMP, info.MaxMP = 20, 40
+	b.feed(fromgameserver.UserInfoID, info)
+}
+
+func (b *testBot) targeted(objectID, targetID int32) {
+	b.feed(fromgameserver.TargetSelectedID, &fromgameserver.TargetSelected{
+		ObjectID: objectID,
+		TargetID: targetID,
+		X:        0,
+		Y:        0,
+		Z:        0,
+	})
+}
+
+func TestMember_Refresh(t *testing.T) {
+	b := New()
+	bot := newTestBot(t, b, "tank")
+
+	state, ok := b.Get("tank")
+	require.True(t, ok)
+	require.Equal(t, "tank", state.Name)
+	require.False(t, state.Online)
+
+	bot.enter()
+	state, _ = b.Get("tank")
+	require.False(t, state.Online)
+	require.Zero(t, state.ObjectID, "offline bot isn't read from world")
+
+	bot.member.SetOnline(true)
+	state, _ = b.Get("tank")
+	require.True(t, state.Online)
+	require.Equal(t, "Tank", state.Character)
+	require.Equal(t, int32(selfID), state.ObjectID)
+	require.Equal(t, world.Position{X: 10, Y: 20, Z: 30}, state.Position)
+	require.InDelta(t, 0.5, state.HP(), 1e-9)
+	require.InDelta(t, 0.5, state.MP(), 1e-9)
+	require.Equal(t, time.Unix(1000, 0), state.Updated)
+
+	bot.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: selfID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurHP, Value: 25},
+		},
+	})
+	state, _ = b.Get("tank")
+	require.Equal(t, int32(25), state.CurHP)
+
+	npc := &fromgameserver.NpcInfo{} //nolint:exhaustruct
+	npc.ObjectID = monsterID
+	npc.Attackable = 1
+	bot.feed(fromgameserver.NpcInfoID, npc)
+	player := &fromgameserver.CharInfo{} //nolint:exhaustruct
+	player.ObjectID = playerID
+	bot.feed(fromgameserver.CharInfoID, player)
+	bot.targeted(monsterID, selfID)
+	bot.targeted(playerID, selfID)
+	state, _ = b.Get("tank")
+	require.Equal(t, []int32{monsterID}, state.Aggro,
+		"only npcs make aggro")
+
+	bot.feed(fromgameserver.MyTargetSelectedID,
+		&fromgameserver.MyTargetSelected{ObjectID: monsterID, Color: 0})
+	state, _ = b.Get("tank")
+	require.Equal(t, int32(monsterID), state.Target)
+
+	bot.feed(fromgameserver.MagicSkillUseID, &fromgameserver.MagicSkillUse{
+		CasterID:   selfID,
+		TargetID:   playerID,
+		SkillID:    healID,
+		SkillLevel: 1,
+		HitTime:    1500,
+		ReuseDelay: 0,
+		X:          0,
+		Y:          0,
+		Z:          0,
+	})
+	bot.feed(fromgameserver.AbnormalStatusUpdateID,
+		&fromgameserver.AbnormalStatusUpdate{
+			Effects: []fromgameserver.AbnormalEffect{
+				{SkillID: mightID, Level: 1, Duration: 60},
+			},
+		})
+	state, _ = b.Get("tank")
+	require.Equal(t, int32(healID), state.Casting)
+	require.Equal(t, int32(playerID), state.CastTarget)
+	require.Equal(t, []combat.Effect{{
+		SkillID: mightID,
+		Level:   1,
+		Expires: time.Unix(1060, 0),
+	}}, state.Effects)
+
+	bot.member.SetOnline(false)
+	state, _ = b.Get("tank")
+	require.False(t, state.Online)
+	require.Equal(t, int32(25), state.CurHP, "offline bot keeps last state")
+}
+
+func TestMember_Leave(t *testing.T) {
+	b := New()
+	first := newTestBot(t, b, "tank")
+	second := newTestBot(t, b, "tank")
+
+	first.member.Leave()
+	_, ok := b.Get("tank")
+	require.True(t, ok, "bot which joined again stays")
+
+	second.member.Leave()
+	_, ok = b.Get("tank")
+	require.False(t, ok)
+}
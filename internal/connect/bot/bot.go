@@ -0,0 +1,441 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/connection"
+)
+
+const DefaultLogoutTimeout = 10 * time.Second
+
+var (
+	ErrAlreadyRunning = errors.New("bot is already running")
+	ErrNotRunning     = errors.New("bot is not running")
+)
+
+type Status struct {
+	Name       string
+	State      State
+	Since      time.Time
+	Err        error
+	Reconnects int
+}
+
+// Gate decides when bot may use shared connector. Acquire blocks until it
+// is turn of bot, returned function must be called after connection attempt.
+type Gate interface {
+	Acquire(ctx context.Context, name string) (func(), error)
+}
+
+type StateListener func(status Status)
+
+// Bot is goroutine backed state machine of single game character.
+// All connections of bot go through shared connector, so rate limits of
+// server apply to all bots together.
+type Bot struct {
+	name          string
+	connector     connection.Connector
+	newSession    SessionFactory
+	logoutTimeout time.Duration
+	reconnect     ReconnectPolicy
+
+	mutex      sync.Mutex
+	state      State
+	since      time.Time
+	lastErr    error
+	reconnects int
+	snapshot   Snapshot
+	cancel     context.CancelFunc
+	done       chan struct{}
+	gate       Gate
+	listeners  []StateListener
+}
+
+func New(
+	name string,
+	connector connection.Connector,
+	newSession SessionFactory,
+) *Bot {
+	return &Bot{
+		name:          name,
+		connector:     connector,
+		newSession:    newSession,
+		logoutTimeout: DefaultLogoutTimeout,
+		reconnect:     DefaultReconnectPolicy(),
+		mutex:         sync.Mutex{},
+		state:         Disconnected,
+		since:         time.Now(),
+		lastErr:       nil,
+		reconnects:    0,
+		snapshot:      Snapshot{},
+		cancel:        nil,
+		done:          nil,
+		gate:          nil,
+		listeners:     nil,
+	}
+}
+
+// SetLogoutTimeout limits how long stopping bot waits for server to confirm
+// logout. Must be called before Start.
+func (b *Bot) SetLogoutTimeout(timeout time.Duration) {
+	b.logoutTimeout = timeout
+}
+
+// SetReconnectPolicy changes reconnect behavior of bot. Must be called
+// before Start.
+func (b *Bot) SetReconnectPolicy(policy ReconnectPolicy) {
+	b.reconnect = policy
+}
+
+// Snapshot returns state which bot restores after reconnect.
+func (b *Bot) Snapshot() Snapshot {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.snapshot.clone()
+}
+
+// UpdateSnapshot lets bot logic record state which must survive reconnect.
+func (b *Bot) UpdateSnapshot(update func(snapshot *Snapshot)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	update(&b.snapshot)
+}
+
+// SetGate makes bot wait for its turn before every connection attempt.
+func (b *Bot) SetGate(gate Gate) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.gate = gate
+}
+
+// OnStateChange registers listener called after every state change of bot.
+// Listeners are called from bot goroutine and must not block.
+func (b *Bot) OnStateChange(listener StateListener) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.listeners = append(b.listeners, listener)
+}
+
+func (b *Bot) notify(status Status, listeners []StateListener) {
+	for _, listener := range listeners {
+		listener(status)
+	}
+}
+
+func (b *Bot) Name() string {
+	return b.name
+}
+
+func (b *Bot) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+func (b *Bot) Status() Status {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.statusLocked()
+}
+
+func (b *Bot) statusLocked() Status {
+	return Status{
+		Name:       b.name,
+		State:      b.state,
+		Since:      b.since,
+		Err:        b.lastErr,
+		Reconnects: b.reconnects,
+	}
+}
+
+func (b *Bot) Running() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.cancel != nil
+}
+
+// Start runs bot in own goroutine until context is canceled, Stop is called
+// or session fails.
+func (b *Bot) Start(ctx context.Context) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.cancel != nil {
+		return fmt.Errorf("%w: %s", ErrAlreadyRunning, b.name)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	b.cancel = cancel
+	b.done = make(chan struct{})
+	b.lastErr = nil
+	b.reconnects = 0
+
+	go b.run(runCtx, b.done)
+
+	return nil
+}
+
+// Stop asks bot to leave game and waits until its goroutine finishes.
+func (b *Bot) Stop() error {
+	b.mutex.Lock()
+	cancel, done := b.cancel, b.done
+	b.mutex.Unlock()
+
+	if cancel == nil {
+		return fmt.Errorf("%w: %s", ErrNotRunning, b.name)
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+// Wait blocks until bot goroutine finishes and returns its error.
+func (b *Bot) Wait() error {
+	b.mutex.Lock()
+	done := b.done
+	b.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+
+	return b.Status().Err
+}
+
+func (b *Bot) setState(next State) {
+	b.mutex.Lock()
+	if b.state == next {
+		b.mutex.Unlock()
+
+		return
+	}
+	if !b.state.CanChangeTo(next) {
+		b.mutex.Unlock()
+		panic(fmt.Sprintf("bot %s: invalid state change %v -> %v",
+			b.name, b.state, next))
+	}
+
+	log.Printf("Bot %s: %v -> %v\n", b.name, b.state, next)
+	b.state = next
+	b.since = time.Now()
+	status, listeners := b.statusLocked(), b.listeners
+	b.mutex.Unlock()
+
+	b.notify(status, listeners)
+}
+
+// disconnected records error of lost session while bot waits to reconnect.
+func (b *Bot) disconnected(err error) {
+	b.setState(Disconnected)
+
+	b.mutex.Lock()
+	b.lastErr = err
+	b.reconnects++
+	b.mutex.Unlock()
+}
+
+func (b *Bot) finish(err error) {
+	b.mutex.Lock()
+	if b.state != Disconnected {
+		log.Printf("Bot %s: %v -> %v\n", b.name, b.state, Disconnected)
+		b.state = Disconnected
+		b.since = time.Now()
+	}
+	b.lastErr = err
+	b.cancel()
+	b.cancel = nil
+	status, listeners := b.statusLocked(), b.listeners
+	b.mutex.Unlock()
+
+	b.notify(status, listeners)
+}
+
+func (b *Bot) run(ctx context.Context, done chan struct{}) {
+	var err error
+	defer close(done)
+	defer func() {
+		// Panic of one bot must not take down rest of bots.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("bot %s panicked: %v", b.name, r)
+			log.Printf("Error %v\n%s", err, debug.Stack())
+		}
+		if err != nil {
+			log.Printf("Error in bot %s: %v\n", b.name, err)
+		}
+		b.finish(err)
+	}()
+
+	err = b.runSessions(ctx)
+}
+
+// runSessions keeps bot connected, lost connection is restored with growing
+// delays. Reaching game world resets delay.
+func (b *Bot) runSessions(ctx context.Context) error {
+	failures := 0
+	for attempt := 0; ; attempt++ {
+		inWorld, err := b.runSession(ctx, attempt > 0)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		if inWorld {
+			failures = 0
+		}
+		failures++
+		if IsPermanent(err) || !b.reconnect.Allows(failures) {
+			return err
+		}
+
+		delay := b.reconnect.Delay(failures)
+		log.Printf("Error in bot %s: %v, reconnecting in %v\n",
+			b.name, err, delay)
+		b.disconnected(err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// resume restores state of bot from before reconnect.
+func (b *Bot) resume(ctx context.Context, session Session) {
+	resumer, ok := session.(Resumer)
+	snapshot := b.Snapshot()
+	if !ok || snapshot.IsEmpty() {
+		return
+	}
+
+	if err := resumer.Resume(ctx, snapshot); err != nil {
+		log.Printf("Error resuming bot %s: %v\n", b.name, err)
+
+		return
+	}
+	log.Printf("Bot %s resumed %s\n", b.name, snapshot.Task)
+}
+
+func (b *Bot) connect(ctx context.Context) (net.Conn, error) {
+	b.mutex.Lock()
+	gate := b.gate
+	b.mutex.Unlock()
+
+	if gate != nil {
+		release, err := gate.Acquire(ctx, b.name)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	return b.connector.Connect()
+}
+
+// runSession runs single connection of bot. It reports if bot got into game
+// world, so reconnect delay can start from beginning.
+func (b *Bot) runSession(
+	ctx context.Context,
+	resume bool,
+) (bool, error) {
+	b.setState(Authenticating)
+	conn, err := b.connect(ctx)
+	if err != nil {
+		return false, sessionError(ctx, "failed to connect", err)
+	}
+
+	session := b.newSession()
+	defer func() {
+		b.setState(Stopping)
+		if err := session.Close(); err != nil {
+			log.Printf("Error closing session of bot %s: %v\n", b.name, err)
+		}
+		_ = conn.Close()
+	}()
+
+	// Blocking login calls of session are interrupted by closing conn.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	if err := session.Authenticate(ctx, conn); err != nil {
+		return false, sessionError(ctx, "failed to authenticate", err)
+	}
+
+	b.setState(SelectingChar)
+	if err := session.SelectCharacter(ctx); err != nil {
+		return false, sessionError(ctx, "failed to select character", err)
+	}
+	if err := session.EnterWorld(ctx); err != nil {
+		return false, sessionError(ctx, "failed to enter world", err)
+	}
+
+	// In world session must stay open until it logs out.
+	if !stop() {
+		return false, nil
+	}
+
+	b.setState(InWorld)
+	if resume {
+		b.resume(ctx, session)
+	}
+
+	err = session.Serve(ctx)
+	if ctx.Err() == nil {
+		if err == nil {
+			err = io.EOF
+		}
+
+		return true, fmt.Errorf("connection lost: %w", err)
+	}
+
+	return true, b.logout(ctx, session)
+}
+
+// logout leaves game world after stop request, so character doesn't stay
+// in game after program exits.
+func (b *Bot) logout(ctx context.Context, session Session) error {
+	b.setState(Stopping)
+
+	logoutCtx, cancel := context.WithTimeout(
+		context.WithoutCancel(ctx), b.logoutTimeout)
+	defer cancel()
+
+	if err := session.Logout(logoutCtx); err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	log.Printf("Bot %s logged out\n", b.name)
+
+	return nil
+}
+
+// sessionError hides errors caused by closing connection on stop request.
+func sessionError(ctx context.Context, message string, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
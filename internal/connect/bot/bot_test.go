@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pipeConnector struct {
+	mutex    sync.Mutex
+	attempts int
+	err      error
+}
+
+func (c *pipeConnector) Connect() (net.Conn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.attempts++
+	if c.err != nil {
+		return nil, c.err
+	}
+	client, server := net.Pipe()
+	_ = server.Close()
+
+	return client, nil
+}
+
+func (c *pipeConnector) Address() string {
+	return "pipe"
+}
+
+func (c *pipeConnector) Attempts() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.attempts
+}
+
+type fakeSession struct {
+	authErr    error
+	selectErr  error
+	serveErr   error
+	panicIn    string
+	logoutHang bool
+	loggedOut  bool
+	closed     bool
+}
+
+func (s *fakeSession) Authenticate(_ context.Context, _ net.Conn) error {
+	if s.panicIn == "auth" {
+		panic("broken packet")
+	}
+
+	return s.authErr
+}
+
+func (s *fakeSession) SelectCharacter(_ context.Context) error {
+	return s.selectErr
+}
+
+func (s *fakeSession) EnterWorld(_ context.Context) error {
+	return nil
+}
+
+func (s *fakeSession) Serve(ctx context.Context) error {
+	if s.serveErr != nil {
+		return s.serveErr
+	}
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (s *fakeSession) Logout(ctx context.Context) error {
+	if s.logoutHang {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+	s.loggedOut = true
+
+	return nil
+}
+
+func (s *fakeSession) Close() error {
+	s.closed = true
+
+	return nil
+}
+
+func newTestBot(name string, session Session) *Bot {
+	connector := &pipeConnector{}
+	bot := New(name, connector, func() Session { return session })
+	bot.SetReconnectPolicy(NoReconnect())
+
+	return bot
+}
+
+// eventually polls condition, testify Eventually of used version can panic.
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition is not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func waitForState(t *testing.T, bot *Bot, state State) {
+	t.Helper()
+
+	eventually(t, func() bool { return bot.State() == state })
+}
+
+func TestBot_StartStop(t *testing.T) {
+	session := &fakeSession{}
+	bot := newTestBot("tank", session)
+	require.Equal(t, Disconnected, bot.State())
+
+	require.NoError(t, bot.Start(context.Background()))
+	waitForState(t, bot, InWorld)
+	require.True(t, errors.Is(bot.Start(context.Background()),
+		ErrAlreadyRunning))
+
+	require.NoError(t, bot.Stop())
+	require.Equal(t, Disconnected, bot.State())
+	require.NoError(t, bot.Status().Err)
+	require.True(t, session.loggedOut)
+	require.True(t, session.closed)
+	require.False(t, bot.Running())
+
+	require.True(t, errors.Is(bot.Stop(), ErrNotRunning))
+}
+
+func TestBot_SessionErrors(t *testing.T) {
+	failure := errors.New("failure")
+	tests := []struct {
+		name    string
+		session *fakeSession
+		message string
+	}{
+		{
+			name:    "auth failure",
+			session: &fakeSession{authErr: failure},
+			message: "failed to authenticate",
+		},
+		{
+			name:    "character selection failure",
+			session: &fakeSession{selectErr: failure},
+			message: "failed to select character",
+		},
+		{
+			name:    "connection lost",
+			session: &fakeSession{serveErr: failure},
+			message: "connection lost",
+		},
+		{
+			name:    "panic",
+			session: &fakeSession{panicIn: "auth"},
+			message: "panicked: broken packet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := newTestBot("tank", tt.session)
+			require.NoError(t, bot.Start(context.Background()))
+
+			err := bot.Wait()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.message)
+			require.Equal(t, Disconnected, bot.State())
+			require.False(t, bot.Running())
+		})
+	}
+}
+
+func TestBot_NoLogoutWithoutWorld(t *testing.T) {
+	session := &fakeSession{selectErr: errors.New("failure")}
+	bot := newTestBot("tank", session)
+
+	require.NoError(t, bot.Start(context.Background()))
+	require.Error(t, bot.Wait())
+	require.False(t, session.loggedOut)
+	require.True(t, session.closed)
+}
+
+func TestBot_LogoutTimeout(t *testing.T) {
+	session := &fakeSession{logoutHang: true}
+	bot := newTestBot("tank", session)
+	bot.SetLogoutTimeout(20 * time.Millisecond)
+
+	require.NoError(t, bot.Start(context.Background()))
+	waitForState(t, bot, InWorld)
+
+	start := time.Now()
+	require.NoError(t, bot.Stop())
+	require.GreaterOrEqual(t, int64(time.Since(start)),
+		int64(20*time.Millisecond))
+
+	err := bot.Status().Err
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to logout")
+	require.True(t, session.closed)
+}
+
+func TestBot_ConnectError(t *testing.T) {
+	connector := &pipeConnector{err: errors.New("refused")}
+	bot := New("tank", connector, func() Session { return &fakeSession{} })
+	bot.SetReconnectPolicy(NoReconnect())
+
+	require.NoError(t, bot.Start(context.Background()))
+	require.Error(t, bot.Wait())
+	require.Equal(t, 1, connector.Attempts())
+}
+
+func TestBot_StopsWithParentContext(t *testing.T) {
+	bot := newTestBot("tank", &fakeSession{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, bot.Start(ctx))
+	waitForState(t, bot, InWorld)
+	cancel()
+
+	require.NoError(t, bot.Wait())
+	require.Equal(t, Disconnected, bot.State())
+}
+
+func TestState_CanChangeTo(t *testing.T) {
+	require.True(t, Disconnected.CanChangeTo(Authenticating))
+	require.False(t, Disconnected.CanChangeTo(InWorld))
+	require.True(t, SelectingChar.CanChangeTo(InWorld))
+	require.True(t, InWorld.CanChangeTo(Disconnected))
+	require.False(t, Stopping.CanChangeTo(InWorld))
+	require.Equal(t, "SelectingChar", SelectingChar.String())
+	require.Equal(t, "State(42)", State(42).String())
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// ReconnectPolicy decides if and when bot connects again after it lost
+// connection. Delay grows exponentially from MinDelay up to MaxDelay with
+// random jitter, so bots disconnected together don't reconnect in lockstep.
+// Zero MaxAttempts means unlimited attempts.
+type ReconnectPolicy struct {
+	Enabled     bool
+	MinDelay    time.Duration
+	MaxDelay    time.Duration
+	MaxAttempts int
+}
+
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		Enabled:     true,
+		MinDelay:    time.Second,
+		MaxDelay:    time.Minute,
+		MaxAttempts: 0,
+	}
+}
+
+func NoReconnect() ReconnectPolicy {
+	return ReconnectPolicy{
+		Enabled:     false,
+		MinDelay:    0,
+		MaxDelay:    0,
+		MaxAttempts: 0,
+	}
+}
+
+// Allows reports if bot may try again after given number of failed attempts
+// in a row.
+func (p ReconnectPolicy) Allows(failures int) bool {
+	if !p.Enabled {
+		return false
+	}
+
+	return p.MaxAttempts == 0 || failures < p.MaxAttempts
+}
+
+// Delay returns wait time before next attempt, failures starts from 1.
+func (p ReconnectPolicy) Delay(failures int) time.Duration {
+	delay := p.MinDelay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+	if delay <= 0 {
+		return 0
+	}
+
+	// Jitter in range [delay/2, delay).
+	half := delay / 2
+
+	return half + rand.N(delay-half) //nolint:gosec
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks session error which can't be fixed by reconnecting, like
+// wrong password or banned account. Errors with Permanent method returning
+// true are permanent too.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func (e *permanentError) Permanent() bool {
+	return true
+}
+
+func IsPermanent(err error) bool {
+	var permanent interface{ Permanent() bool }
+
+	return errors.As(err, &permanent) && permanent.Permanent()
+}
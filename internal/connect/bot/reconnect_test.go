@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resumingSession struct {
+	fakeSession
+	mutex   sync.Mutex
+	resumed []Snapshot
+}
+
+func (s *resumingSession) Resume(_ context.Context, snapshot Snapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.resumed = append(s.resumed, snapshot)
+
+	return nil
+}
+
+func (s *resumingSession) Resumed() []Snapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Snapshot{}, s.resumed...)
+}
+
+func fastReconnect(attempts int) ReconnectPolicy {
+	return ReconnectPolicy{
+		Enabled:     true,
+		MinDelay:    time.Millisecond,
+		MaxDelay:    2 * time.Millisecond,
+		MaxAttempts: attempts,
+	}
+}
+
+func TestReconnectPolicy_Delay(t *testing.T) {
+	policy := ReconnectPolicy{
+		Enabled:     true,
+		MinDelay:    100 * time.Millisecond,
+		MaxDelay:    time.Second,
+		MaxAttempts: 0,
+	}
+
+	tests := []struct {
+		failures int
+		max      time.Duration
+	}{
+		{failures: 1, max: 100 * time.Millisecond},
+		{failures: 2, max: 200 * time.Millisecond},
+		{failures: 3, max: 400 * time.Millisecond},
+		{failures: 10, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.failures), func(t *testing.T) {
+			for range 20 {
+				delay := policy.Delay(tt.failures)
+				require.GreaterOrEqual(t, int64(delay), int64(tt.max/2))
+				require.Less(t, int64(delay), int64(tt.max))
+			}
+		})
+	}
+
+	require.Equal(t, time.Duration(0), NoReconnect().Delay(3))
+}
+
+func TestReconnectPolicy_Allows(t *testing.T) {
+	require.False(t, NoReconnect().Allows(0))
+	require.True(t, DefaultReconnectPolicy().Allows(1000))
+	require.True(t, fastReconnect(2).Allows(1))
+	require.False(t, fastReconnect(2).Allows(2))
+}
+
+func TestPermanent(t *testing.T) {
+	base := errors.New("wrong password")
+	err := fmt.Errorf("failed to authenticate: %w", Permanent(base))
+
+	require.True(t, IsPermanent(err))
+	require.True(t, errors.Is(err, base))
+	require.False(t, IsPermanent(base))
+	require.False(t, IsPermanent(fmt.Errorf("login: %w", errors.ErrUnsupported)))
+	require.Equal(t, "wrong password", Permanent(base).Error())
+
+	require.True(t, IsPermanent(fmt.Errorf("login: %w", refusal(true))))
+	require.False(t, IsPermanent(fmt.Errorf("login: %w", refusal(false))))
+}
+
+// refusal is login error which knows if it is permanent.
+type refusal bool
+
+func (r refusal) Error() string {
+	return "refused"
+}
+
+func (r refusal) Permanent() bool {
+	return bool(r)
+}
+
+func TestBot_ReconnectsAndResumes(t *testing.T) {
+	first := &resumingSession{}
+	first.serveErr = errors.New("connection reset")
+	second := &resumingSession{}
+
+	sessions := []Session{first, second}
+	bot := New("tank", &pipeConnector{}, func() Session {
+		session := sessions[0]
+		sessions = sessions[1:]
+
+		return session
+	})
+	bot.SetReconnectPolicy(fastReconnect(0))
+	bot.UpdateSnapshot(func(snapshot *Snapshot) {
+		snapshot.TargetID = 42
+		snapshot.Task = "farm"
+		snapshot.PartyRole = "tank"
+	})
+
+	require.NoError(t, bot.Start(context.Background()))
+	eventually(t, func() bool { return len(second.Resumed()) == 1 })
+	require.Equal(t, InWorld, bot.State())
+	require.Empty(t, first.Resumed())
+
+	snapshot := second.Resumed()[0]
+	require.Equal(t, int32(42), snapshot.TargetID)
+	require.Equal(t, "farm", snapshot.Task)
+	require.Equal(t, "tank", snapshot.PartyRole)
+
+	status := bot.Status()
+	require.Equal(t, 1, status.Reconnects)
+	require.Error(t, status.Err)
+
+	require.NoError(t, bot.Stop())
+	require.True(t, second.loggedOut)
+}
+
+func TestBot_StopsAfterMaxAttempts(t *testing.T) {
+	connector := &pipeConnector{err: errors.New("refused")}
+	bot := New("tank", connector, func() Session { return &fakeSession{} })
+	bot.SetReconnectPolicy(fastReconnect(3))
+
+	require.NoError(t, bot.Start(context.Background()))
+	require.Error(t, bot.Wait())
+	require.Equal(t, 3, connector.Attempts())
+	require.Equal(t, 2, bot.Status().Reconnects)
+}
+
+func TestBot_PermanentErrorStopsReconnect(t *testing.T) {
+	connector := &pipeConnector{}
+	session := &fakeSession{authErr: Permanent(errors.New("banned"))}
+	bot := New("tank", connector, func() Session { return session })
+	bot.SetReconnectPolicy(fastReconnect(0))
+
+	require.NoError(t, bot.Start(context.Background()))
+	require.Error(t, bot.Wait())
+	require.Equal(t, 1, connector.Attempts())
+}
+
+func TestBot_StopWhileWaitingToReconnect(t *testing.T) {
+	connector := &pipeConnector{err: errors.New("refused")}
+	bot := New("tank", connector, func() Session { return &fakeSession{} })
+	bot.SetReconnectPolicy(ReconnectPolicy{
+		Enabled:     true,
+		MinDelay:    time.Hour,
+		MaxDelay:    time.Hour,
+		MaxAttempts: 0,
+	})
+
+	require.NoError(t, bot.Start(context.Background()))
+	eventually(t, func() bool { return bot.Status().Reconnects == 1 })
+	require.NoError(t, bot.Stop())
+	require.False(t, bot.Running())
+}
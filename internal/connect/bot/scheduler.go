@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const DefaultLoginSettle = 500 * time.Millisecond
+
+var ErrLoginCycle = errors.New("login dependencies form a cycle")
+
+// LoginEntry describes place of bot in login order. Bots with lower priority
+// value log in first, After lists bots which must be in world before this one
+// may connect.
+type LoginEntry struct {
+	Name     string
+	Priority int
+	After    []string
+}
+
+type loginRequest struct {
+	name    string
+	arrived time.Time
+}
+
+// LoginScheduler is Gate which lets bots connect one by one in order of
+// roster. Each request waits for settle time before it can be granted, so
+// after mass reconnect all disconnected bots get into queue and are
+// ordered again instead of connecting in order of arrival.
+type LoginScheduler struct {
+	settle time.Duration
+
+	mutex   sync.Mutex
+	rank    map[string]int
+	after   map[string][]string
+	bots    map[string]*Bot
+	waiting []*loginRequest
+	holder  string
+	changed chan struct{}
+}
+
+func NewLoginScheduler(
+	roster []LoginEntry,
+	settle time.Duration,
+) (*LoginScheduler, error) {
+	order, err := loginOrder(roster)
+	if err != nil {
+		return nil, err
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+	after := make(map[string][]string, len(roster))
+	for _, entry := range roster {
+		after[entry.Name] = entry.After
+	}
+
+	return &LoginScheduler{
+		settle:  settle,
+		mutex:   sync.Mutex{},
+		rank:    rank,
+		after:   after,
+		bots:    make(map[string]*Bot),
+		waiting: nil,
+		holder:  "",
+		changed: make(chan struct{}),
+	}, nil
+}
+
+// loginOrder sorts roster by priority while keeping every bot after its
+// dependencies. Bots with same priority keep order of roster.
+func loginOrder(roster []LoginEntry) ([]string, error) {
+	index := make(map[string]int, len(roster))
+	for i, entry := range roster {
+		if _, ok := index[entry.Name]; ok {
+			return nil, fmt.Errorf("bot %s is listed twice in roster", entry.Name)
+		}
+		index[entry.Name] = i
+	}
+
+	blockers := make([]int, len(roster))
+	for i, entry := range roster {
+		for _, dependency := range entry.After {
+			if _, ok := index[dependency]; !ok {
+				return nil, fmt.Errorf("bot %s waits for unknown bot %s",
+					entry.Name, dependency)
+			}
+			blockers[i]++
+		}
+	}
+
+	order := make([]string, 0, len(roster))
+	done := make([]bool, len(roster))
+	for len(order) < len(roster) {
+		next := -1
+		for i, entry := range roster {
+			if done[i] || blockers[i] > 0 {
+				continue
+			}
+			if next == -1 || entry.Priority < roster[next].Priority {
+				next = i
+			}
+		}
+		if next == -1 {
+			return nil, ErrLoginCycle
+		}
+
+		done[next] = true
+		order = append(order, roster[next].Name)
+		for i, entry := range roster {
+			for _, dependency := range entry.After {
+				if dependency == roster[next].Name {
+					blockers[i]--
+				}
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// Attach makes all bots of supervisor connect through scheduler. Bots
+// missing from roster connect after all bots of roster.
+func (s *LoginScheduler) Attach(supervisor *Supervisor) {
+	for _, bot := range supervisor.Bots() {
+		s.mutex.Lock()
+		s.bots[bot.Name()] = bot
+		if _, ok := s.rank[bot.Name()]; !ok {
+			s.rank[bot.Name()] = len(s.rank)
+		}
+		s.mutex.Unlock()
+
+		bot.SetGate(s)
+		bot.OnStateChange(func(Status) { s.wake() })
+	}
+}
+
+// Order returns names of bots in login order.
+func (s *LoginScheduler) Order() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	order := make([]string, 0, len(s.rank))
+	for name := range s.rank {
+		order = append(order, name)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return s.rank[order[i]] < s.rank[order[j]]
+	})
+
+	return order
+}
+
+// Waiting returns names of bots waiting for their turn in login order.
+func (s *LoginScheduler) Waiting() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names := make([]string, 0, len(s.waiting))
+	for _, request := range s.waiting {
+		names = append(names, request.name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return s.rank[names[i]] < s.rank[names[j]]
+	})
+
+	return names
+}
+
+func (s *LoginScheduler) Acquire(
+	ctx context.Context,
+	name string,
+) (func(), error) {
+	request := &loginRequest{name: name, arrived: time.Now()}
+
+	s.mutex.Lock()
+	s.waiting = append(s.waiting, request)
+	s.mutex.Unlock()
+
+	timer := time.NewTimer(s.settle)
+	defer timer.Stop()
+
+	for {
+		s.mutex.Lock()
+		if s.grantLocked(request) {
+			s.mutex.Unlock()
+
+			return sync.OnceFunc(s.release), nil
+		}
+		changed := s.changed
+		s.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			s.mutex.Lock()
+			s.removeLocked(request)
+			s.mutex.Unlock()
+			s.wake()
+
+			return nil, ctx.Err()
+		case <-changed:
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *LoginScheduler) grantLocked(request *loginRequest) bool {
+	if s.holder != "" || time.Since(request.arrived) < s.settle {
+		return false
+	}
+	if s.nextLocked() != request {
+		return false
+	}
+
+	s.removeLocked(request)
+	s.holder = request.name
+
+	return true
+}
+
+// nextLocked picks waiting bot with best rank whose dependencies are in
+// world. Dependencies which are not running don't block anyone.
+func (s *LoginScheduler) nextLocked() *loginRequest {
+	var next *loginRequest
+	for _, request := range s.waiting {
+		if time.Since(request.arrived) < s.settle || !s.readyLocked(request) {
+			continue
+		}
+		if next == nil || s.rank[request.name] < s.rank[next.name] {
+			next = request
+		}
+	}
+
+	return next
+}
+
+func (s *LoginScheduler) readyLocked(request *loginRequest) bool {
+	for _, dependency := range s.after[request.name] {
+		bot, ok := s.bots[dependency]
+		if !ok {
+			continue
+		}
+		if bot.Running() && bot.State() != InWorld {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *LoginScheduler) removeLocked(request *loginRequest) {
+	for i, waiting := range s.waiting {
+		if waiting == request {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+
+			return
+		}
+	}
+}
+
+func (s *LoginScheduler) release() {
+	s.mutex.Lock()
+	s.holder = ""
+	s.mutex.Unlock()
+	s.wake()
+}
+
+func (s *LoginScheduler) wake() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
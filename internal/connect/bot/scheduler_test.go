@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type connectLog struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (l *connectLog) add(name string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.names = append(l.names, name)
+}
+
+func (l *connectLog) Names() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]string{}, l.names...)
+}
+
+type loggingConnector struct {
+	name string
+	log  *connectLog
+}
+
+func (c *loggingConnector) Connect() (net.Conn, error) {
+	c.log.add(c.name)
+	client, server := net.Pipe()
+	_ = server.Close()
+
+	return client, nil
+}
+
+func (c *loggingConnector) Address() string {
+	return "pipe"
+}
+
+type gatedSession struct {
+	fakeSession
+	selected chan struct{}
+}
+
+func (s *gatedSession) SelectCharacter(ctx context.Context) error {
+	select {
+	case <-s.selected:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newLoggedBots(
+	t *testing.T,
+	log *connectLog,
+	sessions map[string]Session,
+	names ...string,
+) *Supervisor {
+	t.Helper()
+
+	supervisor := NewSupervisor()
+	for _, name := range names {
+		session, ok := sessions[name]
+		if !ok {
+			session = &fakeSession{}
+		}
+		connector := &loggingConnector{name: name, log: log}
+		bot := New(name, connector, func() Session { return session })
+		bot.SetReconnectPolicy(NoReconnect())
+		require.NoError(t, supervisor.Add(bot))
+	}
+
+	return supervisor
+}
+
+func TestLoginOrder(t *testing.T) {
+	order, err := loginOrder([]LoginEntry{
+		{Name: "nuker", Priority: 2, After: nil},
+		{Name: "healer", Priority: 1, After: []string{"tank"}},
+		{Name: "tank", Priority: 5, After: nil},
+		{Name: "archer", Priority: 2, After: nil},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"nuker", "archer", "tank", "healer"}, order)
+}
+
+func TestLoginOrder_Errors(t *testing.T) {
+	_, err := loginOrder([]LoginEntry{
+		{Name: "a", Priority: 0, After: []string{"b"}},
+		{Name: "b", Priority: 0, After: []string{"a"}},
+	})
+	require.True(t, errors.Is(err, ErrLoginCycle))
+
+	_, err = loginOrder([]LoginEntry{
+		{Name: "a", Priority: 0, After: []string{"ghost"}},
+	})
+	require.Error(t, err)
+
+	_, err = loginOrder([]LoginEntry{
+		{Name: "a", Priority: 0, After: nil},
+		{Name: "a", Priority: 1, After: nil},
+	})
+	require.Error(t, err)
+}
+
+func TestLoginScheduler_ConnectsInPriorityOrder(t *testing.T) {
+	log := &connectLog{}
+	supervisor := newLoggedBots(t, log, nil, "nuker", "healer", "tank")
+
+	scheduler, err := NewLoginScheduler([]LoginEntry{
+		{Name: "tank", Priority: 0, After: nil},
+		{Name: "healer", Priority: 1, After: nil},
+		{Name: "nuker", Priority: 2, After: nil},
+	}, 50*time.Millisecond)
+	require.NoError(t, err)
+	scheduler.Attach(supervisor)
+	require.Equal(t, []string{"tank", "healer", "nuker"}, scheduler.Order())
+
+	require.NoError(t, supervisor.StartAll(context.Background()))
+	eventually(t, func() bool { return len(log.Names()) == 3 })
+	require.Equal(t, []string{"tank", "healer", "nuker"}, log.Names())
+
+	// Mass reconnect is ordered again.
+	supervisor.StopAll()
+	require.NoError(t, supervisor.StartAll(context.Background()))
+	eventually(t, func() bool { return len(log.Names()) == 6 })
+	require.Equal(t, []string{"tank", "healer", "nuker"}, log.Names()[3:])
+
+	supervisor.StopAll()
+}
+
+func TestLoginScheduler_WaitsForDependencyInWorld(t *testing.T) {
+	log := &connectLog{}
+	tankSession := &gatedSession{selected: make(chan struct{})}
+	supervisor := newLoggedBots(t, log,
+		map[string]Session{"tank": tankSession}, "tank", "healer")
+
+	scheduler, err := NewLoginScheduler([]LoginEntry{
+		{Name: "tank", Priority: 1, After: nil},
+		{Name: "healer", Priority: 0, After: []string{"tank"}},
+	}, 0)
+	require.NoError(t, err)
+	scheduler.Attach(supervisor)
+
+	require.NoError(t, supervisor.StartAll(context.Background()))
+	tank, err := supervisor.Bot("tank")
+	require.NoError(t, err)
+	waitForState(t, tank, SelectingChar)
+
+	eventually(t, func() bool {
+		return len(scheduler.Waiting()) == 1
+	})
+	require.Equal(t, []string{"tank"}, log.Names())
+	require.Equal(t, []string{"healer"}, scheduler.Waiting())
+
+	close(tankSession.selected)
+	eventually(t, func() bool { return len(log.Names()) == 2 })
+	require.Equal(t, []string{"tank", "healer"}, log.Names())
+
+	supervisor.StopAll()
+}
+
+func TestLoginScheduler_StopWhileWaiting(t *testing.T) {
+	log := &connectLog{}
+	tankSession := &gatedSession{selected: make(chan struct{})}
+	supervisor := newLoggedBots(t, log,
+		map[string]Session{"tank": tankSession}, "tank", "healer")
+
+	scheduler, err := NewLoginScheduler([]LoginEntry{
+		{Name: "tank", Priority: 0, After: nil},
+		{Name: "healer", Priority: 1, After: []string{"tank"}},
+	}, 0)
+	require.NoError(t, err)
+	scheduler.Attach(supervisor)
+	require.NoError(t, supervisor.StartAll(context.Background()))
+
+	eventually(t, func() bool {
+		return len(scheduler.Waiting()) == 1
+	})
+	require.NoError(t, supervisor.Stop("healer"))
+	require.Empty(t, scheduler.Waiting())
+	require.Equal(t, []string{"tank"}, log.Names())
+
+	supervisor.StopAll()
+}
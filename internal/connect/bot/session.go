@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"net"
+)
+
+// Session is one connection of bot to game. Bot creates new session for
+// every connection attempt and calls its methods in order of states.
+type Session interface {
+	// Authenticate logs in with account credentials over fresh connection.
+	Authenticate(ctx context.Context, conn net.Conn) error
+	SelectCharacter(ctx context.Context) error
+	EnterWorld(ctx context.Context) error
+	// Serve handles game packets until context is done or connection fails.
+	Serve(ctx context.Context) error
+	// Logout leaves game world and waits for confirmation until deadline.
+	Logout(ctx context.Context) error
+	Close() error
+}
+
+type SessionFactory func() Session
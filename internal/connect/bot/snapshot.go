@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"maps"
+)
+
+// Snapshot is part of bot state which survives reconnects, so bot continues
+// its work after connection loss instead of starting over.
+type Snapshot struct {
+	TargetID  int32
+	Task      string
+	PartyRole string
+	Values    map[string]string
+}
+
+func (s Snapshot) IsEmpty() bool {
+	return s.TargetID == 0 && s.Task == "" && s.PartyRole == "" &&
+		len(s.Values) == 0
+}
+
+func (s Snapshot) clone() Snapshot {
+	s.Values = maps.Clone(s.Values)
+
+	return s
+}
+
+// Resumer is implemented by sessions which can restore state of bot in game
+// world, like selecting previous target, after reconnect.
+type Resumer interface {
+	Resume(ctx context.Context, snapshot Snapshot) error
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import "strconv"
+
+type State int32
+
+const (
+	Disconnected State = iota
+	Authenticating
+	SelectingChar
+	InWorld
+	Stopping
+)
+
+func (s State) String() string {
+	switch s {
+	case Disconnected:
+		return "Disconnected"
+	case Authenticating:
+		return "Authenticating"
+	case SelectingChar:
+		return "SelectingChar"
+	case InWorld:
+		return "InWorld"
+	case Stopping:
+		return "Stopping"
+	}
+
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// CanChangeTo reports if bot is allowed to move from state s to next state.
+// Any active state can fall back to Disconnected on connection error.
+func (s State) CanChangeTo(next State) bool {
+	switch s {
+	case Disconnected:
+		return next == Authenticating
+	case Authenticating:
+		return next == SelectingChar || next == Stopping || next == Disconnected
+	case SelectingChar:
+		return next == InWorld || next == Stopping || next == Disconnected
+	case InWorld, Stopping:
+		return next == Stopping || next == Disconnected
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrUnknownBot   = errors.New("unknown bot")
+	ErrDuplicateBot = errors.New("bot with same name already exists")
+)
+
+// Supervisor owns all bots of program instance and lets to start, stop and
+// query them by name. Bots are kept in order of addition.
+type Supervisor struct {
+	mutex sync.Mutex
+	bots  map[string]*Bot
+	names []string
+}
+
+func NewSupervisor() *Supervisor {
+	return &Supervisor{
+		mutex: sync.Mutex{},
+		bots:  make(map[string]*Bot),
+		names: nil,
+	}
+}
+
+func (s *Supervisor) Add(bot *Bot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.bots[bot.Name()]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateBot, bot.Name())
+	}
+	s.bots[bot.Name()] = bot
+	s.names = append(s.names, bot.Name())
+
+	return nil
+}
+
+func (s *Supervisor) Bot(name string) (*Bot, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bot, ok := s.bots[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBot, name)
+	}
+
+	return bot, nil
+}
+
+// Bots returns all bots in order of addition.
+func (s *Supervisor) Bots() []*Bot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bots := make([]*Bot, 0, len(s.names))
+	for _, name := range s.names {
+		bots = append(bots, s.bots[name])
+	}
+
+	return bots
+}
+
+func (s *Supervisor) Start(ctx context.Context, name string) error {
+	bot, err := s.Bot(name)
+	if err != nil {
+		return err
+	}
+
+	return bot.Start(ctx)
+}
+
+func (s *Supervisor) Stop(name string) error {
+	bot, err := s.Bot(name)
+	if err != nil {
+		return err
+	}
+
+	return bot.Stop()
+}
+
+func (s *Supervisor) Restart(ctx context.Context, name string) error {
+	bot, err := s.Bot(name)
+	if err != nil {
+		return err
+	}
+	if err := bot.Stop(); err != nil && !errors.Is(err, ErrNotRunning) {
+		return err
+	}
+
+	return bot.Start(ctx)
+}
+
+func (s *Supervisor) Status(name string) (Status, error) {
+	bot, err := s.Bot(name)
+	if err != nil {
+		return Status{}, err
+	}
+
+	return bot.Status(), nil
+}
+
+func (s *Supervisor) Statuses() []Status {
+	bots := s.Bots()
+	statuses := make([]Status, 0, len(bots))
+	for _, bot := range bots {
+		statuses = append(statuses, bot.Status())
+	}
+
+	return statuses
+}
+
+// StartAll starts every bot which is not running yet.
+func (s *Supervisor) StartAll(ctx context.Context) error {
+	var errs []error
+	for _, bot := range s.Bots() {
+		if bot.Running() {
+			continue
+		}
+		errs = append(errs, bot.Start(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// StopAll stops all running bots in parallel and waits for them.
+func (s *Supervisor) StopAll() {
+	var wg sync.WaitGroup
+	for _, bot := range s.Bots() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = bot.Stop()
+		}()
+	}
+	wg.Wait()
+}
+
+// Wait blocks until context is done and all bots finish, then returns their
+// joined errors. Bots are started, stopped and reconnected many times while
+// program runs, so their lifecycle doesn't end waiting, only context does.
+func (s *Supervisor) Wait(ctx context.Context) error {
+	<-ctx.Done()
+
+	var errs []error
+	for _, bot := range s.Bots() {
+		if err := bot.Wait(); err != nil {
+			errs = append(errs, fmt.Errorf("bot %s: %w", bot.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor_AddAndQuery(t *testing.T) {
+	supervisor := NewSupervisor()
+	require.NoError(t, supervisor.Add(newTestBot("tank", &fakeSession{})))
+	require.NoError(t, supervisor.Add(newTestBot("healer", &fakeSession{})))
+
+	err := supervisor.Add(newTestBot("tank", &fakeSession{}))
+	require.True(t, errors.Is(err, ErrDuplicateBot))
+
+	_, err = supervisor.Status("nuker")
+	require.True(t, errors.Is(err, ErrUnknownBot))
+
+	statuses := supervisor.Statuses()
+	require.Len(t, statuses, 2)
+	require.Equal(t, "tank", statuses[0].Name)
+	require.Equal(t, "healer", statuses[1].Name)
+	require.Equal(t, Disconnected, statuses[1].State)
+}
+
+func TestSupervisor_StartStopByName(t *testing.T) {
+	supervisor := NewSupervisor()
+	tank := newTestBot("tank", &fakeSession{})
+	healer := newTestBot("healer", &fakeSession{})
+	require.NoError(t, supervisor.Add(tank))
+	require.NoError(t, supervisor.Add(healer))
+
+	ctx := context.Background()
+	require.NoError(t, supervisor.Start(ctx, "tank"))
+	waitForState(t, tank, InWorld)
+	require.Equal(t, Disconnected, healer.State())
+
+	require.NoError(t, supervisor.Restart(ctx, "tank"))
+	waitForState(t, tank, InWorld)
+
+	require.NoError(t, supervisor.Stop("tank"))
+	require.Equal(t, Disconnected, tank.State())
+	require.True(t, errors.Is(supervisor.Stop("nuker"), ErrUnknownBot))
+}
+
+func TestSupervisor_PanicIsIsolated(t *testing.T) {
+	supervisor := NewSupervisor()
+	broken := newTestBot("broken", &fakeSession{panicIn: "auth"})
+	healthy := newTestBot("healthy", &fakeSession{})
+	require.NoError(t, supervisor.Add(broken))
+	require.NoError(t, supervisor.Add(healthy))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, supervisor.StartAll(ctx))
+	require.Error(t, broken.Wait())
+	waitForState(t, healthy, InWorld)
+
+	supervisor.StopAll()
+	cancel()
+	err := supervisor.Wait(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bot broken")
+	require.NotContains(t, err.Error(), "bot healthy")
+}
+
+func TestSupervisor_WaitOutlivesRestartAndStop(t *testing.T) {
+	supervisor := NewSupervisor()
+	tank := newTestBot("tank", &fakeSession{})
+	require.NoError(t, supervisor.Add(tank))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, supervisor.Start(ctx, "tank"))
+	waitForState(t, tank, InWorld)
+
+	waited := make(chan error, 1)
+	go func() { waited <- supervisor.Wait(ctx) }()
+
+	require.NoError(t, supervisor.Restart(ctx, "tank"))
+	waitForState(t, tank, InWorld)
+	require.NoError(t, supervisor.Stop("tank"))
+	select {
+	case err := <-waited:
+		t.Fatalf("wait returned before context is done: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, supervisor.Start(ctx, "tank"))
+	waitForState(t, tank, InWorld)
+	cancel()
+	require.NoError(t, <-waited)
+	require.False(t, tank.Running())
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package burst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/config"
+)
+
+var (
+	ErrNotInGame = errors.New("character is not in game")
+	ErrNoTarget  = errors.New("target is not known")
+	ErrNoSpeed   = errors.New("character can't move to target")
+	ErrNoBots    = errors.New("burst has no bots")
+)
+
+// Action is something bot does for burst. Lead is how long before impact
+// action must start, Fire does it and returns time it reached target, zero
+// time means impact isn't reported by server.
+type Action struct {
+	Bot  string
+	Lead time.Duration
+	Fire func(ctx context.Context) (time.Time, error)
+}
+
+// Skill is attack skill with known timings. Projectile speed is in game
+// units per second, zero speed means skill hits at once.
+type Skill struct {
+	SkillID         int32
+	CastTime        time.Duration
+	ProjectileSpeed float64
+}
+
+// SpellLead returns time from start of cast until skill hits target at
+// distance.
+func SpellLead(skill Skill, distance float64) time.Duration {
+	if skill.ProjectileSpeed <= 0 {
+		return skill.CastTime
+	}
+
+	return skill.CastTime + seconds(distance/skill.ProjectileSpeed)
+}
+
+// MeleeLead returns time from attack order until first swing hits target at
+// distance. Character runs to target first if it is out of reach.
+func MeleeLead(
+	distance, reach, speed float64,
+	swing time.Duration,
+) (time.Duration, error) {
+	way := distance - reach
+	if way <= 0 {
+		return swing, nil
+	}
+	if speed <= 0 {
+		return 0, fmt.Errorf("%w: %.0f away", ErrNoSpeed, way)
+	}
+
+	return seconds(way/speed) + swing, nil
+}
+
+// Spell returns action of agent which casts skill at target.
+func Spell(a *agent.Agent, skill Skill, targetID int32) (Action, error) {
+	distance, _, err := approach(a, targetID)
+	if err != nil {
+		return Action{}, err //nolint:exhaustruct
+	}
+
+	return Action{
+		Bot:  a.Name,
+		Lead: SpellLead(skill, distance),
+		Fire: func(ctx context.Context) (time.Time, error) {
+			cast, err := a.Combat.Cast(ctx, combat.Request{
+				SkillID:  skill.SkillID,
+				TargetID: targetID,
+				Ctrl:     false,
+				Shift:    false,
+			})
+
+			return cast.Launched, err
+		},
+	}, nil
+}
+
+// Melee returns action of agent which attacks target with weapon. Reach is
+// attack range and swing is time of one attack.
+func Melee(
+	a *agent.Agent,
+	targetID int32,
+	reach float64,
+	swing time.Duration,
+) (Action, error) {
+	distance, speed, err := approach(a, targetID)
+	if err != nil {
+		return Action{}, err //nolint:exhaustruct
+	}
+	lead, err := MeleeLead(distance, reach, speed, swing)
+	if err != nil {
+		return Action{}, err //nolint:exhaustruct
+	}
+
+	return Action{
+		Bot:  a.Name,
+		Lead: lead,
+		Fire: func(context.Context) (time.Time, error) {
+			return time.Time{}, a.Combat.Attack(targetID, false)
+		},
+	}, nil
+}
+
+// Emote returns action of agent which plays social action. Soulshot with
+// shot item id is used right before emote for its glow, zero id means no
+// soulshot.
+func Emote(a *agent.Agent, actionID, shotItemID int32) Action {
+	return Action{
+		Bot:  a.Name,
+		Lead: 0,
+		Fire: func(ctx context.Context) (time.Time, error) {
+			if shotItemID != 0 {
+				if err := a.Inventory.UseByItemID(shotItemID); err != nil {
+					return time.Time{}, err
+				}
+			}
+
+			return a.Combat.Social(ctx, actionID)
+		},
+	}
+}
+
+// FromConfig returns actions of burst at target, emotes ignore target.
+// Bots leased by other instances are left out.
+func FromConfig(
+	cfg config.Burst,
+	agents map[string]*agent.Agent,
+	targetID int32,
+) ([]Action, error) {
+	actions := make([]Action, 0, len(cfg.Actions))
+	for _, settings := range cfg.Actions {
+		a, ok := agents[settings.Login]
+		if !ok {
+			continue
+		}
+		action, err := fromConfig(settings, a, targetID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", settings.Login, err)
+		}
+		actions = append(actions, action)
+	}
+	if len(actions) == 0 {
+		return nil, ErrNoBots
+	}
+
+	return actions, nil
+}
+
+func fromConfig(
+	settings config.BurstAction,
+	a *agent.Agent,
+	targetID int32,
+) (Action, error) {
+	switch settings.Kind {
+	case "spell":
+		return Spell(a, Skill{
+			SkillID:         settings.SkillID,
+			CastTime:        settings.CastTime.Duration,
+			ProjectileSpeed: settings.ProjectileSpeed,
+		}, targetID)
+	case "melee":
+		return Melee(a, targetID, settings.Reach, settings.Swing.Duration)
+	default:
+		return Emote(a, settings.SocialID, settings.ShotItemID), nil
+	}
+}
+
+// approach returns distance from character of agent to target and speed of
+// character.
+func approach(a *agent.Agent, targetID int32) (float64, float64, error) {
+	self, ok := a.View.Self()
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %s", ErrNotInGame, a.Name)
+	}
+	target, ok := a.View.ByObjectID(targetID)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %d", ErrNoTarget, targetID)
+	}
+	now := a.View.Now()
+
+	return self.At(now).Distance(target.At(now)), self.Speed(), nil
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package burst
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/geodata"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+const monsterID = 300
+
+func feed(t *testing.T, a *agent.Agent, id byte, p crypt.Serializable) {
+	t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(t, p.ToBytes(writer))
+	require.NoError(t, a.Dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+// newAgent returns agent in game with monster at distance.
+func newAgent(t *testing.T, distance int32) *agent.Agent {
+	t.Helper()
+
+	a := agent.New("dps", geodata.Open(""))
+	a.World.SetClock(func() time.Time { return time.Unix(1000, 0) })
+
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = 5
+	info.RunSpeed, info.WalkSpeed = 200, 100
+	feed(t, a, fromgameserver.UserInfoID, info)
+
+	npc := &fromgameserver.NpcInfo{} //nolint:exhaustruct
+	npc.ObjectID = monsterID
+	npc.X = distance
+	feed(t, a, fromgameserver.NpcInfoID, npc)
+
+	return a
+}
+
+func TestSpellLead(t *testing.T) {
+	tests := []struct {
+		name     string
+		skill    Skill
+		distance float64
+		lead     time.Duration
+	}{
+		{
+			name: "instant hit",
+			skill: Skill{SkillID: 1, CastTime: time.Second,
+				ProjectileSpeed: 0},
+			distance: 900,
+			lead:     time.Second,
+		},
+		{
+			name: "projectile",
+			skill: Skill{SkillID: 1, CastTime: time.Second,
+				ProjectileSpeed: 600},
+			distance: 900,
+			lead:     2500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.lead, SpellLead(test.skill, test.distance))
+		})
+	}
+}
+
+func TestMeleeLead(t *testing.T) {
+	tests := []struct {
+		name     string
+		distance float64
+		speed    float64
+		lead     time.Duration
+		err      error
+	}{
+		{
+			name:     "in reach",
+			distance: 30,
+			speed:    0,
+			lead:     500 * time.Millisecond,
+			err:      nil,
+		},
+		{
+			name:     "runs first",
+			distance: 440,
+			speed:    200,
+			lead:     2500 * time.Millisecond,
+			err:      nil,
+		},
+		{
+			name:     "can't move",
+			distance: 440,
+			speed:    0,
+			lead:     0,
+			err:      ErrNoSpeed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lead, err := MeleeLead(test.distance, 40, test.speed,
+				500*time.Millisecond)
+			require.True(t, errors.Is(err, test.err), err)
+			require.Equal(t, test.lead, lead)
+		})
+	}
+}
+
+func TestSpell(t *testing.T) {
+	a := newAgent(t, 600)
+	action, err := Spell(a, Skill{SkillID: 1177, CastTime: time.Second,
+		ProjectileSpeed: 600}, monsterID)
+	require.NoError(t, err)
+	require.Equal(t, "dps", action.Bot)
+	require.Equal(t, 2*time.Second, action.Lead)
+
+	_, err = Spell(a, Skill{SkillID: 1177, CastTime: time.Second,
+		ProjectileSpeed: 0}, monsterID+1)
+	require.True(t, errors.Is(err, ErrNoTarget), err)
+
+	_, err = Spell(agent.New("dps", geodata.Open("")), Skill{SkillID: 1177,
+		CastTime: time.Second, ProjectileSpeed: 0}, monsterID)
+	require.True(t, errors.Is(err, ErrNotInGame), err)
+}
+
+func TestMelee(t *testing.T) {
+	a := newAgent(t, 440)
+	action, err := Melee(a, monsterID, 40, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, 2500*time.Millisecond, action.Lead)
+
+	_, err = Melee(a, monsterID+1, 40, 500*time.Millisecond)
+	require.True(t, errors.Is(err, ErrNoTarget), err)
+}
+
+func TestEmote(t *testing.T) {
+	a := newAgent(t, 0)
+	action := Emote(a, 3, 1835)
+	require.Equal(t, "dps", action.Bot)
+	require.Zero(t, action.Lead)
+
+	_, err := action.Fire(context.Background())
+	require.Error(t, err, "soulshot isn't in inventory")
+}
+
+func TestFromConfig(t *testing.T) {
+	agents := map[string]*agent.Agent{
+		"mage":    newAgent(t, 600),
+		"warrior": newAgent(t, 440),
+	}
+	cfg := config.Burst{
+		Name:   "alpha",
+		Margin: config.Duration{Duration: 0},
+		Actions: []config.BurstAction{
+			{ //nolint:exhaustruct
+				Login: "mage", Kind: "spell", SkillID: 1177,
+				CastTime:        config.Duration{Duration: time.Second},
+				ProjectileSpeed: 600,
+			},
+			{ //nolint:exhaustruct
+				Login: "warrior", Kind: "melee", Reach: 40,
+				Swing: config.Duration{Duration: 500 * time.Millisecond},
+			},
+			{ //nolint:exhaustruct
+				Login: "dancer", Kind: "emote", SocialID: 3,
+			},
+		},
+	}
+
+	actions, err := FromConfig(cfg, agents, monsterID)
+	require.NoError(t, err)
+	require.Len(t, actions, 2, "dancer is leased by other instance")
+	require.Equal(t, 2*time.Second, actions[0].Lead)
+	require.Equal(t, 2500*time.Millisecond, actions[1].Lead)
+
+	_, err = FromConfig(cfg, agents, monsterID+1)
+	require.True(t, errors.Is(err, ErrNoTarget), err)
+
+	emotes := cfg
+	emotes.Actions = slices.Clone(cfg.Actions[2:])
+	_, err = FromConfig(emotes, agents, 0)
+	require.True(t, errors.Is(err, ErrNoBots), err)
+	emotes.Actions[0].Login = "mage"
+	actions, err = FromConfig(emotes, agents, 0)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+}
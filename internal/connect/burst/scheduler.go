@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package burst
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultMargin is time orders of burst need to reach bots and server.
+const DefaultMargin = 200 * time.Millisecond
+
+// Entry is action with time it must start.
+type Entry struct {
+	Action
+	Start time.Time
+}
+
+// Plan returns start times of actions so all of them hit at impact,
+// entries are sorted by start.
+func Plan(impact time.Time, actions []Action) []Entry {
+	entries := make([]Entry, 0, len(actions))
+	for _, action := range actions {
+		entries = append(entries, Entry{
+			Action: action,
+			Start:  impact.Add(-action.Lead),
+		})
+	}
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return entries
+}
+
+// Earliest returns first impact time all actions can make if they are
+// planned now. Margin covers time bots need to receive their orders.
+func Earliest(now time.Time, actions []Action, margin time.Duration) time.Time {
+	var lead time.Duration
+	for _, action := range actions {
+		lead = max(lead, action.Lead)
+	}
+
+	return now.Add(lead + margin)
+}
+
+// Report is outcome of one action of burst.
+type Report struct {
+	Bot string
+	// Start is planned start and Fired is when action really started, fired
+	// is zero if action never started.
+	Start time.Time
+	Fired time.Time
+	// Impact is time action hit target as server reported it, zero if it
+	// isn't known.
+	Impact time.Time
+	Err    error
+}
+
+// Jitter returns how late action started.
+func (r Report) Jitter() time.Duration {
+	if r.Fired.IsZero() {
+		return 0
+	}
+
+	return r.Fired.Sub(r.Start)
+}
+
+// Miss returns how far observed impact is from planned one, zero if impact
+// isn't known.
+func (r Report) Miss(impact time.Time) time.Duration {
+	if r.Impact.IsZero() {
+		return 0
+	}
+
+	return r.Impact.Sub(impact)
+}
+
+// MaxJitter returns biggest jitter of reports.
+func MaxJitter(reports []Report) time.Duration {
+	if len(reports) == 0 {
+		return 0
+	}
+
+	return slices.MaxFunc(reports, func(a, b Report) int {
+		return cmp.Compare(a.Jitter(), b.Jitter())
+	}).Jitter()
+}
+
+// Run fires actions so they hit at impact and waits until all of them are
+// done. Times are taken from monotonic clock of process, so impact should
+// come from time.Now. Actions which should have started already fire at
+// once and their jitter shows how late they are. Reports are in order of
+// actions.
+func Run(ctx context.Context, impact time.Time, actions []Action) []Report {
+	reports := make([]Report, len(actions))
+	var group sync.WaitGroup
+	for index, action := range actions {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			reports[index] = fire(ctx, impact.Add(-action.Lead), action)
+		}()
+	}
+	group.Wait()
+
+	return reports
+}
+
+// fire waits until start and fires action.
+func fire(ctx context.Context, start time.Time, action Action) Report {
+	report := Report{
+		Bot:    action.Bot,
+		Start:  start,
+		Fired:  time.Time{},
+		Impact: time.Time{},
+		Err:    nil,
+	}
+
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		report.Err = ctx.Err()
+
+		return report
+	case <-timer.C:
+	}
+
+	report.Fired = time.Now()
+	report.Impact, report.Err = action.Fire(ctx)
+
+	return report
+}
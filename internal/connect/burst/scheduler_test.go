@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package burst
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tolerance is how late action may fire on busy test machine.
+const tolerance = 50 * time.Millisecond
+
+// recorder records order in which actions fire.
+type recorder struct {
+	mutex sync.Mutex
+	fired []string
+}
+
+func (r *recorder) action(bot string, lead time.Duration) Action {
+	return Action{
+		Bot:  bot,
+		Lead: lead,
+		Fire: func(context.Context) (time.Time, error) {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			r.fired = append(r.fired, bot)
+
+			return time.Now().Add(lead), nil
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	var r recorder
+	impact := time.Unix(1000, 0)
+	entries := Plan(impact, []Action{
+		r.action("melee", time.Second),
+		r.action("nuker", 3*time.Second),
+		r.action("emote", 0),
+	})
+
+	var bots []string
+	var starts []time.Time
+	for _, entry := range entries {
+		bots = append(bots, entry.Bot)
+		starts = append(starts, entry.Start)
+	}
+	require.Equal(t, []string{"nuker", "melee", "emote"}, bots)
+	require.Equal(t, []time.Time{
+		time.Unix(997, 0), time.Unix(999, 0), time.Unix(1000, 0),
+	}, starts)
+}
+
+func TestEarliest(t *testing.T) {
+	var r recorder
+	now := time.Unix(1000, 0)
+	actions := []Action{
+		r.action("melee", time.Second),
+		r.action("nuker", 3*time.Second),
+	}
+	require.Equal(t, time.Unix(1003, 5e8),
+		Earliest(now, actions, 500*time.Millisecond))
+	require.Equal(t, now, Earliest(now, nil, 0))
+}
+
+func TestRun(t *testing.T) {
+	var r recorder
+	actions := []Action{
+		r.action("melee", 10*time.Millisecond),
+		r.action("nuker", 40*time.Millisecond),
+		r.action("emote", 0),
+	}
+	impact := Earliest(time.Now(), actions, 10*time.Millisecond)
+
+	reports := Run(context.Background(), impact, actions)
+	require.Equal(t, []string{"nuker", "melee", "emote"}, r.fired)
+	require.Len(t, reports, len(actions))
+	for index, report := range reports {
+		require.Equal(t, actions[index].Bot, report.Bot)
+		require.NoError(t, report.Err)
+		require.Equal(t, impact.Add(-actions[index].Lead), report.Start)
+		require.GreaterOrEqual(t, int64(report.Jitter()), int64(0))
+		require.Less(t, int64(report.Miss(impact)), int64(tolerance))
+	}
+	require.Less(t, int64(MaxJitter(reports)), int64(tolerance))
+}
+
+func TestRun_Late(t *testing.T) {
+	var r recorder
+	impact := time.Now()
+	reports := Run(context.Background(), impact, []Action{
+		r.action("nuker", time.Second),
+	})
+	require.Equal(t, []string{"nuker"}, r.fired, "late action fires at once")
+	require.GreaterOrEqual(t, int64(reports[0].Jitter()), int64(time.Second))
+}
+
+func TestRun_Canceled(t *testing.T) {
+	var r recorder
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reports := Run(ctx, time.Now().Add(time.Hour), []Action{
+		r.action("nuker", time.Second),
+	})
+	require.Empty(t, r.fired)
+	require.True(t, errors.Is(reports[0].Err, context.Canceled))
+	require.True(t, reports[0].Fired.IsZero())
+	require.Zero(t, reports[0].Jitter())
+	require.Zero(t, reports[0].Miss(time.Now()))
+}
+
+func TestMaxJitter(t *testing.T) {
+	start := time.Unix(1000, 0)
+	require.Zero(t, MaxJitter(nil))
+	require.Equal(t, 30*time.Millisecond, MaxJitter([]Report{
+		{Bot: "a", Start: start, Fired: start.Add(10 * time.Millisecond),
+			Impact: time.Time{}, Err: nil},
+		{Bot: "b", Start: start, Fired: start.Add(30 * time.Millisecond),
+			Impact: time.Time{}, Err: nil},
+	}))
+}
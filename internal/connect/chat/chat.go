@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	messageBuffer = 64
+	// maxTextLength is longest message game client lets to send.
+	maxTextLength = 105
+)
+
+var (
+	ErrEmptyText   = errors.New("message is empty")
+	ErrTooLong     = errors.New("message is too long")
+	ErrNotSendable = errors.New("channel can't be used by bot")
+	ErrNoRecipient = errors.New("whisper needs recipient")
+	ErrUseWhisper  = errors.New("whispers are sent by Whisper")
+)
+
+// Channel is chat type of Say2 and CreatureSay.
+type Channel int32
+
+const (
+	All           = Channel(togameserver.ChatAll)
+	Shout         = Channel(togameserver.ChatShout)
+	Whisper       = Channel(togameserver.ChatTell)
+	Party         = Channel(togameserver.ChatParty)
+	Clan          = Channel(togameserver.ChatClan)
+	GM            = Channel(togameserver.ChatGM)
+	Petition      = Channel(togameserver.ChatPetition)
+	PetitionReply = Channel(togameserver.ChatPetitionReply)
+	Trade         = Channel(togameserver.ChatTrade)
+	Alliance      = Channel(togameserver.ChatAlliance)
+	Announcement  = Channel(togameserver.ChatAnnouncement)
+)
+
+func (c Channel) String() string {
+	switch c {
+	case All:
+		return "All"
+	case Shout:
+		return "Shout"
+	case Whisper:
+		return "Whisper"
+	case Party:
+		return "Party"
+	case Clan:
+		return "Clan"
+	case GM:
+		return "GM"
+	case Petition:
+		return "Petition"
+	case PetitionReply:
+		return "PetitionReply"
+	case Trade:
+		return "Trade"
+	case Alliance:
+		return "Alliance"
+	case Announcement:
+		return "Announcement"
+	default:
+		return fmt.Sprintf("Channel(%d)", int32(c))
+	}
+}
+
+// sendable reports if bot can say something to channel by Say.
+func (c Channel) sendable() bool {
+	switch c {
+	case All, Shout, Party, Clan, Trade, Alliance:
+		return true
+	default:
+		return false
+	}
+}
+
+// Message is chat message seen by bot. Sender id is zero for messages of
+// server.
+type Message struct {
+	Time     time.Time
+	Channel  Channel
+	SenderID int32
+	Sender   string
+	Text     string
+}
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Chat sends chat messages of bot and delivers messages it sees to
+// subscribers.
+type Chat struct {
+	mutex       sync.Mutex
+	world       *world.World
+	sender      Sender
+	subscribers map[chan Message]struct{}
+}
+
+func New(model *world.World, sender Sender) *Chat {
+	return &Chat{
+		mutex:       sync.Mutex{},
+		world:       model,
+		sender:      sender,
+		subscribers: make(map[chan Message]struct{}),
+	}
+}
+
+// Register subscribes chat to messages of creatures.
+func (c *Chat) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.CreatureSayID, c.handleSay)
+}
+
+func (c *Chat) handleSay(data []byte) error {
+	say, err := fromgameserver.NewCreatureSayFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	message := Message{
+		Time:     c.world.Now(),
+		Channel:  Channel(say.TextType),
+		SenderID: say.ObjectID,
+		Sender:   say.CharName,
+		Text:     say.Text,
+	}
+	for subscriber := range c.subscribers {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns channel of chat messages and function which cancels
+// subscription. Messages are dropped for subscriber which doesn't keep up.
+func (c *Chat) Subscribe() (<-chan Message, func()) {
+	messages := make(chan Message, messageBuffer)
+
+	c.mutex.Lock()
+	c.subscribers[messages] = struct{}{}
+	c.mutex.Unlock()
+
+	return messages, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		delete(c.subscribers, messages)
+	}
+}
+
+func checkText(text string) error {
+	if text == "" {
+		return ErrEmptyText
+	}
+	if len([]rune(text)) > maxTextLength {
+		return fmt.Errorf("%w: %d characters", ErrTooLong, len([]rune(text)))
+	}
+
+	return nil
+}
+
+// Say sends message to channel, whispers are sent by Whisper.
+func (c *Chat) Say(channel Channel, text string) error {
+	if channel == Whisper {
+		return ErrUseWhisper
+	}
+	if !channel.sendable() {
+		return fmt.Errorf("%w: %v", ErrNotSendable, channel)
+	}
+	if err := checkText(text); err != nil {
+		return err
+	}
+
+	return c.sender.WritePacket(&togameserver.Say2{
+		Text:   text,
+		Type:   int32(channel),
+		Target: "",
+	})
+}
+
+// Whisper sends private message to character.
+func (c *Chat) Whisper(recipient, text string) error {
+	if recipient == "" {
+		return ErrNoRecipient
+	}
+	if err := checkText(text); err != nil {
+		return err
+	}
+
+	return c.sender.WritePacket(&togameserver.Say2{
+		Text:   text,
+		Type:   togameserver.ChatTell,
+		Target: recipient,
+	})
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+}
+
+func (s *recordingSender) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.packets = append(s.packets, p)
+
+	return nil
+}
+
+func (s *recordingSender) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+type fixture struct {
+	t          *testing.T
+	sender     *recordingSender
+	dispatcher *dispatch.Dispatcher
+	chat       *Chat
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	model := world.New()
+	model.SetClock(func() time.Time { return time.Unix(1000, 0) })
+	sender := &recordingSender{mutex: sync.Mutex{}, packets: nil}
+	dispatcher := dispatch.NewDispatcher()
+	chat := New(model, sender)
+	chat.Register(dispatcher)
+
+	return &fixture{
+		t:          t,
+		sender:     sender,
+		dispatcher: dispatcher,
+		chat:       chat,
+	}
+}
+
+func (f *fixture) say(channel Channel, sender, text string) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, (&fromgameserver.CreatureSay{
+		ObjectID: 9,
+		TextType: int32(channel),
+		CharName: sender,
+		Text:     text,
+	}).ToBytes(writer))
+	require.NoError(f.t,
+		f.dispatcher.Dispatch(fromgameserver.CreatureSayID, writer.Bytes()))
+}
+
+func TestChat_Subscribe(t *testing.T) {
+	f := newFixture(t)
+	messages, cancel := f.chat.Subscribe()
+
+	f.say(Party, "Tank", "hello")
+	require.Equal(t, Message{
+		Time:     time.Unix(1000, 0),
+		Channel:  Party,
+		SenderID: 9,
+		Sender:   "Tank",
+		Text:     "hello",
+	}, <-messages)
+
+	cancel()
+	f.say(Party, "Tank", "again")
+	require.Empty(t, messages)
+}
+
+func TestChat_Send(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.chat.Say(Shout, "wts"))
+	require.NoError(t, f.chat.Whisper("Tank", "hi"))
+
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.Say2{Text: "wts", Type: togameserver.ChatShout, Target: ""},
+		&togameserver.Say2{Text: "hi", Type: togameserver.ChatTell, Target: "Tank"},
+	}, f.sender.sent())
+}
+
+func TestChat_SendErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		send func(chat *Chat) error
+		err  error
+	}{
+		{
+			name: "empty",
+			send: func(c *Chat) error { return c.Say(All, "") },
+			err:  ErrEmptyText,
+		},
+		{
+			name: "too long",
+			send: func(c *Chat) error {
+				return c.Say(All, strings.Repeat("a", maxTextLength+1))
+			},
+			err: ErrTooLong,
+		},
+		{
+			name: "whisper by say",
+			send: func(c *Chat) error { return c.Say(Whisper, "hi") },
+			err:  ErrUseWhisper,
+		},
+		{
+			name: "announcement",
+			send: func(c *Chat) error { return c.Say(Announcement, "hi") },
+			err:  ErrNotSendable,
+		},
+		{
+			name: "whisper to nobody",
+			send: func(c *Chat) error { return c.Whisper("", "hi") },
+			err:  ErrNoRecipient,
+		},
+		{
+			name: "empty whisper",
+			send: func(c *Chat) error { return c.Whisper("Tank", "") },
+			err:  ErrEmptyText,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			err := test.send(f.chat)
+			require.True(t, errors.Is(err, test.err), err)
+			require.Empty(t, f.sender.sent())
+		})
+	}
+}
+
+func TestChat_BrokenPacket(t *testing.T) {
+	f := newFixture(t)
+	require.Error(t, f.dispatcher.Dispatch(fromgameserver.CreatureSayID,
+		[]byte{1}))
+}
+
+func TestChannel_String(t *testing.T) {
+	require.Equal(t, "Party", Party.String())
+	require.Equal(t, "Trade", Trade.String())
+	require.Equal(t, "Channel(42)", Channel(42).String())
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrBadArguments   = errors.New("wrong arguments of command")
+)
+
+// CommandKind is what bot is asked to do.
+type CommandKind int
+
+const (
+	// Follow keeps bot near commander.
+	Follow CommandKind = iota
+	// Stop stops following and movement.
+	Stop
+	// Assist attacks target of character named in command.
+	Assist
+)
+
+func (k CommandKind) String() string {
+	switch k {
+	case Follow:
+		return "follow"
+	case Stop:
+		return "stop"
+	case Assist:
+		return "assist"
+	default:
+		return fmt.Sprintf("CommandKind(%d)", int(k))
+	}
+}
+
+// arguments are counts of words after command.
+var arguments = map[CommandKind]int{Follow: 0, Stop: 0, Assist: 1}
+
+// Command is order to bot given in chat by commander.
+type Command struct {
+	Kind CommandKind
+	// From is name of commander.
+	From string
+	// Target is name of character command is about, it is empty for
+	// commands without argument.
+	Target string
+}
+
+// ParseCommand parses text of message like "follow" or "assist Tank".
+// Words are case insensitive.
+func ParseCommand(text string) (Command, error) {
+	var none Command
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return none, ErrUnknownCommand
+	}
+
+	for kind, count := range arguments {
+		if !strings.EqualFold(words[0], kind.String()) {
+			continue
+		}
+		if len(words)-1 != count {
+			return none, fmt.Errorf("%w: %v takes %d", ErrBadArguments,
+				kind, count)
+		}
+
+		command := Command{Kind: kind, From: "", Target: ""}
+		if count > 0 {
+			command.Target = words[1]
+		}
+
+		return command, nil
+	}
+
+	return none, fmt.Errorf("%w: %s", ErrUnknownCommand, words[0])
+}
+
+// Commander turns whispers and party messages of whitelisted characters
+// into commands of bot.
+type Commander struct {
+	chat      *Chat
+	whitelist map[string]struct{}
+}
+
+func NewCommander(chat *Chat, whitelist []string) *Commander {
+	names := make(map[string]struct{}, len(whitelist))
+	for _, name := range whitelist {
+		names[strings.ToLower(name)] = struct{}{}
+	}
+
+	return &Commander{chat: chat, whitelist: names}
+}
+
+// Accepts reports if message may carry command.
+func (c *Commander) Accepts(message Message) bool {
+	if message.Channel != Whisper && message.Channel != Party {
+		return false
+	}
+	_, ok := c.whitelist[strings.ToLower(message.Sender)]
+
+	return ok
+}
+
+// Run executes commands by handle until context is done. Failures are
+// whispered back to commander. Party messages which aren't commands are
+// ordinary conversation and are ignored.
+func (c *Commander) Run(ctx context.Context, handle func(Command) error) {
+	messages, cancel := c.chat.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-messages:
+			if !c.Accepts(message) {
+				continue
+			}
+			command, err := ParseCommand(message.Text)
+			if err != nil && message.Channel == Party {
+				continue
+			}
+			if err == nil {
+				command.From = message.Sender
+				err = handle(command)
+			}
+			if err != nil {
+				c.reply(message.Sender, err)
+			}
+		}
+	}
+}
+
+func (c *Commander) reply(commander string, failure error) {
+	text := []rune("Error: " + failure.Error())
+	if len(text) > maxTextLength {
+		text = text[:maxTextLength]
+	}
+	if err := c.chat.Whisper(commander, string(text)); err != nil {
+		log.Printf("Error replying to %s: %v\n", commander, err)
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func command(kind CommandKind, target string) Command {
+	return Command{Kind: kind, From: "", Target: target}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Command
+		err      error
+	}{
+		{text: "follow", expected: command(Follow, ""), err: nil},
+		{text: " STOP ", expected: command(Stop, ""), err: nil},
+		{text: "assist Tank", expected: command(Assist, "Tank"), err: nil},
+		{text: "", expected: command(Follow, ""), err: ErrUnknownCommand},
+		{text: "dance", expected: command(Follow, ""), err: ErrUnknownCommand},
+		{text: "assist", expected: command(Follow, ""), err: ErrBadArguments},
+		{
+			text:     "follow me",
+			expected: command(Follow, ""),
+			err:      ErrBadArguments,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			parsed, err := ParseCommand(test.text)
+			if test.err != nil {
+				require.True(t, errors.Is(err, test.err), err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, parsed)
+		})
+	}
+}
+
+func TestCommandKind_String(t *testing.T) {
+	require.Equal(t, "assist", Assist.String())
+	require.Equal(t, "CommandKind(9)", CommandKind(9).String())
+}
+
+func TestCommander_Accepts(t *testing.T) {
+	commander := NewCommander(nil, []string{"Human"})
+	message := func(channel Channel, sender string) Message {
+		return Message{
+			Time:     time.Time{},
+			Channel:  channel,
+			SenderID: 1,
+			Sender:   sender,
+			Text:     "follow",
+		}
+	}
+
+	require.True(t, commander.Accepts(message(Whisper, "Human")))
+	require.True(t, commander.Accepts(message(Party, "human")))
+	require.False(t, commander.Accepts(message(All, "Human")))
+	require.False(t, commander.Accepts(message(Whisper, "Stranger")))
+}
+
+func TestCommander_Run(t *testing.T) {
+	f := newFixture(t)
+	commander := NewCommander(f.chat, []string{"Human"})
+	commands := make(chan Command, 10)
+	handle := func(command Command) error {
+		commands <- command
+		if command.Kind == Assist {
+			return errors.New("nobody to assist")
+		}
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		commander.Run(ctx, handle)
+	}()
+
+	// Subscription of commander is made in its goroutine.
+	deadline := time.Now().Add(time.Second)
+	for len(commands) == 0 && time.Now().Before(deadline) {
+		f.say(Whisper, "Human", "follow")
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, Command{Kind: Follow, From: "Human", Target: ""},
+		<-commands)
+	for len(commands) > 0 {
+		<-commands
+	}
+
+	f.say(Party, "Human", "good job")
+	f.say(Party, "Stranger", "stop")
+	f.say(Whisper, "Human", "dance")
+	f.say(Party, "Human", "assist Tank")
+	require.Equal(t, Command{Kind: Assist, From: "Human", Target: "Tank"},
+		<-commands)
+
+	cancel()
+	<-done
+	require.Empty(t, commands)
+
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.Say2{
+			Text:   "Error: unknown command: dance",
+			Type:   togameserver.ChatTell,
+			Target: "Human",
+		},
+		&togameserver.Say2{
+			Text:   "Error: nobody to assist",
+			Type:   togameserver.ChatTell,
+			Target: "Human",
+		},
+	}, f.sender.sent())
+}
@@ -0,0 +1,561 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	eventBuffer = 64
+	// answerTimeout is how long server answer to request is waited.
+	answerTimeout = 2 * time.Second
+	// landingTimeout is added to hit time of skill while landing is waited.
+	landingTimeout = time.Second
+)
+
+var (
+	ErrRefused     = errors.New("request is refused by server")
+	ErrInterrupted = errors.New("cast is interrupted")
+	ErrNoAnswer    = errors.New("server didn't answer")
+	ErrNotReady    = errors.New("skill is not ready")
+)
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Cast is skill cast by own character.
+type Cast struct {
+	SkillID  int32
+	Level    int32
+	TargetID int32
+	Started  time.Time
+	HitTime  time.Duration
+	Reuse    time.Duration
+	// Launched is zero until skill reaches its targets.
+	Launched time.Time
+	Targets  []int32
+}
+
+// Ends returns time skill is expected to land.
+func (c Cast) Ends() time.Time {
+	return c.Started.Add(c.HitTime)
+}
+
+// Request is skill to cast. Zero target means current target, ctrl and
+// shift are modifiers of game client.
+type Request struct {
+	SkillID  int32
+	TargetID int32
+	Ctrl     bool
+	Shift    bool
+}
+
+// Combat does combat actions of own character and tracks their outcome:
+// target, casts and cooldowns of skills.
+type Combat struct {
+	mutex       sync.Mutex
+	world       *world.World
+	sender      Sender
+	target      int32
+	casting     *Cast
+	cooldowns   map[int32]time.Time
+	subscribers map[chan Event]struct{}
+	// others are targets of other creatures by their object ids.
+	others map[int32]int32
+	// effects are buffs and debuffs on own character by skill ids.
+	effects map[int32]Effect
+}
+
+func New(model *world.World, sender Sender) *Combat {
+	return &Combat{
+		mutex:       sync.Mutex{},
+		world:       model,
+		sender:      sender,
+		target:      0,
+		casting:     nil,
+		cooldowns:   make(map[int32]time.Time),
+		subscribers: make(map[chan Event]struct{}),
+		others:      make(map[int32]int32),
+		effects:     make(map[int32]Effect),
+	}
+}
+
+// Register subscribes combat to outcome packets.
+func (c *Combat) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.MyTargetSelectedID, c.handleTarget)
+	dispatcher.Handle(fromgameserver.MagicSkillUseID, c.handleUse)
+	dispatcher.Handle(fromgameserver.MagicSkillLaunchedID, c.handleLaunched)
+	dispatcher.Handle(fromgameserver.MagicSkillCanceldID, c.handleCanceled)
+	dispatcher.Handle(fromgameserver.ActionFailedID, c.handleFailed)
+	dispatcher.Handle(fromgameserver.SkillCoolTimeID, c.handleCoolTime)
+	dispatcher.Handle(fromgameserver.TargetSelectedID, c.handleOtherTarget)
+	dispatcher.Handle(fromgameserver.TargetUnselectedID,
+		c.handleOtherUnselected)
+	dispatcher.Handle(fromgameserver.AbnormalStatusUpdateID, c.handleEffects)
+	dispatcher.Handle(fromgameserver.SocialActionID, c.handleSocial)
+}
+
+func (c *Combat) handleTarget(data []byte) error {
+	selected, err := fromgameserver.NewMyTargetSelectedFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.target = selected.ObjectID
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     TargetSelected,
+		Time:     c.world.Now(),
+		CasterID: c.world.SelfID(),
+		TargetID: selected.ObjectID,
+	})
+
+	return nil
+}
+
+func (c *Combat) handleOtherTarget(data []byte) error {
+	selected, err := fromgameserver.NewTargetSelectedFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.others[selected.ObjectID] = selected.TargetID
+
+	return nil
+}
+
+func (c *Combat) handleOtherUnselected(data []byte) error {
+	unselected, err := fromgameserver.NewTargetUnselectedFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.others, unselected.ObjectID)
+
+	return nil
+}
+
+func milliseconds(value int32) time.Duration {
+	return time.Duration(value) * time.Millisecond
+}
+
+func (c *Combat) handleUse(data []byte) error {
+	use, err := fromgameserver.NewMagicSkillUseFromBytes(data)
+	if err != nil {
+		return err
+	}
+	now := c.world.Now()
+	event := Event{
+		Kind:     CastStarted,
+		Time:     now,
+		CasterID: use.CasterID,
+		TargetID: use.TargetID,
+		SkillID:  use.SkillID,
+		Level:    use.SkillLevel,
+		HitTime:  milliseconds(use.HitTime),
+		Reuse:    milliseconds(use.ReuseDelay),
+		Targets:  nil,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if use.CasterID == c.world.SelfID() {
+		c.casting = &Cast{
+			SkillID:  use.SkillID,
+			Level:    use.SkillLevel,
+			TargetID: use.TargetID,
+			Started:  now,
+			HitTime:  event.HitTime,
+			Reuse:    event.Reuse,
+			Launched: time.Time{},
+			Targets:  nil,
+		}
+		if event.Reuse > 0 {
+			c.cooldowns[use.SkillID] = now.Add(event.Reuse)
+		}
+	} else {
+		c.others[use.CasterID] = use.TargetID
+	}
+	c.publish(event)
+
+	return nil
+}
+
+func (c *Combat) handleLaunched(data []byte) error {
+	launched, err := fromgameserver.NewMagicSkillLaunchedFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if launched.CasterID == c.world.SelfID() {
+		c.casting = nil
+	}
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     CastLaunched,
+		Time:     c.world.Now(),
+		CasterID: launched.CasterID,
+		SkillID:  launched.SkillID,
+		Level:    launched.SkillLevel,
+		Targets:  launched.Targets,
+	})
+
+	return nil
+}
+
+func (c *Combat) handleCanceled(data []byte) error {
+	canceled, err := fromgameserver.NewMagicSkillCanceldFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if canceled.ObjectID == c.world.SelfID() && c.casting != nil {
+		// Interrupted skill is ready again at once.
+		delete(c.cooldowns, c.casting.SkillID)
+		c.casting = nil
+	}
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     CastCanceled,
+		Time:     c.world.Now(),
+		CasterID: canceled.ObjectID,
+	})
+
+	return nil
+}
+
+func (c *Combat) handleFailed([]byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     ActionFailed,
+		Time:     c.world.Now(),
+		CasterID: c.world.SelfID(),
+	})
+
+	return nil
+}
+
+func (c *Combat) handleCoolTime(data []byte) error {
+	coolTime, err := fromgameserver.NewSkillCoolTimeFromBytes(data)
+	if err != nil {
+		return err
+	}
+	now := c.world.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cooldowns = make(map[int32]time.Time, len(coolTime.Skills))
+	for _, skill := range coolTime.Skills {
+		remaining := time.Duration(skill.Remaining) * time.Second
+		c.cooldowns[skill.SkillID] = now.Add(remaining)
+	}
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     CooldownsUpdated,
+		Time:     now,
+		CasterID: c.world.SelfID(),
+	})
+
+	return nil
+}
+
+// Clear forgets targets, cast, cooldowns and effects, server sends them
+// again after reconnect.
+func (c *Combat) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.target = 0
+	c.casting = nil
+	c.cooldowns = make(map[int32]time.Time)
+	c.others = make(map[int32]int32)
+	c.effects = make(map[int32]Effect)
+}
+
+// Target returns object id of current target, zero if there is none.
+func (c *Combat) Target() int32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.target
+}
+
+// TargetOf returns target of creature as last seen by its target selection
+// or cast, zero if it is unknown.
+func (c *Combat) TargetOf(objectID int32) int32 {
+	if objectID == c.world.SelfID() {
+		return c.Target()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.others[objectID]
+}
+
+// Targeting returns sorted ids of other creatures which target object.
+func (c *Combat) Targeting(objectID int32) []int32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var result []int32
+	for creature, target := range c.others {
+		if target == objectID {
+			result = append(result, creature)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// Casting returns skill own character casts now.
+func (c *Combat) Casting() (Cast, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.casting == nil {
+		return Cast{}, false //nolint:exhaustruct
+	}
+
+	return *c.casting, true
+}
+
+// Cooldown returns time left until skill is ready.
+func (c *Combat) Cooldown(skillID int32) time.Duration {
+	now := c.world.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return max(c.cooldowns[skillID].Sub(now), 0)
+}
+
+// Ready reports if skill can be used now.
+func (c *Combat) Ready(skillID int32) bool {
+	return c.Cooldown(skillID) == 0
+}
+
+// origin returns position of own character sent with requests.
+func (c *Combat) origin() world.Position {
+	self, ok := c.world.Self()
+	if !ok {
+		return world.Position{} //nolint:exhaustruct
+	}
+
+	return self.At(c.world.Now())
+}
+
+// Select makes object target of own character and waits until server
+// confirms it.
+func (c *Combat) Select(ctx context.Context, objectID int32) error {
+	if c.Target() == objectID {
+		return nil
+	}
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	if err := c.Aim(objectID); err != nil {
+		return err
+	}
+
+	_, err := wait(ctx, events, answerTimeout, func(event Event) (bool, error) {
+		switch event.Kind {
+		case TargetSelected:
+			return event.TargetID == objectID, nil
+		case ActionFailed:
+			return true, ErrRefused
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to select %d: %w", objectID, err)
+	}
+
+	return nil
+}
+
+// Aim asks server to make object target of own character without waiting
+// for confirmation, for callers which can't wait for packets.
+func (c *Combat) Aim(objectID int32) error {
+	origin := c.origin()
+
+	return c.sender.WritePacket(&togameserver.Action{
+		ObjectID: objectID,
+		OriginX:  origin.X,
+		OriginY:  origin.Y,
+		OriginZ:  origin.Z,
+		Shift:    true,
+	})
+}
+
+// Interact does default action on selected object like game client does
+// on second click: talks to npc, picks up item or attacks monster.
+func (c *Combat) Interact(objectID int32) error {
+	origin := c.origin()
+
+	return c.sender.WritePacket(&togameserver.Action{
+		ObjectID: objectID,
+		OriginX:  origin.X,
+		OriginY:  origin.Y,
+		OriginZ:  origin.Z,
+		Shift:    false,
+	})
+}
+
+// Attack starts auto attack of object, shift attacks without moving.
+func (c *Combat) Attack(objectID int32, shift bool) error {
+	origin := c.origin()
+
+	return c.sender.WritePacket(&togameserver.AttackRequest{
+		ObjectID: objectID,
+		OriginX:  origin.X,
+		OriginY:  origin.Y,
+		OriginZ:  origin.Z,
+		Shift:    shift,
+	})
+}
+
+// UseAction uses action of actions window.
+func (c *Combat) UseAction(actionID int32, ctrl, shift bool) error {
+	return c.sender.WritePacket(&togameserver.RequestActionUse{
+		ActionID: actionID,
+		Ctrl:     ctrl,
+		Shift:    shift,
+	})
+}
+
+// Cast casts skill and waits until it lands. Target is selected first if
+// request has one. Returned cast tells when skill started and landed and
+// which objects it reached.
+func (c *Combat) Cast(ctx context.Context, request Request) (Cast, error) {
+	var none Cast
+	if cooldown := c.Cooldown(request.SkillID); cooldown > 0 {
+		return none, fmt.Errorf("%w: %d ready in %v",
+			ErrNotReady, request.SkillID, cooldown)
+	}
+	if request.TargetID != 0 {
+		if err := c.Select(ctx, request.TargetID); err != nil {
+			return none, err
+		}
+	}
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	err := c.sender.WritePacket(&togameserver.RequestMagicSkillUse{
+		SkillID: request.SkillID,
+		Ctrl:    request.Ctrl,
+		Shift:   request.Shift,
+	})
+	if err != nil {
+		return none, err
+	}
+
+	selfID := c.world.SelfID()
+	started, err := wait(ctx, events, answerTimeout,
+		func(event Event) (bool, error) {
+			switch {
+			case event.Kind == ActionFailed:
+				return true, ErrRefused
+			case event.CasterID != selfID:
+				return false, nil
+			case event.Kind == CastCanceled:
+				return true, ErrInterrupted
+			default:
+				return event.Kind == CastStarted &&
+					event.SkillID == request.SkillID, nil
+			}
+		})
+	if err != nil {
+		return none, fmt.Errorf("failed to cast %d: %w", request.SkillID, err)
+	}
+
+	cast := Cast{
+		SkillID:  started.SkillID,
+		Level:    started.Level,
+		TargetID: started.TargetID,
+		Started:  started.Time,
+		HitTime:  started.HitTime,
+		Reuse:    started.Reuse,
+		Launched: time.Time{},
+		Targets:  nil,
+	}
+	landed, err := wait(ctx, events, cast.HitTime+landingTimeout,
+		func(event Event) (bool, error) {
+			switch {
+			case event.CasterID != selfID:
+				return false, nil
+			case event.Kind == CastCanceled:
+				return true, ErrInterrupted
+			default:
+				return event.Kind == CastLaunched &&
+					event.SkillID == request.SkillID, nil
+			}
+		})
+	if err != nil {
+		return cast, fmt.Errorf("failed to cast %d: %w", request.SkillID, err)
+	}
+	cast.Launched = landed.Time
+	cast.Targets = landed.Targets
+
+	return cast, nil
+}
+
+// wait returns first event for which match is done, error of match is
+// returned with event.
+func wait(
+	ctx context.Context,
+	events <-chan Event,
+	timeout time.Duration,
+	match func(event Event) (bool, error),
+) (Event, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return Event{}, ctx.Err() //nolint:exhaustruct
+		case <-timer.C:
+			return Event{}, ErrNoAnswer //nolint:exhaustruct
+		case event := <-events:
+			if done, err := match(event); done {
+				return event, err
+			}
+		}
+	}
+}
@@ -0,0 +1,469 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	selfID   = 7
+	otherID  = 8
+	targetID = 300
+	healID   = 1011
+)
+
+// server records packets of client and answers them like game server.
+type server struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+	err     error
+	answer  func(p crypt.Serializable)
+}
+
+func (s *server) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.packets = append(s.packets, p)
+	if s.answer != nil {
+		go s.answer(p)
+	}
+
+	return nil
+}
+
+func (s *server) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+func (s *server) setAnswer(answer func(p crypt.Serializable)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.answer = answer
+}
+
+type fixture struct {
+	t          *testing.T
+	now        time.Time
+	server     *server
+	dispatcher *dispatch.Dispatcher
+	combat     *Combat
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	model := world.New()
+	model.SetClock(func() time.Time { return time.Unix(1000, 0) })
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	server := &server{
+		mutex:   sync.Mutex{},
+		packets: nil,
+		err:     nil,
+		answer:  nil,
+	}
+	combat := New(model, server)
+	combat.Register(dispatcher)
+
+	f := &fixture{
+		t:          t,
+		now:        time.Unix(1000, 0),
+		server:     server,
+		dispatcher: dispatcher,
+		combat:     combat,
+	}
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = selfID
+	info.X = 10
+	info.Y = 20
+	info.Z = 30
+	f.feed(fromgameserver.UserInfoID, info)
+
+	return f
+}
+
+func (f *fixture) feed(id byte, p crypt.Serializable) {
+	writer := packet.NewWriter()
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (f *fixture) selected(objectID int32) {
+	f.feed(fromgameserver.MyTargetSelectedID,
+		&fromgameserver.MyTargetSelected{ObjectID: objectID, Color: 0})
+}
+
+func (f *fixture) started(casterID, skillID, hitTime, reuse int32) {
+	f.feed(fromgameserver.MagicSkillUseID, &fromgameserver.MagicSkillUse{
+		CasterID:   casterID,
+		TargetID:   targetID,
+		SkillID:    skillID,
+		SkillLevel: 2,
+		HitTime:    hitTime,
+		ReuseDelay: reuse,
+		X:          0,
+		Y:          0,
+		Z:          0,
+	})
+}
+
+func (f *fixture) launched(casterID, skillID int32) {
+	f.feed(fromgameserver.MagicSkillLaunchedID,
+		&fromgameserver.MagicSkillLaunched{
+			CasterID:   casterID,
+			SkillID:    skillID,
+			SkillLevel: 2,
+			Targets:    []int32{targetID},
+		})
+}
+
+func (f *fixture) canceled(objectID int32) {
+	f.feed(fromgameserver.MagicSkillCanceldID,
+		&fromgameserver.MagicSkillCanceld{ObjectID: objectID})
+}
+
+func (f *fixture) failed() {
+	f.feed(fromgameserver.ActionFailedID, &fromgameserver.ActionFailed{})
+}
+
+func TestCombat_Select(t *testing.T) {
+	f := newFixture(t)
+	f.server.setAnswer(func(p crypt.Serializable) {
+		if action, ok := p.(*togameserver.Action); ok {
+			f.selected(action.ObjectID - 1)
+			f.selected(action.ObjectID)
+		}
+	})
+
+	require.NoError(t, f.combat.Select(context.Background(), targetID))
+	require.Equal(t, int32(targetID), f.combat.Target())
+	require.Equal(t, []crypt.Serializable{&togameserver.Action{
+		ObjectID: targetID,
+		OriginX:  10,
+		OriginY:  20,
+		OriginZ:  30,
+		Shift:    true,
+	}}, f.server.sent())
+
+	// Current target is not selected again.
+	require.NoError(t, f.combat.Select(context.Background(), targetID))
+	require.Len(t, f.server.sent(), 1)
+}
+
+func TestCombat_SelectRefused(t *testing.T) {
+	f := newFixture(t)
+	f.server.setAnswer(func(crypt.Serializable) { f.failed() })
+
+	err := f.combat.Select(context.Background(), targetID)
+	require.True(t, errors.Is(err, ErrRefused))
+	require.Zero(t, f.combat.Target())
+}
+
+func TestCombat_Cast(t *testing.T) {
+	f := newFixture(t)
+	f.selected(targetID)
+	f.server.setAnswer(func(p crypt.Serializable) {
+		if use, ok := p.(*togameserver.RequestMagicSkillUse); ok {
+			// Casts of other creatures don't confuse own cast.
+			f.started(otherID, use.SkillID, 100, 0)
+			f.canceled(otherID)
+			f.started(selfID, use.SkillID, 20, 30000)
+			f.launched(otherID, use.SkillID)
+			f.launched(selfID, use.SkillID)
+		}
+	})
+
+	cast, err := f.combat.Cast(context.Background(), Request{
+		SkillID:  healID,
+		TargetID: 0,
+		Ctrl:     false,
+		Shift:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, Cast{
+		SkillID:  healID,
+		Level:    2,
+		TargetID: targetID,
+		Started:  f.now,
+		HitTime:  20 * time.Millisecond,
+		Reuse:    30 * time.Second,
+		Launched: f.now,
+		Targets:  []int32{targetID},
+	}, cast)
+	require.Equal(t, f.now.Add(20*time.Millisecond), cast.Ends())
+	require.Equal(t, []crypt.Serializable{&togameserver.RequestMagicSkillUse{
+		SkillID: healID,
+		Ctrl:    false,
+		Shift:   true,
+	}}, f.server.sent())
+
+	_, casting := f.combat.Casting()
+	require.False(t, casting)
+	require.False(t, f.combat.Ready(healID))
+	require.Equal(t, 30*time.Second, f.combat.Cooldown(healID))
+
+	_, err = f.combat.Cast(context.Background(), Request{
+		SkillID:  healID,
+		TargetID: 0,
+		Ctrl:     false,
+		Shift:    false,
+	})
+	require.True(t, errors.Is(err, ErrNotReady))
+}
+
+func TestCombat_CastSelectsTarget(t *testing.T) {
+	f := newFixture(t)
+	f.server.setAnswer(func(p crypt.Serializable) {
+		switch request := p.(type) {
+		case *togameserver.Action:
+			f.selected(request.ObjectID)
+		case *togameserver.RequestMagicSkillUse:
+			f.started(selfID, request.SkillID, 0, 0)
+			f.launched(selfID, request.SkillID)
+		}
+	})
+
+	_, err := f.combat.Cast(context.Background(), Request{
+		SkillID:  healID,
+		TargetID: targetID,
+		Ctrl:     false,
+		Shift:    false,
+	})
+	require.NoError(t, err)
+	require.Len(t, f.server.sent(), 2)
+	require.Equal(t, int32(targetID), f.combat.Target())
+	require.True(t, f.combat.Ready(healID), "skill without reuse delay")
+}
+
+func TestCombat_CastErrors(t *testing.T) {
+	request := Request{SkillID: healID, TargetID: 0, Ctrl: false, Shift: false}
+
+	tests := []struct {
+		name     string
+		answer   func(f *fixture)
+		expected error
+	}{
+		{
+			name:     "refused",
+			answer:   func(f *fixture) { f.failed() },
+			expected: ErrRefused,
+		},
+		{
+			name: "interrupted",
+			answer: func(f *fixture) {
+				f.started(selfID, healID, 1000, 5000)
+				f.canceled(selfID)
+			},
+			expected: ErrInterrupted,
+		},
+		{
+			name: "interrupted before start",
+			answer: func(f *fixture) {
+				f.canceled(selfID)
+			},
+			expected: ErrInterrupted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.server.setAnswer(func(crypt.Serializable) { test.answer(f) })
+
+			_, err := f.combat.Cast(context.Background(), request)
+			require.True(t, errors.Is(err, test.expected), err)
+			require.True(t, f.combat.Ready(healID),
+				"interrupted skill is ready")
+			_, casting := f.combat.Casting()
+			require.False(t, casting)
+		})
+	}
+}
+
+func TestCombat_CastCanceledContext(t *testing.T) {
+	f := newFixture(t)
+	started := make(chan struct{})
+	f.server.setAnswer(func(crypt.Serializable) {
+		f.started(selfID, healID, 60000, 0)
+		close(started)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := f.combat.Cast(ctx, Request{
+		SkillID:  healID,
+		TargetID: 0,
+		Ctrl:     false,
+		Shift:    false,
+	})
+	require.True(t, errors.Is(err, context.Canceled))
+	cast, casting := f.combat.Casting()
+	require.True(t, casting, "character still casts")
+	require.Equal(t, int32(healID), cast.SkillID)
+}
+
+func TestCombat_SendErrors(t *testing.T) {
+	f := newFixture(t)
+	f.server.err = errors.New("offline")
+
+	require.Error(t, f.combat.Select(context.Background(), targetID))
+	require.Error(t, f.combat.Attack(targetID, false))
+	require.Error(t, f.combat.UseAction(togameserver.ActionSit, false, false))
+	_, err := f.combat.Cast(context.Background(), Request{
+		SkillID:  healID,
+		TargetID: 0,
+		Ctrl:     false,
+		Shift:    false,
+	})
+	require.True(t, errors.Is(err, f.server.err))
+}
+
+func TestCombat_AttackAndAction(t *testing.T) {
+	f := newFixture(t)
+
+	require.NoError(t, f.combat.Attack(targetID, true))
+	require.NoError(t, f.combat.UseAction(togameserver.ActionSit, true, false))
+	require.NoError(t, f.combat.Interact(targetID))
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.AttackRequest{
+			ObjectID: targetID,
+			OriginX:  10,
+			OriginY:  20,
+			OriginZ:  30,
+			Shift:    true,
+		},
+		&togameserver.RequestActionUse{
+			ActionID: togameserver.ActionSit,
+			Ctrl:     true,
+			Shift:    false,
+		},
+		&togameserver.Action{
+			ObjectID: targetID,
+			OriginX:  10,
+			OriginY:  20,
+			OriginZ:  30,
+			Shift:    false,
+		},
+	}, f.server.sent())
+}
+
+func TestCombat_CoolTime(t *testing.T) {
+	f := newFixture(t)
+	f.started(selfID, 1, 0, 60000)
+	f.feed(fromgameserver.SkillCoolTimeID, &fromgameserver.SkillCoolTime{
+		Skills: []fromgameserver.SkillReuse{
+			{SkillID: healID, Level: 1, Reuse: 30, Remaining: 12},
+		},
+	})
+
+	require.Equal(t, 12*time.Second, f.combat.Cooldown(healID))
+	require.True(t, f.combat.Ready(1), "cool time replaces all cooldowns")
+
+	f.selected(targetID)
+	f.started(selfID, 2, 1000, 1000)
+	f.combat.Clear()
+	require.True(t, f.combat.Ready(healID))
+	require.Zero(t, f.combat.Target())
+	_, casting := f.combat.Casting()
+	require.False(t, casting)
+}
+
+func TestCombat_TargetOf(t *testing.T) {
+	f := newFixture(t)
+	require.Zero(t, f.combat.TargetOf(otherID))
+
+	f.feed(fromgameserver.TargetSelectedID, &fromgameserver.TargetSelected{
+		ObjectID: otherID,
+		TargetID: targetID,
+		X:        0,
+		Y:        0,
+		Z:        0,
+	})
+	require.Equal(t, int32(targetID), f.combat.TargetOf(otherID))
+
+	f.feed(fromgameserver.TargetUnselectedID,
+		&fromgameserver.TargetUnselected{ObjectID: otherID, X: 0, Y: 0, Z: 0})
+	require.Zero(t, f.combat.TargetOf(otherID))
+
+	f.started(otherID, healID, 0, 0)
+	require.Equal(t, int32(targetID), f.combat.TargetOf(otherID),
+		"cast reveals target")
+
+	f.selected(targetID + 1)
+	require.Equal(t, int32(targetID+1), f.combat.TargetOf(selfID))
+
+	f.combat.Clear()
+	require.Zero(t, f.combat.TargetOf(otherID))
+}
+
+func TestCombat_Targeting(t *testing.T) {
+	f := newFixture(t)
+	require.Empty(t, f.combat.Targeting(selfID))
+
+	for _, id := range []int32{otherID + 2, otherID, otherID + 1} {
+		target := int32(selfID)
+		if id == otherID+1 {
+			target = targetID
+		}
+		f.feed(fromgameserver.TargetSelectedID, &fromgameserver.TargetSelected{
+			ObjectID: id,
+			TargetID: target,
+			X:        0,
+			Y:        0,
+			Z:        0,
+		})
+	}
+	require.Equal(t, []int32{otherID, otherID + 2}, f.combat.Targeting(selfID))
+	require.Equal(t, []int32{otherID + 1}, f.combat.Targeting(targetID))
+}
+
+func TestCombat_BrokenPackets(t *testing.T) {
+	f := newFixture(t)
+	for _, id := range []byte{
+		fromgameserver.MyTargetSelectedID,
+		fromgameserver.MagicSkillUseID,
+		fromgameserver.MagicSkillLaunchedID,
+		fromgameserver.MagicSkillCanceldID,
+		fromgameserver.SkillCoolTimeID,
+		fromgameserver.TargetSelectedID,
+		fromgameserver.TargetUnselectedID,
+		fromgameserver.AbnormalStatusUpdateID,
+		fromgameserver.SocialActionID,
+	} {
+		require.Error(t, f.dispatcher.Dispatch(id, []byte{1}))
+	}
+}
+
+func TestWait_NoAnswer(t *testing.T) {
+	events := make(chan Event)
+	_, err := wait(context.Background(), events, time.Millisecond,
+		func(Event) (bool, error) { return true, nil })
+	require.True(t, errors.Is(err, ErrNoAnswer))
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+)
+
+// Effect is buff or debuff on own character.
+type Effect struct {
+	SkillID int32
+	Level   int32
+	// Expires is zero for effect which doesn't expire.
+	Expires time.Time
+}
+
+// Left returns time until effect expires, permanent effect never does.
+func (e Effect) Left(now time.Time) time.Duration {
+	if e.Expires.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return max(e.Expires.Sub(now), 0)
+}
+
+func (c *Combat) handleEffects(data []byte) error {
+	update, err := fromgameserver.NewAbnormalStatusUpdateFromBytes(data)
+	if err != nil {
+		return err
+	}
+	now := c.world.Now()
+
+	effects := make(map[int32]Effect, len(update.Effects))
+	for _, effect := range update.Effects {
+		expires := time.Time{}
+		if effect.Duration != fromgameserver.PermanentEffect {
+			expires = now.Add(time.Duration(effect.Duration) * time.Second)
+		}
+		effects[effect.SkillID] = Effect{
+			SkillID: effect.SkillID,
+			Level:   int32(effect.Level),
+			Expires: expires,
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.effects = effects
+
+	return nil
+}
+
+// Effects returns effects on own character sorted by skill id. Expired
+// effects are kept until server sends new list.
+func (c *Combat) Effects() []Effect {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result := make([]Effect, 0, len(c.effects))
+	for _, effect := range c.effects {
+		result = append(result, effect)
+	}
+	slices.SortFunc(result, func(a, b Effect) int {
+		return cmp.Compare(a.SkillID, b.SkillID)
+	})
+
+	return result
+}
+
+// Effect returns effect of skill on own character.
+func (c *Combat) Effect(skillID int32) (Effect, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	effect, ok := c.effects[skillID]
+
+	return effect, ok
+}
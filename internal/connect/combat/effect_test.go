@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	shieldID = 1040
+	mightID  = 1068
+)
+
+func TestCombat_Effects(t *testing.T) {
+	f := newFixture(t)
+	require.Empty(t, f.combat.Effects())
+
+	f.feed(fromgameserver.AbnormalStatusUpdateID,
+		&fromgameserver.AbnormalStatusUpdate{
+			Effects: []fromgameserver.AbnormalEffect{
+				{SkillID: mightID, Level: 1, Duration: 60},
+				{SkillID: shieldID, Level: 3, Duration: -1},
+			},
+		})
+	require.Equal(t, []Effect{
+		{SkillID: shieldID, Level: 3, Expires: time.Time{}},
+		{SkillID: mightID, Level: 1, Expires: f.now.Add(time.Minute)},
+	}, f.combat.Effects())
+
+	might, ok := f.combat.Effect(mightID)
+	require.True(t, ok)
+	require.Equal(t, 20*time.Second, might.Left(f.now.Add(40*time.Second)))
+	require.Zero(t, might.Left(f.now.Add(time.Hour)))
+	shield, _ := f.combat.Effect(shieldID)
+	require.Equal(t, time.Duration(math.MaxInt64), shield.Left(f.now))
+
+	f.feed(fromgameserver.AbnormalStatusUpdateID,
+		&fromgameserver.AbnormalStatusUpdate{
+			Effects: []fromgameserver.AbnormalEffect{
+				{SkillID: mightID, Level: 1, Duration: 30},
+			},
+		})
+	_, ok = f.combat.Effect(shieldID)
+	require.False(t, ok, "new list replaces old one")
+	require.Len(t, f.combat.Effects(), 1)
+
+	f.combat.Clear()
+	require.Empty(t, f.combat.Effects())
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventKind is kind of combat outcome reported by server.
+type EventKind int
+
+const (
+	TargetSelected EventKind = iota
+	CastStarted
+	CastLaunched
+	CastCanceled
+	ActionFailed
+	CooldownsUpdated
+	SocialActed
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case TargetSelected:
+		return "TargetSelected"
+	case CastStarted:
+		return "CastStarted"
+	case CastLaunched:
+		return "CastLaunched"
+	case CastCanceled:
+		return "CastCanceled"
+	case ActionFailed:
+		return "ActionFailed"
+	case CooldownsUpdated:
+		return "CooldownsUpdated"
+	case SocialActed:
+		return "SocialActed"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// Event is outcome of combat action of own character or of creature
+// around. Fields which don't make sense for kind are zero, social action
+// keeps id of emote in skill id.
+type Event struct {
+	Kind     EventKind
+	Time     time.Time
+	CasterID int32
+	TargetID int32
+	SkillID  int32
+	Level    int32
+	HitTime  time.Duration
+	Reuse    time.Duration
+	Targets  []int32
+}
+
+// Subscribe returns channel of all combat events and function which
+// cancels subscription. Events are dropped for subscriber which doesn't
+// keep up, so packet handling never waits for it.
+func (c *Combat) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, eventBuffer)
+
+	c.mutex.Lock()
+	c.subscribers[events] = struct{}{}
+	c.mutex.Unlock()
+
+	return events, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		delete(c.subscribers, events)
+	}
+}
+
+// publish sends event to subscribers, mutex must be held.
+func (c *Combat) publish(event Event) {
+	for subscriber := range c.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventKind_String(t *testing.T) {
+	require.Equal(t, "TargetSelected", TargetSelected.String())
+	require.Equal(t, "CastStarted", CastStarted.String())
+	require.Equal(t, "CastLaunched", CastLaunched.String())
+	require.Equal(t, "CastCanceled", CastCanceled.String())
+	require.Equal(t, "ActionFailed", ActionFailed.String())
+	require.Equal(t, "CooldownsUpdated", CooldownsUpdated.String())
+	require.Equal(t, "SocialActed", SocialActed.String())
+	require.Equal(t, "EventKind(42)", EventKind(42).String())
+}
+
+func TestCombat_Subscribe(t *testing.T) {
+	f := newFixture(t)
+	events, cancel := f.combat.Subscribe()
+
+	f.started(otherID, healID, 1500, 0)
+	event := <-events
+	require.Equal(t, CastStarted, event.Kind)
+	require.Equal(t, int32(otherID), event.CasterID)
+	require.Equal(t, int32(healID), event.SkillID)
+
+	// Slow subscriber loses events instead of blocking handlers.
+	for range eventBuffer + 1 {
+		f.failed()
+	}
+	require.Len(t, events, eventBuffer)
+
+	cancel()
+	f.combat.mutex.Lock()
+	require.Empty(t, f.combat.subscribers)
+	f.combat.mutex.Unlock()
+}
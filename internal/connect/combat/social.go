@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+)
+
+func (c *Combat) handleSocial(data []byte) error {
+	social, err := fromgameserver.NewSocialActionFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.publish(Event{ //nolint:exhaustruct
+		Kind:     SocialActed,
+		Time:     c.world.Now(),
+		CasterID: social.ObjectID,
+		SkillID:  social.ActionID,
+	})
+
+	return nil
+}
+
+// Social plays emote and waits until server shows it, time emote started
+// is returned.
+func (c *Combat) Social(
+	ctx context.Context,
+	actionID int32,
+) (time.Time, error) {
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	err := c.sender.WritePacket(&togameserver.RequestSocialAction{
+		ActionID: actionID,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	selfID := c.world.SelfID()
+	acted, err := wait(ctx, events, answerTimeout,
+		func(event Event) (bool, error) {
+			switch {
+			case event.Kind == ActionFailed:
+				return true, ErrRefused
+			default:
+				return event.Kind == SocialActed &&
+					event.CasterID == selfID && event.SkillID == actionID, nil
+			}
+		})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to play emote %d: %w",
+			actionID, err)
+	}
+
+	return acted.Time, nil
+}
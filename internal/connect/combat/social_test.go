@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package combat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombat_Social(t *testing.T) {
+	f := newFixture(t)
+	f.server.setAnswer(func(p crypt.Serializable) {
+		if social, ok := p.(*togameserver.RequestSocialAction); ok {
+			// Emotes of others and other emotes don't confuse own one.
+			f.feed(fromgameserver.SocialActionID, &fromgameserver.SocialAction{
+				ObjectID: otherID,
+				ActionID: social.ActionID,
+			})
+			f.feed(fromgameserver.SocialActionID, &fromgameserver.SocialAction{
+				ObjectID: selfID,
+				ActionID: social.ActionID + 1,
+			})
+			f.feed(fromgameserver.SocialActionID, &fromgameserver.SocialAction{
+				ObjectID: selfID,
+				ActionID: social.ActionID,
+			})
+		}
+	})
+
+	acted, err := f.combat.Social(context.Background(),
+		togameserver.SocialVictory)
+	require.NoError(t, err)
+	require.Equal(t, f.now, acted)
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestSocialAction{ActionID: togameserver.SocialVictory},
+	}, f.server.sent())
+}
+
+func TestCombat_SocialRefused(t *testing.T) {
+	f := newFixture(t)
+	f.server.setAnswer(func(crypt.Serializable) {
+		f.feed(fromgameserver.ActionFailedID, &fromgameserver.ActionFailed{})
+	})
+
+	_, err := f.combat.Social(context.Background(), togameserver.SocialBow)
+	require.True(t, errors.Is(err, ErrRefused), err)
+
+	f.server.err = errors.New("closed")
+	_, err = f.combat.Social(context.Background(), togameserver.SocialBow)
+	require.Error(t, err)
+}
@@ -0,0 +1,644 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultServer      = "127.0.0.1:2106"
+	maxPartySize       = 9
+	defaultLockDir     = "connect-locks"
+	defaultEyesRadius  = 1500
+	defaultDedupWindow = 250 * time.Millisecond
+	// DefaultDashboard is address of web dashboard, it is reachable only
+	// from same machine.
+	DefaultDashboard = "localhost:8080"
+)
+
+// Account is single bot. Bots log in by ascending priority, after lists
+// logins of bots which must be in world before this bot connects. Healer
+// is set for bots which heal and buff others.
+type Account struct {
+	Login     string   `json:"login"`
+	Password  string   `json:"password"`
+	Character string   `json:"character"`
+	Priority  int      `json:"priority"`
+	After     []string `json:"after"`
+	Healer    *Healer  `json:"healer"`
+}
+
+// HealSkill is heal skill of healer, power is HP it restores and mp is
+// mana it costs.
+type HealSkill struct {
+	SkillID  int32    `json:"skill_id"`
+	Power    int32    `json:"power"`
+	MP       int32    `json:"mp"`
+	CastTime Duration `json:"cast_time"`
+}
+
+// BuffSkill is buff healer keeps on team, it is cast again when it expires
+// sooner than refresh time. Mp is mana it costs.
+type BuffSkill struct {
+	SkillID int32    `json:"skill_id"`
+	MP      int32    `json:"mp"`
+	Refresh Duration `json:"refresh"`
+}
+
+// Healer is skills and priorities of healer. Thresholds are shares of HP,
+// zero means default. Tanks and team are logins, empty team means all bots
+// of process.
+type Healer struct {
+	Heals     []HealSkill `json:"heals"`
+	Buffs     []BuffSkill `json:"buffs"`
+	Threshold float64     `json:"threshold"`
+	Emergency float64     `json:"emergency"`
+	Tanks     []string    `json:"tanks"`
+	Team      []string    `json:"team"`
+}
+
+// Duration is time.Duration written in json as string like "1m30s".
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be string like \"1s\": %w", err)
+	}
+
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+
+	return nil
+}
+
+// Reconnect controls how bots restore lost connection, zero max attempts
+// means bots never give up.
+type Reconnect struct {
+	Disabled    bool     `json:"disabled"`
+	MinDelay    Duration `json:"min_delay"`
+	MaxDelay    Duration `json:"max_delay"`
+	MaxAttempts int      `json:"max_attempts"`
+}
+
+// Eyes turns on shared observation: in each group of bots within radius
+// only one bot decodes packets about objects around, others use its view.
+type Eyes struct {
+	Enabled bool    `json:"enabled"`
+	Radius  float64 `json:"radius"`
+}
+
+// Dedup turns on suppression of copies of same entity packet received by
+// several bots within window.
+type Dedup struct {
+	Enabled bool     `json:"enabled"`
+	Window  Duration `json:"window"`
+}
+
+// Dashboard turns on web page with live status and controls of bots.
+type Dashboard struct {
+	Enabled bool   `json:"enabled"`
+	Address string `json:"address"`
+}
+
+// LootModes are names of loot modes of party, index of name is mode id of
+// game.
+var LootModes = []string{
+	"finder", "random", "random_spoil", "by_turn", "by_turn_spoil",
+}
+
+// Party is composition bots form after entering world. Leader and members
+// are account logins, empty loot means finder keeps items.
+type Party struct {
+	Leader  string   `json:"leader"`
+	Members []string `json:"members"`
+	Loot    string   `json:"loot"`
+}
+
+// LootMode returns game id of loot mode of party.
+func (p Party) LootMode() int32 {
+	for i, name := range LootModes {
+		if name == p.Loot {
+			return int32(i) //nolint:gosec
+		}
+	}
+
+	return 0
+}
+
+// Plans are names of built-in scenario plans.
+var Plans = []string{"farm"}
+
+// Levels are names of coordination levels of scenario groups, index of name
+// is level.
+var Levels = []string{
+	"independent", "semi_synchronized", "synchronized", "hyper_synchronized",
+}
+
+// Point is position in game world.
+type Point struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	Z int32 `json:"z"`
+}
+
+// Scenario is plan run by group of bots. Roles map account logins to roles
+// of plan, points are named places plan uses, like gathering point.
+type Scenario struct {
+	Name     string            `json:"name"`
+	Plan     string            `json:"plan"`
+	Level    string            `json:"level"`
+	Roles    map[string]string `json:"roles"`
+	Points   map[string]Point  `json:"points"`
+	Duration Duration          `json:"duration"`
+	Loop     bool              `json:"loop"`
+}
+
+// Picture is png image painted on ground by dropping gold. Image is scaled
+// to width pixels, origin is world point of top left pixel and step is
+// distance between pixels. Artists are account logins of bots which paint.
+type Picture struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Width   int      `json:"width"`
+	Origin  Point    `json:"origin"`
+	Step    int32    `json:"step"`
+	Artists []string `json:"artists"`
+}
+
+// BurstKinds are names of actions of burst.
+var BurstKinds = []string{"spell", "melee", "emote"}
+
+// BurstAction is what bot with login does in burst. Spell is skill with cast
+// time and projectile speed in units per second, zero speed means skill
+// hits at once. Melee hits from reach, swing is time of one attack. Emote
+// is social action, soulshot with shot item id is used before it, zero id
+// means no soulshot.
+type BurstAction struct {
+	Login           string   `json:"login"`
+	Kind            string   `json:"kind"`
+	SkillID         int32    `json:"skill_id"`
+	CastTime        Duration `json:"cast_time"`
+	ProjectileSpeed float64  `json:"projectile_speed"`
+	Reach           float64  `json:"reach"`
+	Swing           Duration `json:"swing"`
+	SocialID        int32    `json:"social_id"`
+	ShotItemID      int32    `json:"shot_item_id"`
+}
+
+// Burst is group action timed so all actions hit at same moment. Margin is
+// time bots need to receive their orders, zero means default.
+type Burst struct {
+	Name    string        `json:"name"`
+	Margin  Duration      `json:"margin"`
+	Actions []BurstAction `json:"actions"`
+}
+
+// DisplayShapes are names of formations of display. Ranks puts each group
+// of performers in its own rank, text writes text with performers.
+var DisplayShapes = []string{
+	"line", "column", "wedge", "grid", "circle", "ranks", "text",
+}
+
+// Performer is bot with login which takes part in display, performers of
+// one group stand in one rank.
+type Performer struct {
+	Login string `json:"login"`
+	Group string `json:"group"`
+}
+
+// Display is formation bots stand in for screenshots. Anchor is world point
+// of formation, heading is direction it faces and spacing is distance
+// between neighbours. Text is written when shape is text.
+type Display struct {
+	Name       string      `json:"name"`
+	Shape      string      `json:"shape"`
+	Text       string      `json:"text"`
+	Anchor     Point       `json:"anchor"`
+	Heading    int32       `json:"heading"`
+	Spacing    float64     `json:"spacing"`
+	Performers []Performer `json:"performers"`
+}
+
+// Config describes single program instance. Several instances can run at
+// same time as long as they use different configs and accounts.
+type Config struct {
+	Name      string    `json:"name"`
+	Server    string    `json:"server"`
+	LockDir   string    `json:"lock_dir"`
+	Geodata   string    `json:"geodata_dir"`
+	Reconnect Reconnect `json:"reconnect"`
+	Eyes      Eyes      `json:"eyes"`
+	Dedup     Dedup     `json:"dedup"`
+	Dashboard Dashboard `json:"dashboard"`
+	Accounts  []Account `json:"accounts"`
+	Parties   []Party   `json:"parties"`
+	// Commanders are names of characters allowed to control bots by
+	// whispers and party chat, empty list turns commands off.
+	Commanders []string   `json:"commanders"`
+	Scenarios  []Scenario `json:"scenarios"`
+	Pictures   []Picture  `json:"pictures"`
+	Bursts     []Burst    `json:"bursts"`
+	Displays   []Display  `json:"displays"`
+}
+
+func Default() *Config {
+	return &Config{
+		Name:    "default",
+		Server:  DefaultServer,
+		LockDir: filepath.Join(os.TempDir(), defaultLockDir),
+		Geodata: "",
+		Reconnect: Reconnect{
+			Disabled:    false,
+			MinDelay:    Duration{time.Second},
+			MaxDelay:    Duration{time.Minute},
+			MaxAttempts: 0,
+		},
+		Eyes: Eyes{
+			Enabled: false,
+			Radius:  defaultEyesRadius,
+		},
+		Dedup: Dedup{
+			Enabled: false,
+			Window:  Duration{defaultDedupWindow},
+		},
+		Dashboard: Dashboard{
+			Enabled: false,
+			Address: DefaultDashboard,
+		},
+		Accounts:   nil,
+		Parties:    nil,
+		Commanders: nil,
+		Scenarios:  nil,
+		Pictures:   nil,
+		Bursts:     nil,
+		Displays:   nil,
+	}
+}
+
+// Load reads config from json file. Missing optional fields are filled with
+// defaults, name of config defaults to file name without extension.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := Default()
+	cfg.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is empty")
+	}
+	if c.Server == "" {
+		return errors.New("server address is empty")
+	}
+	if c.LockDir == "" {
+		return errors.New("lock dir is empty")
+	}
+	// Zero delay would reconnect in tight loop while server is down.
+	if c.Reconnect.MinDelay.Duration <= 0 {
+		return errors.New("reconnect min delay must be positive")
+	}
+	if c.Reconnect.MinDelay.Duration > c.Reconnect.MaxDelay.Duration {
+		return errors.New("reconnect min delay is bigger than max delay")
+	}
+	if c.Eyes.Radius <= 0 {
+		return errors.New("eyes radius must be positive")
+	}
+	if c.Dedup.Window.Duration <= 0 {
+		return errors.New("dedup window must be positive")
+	}
+	if c.Eyes.Enabled && c.Dedup.Enabled {
+		return errors.New("eyes and dedup can't be enabled together")
+	}
+	if _, _, err := net.SplitHostPort(c.Dashboard.Address); err != nil {
+		return fmt.Errorf("invalid dashboard address: %w", err)
+	}
+
+	logins := make(map[string]bool, len(c.Accounts))
+	for _, account := range c.Accounts {
+		if account.Login == "" {
+			return errors.New("account login is empty")
+		}
+		if logins[account.Login] {
+			return fmt.Errorf("account %s is listed twice", account.Login)
+		}
+		logins[account.Login] = true
+	}
+
+	for _, account := range c.Accounts {
+		for _, login := range account.After {
+			if !logins[login] {
+				return fmt.Errorf("account %s waits for unknown account %s",
+					account.Login, login)
+			}
+		}
+	}
+
+	for _, name := range c.Commanders {
+		if name == "" {
+			return errors.New("commander name is empty")
+		}
+	}
+
+	if err := c.validateParties(); err != nil {
+		return err
+	}
+	if err := c.validateHealers(logins); err != nil {
+		return err
+	}
+
+	if err := c.validateScenarios(); err != nil {
+		return err
+	}
+
+	if err := c.validatePictures(logins); err != nil {
+		return err
+	}
+
+	if err := c.validateBursts(logins); err != nil {
+		return err
+	}
+
+	return c.validateDisplays(logins)
+}
+
+func (c *Config) validateParties() error {
+	characters := make(map[string]string, len(c.Accounts))
+	for _, account := range c.Accounts {
+		characters[account.Login] = account.Character
+	}
+
+	parties := make(map[string]bool)
+	for _, party := range c.Parties {
+		if party.Loot != "" && !slices.Contains(LootModes, party.Loot) {
+			return fmt.Errorf("party of %s has unknown loot mode %q",
+				party.Leader, party.Loot)
+		}
+		if len(party.Members) == 0 {
+			return fmt.Errorf("party of %s has no members", party.Leader)
+		}
+		if len(party.Members)+1 > maxPartySize {
+			return fmt.Errorf("party of %s has more than %d members",
+				party.Leader, maxPartySize)
+		}
+
+		for _, login := range append([]string{party.Leader}, party.Members...) {
+			character, ok := characters[login]
+			if !ok {
+				return fmt.Errorf("party has unknown account %s", login)
+			}
+			if character == "" {
+				return fmt.Errorf("party account %s has no character", login)
+			}
+			if parties[login] {
+				return fmt.Errorf("account %s is in two parties", login)
+			}
+			parties[login] = true
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateHealers(logins map[string]bool) error {
+	for _, account := range c.Accounts {
+		healer := account.Healer
+		if healer == nil {
+			continue
+		}
+		if len(healer.Heals) == 0 && len(healer.Buffs) == 0 {
+			return fmt.Errorf("healer %s has no skills", account.Login)
+		}
+		for _, heal := range healer.Heals {
+			if heal.SkillID <= 0 || heal.Power <= 0 || heal.MP < 0 {
+				return fmt.Errorf("healer %s has invalid heal %d",
+					account.Login, heal.SkillID)
+			}
+		}
+		for _, buff := range healer.Buffs {
+			if buff.SkillID <= 0 || buff.MP < 0 {
+				return fmt.Errorf("healer %s has invalid buff %d",
+					account.Login, buff.SkillID)
+			}
+		}
+		if healer.Threshold < 0 || healer.Threshold > 1 ||
+			healer.Emergency < 0 || healer.Emergency > 1 {
+			return fmt.Errorf("healer %s thresholds must be between 0 and 1",
+				account.Login)
+		}
+		for _, login := range append(slices.Clone(healer.Tanks),
+			healer.Team...) {
+			if !logins[login] {
+				return fmt.Errorf("healer %s looks after unknown account %s",
+					account.Login, login)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateScenarios() error {
+	logins := make(map[string]bool, len(c.Accounts))
+	for _, account := range c.Accounts {
+		logins[account.Login] = true
+	}
+
+	names := make(map[string]bool, len(c.Scenarios))
+	for _, scenario := range c.Scenarios {
+		if scenario.Name == "" {
+			return errors.New("scenario name is empty")
+		}
+		if names[scenario.Name] {
+			return fmt.Errorf("scenario %s is listed twice", scenario.Name)
+		}
+		names[scenario.Name] = true
+
+		if !slices.Contains(Plans, scenario.Plan) {
+			return fmt.Errorf("scenario %s has unknown plan %q",
+				scenario.Name, scenario.Plan)
+		}
+		if !slices.Contains(Levels, scenario.Level) {
+			return fmt.Errorf("scenario %s has unknown level %q",
+				scenario.Name, scenario.Level)
+		}
+		if len(scenario.Roles) == 0 {
+			return fmt.Errorf("scenario %s has no roles", scenario.Name)
+		}
+		for login := range scenario.Roles {
+			if !logins[login] {
+				return fmt.Errorf("scenario %s has unknown account %s",
+					scenario.Name, login)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validatePictures(logins map[string]bool) error {
+	names := make(map[string]bool, len(c.Pictures))
+	for _, picture := range c.Pictures {
+		if picture.Name == "" {
+			return errors.New("picture name is empty")
+		}
+		if names[picture.Name] {
+			return fmt.Errorf("picture %s is listed twice", picture.Name)
+		}
+		names[picture.Name] = true
+
+		if picture.Image == "" {
+			return fmt.Errorf("picture %s has no image", picture.Name)
+		}
+		if picture.Width <= 0 {
+			return fmt.Errorf("picture %s width must be positive",
+				picture.Name)
+		}
+		if picture.Step <= 0 {
+			return fmt.Errorf("picture %s step must be positive",
+				picture.Name)
+		}
+		if len(picture.Artists) == 0 {
+			return fmt.Errorf("picture %s has no artists", picture.Name)
+		}
+		for _, login := range picture.Artists {
+			if !logins[login] {
+				return fmt.Errorf("picture %s has unknown account %s",
+					picture.Name, login)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateBursts(logins map[string]bool) error {
+	names := make(map[string]bool, len(c.Bursts))
+	for _, burst := range c.Bursts {
+		if burst.Name == "" {
+			return errors.New("burst name is empty")
+		}
+		if names[burst.Name] {
+			return fmt.Errorf("burst %s is listed twice", burst.Name)
+		}
+		names[burst.Name] = true
+
+		if burst.Margin.Duration < 0 {
+			return fmt.Errorf("burst %s margin is negative", burst.Name)
+		}
+		if len(burst.Actions) == 0 {
+			return fmt.Errorf("burst %s has no actions", burst.Name)
+		}
+		for _, action := range burst.Actions {
+			if !logins[action.Login] {
+				return fmt.Errorf("burst %s has unknown account %s",
+					burst.Name, action.Login)
+			}
+			if err := action.validate(); err != nil {
+				return fmt.Errorf("burst %s action of %s: %w",
+					burst.Name, action.Login, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateDisplays(logins map[string]bool) error {
+	names := make(map[string]bool, len(c.Displays))
+	for _, display := range c.Displays {
+		if display.Name == "" {
+			return errors.New("display name is empty")
+		}
+		if names[display.Name] {
+			return fmt.Errorf("display %s is listed twice", display.Name)
+		}
+		names[display.Name] = true
+
+		if !slices.Contains(DisplayShapes, display.Shape) {
+			return fmt.Errorf("display %s has unknown shape %q",
+				display.Name, display.Shape)
+		}
+		if display.Shape == "text" && display.Text == "" {
+			return fmt.Errorf("display %s has no text", display.Name)
+		}
+		if display.Spacing <= 0 {
+			return fmt.Errorf("display %s spacing must be positive",
+				display.Name)
+		}
+		if len(display.Performers) == 0 {
+			return fmt.Errorf("display %s has no performers", display.Name)
+		}
+		performers := make(map[string]bool, len(display.Performers))
+		for _, performer := range display.Performers {
+			if !logins[performer.Login] {
+				return fmt.Errorf("display %s has unknown account %s",
+					display.Name, performer.Login)
+			}
+			if performers[performer.Login] {
+				return fmt.Errorf("display %s lists account %s twice",
+					display.Name, performer.Login)
+			}
+			performers[performer.Login] = true
+		}
+	}
+
+	return nil
+}
+
+func (a BurstAction) validate() error {
+	switch a.Kind {
+	case "spell":
+		if a.SkillID <= 0 {
+			return errors.New("spell has no skill")
+		}
+	case "melee":
+		if a.Swing.Duration <= 0 {
+			return errors.New("melee swing must be positive")
+		}
+	case "emote":
+		if a.SocialID <= 0 {
+			return errors.New("emote has no social action")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", a.Kind)
+	}
+	if a.CastTime.Duration < 0 || a.ProjectileSpeed < 0 || a.Reach < 0 {
+		return errors.New("timings and distances must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,491 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, "debug.json", `{
+		"server": "10.0.0.1:2106",
+		"geodata_dir": "/data/geodata",
+		"reconnect": {"min_delay": "500ms", "max_delay": "30s"},
+		"eyes": {"enabled": true},
+		"dashboard": {"enabled": true},
+		"accounts": [
+			{"login": "tank", "password": "1", "character": "Tank"},
+			{"login": "healer", "password": "2", "character": "Healer",
+			 "priority": 1, "after": ["tank"],
+			 "healer": {
+				"heals": [{"skill_id": 1011, "power": 100, "mp": 25,
+				           "cast_time": "2s"}],
+				"buffs": [{"skill_id": 1040, "mp": 30, "refresh": "1m"}],
+				"threshold": 0.7, "tanks": ["tank"]
+			 }}
+		],
+		"parties": [
+			{"leader": "tank", "members": ["healer"], "loot": "by_turn"}
+		],
+		"commanders": ["Human"],
+		"scenarios": [
+			{"name": "elpies", "plan": "farm", "level": "synchronized",
+			 "roles": {"tank": "tank", "healer": "healer"},
+			 "points": {"gather": {"x": 1, "y": 2, "z": 3}},
+			 "duration": "10m", "loop": true}
+		],
+		"pictures": [
+			{"name": "logo", "image": "logo.png", "width": 64,
+			 "origin": {"x": 100, "y": 200, "z": -300}, "step": 20,
+			 "artists": ["tank", "healer"]}
+		],
+		"bursts": [
+			{"name": "alpha", "margin": "300ms", "actions": [
+				{"login": "tank", "kind": "melee", "reach": 40,
+				 "swing": "500ms"},
+				{"login": "healer", "kind": "spell", "skill_id": 1177,
+				 "cast_time": "1s", "projectile_speed": 600}
+			]}
+		],
+		"displays": [
+			{"name": "hello", "shape": "text", "text": "hi",
+			 "anchor": {"x": 10, "y": 20, "z": 30}, "heading": 16384,
+			 "spacing": 40, "performers": [
+				{"login": "tank", "group": "fighters"},
+				{"login": "healer"}
+			]}
+		]
+	}`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "debug", cfg.Name)
+	require.Equal(t, "10.0.0.1:2106", cfg.Server)
+	require.Equal(t, Default().LockDir, cfg.LockDir)
+	require.Equal(t, "/data/geodata", cfg.Geodata)
+	require.Equal(t, 500*time.Millisecond, cfg.Reconnect.MinDelay.Duration)
+	require.Equal(t, 30*time.Second, cfg.Reconnect.MaxDelay.Duration)
+	require.False(t, cfg.Reconnect.Disabled)
+	require.True(t, cfg.Eyes.Enabled)
+	require.InDelta(t, defaultEyesRadius, cfg.Eyes.Radius, 0)
+	require.False(t, cfg.Dedup.Enabled)
+	require.Equal(t, defaultDedupWindow, cfg.Dedup.Window.Duration)
+	require.Equal(t, Dashboard{Enabled: true, Address: DefaultDashboard},
+		cfg.Dashboard)
+	require.Len(t, cfg.Accounts, 2)
+	require.Equal(t, "Healer", cfg.Accounts[1].Character)
+	require.Equal(t, 1, cfg.Accounts[1].Priority)
+	require.Equal(t, []string{"tank"}, cfg.Accounts[1].After)
+	require.Len(t, cfg.Parties, 1)
+	require.Equal(t, "tank", cfg.Parties[0].Leader)
+	require.Equal(t, []string{"healer"}, cfg.Parties[0].Members)
+	require.Equal(t, int32(3), cfg.Parties[0].LootMode())
+	require.Equal(t, []string{"Human"}, cfg.Commanders)
+	require.Nil(t, cfg.Accounts[0].Healer)
+	require.Equal(t, &Healer{
+		Heals: []HealSkill{{
+			SkillID:  1011,
+			Power:    100,
+			MP:       25,
+			CastTime: Duration{2 * time.Second},
+		}},
+		Buffs: []BuffSkill{
+			{SkillID: 1040, MP: 30, Refresh: Duration{time.Minute}},
+		},
+		Threshold: 0.7,
+		Emergency: 0,
+		Tanks:     []string{"tank"},
+		Team:      nil,
+	}, cfg.Accounts[1].Healer)
+	require.Len(t, cfg.Scenarios, 1)
+	require.Equal(t, "farm", cfg.Scenarios[0].Plan)
+	require.Equal(t, "healer", cfg.Scenarios[0].Roles["healer"])
+	require.Equal(t, Point{X: 1, Y: 2, Z: 3}, cfg.Scenarios[0].Points["gather"])
+	require.Equal(t, 10*time.Minute, cfg.Scenarios[0].Duration.Duration)
+	require.True(t, cfg.Scenarios[0].Loop)
+	require.Equal(t, []Picture{{
+		Name:    "logo",
+		Image:   "logo.png",
+		Width:   64,
+		Origin:  Point{X: 100, Y: 200, Z: -300},
+		Step:    20,
+		Artists: []string{"tank", "healer"},
+	}}, cfg.Pictures)
+	require.Len(t, cfg.Bursts, 1)
+	require.Equal(t, 300*time.Millisecond, cfg.Bursts[0].Margin.Duration)
+	require.Equal(t, []BurstAction{
+		{
+			Login:           "tank",
+			Kind:            "melee",
+			SkillID:         0,
+			CastTime:        Duration{0},
+			ProjectileSpeed: 0,
+			Reach:           40,
+			Swing:           Duration{500 * time.Millisecond},
+			SocialID:        0,
+			ShotItemID:      0,
+		},
+		{
+			Login:           "healer",
+			Kind:            "spell",
+			SkillID:         1177,
+			CastTime:        Duration{time.Second},
+			ProjectileSpeed: 600,
+			Reach:           0,
+			Swing:           Duration{0},
+			SocialID:        0,
+			ShotItemID:      0,
+		},
+	}, cfg.Bursts[0].Actions)
+	require.Equal(t, []Display{{
+		Name:    "hello",
+		Shape:   "text",
+		Text:    "hi",
+		Anchor:  Point{X: 10, Y: 20, Z: 30},
+		Heading: 16384,
+		Spacing: 40,
+		Performers: []Performer{
+			{Login: "tank", Group: "fighters"},
+			{Login: "healer", Group: ""},
+		},
+	}}, cfg.Displays)
+}
+
+func TestParty_LootMode(t *testing.T) {
+	require.Equal(t, int32(0), Party{}.LootMode()) //nolint:exhaustruct
+	for i, name := range LootModes {
+		party := Party{Leader: "a", Members: nil, Loot: name}
+		require.Equal(t, int32(i), party.LootMode()) //nolint:gosec
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "broken json", content: `{"server": `},
+		{name: "empty server", content: `{"server": ""}`},
+		{
+			name:    "bad duration",
+			content: `{"reconnect": {"min_delay": "soon"}}`,
+		},
+		{
+			name:    "numeric duration",
+			content: `{"reconnect": {"min_delay": 5}}`,
+		},
+		{
+			name:    "zero min delay",
+			content: `{"reconnect": {"min_delay": "0s"}}`,
+		},
+		{
+			name:    "min delay above max",
+			content: `{"reconnect": {"min_delay": "2m"}}`,
+		},
+		{name: "zero eyes radius", content: `{"eyes": {"radius": 0}}`},
+		{name: "zero dedup window", content: `{"dedup": {"window": "0s"}}`},
+		{
+			name:    "dashboard without port",
+			content: `{"dashboard": {"address": "localhost"}}`,
+		},
+		{
+			name: "eyes with dedup",
+			content: `{"eyes": {"enabled": true},
+				"dedup": {"enabled": true}}`,
+		},
+		{name: "empty login", content: `{"accounts": [{"login": ""}]}`},
+		{
+			name:    "unknown dependency",
+			content: `{"accounts": [{"login": "a", "after": ["b"]}]}`,
+		},
+		{
+			name:    "duplicate login",
+			content: `{"accounts": [{"login": "a"}, {"login": "a"}]}`,
+		},
+		{
+			name: "unknown party member",
+			content: `{"accounts": [{"login": "a", "character": "A"}],
+				"parties": [{"leader": "a", "members": ["b"]}]}`,
+		},
+		{
+			name: "party member without character",
+			content: `{"accounts": [{"login": "a", "character": "A"},
+				{"login": "b"}],
+				"parties": [{"leader": "a", "members": ["b"]}]}`,
+		},
+		{
+			name: "empty party",
+			content: `{"accounts": [{"login": "a", "character": "A"}],
+				"parties": [{"leader": "a", "members": []}]}`,
+		},
+		{
+			name: "unknown loot mode",
+			content: `{"accounts": [{"login": "a", "character": "A"},
+				{"login": "b", "character": "B"}],
+				"parties": [{"leader": "a", "members": ["b"],
+				"loot": "all"}]}`,
+		},
+		{
+			name: "account in two parties",
+			content: `{"accounts": [{"login": "a", "character": "A"},
+				{"login": "b", "character": "B"},
+				{"login": "c", "character": "C"}],
+				"parties": [{"leader": "a", "members": ["b"]},
+				{"leader": "c", "members": ["b"]}]}`,
+		},
+		{name: "empty commander", content: `{"commanders": [""]}`},
+		{
+			name:    "healer without skills",
+			content: `{"accounts": [{"login": "a", "healer": {}}]}`,
+		},
+		{
+			name: "healer with invalid heal",
+			content: `{"accounts": [{"login": "a", "healer": {
+				"heals": [{"skill_id": 1011}]}}]}`,
+		},
+		{
+			name: "healer with invalid buff",
+			content: `{"accounts": [{"login": "a", "healer": {
+				"buffs": [{"skill_id": 0}]}}]}`,
+		},
+		{
+			name: "healer with negative mp",
+			content: `{"accounts": [{"login": "a", "healer": {
+				"buffs": [{"skill_id": 1040, "mp": -1}]}}]}`,
+		},
+		{
+			name: "healer threshold above one",
+			content: `{"accounts": [{"login": "a", "healer": {
+				"buffs": [{"skill_id": 1040}], "threshold": 1.5}}]}`,
+		},
+		{
+			name: "healer with unknown tank",
+			content: `{"accounts": [{"login": "a", "healer": {
+				"buffs": [{"skill_id": 1040}], "tanks": ["b"]}}]}`,
+		},
+		{
+			name: "scenario without name",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"plan": "farm", "level": "independent",
+				"roles": {"a": "tank"}}]}`,
+		},
+		{
+			name: "duplicate scenario",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"name": "s", "plan": "farm", "level": "independent",
+				"roles": {"a": "tank"}},
+				{"name": "s", "plan": "farm", "level": "independent",
+				"roles": {"a": "tank"}}]}`,
+		},
+		{
+			name: "unknown plan",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"name": "s", "plan": "dance", "level": "independent",
+				"roles": {"a": "tank"}}]}`,
+		},
+		{
+			name: "unknown level",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"name": "s", "plan": "farm", "level": "chaotic",
+				"roles": {"a": "tank"}}]}`,
+		},
+		{
+			name: "scenario without roles",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"name": "s", "plan": "farm", "level": "independent"}]}`,
+		},
+		{
+			name: "scenario with unknown account",
+			content: `{"accounts": [{"login": "a"}], "scenarios": [
+				{"name": "s", "plan": "farm", "level": "independent",
+				"roles": {"b": "tank"}}]}`,
+		},
+		{
+			name: "picture without name",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"image": "p.png", "width": 8, "step": 20,
+				"artists": ["a"]}]}`,
+		},
+		{
+			name: "duplicate picture",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "image": "p.png", "width": 8, "step": 20,
+				"artists": ["a"]},
+				{"name": "p", "image": "p.png", "width": 8, "step": 20,
+				"artists": ["a"]}]}`,
+		},
+		{
+			name: "picture without image",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "width": 8, "step": 20, "artists": ["a"]}]}`,
+		},
+		{
+			name: "picture without width",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "image": "p.png", "step": 20,
+				"artists": ["a"]}]}`,
+		},
+		{
+			name: "picture without step",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "image": "p.png", "width": 8,
+				"artists": ["a"]}]}`,
+		},
+		{
+			name: "picture without artists",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "image": "p.png", "width": 8, "step": 20}]}`,
+		},
+		{
+			name: "picture with unknown account",
+			content: `{"accounts": [{"login": "a"}], "pictures": [
+				{"name": "p", "image": "p.png", "width": 8, "step": 20,
+				"artists": ["b"]}]}`,
+		},
+		{
+			name: "burst without name",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"actions": [{"login": "a", "kind": "emote",
+				"social_id": 2}]}]}`,
+		},
+		{
+			name: "duplicate burst",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "emote",
+				"social_id": 2}]},
+				{"name": "b", "actions": [{"login": "a", "kind": "emote",
+				"social_id": 2}]}]}`,
+		},
+		{
+			name:    "burst without actions",
+			content: `{"accounts": [{"login": "a"}], "bursts": [{"name": "b"}]}`,
+		},
+		{
+			name: "burst with negative margin",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "margin": "-1s", "actions": [{"login": "a",
+				"kind": "emote", "social_id": 2}]}]}`,
+		},
+		{
+			name: "burst with unknown account",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "b", "kind": "emote",
+				"social_id": 2}]}]}`,
+		},
+		{
+			name: "burst with unknown kind",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "dance"}]}]}`,
+		},
+		{
+			name: "spell without skill",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "spell"}]}]}`,
+		},
+		{
+			name: "melee without swing",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "melee"}]}]}`,
+		},
+		{
+			name: "emote without social action",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "emote"}]}]}`,
+		},
+		{
+			name: "spell with negative speed",
+			content: `{"accounts": [{"login": "a"}], "bursts": [
+				{"name": "b", "actions": [{"login": "a", "kind": "spell",
+				"skill_id": 1, "projectile_speed": -1}]}]}`,
+		},
+		{
+			name: "display without name",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"shape": "line", "spacing": 40,
+				"performers": [{"login": "a"}]}]}`,
+		},
+		{
+			name: "duplicate display",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "line", "spacing": 40,
+				"performers": [{"login": "a"}]},
+				{"name": "d", "shape": "line", "spacing": 40,
+				"performers": [{"login": "a"}]}]}`,
+		},
+		{
+			name: "display with unknown shape",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "star", "spacing": 40,
+				"performers": [{"login": "a"}]}]}`,
+		},
+		{
+			name: "text display without text",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "text", "spacing": 40,
+				"performers": [{"login": "a"}]}]}`,
+		},
+		{
+			name: "display without spacing",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "line",
+				"performers": [{"login": "a"}]}]}`,
+		},
+		{
+			name: "display without performers",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "line", "spacing": 40}]}`,
+		},
+		{
+			name: "display with unknown account",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "line", "spacing": 40,
+				"performers": [{"login": "b"}]}]}`,
+		},
+		{
+			name: "display with performer listed twice",
+			content: `{"accounts": [{"login": "a"}], "displays": [
+				{"name": "d", "shape": "line", "spacing": 40,
+				"performers": [{"login": "a"}, {"login": "a"}]}]}`,
+		},
+		{
+			name: "too big party",
+			content: `{"accounts": [{"login": "a", "character": "A"}],
+				"parties": [{"leader": "a", "members":
+				["1", "2", "3", "4", "5", "6", "7", "8", "9"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, "config.json", tt.content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	data, err := Duration{90 * time.Second}.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `"1m30s"`, string(data))
+}
@@ -5,12 +5,14 @@
 package connection
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
+	"slices"
 	"strconv"
 
 	"github.com/melg8/connect/internal/connect/crypt"
@@ -50,13 +52,18 @@ func ExtractPacketFromRawData(data []byte) (int32, []byte, error) {
 
 // Reads full packet from connection.
 func ReadPacket(conn net.Conn) ([]byte, error) {
-	rawData := make([]byte, 1024)
-	_, err := io.ReadFull(conn, rawData[:2])
+	var sizeData [packetSizeLen]byte
+	_, err := io.ReadFull(conn, sizeData[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to read packet size: %w", err)
 	}
-	n := int(binary.LittleEndian.Uint16(rawData[:2]))
-	_, err = io.ReadFull(conn, rawData[2:n])
+	n := int(binary.LittleEndian.Uint16(sizeData[:]))
+	if n <= packetSizeLen {
+		return nil, fmt.Errorf("invalid packet size: %d", n)
+	}
+	rawData := make([]byte, n)
+	copy(rawData, sizeData[:])
+	_, err = io.ReadFull(conn, rawData[packetSizeLen:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to read packet data: %w", err)
 	}
@@ -69,6 +76,11 @@ func ReadPacket(conn net.Conn) ([]byte, error) {
 // Writes full packet to connection.
 func WritePacket(conn net.Conn, data []byte) error {
 	LogSentData(data)
+
+	return writeAll(conn, data)
+}
+
+func writeAll(conn net.Conn, data []byte) error {
 	needToWrite := data
 	for {
 		n, err := conn.Write(needToWrite)
@@ -104,84 +116,276 @@ func RequestInit(rawData []byte) (*fromauthserver.InitPacket, error) {
 	return initPacket, nil
 }
 
-func GGAuth(rawData []byte) (int, error) {
-	packetID, packetData, err := ExtractPacketFromRawData(rawData)
+// authClient exchanges blowfish encrypted packets with auth server after
+// Init packet.
+type authClient struct {
+	conn   net.Conn
+	cipher *crypt.BlowfishCipher
+	init   *fromauthserver.InitPacket
+}
+
+func newAuthClient(conn net.Conn) (*authClient, error) {
+	rawData, err := ReadPacket(conn)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	if packetID != 0x11 {
-		return 0,
-			fmt.Errorf("unexpected packet %v while waiting for GGAuth 0x11",
-				packetID)
+	initResponse, err := RequestInit(rawData)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("got GGAuth packet with data:")
-	helpers.ShowAsHexAndASCII(packetData)
-
-	// ggAuthPacket, err := fromauthserver.NewGGAuthPacketFromBytes(packetData)
-	// if err != nil {
-	// 	return 0, err
-	// }
-	// LogGGAuthPacket(ggAuthPacket)
+	cipher := crypt.DefaultAuthKey()
+	// Server in compatibility mode sends own key followed by end of key
+	// indicator.
+	if key := bytes.TrimSuffix(initResponse.BlowfishKey, []byte{0}); len(key) > 0 {
+		if cipher, err = crypt.NewBlowfishCipher(key); err != nil {
+			return nil, err
+		}
+	}
 
-	// return ggAuthPacket, nil
-	return 0, nil
+	return &authClient{conn: conn, cipher: cipher, init: initResponse}, nil
 }
 
-func RequestGGAuth(conn net.Conn, initResponse *fromauthserver.InitPacket) (int, error) {
-	requestGGAuth := toauthserver.NewDefaultRequestGGAuth(initResponse.SessionID)
-	log.Printf("Sending GGAuth packet:")
-	writer := packet.NewWriter()
-	requestGGAuth.ToBytes(writer)
-	helpers.ShowAsHexAndASCII(writer.Bytes())
-	encryptor := crypt.NewEncryptor(*packet.NewWriter(), crypt.DefaultAuthKey())
-	err := encryptor.Write(requestGGAuth)
+// readPacket returns id and body of next packet, body keeps padding and
+// checksum of packet.
+func (c *authClient) readPacket() (byte, []byte, error) {
+	rawData, err := ReadPacket(c.conn)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-	err = WritePacket(conn, encryptor.Bytes())
+
+	data := rawData[packetSizeLen:]
+	if len(data) == 0 || len(data)%8 != 0 {
+		return 0, nil, fmt.Errorf("invalid encrypted packet len: %d",
+			len(data))
+	}
+	if err := c.cipher.DecryptInplace(data); err != nil {
+		return 0, nil, err
+	}
+	checksum, err := crypt.Checksum(data)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
+	}
+	if checksum != 0 {
+		return 0, nil, errors.New("invalid packet checksum")
 	}
-	rawResponse, err := ReadPacket(conn)
+
+	return data[0], data[1:], nil
+}
+
+func (c *authClient) writePacket(p crypt.Serializable) error {
+	encryptor := crypt.NewEncryptor(*packet.NewWriter(), c.cipher)
+	if err := encryptor.Write(p); err != nil {
+		return err
+	}
+
+	return WritePacket(c.conn, encryptor.Bytes())
+}
+
+// request sends packet and returns first answer with one of expected ids.
+func (c *authClient) request(
+	p crypt.Serializable,
+	expected ...byte,
+) (byte, []byte, error) {
+	if err := c.writePacket(p); err != nil {
+		return 0, nil, err
+	}
+	id, body, err := c.readPacket()
 	if err != nil {
-		return 0, err
+		return 0, nil, err
+	}
+	if !slices.Contains(expected, id) {
+		return 0, nil, fmt.Errorf("unexpected packet %#x, waiting for % #x",
+			id, expected)
 	}
-	_ = crypt.NewDecryptor(packet.NewReader(rawResponse), crypt.DefaultAuthKey())
-	// err = decryptor.Read()
 
-	return GGAuth(rawResponse)
+	return id, body, nil
 }
 
-func AuthentificateConn(conn net.Conn) error {
-	defer conn.Close()
-	rawData, err := ReadPacket(conn)
+func (c *authClient) ggAuth() error {
+	request := toauthserver.NewDefaultRequestGGAuth(c.init.SessionID)
+	_, body, err := c.request(request, fromauthserver.GGAuthID)
 	if err != nil {
 		return err
 	}
-	initResponse, err := RequestInit(rawData)
+	response, err := fromauthserver.NewGGAuthPacketFromBytes(body)
+	if err != nil {
+		return err
+	}
+	log.Println(response.ToString())
+
+	return nil
+}
+
+// login sends credentials encrypted with RSA key of Init packet. Servers
+// which don't show licence answer with server list right away, their list
+// is returned with zero keys.
+func (c *authClient) login(
+	credentials Credentials,
+) (*fromauthserver.LoginOk, *fromauthserver.ServerList, error) {
+	block, err := toauthserver.NewCredentials(credentials.Login,
+		credentials.Password)
+	if err != nil {
+		return nil, nil, &LoginError{Reason: err.Error(), permanent: true}
+	}
+	modulus, err := crypt.UnscrambleModulus(c.init.RsaPublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encrypted, err := crypt.EncryptRSA(modulus, block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, body, err := c.request(
+		&toauthserver.RequestAuthLogin{Credentials: encrypted},
+		fromauthserver.LoginOkID, fromauthserver.LoginFailID,
+		fromauthserver.AccountKickedID, fromauthserver.ServerListID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch id {
+	case fromauthserver.LoginOkID:
+		loginOk, err := fromauthserver.NewLoginOkFromBytes(body)
+
+		return loginOk, nil, err
+	case fromauthserver.ServerListID:
+		servers, err := fromauthserver.NewServerListFromBytes(body)
+		loginOk := &fromauthserver.LoginOk{LoginOk1: 0, LoginOk2: 0}
+
+		return loginOk, servers, err
+	default:
+		return nil, nil, loginFailError(id, body)
+	}
+}
+
+func loginFailError(id byte, body []byte) error {
+	fail, err := fromauthserver.NewLoginFailFromBytes(body)
 	if err != nil {
 		return err
 	}
+	if id == fromauthserver.AccountKickedID {
+		return &LoginError{
+			Reason:    fmt.Sprintf("account kicked, reason %d", fail.Reason),
+			permanent: true,
+		}
+	}
+
+	return &LoginError{
+		Reason:    fmt.Sprintf("login failed, reason %d", fail.Reason),
+		permanent: fail.Permanent(),
+	}
+}
+
+func (c *authClient) serverList(
+	loginOk *fromauthserver.LoginOk,
+) (*fromauthserver.ServerList, error) {
+	_, body, err := c.request(&toauthserver.RequestServerList{
+		LoginOk1: loginOk.LoginOk1,
+		LoginOk2: loginOk.LoginOk2,
+	}, fromauthserver.ServerListID)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromauthserver.NewServerListFromBytes(body)
+}
 
-	_, err = RequestGGAuth(conn, initResponse)
+func (c *authClient) serverLogin(
+	loginOk *fromauthserver.LoginOk,
+	serverID int8,
+) (*fromauthserver.PlayOk, error) {
+	id, body, err := c.request(&toauthserver.RequestServerLogin{
+		LoginOk1: loginOk.LoginOk1,
+		LoginOk2: loginOk.LoginOk2,
+		ServerID: serverID,
+	}, fromauthserver.PlayOkID, fromauthserver.PlayFailID)
+	if err != nil {
+		return nil, err
+	}
+	if id == fromauthserver.PlayFailID {
+		fail, err := fromauthserver.NewPlayFailFromBytes(body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, &LoginError{
+			Reason:    fmt.Sprintf("game server refused, reason %d", fail.Reason),
+			permanent: false,
+		}
+	}
+
+	return fromauthserver.NewPlayOkFromBytes(body)
+}
+
+// pickServer prefers server account played on last time.
+func pickServer(
+	list *fromauthserver.ServerList,
+) (*fromauthserver.GameServer, error) {
+	if server, ok := list.Find(list.LastServer); ok && server.Up == 1 {
+		return server, nil
+	}
+	for i := range list.Servers {
+		if list.Servers[i].Up == 1 {
+			return &list.Servers[i], nil
+		}
+	}
+
+	return nil, errors.New("no game server is up")
+}
+
+// AuthentificateConn checks that auth server answers, account isn't logged
+// in.
+func AuthentificateConn(conn net.Conn) error {
+	defer conn.Close()
+	client, err := newAuthClient(conn)
 	if err != nil {
 		return err
 	}
 
-	// responseLogin, err := RequestLogin(conn, ggAuthResponse)
-	// if err != nil {
-	// 	return err
-	// }
+	return client.ggAuth()
+}
 
-	// responseServerList, err := RequestServerList(conn, responseLogin)
-	// if err != nil {
-	// 	return err
-	// }
+// LoginAuth logs account in on auth server, it returns address of game
+// server and key to enter it.
+func LoginAuth(
+	conn net.Conn,
+	credentials Credentials,
+) (string, SessionKey, error) {
+	var key SessionKey
+	client, err := newAuthClient(conn)
+	if err != nil {
+		return "", key, err
+	}
+	if err := client.ggAuth(); err != nil {
+		return "", key, err
+	}
 
-	// responseServerLogin, err := RequestServerLogin(conn, responseServerList)
-	// if err != nil {
-	// 	return err
-	// }
-	return nil
+	loginOk, servers, err := client.login(credentials)
+	if err != nil {
+		return "", key, err
+	}
+	if servers == nil {
+		if servers, err = client.serverList(loginOk); err != nil {
+			return "", key, err
+		}
+	}
+	server, err := pickServer(servers)
+	if err != nil {
+		return "", key, err
+	}
+	playOk, err := client.serverLogin(loginOk, server.ID)
+	if err != nil {
+		return "", key, err
+	}
+
+	key = SessionKey{
+		LoginOk1: loginOk.LoginOk1,
+		LoginOk2: loginOk.LoginOk2,
+		PlayOk1:  playOk.PlayOk1,
+		PlayOk2:  playOk.PlayOk2,
+	}
+
+	return server.Address(), key, nil
 }
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	fromauthserver "github.com/melg8/connect/internal/connect/packets/from_auth_server"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentials() Credentials {
+	return Credentials{
+		Login:     testLogin,
+		Password:  testPassword,
+		Character: testCharacter,
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	auth := newFakeAuthServer(t, server)
+	done := make(chan error, 1)
+	go func() { done <- auth.serve() }()
+
+	address, key, err := LoginAuth(client, testCredentials())
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+	require.Equal(t, auth.gameServer, address)
+	require.Equal(t,
+		SessionKey{LoginOk1: 1, LoginOk2: 2, PlayOk1: 3, PlayOk2: 4}, key)
+}
+
+func TestLoginAuth_Refused(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		reason    int32
+		permanent bool
+	}{
+		{
+			name:      "wrong password",
+			password:  "wrong",
+			reason:    fromauthserver.LoginFailPassWrong,
+			permanent: true,
+		},
+		{
+			name:      "account in use",
+			password:  testPassword,
+			reason:    fromauthserver.LoginFailAccountInUse,
+			permanent: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			auth := newFakeAuthServer(t, server)
+			auth.loginFail = test.reason
+			go func() { _ = auth.serve() }()
+
+			credentials := testCredentials()
+			credentials.Password = test.password
+			_, _, err := LoginAuth(client, credentials)
+
+			var refused *LoginError
+			require.True(t, errors.As(err, &refused), err)
+			require.Equal(t, test.permanent, refused.Permanent())
+		})
+	}
+}
+
+func TestAuthentificateConn(t *testing.T) {
+	client, server := net.Pipe()
+	auth := newFakeAuthServer(t, server)
+	go func() { _ = auth.serve() }()
+
+	require.NoError(t, AuthentificateConn(client))
+}
+
+func TestPickServer(t *testing.T) {
+	up := fromauthserver.GameServer{
+		ID: 1, IP: [4]byte{}, Port: 1, AgeLimit: 0, PvP: 0, Online: 0,
+		MaxPlayers: 0, Up: 1, Flags: 0, Brackets: 0,
+	}
+	down := up
+	down.ID, down.Up = 2, 0
+	last := up
+	last.ID = 3
+
+	server, err := pickServer(&fromauthserver.ServerList{
+		LastServer: 3, Servers: []fromauthserver.GameServer{up, down, last},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int8(3), server.ID)
+
+	server, err = pickServer(&fromauthserver.ServerList{
+		LastServer: 2, Servers: []fromauthserver.GameServer{down, up},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int8(1), server.ID)
+
+	_, err = pickServer(&fromauthserver.ServerList{
+		LastServer: 2, Servers: []fromauthserver.GameServer{down},
+	})
+	require.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
 	"time"
 )
 
@@ -38,7 +39,10 @@ func (c *TCPConnector) Address() string {
 	return c.serverAddress
 }
 
+// RateLimitedConnector can be shared by many bots, connection attempts are
+// serialized so rate limit applies to all of them together.
 type RateLimitedConnector struct {
+	mutex        sync.Mutex
 	connector    Connector
 	lastConnTime time.Time
 	timeout      time.Duration
@@ -46,6 +50,7 @@ type RateLimitedConnector struct {
 
 func NewRateLimitedConnector(connector Connector, timeout time.Duration) *RateLimitedConnector {
 	return &RateLimitedConnector{
+		mutex:        sync.Mutex{},
 		connector:    connector,
 		lastConnTime: time.Now().Add(-timeout),
 		timeout:      timeout,
@@ -57,6 +62,9 @@ func (c *RateLimitedConnector) Address() string {
 }
 
 func (c *RateLimitedConnector) Connect() (net.Conn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	now := time.Now()
 
 	if now.Sub(c.lastConnTime) < c.timeout {
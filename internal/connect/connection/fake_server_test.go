@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	fromauthserver "github.com/melg8/connect/internal/connect/packets/from_auth_server"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLogin     = "user"
+	testPassword  = "secret"
+	testCharacter = "Bob"
+)
+
+// fakeAuthServer answers single client like auth server without licence
+// step.
+type fakeAuthServer struct {
+	key        *rsa.PrivateKey
+	conn       net.Conn
+	cipher     *crypt.BlowfishCipher
+	gameServer string
+	loginFail  int32
+}
+
+func newFakeAuthServer(t *testing.T, conn net.Conn) *fakeAuthServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, crypt.ModulusSize*8)
+	require.NoError(t, err)
+
+	return &fakeAuthServer{
+		key:        key,
+		conn:       conn,
+		cipher:     crypt.DefaultAuthKey(),
+		gameServer: "127.0.0.1:7777",
+		loginFail:  0,
+	}
+}
+
+func (s *fakeAuthServer) sendInit() error {
+	modulus := s.key.N.FillBytes(make([]byte, crypt.ModulusSize))
+	scrambled, err := crypt.ScrambleModulus(modulus)
+	if err != nil {
+		return err
+	}
+
+	writer := packet.NewWriter()
+	_ = writer.WriteInt16(0)
+	_ = writer.WriteByte(fromauthserver.InitID)
+	err = (&fromauthserver.InitPacket{
+		SessionID:       0x1234,
+		ProtocolVersion: 0xc621,
+		RsaPublicKey:    scrambled,
+		GameGuard1:      1,
+		GameGuard2:      2,
+		GameGuard3:      3,
+		GameGuard4:      4,
+		BlowfishKey:     []byte{0, 0, 0, 0},
+	}).ToBytes(writer)
+	if err != nil {
+		return err
+	}
+	data := writer.Bytes()
+	data[0], data[1] = byte(len(data)), byte(len(data)>>8)
+
+	return writeAll(s.conn, data)
+}
+
+func (s *fakeAuthServer) read() (byte, []byte, error) {
+	data, err := ReadPacket(s.conn)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := s.cipher.DecryptInplace(data[packetSizeLen:]); err != nil {
+		return 0, nil, err
+	}
+
+	return data[packetSizeLen], data[packetSizeLen+1:], nil
+}
+
+func (s *fakeAuthServer) send(id byte, body crypt.Serializable) error {
+	writer := packet.NewWriter()
+	if err := body.ToBytes(writer); err != nil {
+		return err
+	}
+	encryptor := crypt.NewEncryptor(*packet.NewWriter(), s.cipher)
+	err := encryptor.Write(&rawPacket{id: id, body: writer.Bytes()})
+	if err != nil {
+		return err
+	}
+
+	return writeAll(s.conn, encryptor.Bytes())
+}
+
+// credentials decrypts RSA block of RequestAuthLogin.
+func (s *fakeAuthServer) credentials(body []byte) (string, string) {
+	c := new(big.Int).SetBytes(body[:crypt.ModulusSize])
+	block := new(big.Int).Exp(c, s.key.D, s.key.N).
+		FillBytes(make([]byte, crypt.ModulusSize))
+	login := string(block[0x5e : 0x5e+len(testLogin)])
+	password := string(block[0x6c : 0x6c+len(testPassword)])
+
+	return login, password
+}
+
+func (s *fakeAuthServer) serve() error {
+	if err := s.sendInit(); err != nil {
+		return err
+	}
+	if _, _, err := s.read(); err != nil {
+		return err
+	}
+	err := s.send(fromauthserver.GGAuthID,
+		&fromauthserver.GGAuthPacket{SessionID: 0x1234, Unknown: 0})
+	if err != nil {
+		return err
+	}
+
+	_, body, err := s.read()
+	if err != nil {
+		return err
+	}
+	login, password := s.credentials(body)
+	if s.loginFail != 0 || login != testLogin || password != testPassword {
+		return s.send(fromauthserver.LoginFailID,
+			&fromauthserver.LoginFail{Reason: s.loginFail})
+	}
+	err = s.send(fromauthserver.LoginOkID,
+		&fromauthserver.LoginOk{LoginOk1: 1, LoginOk2: 2})
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := s.read(); err != nil {
+		return err
+	}
+	if err := s.send(fromauthserver.ServerListID, s.serverList()); err != nil {
+		return err
+	}
+
+	if _, _, err := s.read(); err != nil {
+		return err
+	}
+
+	return s.send(fromauthserver.PlayOkID,
+		&fromauthserver.PlayOk{PlayOk1: 3, PlayOk2: 4})
+}
+
+func (s *fakeAuthServer) serverList() *fromauthserver.ServerList {
+	host, portText, _ := net.SplitHostPort(s.gameServer)
+	port, _ := strconv.Atoi(portText)
+	var ip [4]byte
+	copy(ip[:], net.ParseIP(host).To4())
+
+	return &fromauthserver.ServerList{
+		LastServer: 0,
+		Servers: []fromauthserver.GameServer{{
+			ID: 1, IP: ip, Port: int32(port), //nolint:gosec
+			AgeLimit: 0, PvP: 0, Online: 0, MaxPlayers: 10,
+			Up: 1, Flags: 0, Brackets: 0,
+		}},
+	}
+}
+
+// serveFakeGame answers single client like game server until it logs out.
+func serveFakeGame(conn net.Conn) error {
+	game := NewGameConn(conn)
+	defer game.Close()
+
+	key := [4]byte{0x11, 0x22, 0x33, 0x44}
+	if _, _, err := game.ReadPacket(); err != nil {
+		return err
+	}
+	err := game.WritePacket(&rawPacket{
+		id: fromgameserver.KeyPacketID, body: []byte{1, 0x11, 0x22, 0x33, 0x44},
+	})
+	if err != nil {
+		return err
+	}
+	game.EnableCrypt(key)
+
+	responses := map[byte]crypt.Serializable{
+		togameserver.AuthLoginID:         fakeCharSelectInfo(),
+		togameserver.CharacterSelectedID: fakeCharSelected(),
+		togameserver.EnterWorldID:        fakeUserInfo(),
+		togameserver.LogoutID:            &rawPacket{id: fromgameserver.LogOutOkID},
+	}
+	for {
+		id, _, err := game.ReadPacket()
+		if err != nil {
+			return err
+		}
+		response, ok := responses[id]
+		if !ok {
+			continue
+		}
+		if err := game.WritePacket(response); err != nil {
+			return err
+		}
+		if id == togameserver.LogoutID {
+			return nil
+		}
+	}
+}
+
+func packetOf(id byte, body crypt.Serializable) *rawPacket {
+	writer := packet.NewWriter()
+	_ = body.ToBytes(writer)
+
+	return &rawPacket{id: id, body: writer.Bytes()}
+}
+
+func fakeCharSelectInfo() *rawPacket {
+	return packetOf(fromgameserver.CharSelectInfoID,
+		&fromgameserver.CharSelectInfo{
+			Login:     testLogin,
+			SessionID: 3,
+			Characters: []fromgameserver.CharacterSlot{
+				{Name: "Alice", ObjectID: 1, Details: []byte{1, 2, 3}},
+				{Name: testCharacter, ObjectID: 2, Details: []byte{1, 2, 3}},
+			},
+		})
+}
+
+func fakeCharSelected() *rawPacket {
+	return packetOf(fromgameserver.CharSelectedID,
+		&fromgameserver.CharSelected{
+			Name: testCharacter, ObjectID: 2, Title: "", SessionID: 3,
+		})
+}
+
+func fakeUserInfo() *rawPacket {
+	return &rawPacket{id: fromgameserver.UserInfoID, body: []byte{1, 2, 3}}
+}
+
+// listenFakeGame starts game server for single client, its error is sent
+// to returned channel.
+func listenFakeGame(t *testing.T) (string, <-chan error) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			done <- err
+
+			return
+		}
+		done <- serveFakeGame(conn)
+	}()
+
+	return listener.Addr().String(), done
+}
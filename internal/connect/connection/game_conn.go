@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+)
+
+const packetSizeLen = 2
+
+// GameConn is connection to game server. Packets are sent without hex
+// logging, game traffic of many bots is too big for it. Writes are safe for
+// concurrent use, reads are expected from single goroutine.
+type GameConn struct {
+	conn       net.Conn
+	writeMutex sync.Mutex
+	in         *crypt.GameCipher
+	out        *crypt.GameCipher
+}
+
+func NewGameConn(conn net.Conn) *GameConn {
+	return &GameConn{
+		conn:       conn,
+		writeMutex: sync.Mutex{},
+		in:         nil,
+		out:        nil,
+	}
+}
+
+// EnableCrypt turns on encryption with key from KeyPacket, all packets
+// after KeyPacket are encrypted in both directions.
+func (c *GameConn) EnableCrypt(key [4]byte) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	c.in = crypt.NewGameCipherFromKeyPacket(key)
+	c.out = crypt.NewGameCipherFromKeyPacket(key)
+}
+
+// ReadPacket returns id and body of next packet from server.
+func (c *GameConn) ReadPacket() (byte, []byte, error) {
+	var sizeData [packetSizeLen]byte
+	if _, err := io.ReadFull(c.conn, sizeData[:]); err != nil {
+		return 0, nil, fmt.Errorf("failed to read packet size: %w", err)
+	}
+
+	size := int(binary.LittleEndian.Uint16(sizeData[:]))
+	if size <= packetSizeLen {
+		return 0, nil, fmt.Errorf("invalid packet size: %d", size)
+	}
+
+	data := make([]byte, size-packetSizeLen)
+	if _, err := io.ReadFull(c.conn, data); err != nil {
+		return 0, nil, fmt.Errorf("failed to read packet data: %w", err)
+	}
+	if c.in != nil {
+		c.in.DecryptInplace(data)
+	}
+
+	return data[0], data[1:], nil
+}
+
+func (c *GameConn) WritePacket(p crypt.Serializable) error {
+	writer := packet.NewWriter()
+	// Reserve 2 bytes for future size value
+	if err := writer.WriteInt16(0); err != nil {
+		return err
+	}
+	if err := p.ToBytes(writer); err != nil {
+		return err
+	}
+
+	data := writer.Bytes()
+	if len(data) > math.MaxUint16 {
+		return fmt.Errorf("packet is too big: %d bytes", len(data))
+	}
+	binary.LittleEndian.PutUint16(data, uint16(len(data)))
+
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	if c.out != nil {
+		c.out.EncryptInplace(data[packetSizeLen:])
+	}
+
+	return writeAll(c.conn, data)
+}
+
+func (c *GameConn) Close() error {
+	return c.conn.Close()
+}
+
+// requestAndWait sends request and skips incoming packets until packet with
+// response id arrives or context is done.
+func requestAndWait(
+	ctx context.Context,
+	game *GameConn,
+	request crypt.Serializable,
+	responseID byte,
+) ([]byte, error) {
+	_, body, err := requestAndWaitAny(ctx, game, request, responseID)
+
+	return body, err
+}
+
+// requestAndWaitAny is requestAndWait for requests with several possible
+// responses.
+func requestAndWaitAny(
+	ctx context.Context,
+	game *GameConn,
+	request crypt.Serializable,
+	responseIDs ...byte,
+) (byte, []byte, error) {
+	var responseID byte
+	var response []byte
+	err := exchange(ctx, game, request, func(id byte, body []byte) bool {
+		if !slices.Contains(responseIDs, id) {
+			return false
+		}
+		responseID, response = id, body
+
+		return true
+	})
+
+	return responseID, response, err
+}
+
+// exchange sends request and passes incoming packets to handle until it
+// reports that exchange is done or context is done.
+func exchange(
+	ctx context.Context,
+	game *GameConn,
+	request crypt.Serializable,
+	handle func(id byte, body []byte) bool,
+) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := game.conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+		defer func() { _ = game.conn.SetDeadline(time.Time{}) }()
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = game.conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := game.WritePacket(request); err != nil {
+		return err
+	}
+
+	for {
+		id, body, err := game.ReadPacket()
+		if err != nil {
+			return errors.Join(err, ctx.Err())
+		}
+		if handle(id, body) {
+			return nil
+		}
+	}
+}
+
+// LogoutGame leaves game world and waits for server confirmation.
+func LogoutGame(ctx context.Context, game *GameConn) error {
+	_, err := requestAndWait(ctx, game,
+		&togameserver.Logout{}, fromgameserver.LogOutOkID)
+	if err != nil {
+		return fmt.Errorf("no logout confirmation: %w", err)
+	}
+
+	return nil
+}
+
+// RestartGame returns character to character selection screen.
+func RestartGame(ctx context.Context, game *GameConn) error {
+	body, err := requestAndWait(ctx, game,
+		&togameserver.RequestRestart{}, fromgameserver.RestartResponseID)
+	if err != nil {
+		return fmt.Errorf("no restart confirmation: %w", err)
+	}
+
+	response, err := fromgameserver.NewRestartResponseFromBytes(body)
+	if err != nil {
+		return err
+	}
+	if !response.Allowed() {
+		return errors.New("restart is not allowed by server")
+	}
+
+	return nil
+}
+
+// ServeGame passes incoming packets to dispatcher until connection fails or
+// context is done. Packets which handlers fail to process are logged and
+// skipped, single broken packet shouldn't drop bot from game.
+func ServeGame(
+	ctx context.Context,
+	game *GameConn,
+	dispatcher *dispatch.Dispatcher,
+) error {
+	stop := context.AfterFunc(ctx, func() {
+		_ = game.conn.SetReadDeadline(time.Now())
+	})
+	defer func() {
+		if stop() {
+			return
+		}
+		// Deadline is set already, next reader needs it to be cleared.
+		_ = game.conn.SetReadDeadline(time.Time{})
+	}()
+
+	for {
+		id, body, err := game.ReadPacket()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+		if err := dispatcher.Dispatch(id, body); err != nil {
+			log.Printf("Error handling game packet: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+type rawPacket struct {
+	id   byte
+	body []byte
+}
+
+func (p *rawPacket) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteByte(p.id); err != nil {
+		return err
+	}
+
+	return writer.WriteBytes(p.body)
+}
+
+func newGameConnPair(t *testing.T) (*GameConn, *GameConn) {
+	t.Helper()
+
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	key := [4]byte{0x11, 0x22, 0x33, 0x44}
+	clientConn, serverConn := NewGameConn(client), NewGameConn(server)
+	clientConn.EnableCrypt(key)
+	serverConn.EnableCrypt(key)
+
+	return clientConn, serverConn
+}
+
+func TestGameConn_ReadWrite(t *testing.T) {
+	client, server := newGameConnPair(t)
+
+	go func() {
+		_ = client.WritePacket(&rawPacket{id: 0x38, body: []byte{1, 2, 3}})
+		_ = client.WritePacket(&rawPacket{id: 0x09, body: nil})
+	}()
+
+	id, body, err := server.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, byte(0x38), id)
+	require.Equal(t, []byte{1, 2, 3}, body)
+
+	id, body, err = server.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, byte(0x09), id)
+	require.Empty(t, body)
+}
+
+func TestLogoutGame(t *testing.T) {
+	client, server := newGameConnPair(t)
+
+	go func() {
+		id, _, err := server.ReadPacket()
+		if err != nil || id != togameserver.LogoutID {
+			return
+		}
+		_ = server.WritePacket(&rawPacket{id: 0x04, body: []byte{0}})
+		_ = server.WritePacket(&rawPacket{id: fromgameserver.LogOutOkID})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, LogoutGame(ctx, client))
+}
+
+func TestLogoutGame_Timeout(t *testing.T) {
+	client, server := newGameConnPair(t)
+
+	go func() {
+		_, _, _ = server.ReadPacket()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	require.Error(t, LogoutGame(ctx, client))
+}
+
+func TestRestartGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		ok      byte
+		wantErr bool
+	}{
+		{name: "allowed", ok: 1, wantErr: false},
+		{name: "denied", ok: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := newGameConnPair(t)
+
+			go func() {
+				id, _, err := server.ReadPacket()
+				if err != nil || id != togameserver.RequestRestartID {
+					return
+				}
+				_ = server.WritePacket(&rawPacket{
+					id:   fromgameserver.RestartResponseID,
+					body: []byte{tt.ok, 0, 0, 0},
+				})
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := RestartGame(ctx, client)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServeGame(t *testing.T) {
+	client, server := newGameConnPair(t)
+
+	var bodies [][]byte
+	dispatcher := dispatch.NewDispatcher()
+	dispatcher.Handle(0x16, func(data []byte) error {
+		bodies = append(bodies, data)
+
+		return nil
+	})
+	dispatcher.Handle(0x12, func(_ []byte) error {
+		return errors.New("broken packet")
+	})
+
+	go func() {
+		_ = server.WritePacket(&rawPacket{id: 0x12, body: []byte{9}})
+		_ = server.WritePacket(&rawPacket{id: 0x16, body: []byte{1}})
+		_ = server.WritePacket(&rawPacket{id: 0x99, body: []byte{2}})
+		_ = server.WritePacket(&rawPacket{id: 0x16, body: []byte{3}})
+		server.Close()
+	}()
+
+	err := ServeGame(context.Background(), client, dispatcher)
+	require.Error(t, err)
+	require.Equal(t, [][]byte{{1}, {3}}, bodies)
+}
+
+func TestServeGame_Canceled(t *testing.T) {
+	client, _ := newGameConnPair(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := ServeGame(ctx, client, dispatch.NewDispatcher())
+	require.True(t, errors.Is(err, context.Canceled))
+}
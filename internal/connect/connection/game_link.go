@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+)
+
+// ErrNotInGame is returned when packet is sent while bot has no connection
+// to game server.
+var ErrNotInGame = errors.New("not connected to game server")
+
+// GameLink sends packets to game server through current session of bot.
+// Bot keeps one link while sessions change after reconnects, so parts of bot
+// don't need to know about sessions.
+type GameLink struct {
+	mutex sync.RWMutex
+	game  *GameConn
+}
+
+func NewGameLink() *GameLink {
+	return &GameLink{mutex: sync.RWMutex{}, game: nil}
+}
+
+func (l *GameLink) WritePacket(p crypt.Serializable) error {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.game == nil {
+		return ErrNotInGame
+	}
+
+	return l.game.WritePacket(p)
+}
+
+// Connected reports if link has game connection.
+func (l *GameLink) Connected() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.game != nil
+}
+
+func (l *GameLink) attach(game *GameConn) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.game = game
+}
+
+// detach forgets connection if it is still current one.
+func (l *GameLink) detach(game *GameConn) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.game == game {
+		l.game = nil
+	}
+}
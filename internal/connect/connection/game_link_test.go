@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameLink(t *testing.T) {
+	link := NewGameLink()
+	require.False(t, link.Connected())
+	err := link.WritePacket(&rawPacket{id: 0x01, body: nil})
+	require.True(t, errors.Is(err, ErrNotInGame))
+
+	client, server := newGameConnPair(t)
+	link.attach(client)
+	require.True(t, link.Connected())
+
+	go func() {
+		_ = link.WritePacket(&rawPacket{id: 0x48, body: []byte{7}})
+	}()
+	id, body, err := server.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, byte(0x48), id)
+	require.Equal(t, []byte{7}, body)
+
+	link.detach(server)
+	require.True(t, link.Connected(), "other connection isn't detached")
+	link.detach(client)
+	require.False(t, link.Connected())
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+)
+
+// Credentials are account login and password with name of character bot
+// plays.
+type Credentials struct {
+	Login     string
+	Password  string
+	Character string
+}
+
+// SessionKey is given by auth server after login, game server lets account
+// in only with it.
+type SessionKey struct {
+	LoginOk1 int32
+	LoginOk2 int32
+	PlayOk1  int32
+	PlayOk2  int32
+}
+
+// LoginError is refusal of server to let account or character in.
+type LoginError struct {
+	Reason    string
+	permanent bool
+}
+
+func (e *LoginError) Error() string {
+	return "login refused: " + e.Reason
+}
+
+// Permanent reports if server refuses same login again, like with wrong
+// password or banned account.
+func (e *LoginError) Permanent() bool {
+	return e.permanent
+}
+
+// DialGame connects to game server and turns on encryption of game
+// packets.
+func DialGame(ctx context.Context, address string) (*GameConn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game server: %w", err)
+	}
+
+	game := NewGameConn(conn)
+	body, err := requestAndWait(ctx, game,
+		&togameserver.ProtocolVersion{Version: togameserver.DefaultProtocol},
+		fromgameserver.KeyPacketID)
+	if err != nil {
+		_ = game.Close()
+
+		return nil, fmt.Errorf("no key packet: %w", err)
+	}
+	keyPacket, err := fromgameserver.NewKeyPacketFromBytes(body)
+	if err != nil {
+		_ = game.Close()
+
+		return nil, err
+	}
+	if !keyPacket.Accepted() {
+		_ = game.Close()
+
+		return nil, &LoginError{
+			Reason:    "protocol version is rejected by game server",
+			permanent: true,
+		}
+	}
+	game.EnableCrypt(keyPacket.Key)
+
+	return game, nil
+}
+
+// SelectCharacter logs account in to game server and selects character by
+// name, first character of account is selected when name is empty.
+func SelectCharacter(
+	ctx context.Context,
+	game *GameConn,
+	credentials Credentials,
+	key SessionKey,
+) error {
+	id, body, err := requestAndWaitAny(ctx, game, &togameserver.AuthLogin{
+		Login:    strings.ToLower(credentials.Login),
+		PlayOk1:  key.PlayOk1,
+		PlayOk2:  key.PlayOk2,
+		LoginOk1: key.LoginOk1,
+		LoginOk2: key.LoginOk2,
+	}, fromgameserver.CharSelectInfoID, fromgameserver.AuthLoginFailID)
+	if err != nil {
+		return fmt.Errorf("no character list: %w", err)
+	}
+	if id == fromgameserver.AuthLoginFailID {
+		fail, err := fromgameserver.NewAuthLoginFailFromBytes(body)
+		if err != nil {
+			return err
+		}
+
+		return &LoginError{
+			Reason: fmt.Sprintf("game server login failed, reason %d",
+				fail.Reason),
+			permanent: false,
+		}
+	}
+
+	info, err := fromgameserver.NewCharSelectInfoFromBytes(body)
+	if err != nil {
+		return err
+	}
+	slot, ok := info.Slot(credentials.Character)
+	if credentials.Character == "" {
+		slot, ok = 0, len(info.Characters) > 0
+	}
+	if !ok {
+		return &LoginError{
+			Reason: fmt.Sprintf("no character %s on account %s",
+				credentials.Character, credentials.Login),
+			permanent: true,
+		}
+	}
+
+	body, err = requestAndWait(ctx, game,
+		&togameserver.CharacterSelected{Slot: slot},
+		fromgameserver.CharSelectedID)
+	if err != nil {
+		return fmt.Errorf("no character selection confirmation: %w", err)
+	}
+	selected, err := fromgameserver.NewCharSelectedFromBytes(body)
+	if err != nil {
+		return err
+	}
+	log.Printf("Selected character %s\n", selected.Name)
+
+	return nil
+}
+
+// EnterWorld places selected character into world. Packets server sends
+// meanwhile are passed to dispatcher, world is entered when UserInfo of
+// character arrives.
+func EnterWorld(
+	ctx context.Context,
+	game *GameConn,
+	dispatcher *dispatch.Dispatcher,
+) error {
+	err := exchange(ctx, game, &togameserver.EnterWorld{},
+		func(id byte, body []byte) bool {
+			if err := dispatcher.Dispatch(id, body); err != nil {
+				log.Printf("Error handling game packet: %v\n", err)
+			}
+
+			return id == fromgameserver.UserInfoID
+		})
+	if err != nil {
+		return fmt.Errorf("no user info: %w", err)
+	}
+
+	return nil
+}
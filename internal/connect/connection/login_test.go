@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnterGame(t *testing.T) {
+	address, done := listenFakeGame(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	game, err := DialGame(ctx, address)
+	require.NoError(t, err)
+	defer game.Close()
+
+	key := SessionKey{LoginOk1: 1, LoginOk2: 2, PlayOk1: 3, PlayOk2: 4}
+	require.NoError(t, SelectCharacter(ctx, game, testCredentials(), key))
+
+	var userInfo []byte
+	dispatcher := dispatch.NewDispatcher()
+	dispatcher.Handle(fromgameserver.UserInfoID, func(data []byte) error {
+		userInfo = data
+
+		return nil
+	})
+	require.NoError(t, EnterWorld(ctx, game, dispatcher))
+	require.Equal(t, []byte{1, 2, 3}, userInfo)
+
+	require.NoError(t, LogoutGame(ctx, game))
+	require.NoError(t, <-done)
+}
+
+func TestSelectCharacter_Unknown(t *testing.T) {
+	address, _ := listenFakeGame(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	game, err := DialGame(ctx, address)
+	require.NoError(t, err)
+	defer game.Close()
+
+	credentials := testCredentials()
+	credentials.Character = "Eve"
+	err = SelectCharacter(ctx, game, credentials, SessionKey{
+		LoginOk1: 0, LoginOk2: 0, PlayOk1: 0, PlayOk2: 0,
+	})
+
+	var refused *LoginError
+	require.True(t, errors.As(err, &refused), err)
+	require.True(t, refused.Permanent())
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+)
+
+// loginTimeout limits every login step, server which stopped answering
+// shouldn't hang bot.
+const loginTimeout = 30 * time.Second
+
+// GameSession drives connection of single bot through login steps.
+type GameSession struct {
+	conn        net.Conn
+	game        *GameConn
+	dispatcher  *dispatch.Dispatcher
+	link        *GameLink
+	credentials Credentials
+	address     string
+	key         SessionKey
+}
+
+// NewGameSession creates session which passes game packets to dispatcher
+// after entering world. Link sends packets through game connection of
+// session while it is open.
+func NewGameSession(
+	dispatcher *dispatch.Dispatcher,
+	link *GameLink,
+	credentials Credentials,
+) *GameSession {
+	return &GameSession{
+		conn:        nil,
+		game:        nil,
+		dispatcher:  dispatcher,
+		link:        link,
+		credentials: credentials,
+		address:     "",
+		key:         SessionKey{LoginOk1: 0, LoginOk2: 0, PlayOk1: 0, PlayOk2: 0},
+	}
+}
+
+// Authenticate logs account in on auth server, connection to it is closed
+// afterwards.
+func (s *GameSession) Authenticate(_ context.Context, conn net.Conn) error {
+	s.conn = conn
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(loginTimeout)); err != nil {
+		return err
+	}
+	address, key, err := LoginAuth(conn, s.credentials)
+	if err != nil {
+		return err
+	}
+	s.address, s.key = address, key
+
+	return nil
+}
+
+// SelectCharacter connects to game server given by auth server and selects
+// character of credentials.
+func (s *GameSession) SelectCharacter(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+
+	game, err := DialGame(ctx, s.address)
+	if err != nil {
+		return err
+	}
+	s.game = game
+
+	return SelectCharacter(ctx, game, s.credentials, s.key)
+}
+
+// EnterWorld places character into world, link sends packets of bot from
+// then on.
+func (s *GameSession) EnterWorld(ctx context.Context) error {
+	if s.game == nil {
+		return errors.New("character isn't selected")
+	}
+	loginCtx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+
+	if err := EnterWorld(loginCtx, s.game, s.dispatcher); err != nil {
+		return err
+	}
+	s.link.attach(s.game)
+
+	return nil
+}
+
+func (s *GameSession) Serve(ctx context.Context) error {
+	if s.game == nil {
+		return errors.New("game packets handling: not in world")
+	}
+
+	return ServeGame(ctx, s.game, s.dispatcher)
+}
+
+// Logout leaves game world if session is in it.
+func (s *GameSession) Logout(ctx context.Context) error {
+	if s.game == nil {
+		return nil
+	}
+
+	return LogoutGame(ctx, s.game)
+}
+
+func (s *GameSession) Close() error {
+	if s.game != nil {
+		s.link.detach(s.game)
+		if err := s.game.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			return err
+		}
+	}
+	if s.conn == nil {
+		return nil
+	}
+
+	if err := s.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSession_Login(t *testing.T) {
+	address, done := listenFakeGame(t)
+	client, server := net.Pipe()
+	auth := newFakeAuthServer(t, server)
+	auth.gameServer = address
+	go func() { _ = auth.serve() }()
+
+	link := NewGameLink()
+	session := NewGameSession(dispatch.NewDispatcher(), link,
+		testCredentials())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, session.Authenticate(ctx, client))
+	require.NoError(t, session.SelectCharacter(ctx))
+	require.False(t, link.Connected())
+	require.NoError(t, session.EnterWorld(ctx))
+	require.True(t, link.Connected())
+
+	require.NoError(t, session.Logout(ctx))
+	require.NoError(t, <-done)
+	require.NoError(t, session.Close())
+	require.False(t, link.Connected())
+}
+
+func TestGameSession_NotInWorld(t *testing.T) {
+	session := NewGameSession(dispatch.NewDispatcher(), NewGameLink(),
+		testCredentials())
+	ctx := context.Background()
+
+	require.Error(t, session.EnterWorld(ctx))
+	require.Error(t, session.Serve(ctx))
+	require.NoError(t, session.Logout(ctx))
+}
+
+func TestGameSession_CloseIgnoresClosedConnection(t *testing.T) {
+	session := NewGameSession(dispatch.NewDispatcher(), NewGameLink(),
+		testCredentials())
+	require.NoError(t, session.Close())
+
+	client, server := net.Pipe()
+	defer server.Close()
+	session.conn = client
+
+	require.NoError(t, client.Close())
+	require.NoError(t, session.Close())
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"slices"
+	"strings"
+)
+
+// botCommands take bot name as first argument.
+var botCommands = []string{
+	"status", "start", "stop", "restart", "say", "move",
+}
+
+// Complete returns variants of last word of line: commands, their
+// subcommands, bot names, scenario, burst and display names. Variants are
+// sorted.
+func (c *Console) Complete(line string) []string {
+	words := strings.Fields(line)
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+	last := words[len(words)-1]
+
+	var variants []string
+	for _, variant := range c.variants(words[:len(words)-1]) {
+		if strings.HasPrefix(variant, last) {
+			variants = append(variants, variant)
+		}
+	}
+	slices.Sort(variants)
+
+	return variants
+}
+
+// variants returns all words which may follow words.
+func (c *Console) variants(words []string) []string {
+	switch {
+	case len(words) == 0:
+		var names []string
+		for _, command := range commands() {
+			names = append(names, command.name)
+		}
+
+		return names
+	case len(words) == 1 && slices.Contains(botCommands, words[0]):
+		return c.botNames()
+	case len(words) == 1 && words[0] == "scenario":
+		return []string{"list", "run", "stop"}
+	case len(words) == 1 && words[0] == "burst":
+		return []string{"list", "fire"}
+	case len(words) == 1 && words[0] == "display":
+		return []string{"list", "show"}
+	case len(words) == 1 && words[0] == "packets":
+		return []string{"tail", "stop"}
+	case len(words) == 2 && words[0] == "scenario" && words[1] != "list":
+		var names []string
+		for _, status := range c.controller.Scenarios() {
+			names = append(names, status.Name)
+		}
+
+		return names
+	case len(words) == 2 && words[0] == "burst" && words[1] == "fire":
+		return c.controller.Bursts()
+	case len(words) == 2 && words[0] == "display" && words[1] == "show":
+		return c.controller.Displays()
+	case len(words) == 2 && words[0] == "packets":
+		return c.botNames()
+	default:
+		return nil
+	}
+}
+
+func (c *Console) botNames() []string {
+	var names []string
+	for _, status := range c.controller.Bots() {
+		names = append(names, status.Bot.Name)
+	}
+
+	return names
+}
+
+// commonPrefix returns longest prefix of all variants.
+func commonPrefix(variants []string) string {
+	if len(variants) == 0 {
+		return ""
+	}
+	prefix := variants[0]
+	for _, variant := range variants[1:] {
+		for !strings.HasPrefix(variant, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsole_Complete(t *testing.T) {
+	tests := []struct {
+		line     string
+		variants []string
+	}{
+		{line: "", variants: []string{
+			"bots", "burst", "display", "help", "history", "move", "packets",
+			"quit", "restart", "say", "scenario", "start", "status", "stop",
+		}},
+		{line: "st", variants: []string{"start", "status", "stop"}},
+		{line: "sc", variants: []string{"scenario"}},
+		{line: "status ", variants: []string{"healer", "tank"}},
+		{line: "say t", variants: []string{"tank"}},
+		{line: "say tank hel", variants: nil},
+		{line: "scenario ", variants: []string{"list", "run", "stop"}},
+		{line: "scenario run f", variants: []string{"farm", "fight"}},
+		{line: "scenario list ", variants: nil},
+		{line: "burst ", variants: []string{"fire", "list"}},
+		{line: "burst fire ", variants: []string{"alpha", "bow"}},
+		{line: "burst list ", variants: nil},
+		{line: "display ", variants: []string{"list", "show"}},
+		{line: "display show ", variants: []string{"hello", "ranks"}},
+		{line: "packets t", variants: []string{"tail"}},
+		{line: "packets tail h", variants: []string{"healer"}},
+		{line: "dance ", variants: nil},
+	}
+
+	c := New(newController(), &output{}) //nolint:exhaustruct
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			require.Equal(t, test.variants, c.Complete(test.line))
+		})
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	require.Empty(t, commonPrefix(nil))
+	require.Equal(t, "st", commonPrefix([]string{"start", "status", "stop"}))
+	require.Equal(t, "sta", commonPrefix([]string{"start", "status"}))
+	require.Equal(t, "tank", commonPrefix([]string{"tank"}))
+}
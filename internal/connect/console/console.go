@@ -0,0 +1,517 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/melg8/connect/internal/connect/control"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	prompt = "connect> "
+	// tailBytes is how many bytes of packet body tail prints.
+	tailBytes = 16
+)
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrUsage          = errors.New("usage")
+	// errQuit is returned by quit command to end console.
+	errQuit = errors.New("quit")
+)
+
+// Controller is control API console goes through, control.Controller is
+// one.
+type Controller interface {
+	Bots() []control.Status
+	Status(name string) (control.Status, error)
+	Start(ctx context.Context, name string) error
+	Stop(name string) error
+	Restart(ctx context.Context, name string) error
+	Say(name, text string) error
+	Move(ctx context.Context, name string, destination world.Position) error
+	Scenarios() []control.ScenarioStatus
+	RunScenario(ctx context.Context, name string) error
+	StopScenario(name string) error
+	Bursts() []string
+	Burst(
+		ctx context.Context,
+		name string,
+		targetID int32,
+	) (control.BurstResult, error)
+	Displays() []string
+	Display(ctx context.Context, name string) error
+	Tail(name string) (<-chan control.Packet, func(), error)
+}
+
+// Console runs commands typed in terminal of bot server.
+type Console struct {
+	// mutex orders writes of commands and packet tails.
+	mutex      sync.Mutex
+	controller Controller
+	out        io.Writer
+	history    *History
+	// tails stop packet tails by bot names.
+	tails map[string]func()
+}
+
+func New(controller Controller, out io.Writer) *Console {
+	return &Console{
+		mutex:      sync.Mutex{},
+		controller: controller,
+		out:        out,
+		history:    NewHistory(DefaultHistorySize),
+		tails:      make(map[string]func()),
+	}
+}
+
+// command is console command. Args is least number of arguments.
+type command struct {
+	name  string
+	usage string
+	args  int
+	run   func(c *Console, ctx context.Context, args []string) error
+}
+
+// commands returns all commands of console. It is function, since help
+// lists commands.
+func commands() []command {
+	return []command{
+		{"help", "help", 0, (*Console).help},
+		{"bots", "bots", 0, (*Console).bots},
+		{"status", "status <bot>", 1, (*Console).status},
+		{"start", "start <bot>", 1, (*Console).start},
+		{"stop", "stop <bot>", 1, (*Console).stop},
+		{"restart", "restart <bot>", 1, (*Console).restart},
+		{"say", "say <bot> <text>", 2, (*Console).say},
+		{"move", "move <bot> <x> <y> <z>", 4, (*Console).move},
+		{"scenario", "scenario list|run <name>|stop <name>", 1,
+			(*Console).scenario},
+		{"burst", "burst list|fire <name> [target]", 1, (*Console).burst},
+		{"display", "display list|show <name>", 1, (*Console).display},
+		{"packets", "packets tail|stop <bot>", 2, (*Console).packets},
+		{"history", "history", 0, (*Console).showHistory},
+		{"quit", "quit", 0, (*Console).quit},
+	}
+}
+
+// Execute runs one command line.
+func (c *Console) Execute(ctx context.Context, line string) error {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil
+	}
+	c.history.Add(strings.TrimSpace(line))
+
+	for _, command := range commands() {
+		if command.name != words[0] {
+			continue
+		}
+		if len(words)-1 < command.args {
+			return fmt.Errorf("%w: %s", ErrUsage, command.usage)
+		}
+		args := words[1:]
+		if command.name == "say" {
+			// Text keeps its spaces.
+			args = []string{words[1], text(line, 2)}
+		}
+
+		return command.run(c, ctx, args)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownCommand, words[0])
+}
+
+// text returns rest of line after words.
+func text(line string, words int) string {
+	rest := strings.TrimSpace(line)
+	for range words {
+		index := strings.IndexFunc(rest, func(r rune) bool { return r == ' ' })
+		if index < 0 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[index:])
+	}
+
+	return rest
+}
+
+func (c *Console) printf(format string, args ...any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	fmt.Fprintf(c.out, format, args...)
+}
+
+func (c *Console) help(context.Context, []string) error {
+	for _, command := range commands() {
+		c.printf("  %s\n", command.usage)
+	}
+
+	return nil
+}
+
+func (c *Console) bots(context.Context, []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	for _, status := range c.controller.Bots() {
+		character := status.Character
+		fmt.Fprintf(table, "%s\t%v\tHP %.0f%%\t%d %d %d\n",
+			status.Bot.Name, status.Bot.State, character.HP()*100,
+			character.Position.X, character.Position.Y, character.Position.Z)
+	}
+
+	return table.Flush()
+}
+
+func (c *Console) status(_ context.Context, args []string) error {
+	status, err := c.controller.Status(args[0])
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	table := tabwriter.NewWriter(c.out, 0, 0, 1, ' ', 0)
+	state, character := status.Bot, status.Character
+	fmt.Fprintf(table, "Bot:\t%s\n", state.Name)
+	fmt.Fprintf(table, "State:\t%v since %s\n", state.State,
+		state.Since.Format("15:04:05"))
+	fmt.Fprintf(table, "Reconnects:\t%d\n", state.Reconnects)
+	if state.Err != nil {
+		fmt.Fprintf(table, "Error:\t%v\n", state.Err)
+	}
+	fmt.Fprintf(table, "Character:\t%s (object %d)\n", character.Character,
+		character.ObjectID)
+	fmt.Fprintf(table, "HP:\t%d/%d\n", character.CurHP, character.MaxHP)
+	fmt.Fprintf(table, "MP:\t%d/%d\n", character.CurMP, character.MaxMP)
+	fmt.Fprintf(table, "CP:\t%d/%d\n", character.CurCP, character.MaxCP)
+	fmt.Fprintf(table, "Position:\t%d %d %d\n", character.Position.X,
+		character.Position.Y, character.Position.Z)
+	fmt.Fprintf(table, "Target:\t%d\n", character.Target)
+	fmt.Fprintf(table, "Aggro:\t%v\n", character.Aggro)
+	if character.Casting != 0 {
+		fmt.Fprintf(table, "Casting:\t%d at %d\n", character.Casting,
+			character.CastTarget)
+	}
+	fmt.Fprintf(table, "Effects:\t%d\n", len(character.Effects))
+
+	return table.Flush()
+}
+
+func (c *Console) start(ctx context.Context, args []string) error {
+	return c.controller.Start(ctx, args[0])
+}
+
+func (c *Console) stop(_ context.Context, args []string) error {
+	return c.controller.Stop(args[0])
+}
+
+func (c *Console) restart(ctx context.Context, args []string) error {
+	return c.controller.Restart(ctx, args[0])
+}
+
+func (c *Console) say(_ context.Context, args []string) error {
+	return c.controller.Say(args[0], args[1])
+}
+
+func (c *Console) move(ctx context.Context, args []string) error {
+	var coordinates [3]int32
+	for index, arg := range args[1:4] {
+		value, err := strconv.ParseInt(arg, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid coordinate %q: %w", arg, err)
+		}
+		coordinates[index] = int32(value)
+	}
+
+	return c.controller.Move(ctx, args[0], world.Position{
+		X: coordinates[0],
+		Y: coordinates[1],
+		Z: coordinates[2],
+	})
+}
+
+func (c *Console) scenario(ctx context.Context, args []string) error {
+	switch {
+	case args[0] == "list":
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		for _, status := range c.controller.Scenarios() {
+			state := "idle"
+			if status.Running {
+				state = "running"
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\n", status.Name, status.Plan, state)
+			for _, progress := range status.Progress {
+				fmt.Fprintf(table, "  %s\t%s\t%s\n", progress.Name,
+					progress.Role, progress.Phase)
+			}
+		}
+
+		return table.Flush()
+	case args[0] == "run" && len(args) > 1:
+		return c.controller.RunScenario(ctx, args[1])
+	case args[0] == "stop" && len(args) > 1:
+		return c.controller.StopScenario(args[1])
+	default:
+		return fmt.Errorf("%w: scenario list|run <name>|stop <name>",
+			ErrUsage)
+	}
+}
+
+func (c *Console) burst(ctx context.Context, args []string) error {
+	switch {
+	case args[0] == "list":
+		for _, name := range c.controller.Bursts() {
+			c.printf("  %s\n", name)
+		}
+
+		return nil
+	case args[0] == "fire" && len(args) > 1:
+		var targetID int64
+		if len(args) > 2 {
+			var err error
+			targetID, err = strconv.ParseInt(args[2], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid target %q: %w", args[2], err)
+			}
+		}
+		result, err := c.controller.Burst(ctx, args[1], int32(targetID))
+		if err != nil {
+			return err
+		}
+
+		return c.showBurst(result)
+	default:
+		return fmt.Errorf("%w: burst list|fire <name> [target]", ErrUsage)
+	}
+}
+
+// showBurst prints how late each action of burst started and how far from
+// planned impact it hit.
+func (c *Console) showBurst(result control.BurstResult) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	for _, report := range result.Reports {
+		outcome := "ok"
+		if report.Err != nil {
+			outcome = report.Err.Error()
+		}
+		fmt.Fprintf(table, "%s\tjitter %v\tmiss %v\t%s\n", report.Bot,
+			report.Jitter(), report.Miss(result.Impact), outcome)
+	}
+
+	return table.Flush()
+}
+
+func (c *Console) display(ctx context.Context, args []string) error {
+	switch {
+	case args[0] == "list":
+		for _, name := range c.controller.Displays() {
+			c.printf("  %s\n", name)
+		}
+
+		return nil
+	case args[0] == "show" && len(args) > 1:
+		return c.controller.Display(ctx, args[1])
+	default:
+		return fmt.Errorf("%w: display list|show <name>", ErrUsage)
+	}
+}
+
+func (c *Console) packets(_ context.Context, args []string) error {
+	name := args[1]
+	switch args[0] {
+	case "tail":
+		return c.tail(name)
+	case "stop":
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		if stop, ok := c.tails[name]; ok {
+			stop()
+			delete(c.tails, name)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: packets tail|stop <bot>", ErrUsage)
+	}
+}
+
+// tail prints packets bot receives until tail is stopped.
+func (c *Console) tail(name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.tails[name]; ok {
+		return nil
+	}
+	packets, remove, err := c.controller.Tail(name)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	c.tails[name] = func() {
+		remove()
+		close(done)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case packet := <-packets:
+				body := packet.Data[:min(len(packet.Data), tailBytes)]
+				c.printf("%s %s %#02x %d bytes: % x\n", packet.Bot,
+					packet.Time.Format("15:04:05.000"), packet.ID,
+					len(packet.Data), body)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (c *Console) showHistory(context.Context, []string) error {
+	for index, line := range c.history.Lines() {
+		c.printf("%4d  %s\n", index+1, line)
+	}
+
+	return nil
+}
+
+func (c *Console) quit(context.Context, []string) error {
+	return errQuit
+}
+
+// Close stops packet tails.
+func (c *Console) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for name, stop := range c.tails {
+		stop()
+		delete(c.tails, name)
+	}
+}
+
+// Run reads commands from input until it ends, quit is typed or context is
+// done. Terminal gets line editing with completion and history, other
+// input is read line by line.
+func (c *Console) Run(ctx context.Context, in io.Reader) error {
+	defer c.Close()
+
+	readLine := c.lineReader(ctx, in)
+	for ctx.Err() == nil {
+		line, err := read(ctx, readLine)
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = c.Execute(ctx, line)
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		if err != nil {
+			c.printf("Error: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// read returns next line or returns at once when context is done. Read of
+// input can't be interrupted, so line typed after that is lost.
+func read(
+	ctx context.Context,
+	readLine func() (string, error),
+) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		line, err := readLine()
+		results <- result{line: line, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case read := <-results:
+		return read.line, read.err
+	}
+}
+
+// lineReader returns editor for terminal and plain line reader otherwise.
+func (c *Console) lineReader(
+	ctx context.Context,
+	in io.Reader,
+) func() (string, error) {
+	if file, ok := in.(*os.File); ok {
+		if restore, err := makeRaw(file); err == nil {
+			restore()
+			editor := NewEditor(in, c.out, prompt, c.Complete, c.history)
+
+			// Terminal is raw only while line is typed, so commands and logs
+			// print as usual.
+			return func() (string, error) {
+				restore, err := makeRaw(file)
+				if err != nil {
+					return "", err
+				}
+				// Terminal is restored on shutdown while line is typed.
+				stop := context.AfterFunc(ctx, restore)
+				defer func() {
+					if stop() {
+						restore()
+					}
+				}()
+
+				return editor.ReadLine()
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(in)
+
+	return func() (string, error) {
+		c.printf("%s", prompt)
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+
+			return "", io.EOF
+		}
+
+		return scanner.Text(), nil
+	}
+}
@@ -0,0 +1,401 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/burst"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/control"
+	"github.com/melg8/connect/internal/connect/scenario"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Controller = (*control.Controller)(nil)
+
+// controller records calls of console.
+type controller struct {
+	mutex   sync.Mutex
+	calls   []string
+	packets chan control.Packet
+	removed bool
+}
+
+func newController() *controller {
+	return &controller{
+		mutex:   sync.Mutex{},
+		calls:   nil,
+		packets: make(chan control.Packet, 1),
+		removed: false,
+	}
+}
+
+func (c *controller) call(format string, args ...any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.calls = append(c.calls, fmt.Sprintf(format, args...))
+}
+
+func (c *controller) Bots() []control.Status {
+	return []control.Status{
+		{
+			Bot: bot.Status{
+				Name:       "tank",
+				State:      bot.InWorld,
+				Since:      time.Time{},
+				Err:        nil,
+				Reconnects: 0,
+			},
+			Character: board.State{ //nolint:exhaustruct
+				Name:     "tank",
+				Online:   true,
+				Position: world.Position{X: 1, Y: 2, Z: 3},
+				CurHP:    50,
+				MaxHP:    100,
+			},
+		},
+		{
+			Bot: bot.Status{
+				Name:       "healer",
+				State:      bot.Disconnected,
+				Since:      time.Time{},
+				Err:        nil,
+				Reconnects: 2,
+			},
+			Character: board.State{Name: "healer"}, //nolint:exhaustruct
+		},
+	}
+}
+
+func (c *controller) Status(name string) (control.Status, error) {
+	for _, status := range c.Bots() {
+		if status.Bot.Name == name {
+			return status, nil
+		}
+	}
+
+	return control.Status{}, bot.ErrUnknownBot //nolint:exhaustruct
+}
+
+func (c *controller) Start(_ context.Context, name string) error {
+	c.call("start %s", name)
+
+	return nil
+}
+
+func (c *controller) Stop(name string) error {
+	c.call("stop %s", name)
+
+	return nil
+}
+
+func (c *controller) Restart(_ context.Context, name string) error {
+	c.call("restart %s", name)
+
+	return nil
+}
+
+func (c *controller) Say(name, text string) error {
+	c.call("say %s %s", name, text)
+
+	return nil
+}
+
+func (c *controller) Move(
+	_ context.Context,
+	name string,
+	destination world.Position,
+) error {
+	c.call("move %s %d %d %d", name, destination.X, destination.Y,
+		destination.Z)
+
+	return nil
+}
+
+func (c *controller) Scenarios() []control.ScenarioStatus {
+	return []control.ScenarioStatus{
+		{Name: "farm", Plan: "spot", Running: true, Progress: []scenario.Progress{
+			{Name: "tank", Role: "tank", Phase: "pull", Round: 1, Waiting: false},
+		}},
+		{Name: "fight", Plan: "pvp", Running: false, Progress: nil},
+	}
+}
+
+func (c *controller) RunScenario(_ context.Context, name string) error {
+	c.call("scenario run %s", name)
+
+	return nil
+}
+
+func (c *controller) StopScenario(name string) error {
+	c.call("scenario stop %s", name)
+
+	return nil
+}
+
+func (c *controller) Bursts() []string {
+	return []string{"alpha", "bow"}
+}
+
+func (c *controller) Burst(
+	_ context.Context,
+	name string,
+	targetID int32,
+) (control.BurstResult, error) {
+	c.call("burst %s %d", name, targetID)
+	impact := time.Unix(1000, 0)
+
+	return control.BurstResult{
+		Impact: impact,
+		Reports: []burst.Report{
+			{
+				Bot:    "tank",
+				Start:  impact.Add(-time.Second),
+				Fired:  impact.Add(-time.Second + 5*time.Millisecond),
+				Impact: impact.Add(10 * time.Millisecond),
+				Err:    nil,
+			},
+			{
+				Bot:    "healer",
+				Start:  impact,
+				Fired:  time.Time{},
+				Impact: time.Time{},
+				Err:    connection.ErrNotInGame,
+			},
+		},
+	}, nil
+}
+
+func (c *controller) Displays() []string {
+	return []string{"hello", "ranks"}
+}
+
+func (c *controller) Display(_ context.Context, name string) error {
+	c.call("display %s", name)
+
+	return nil
+}
+
+func (c *controller) Tail(name string) (<-chan control.Packet, func(), error) {
+	if _, err := c.Status(name); err != nil {
+		return nil, nil, err
+	}
+	c.call("tail %s", name)
+
+	return c.packets, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		c.removed = true
+	}, nil
+}
+
+// output is writer shared by console and its packet tails.
+type output struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.buffer.Write(p)
+}
+
+func (o *output) String() string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.buffer.String()
+}
+
+func TestConsole_Execute(t *testing.T) {
+	tests := []struct {
+		line   string
+		call   string
+		output []string
+		err    error
+	}{
+		{line: "", call: "", output: nil, err: nil},
+		{line: "start tank", call: "start tank", output: nil, err: nil},
+		{line: "stop tank", call: "stop tank", output: nil, err: nil},
+		{line: "restart tank", call: "restart tank", output: nil, err: nil},
+		{
+			line:   "  say tank  hello   all ",
+			call:   "say tank hello   all",
+			output: nil,
+			err:    nil,
+		},
+		{line: "move tank 1 2 3", call: "move tank 1 2 3", output: nil,
+			err: nil},
+		{line: "scenario run farm", call: "scenario run farm", output: nil,
+			err: nil},
+		{line: "scenario stop farm", call: "scenario stop farm", output: nil,
+			err: nil},
+		{
+			line:   "bots",
+			call:   "",
+			output: []string{"tank    InWorld", "HP 50%", "1 2 3", "healer"},
+			err:    nil,
+		},
+		{
+			line: "status tank",
+			call: "",
+			output: []string{
+				"Bot:", "tank", "State:", "InWorld", "HP:", "50/100",
+				"Position:", "1 2 3",
+			},
+			err: nil,
+		},
+		{
+			line:   "scenario list",
+			call:   "",
+			output: []string{"farm", "spot", "running", "pull", "fight", "idle"},
+			err:    nil,
+		},
+		{
+			line:   "help",
+			call:   "",
+			output: []string{"move <bot> <x> <y> <z>", "packets tail|stop <bot>"},
+			err:    nil,
+		},
+		{
+			line: "burst fire alpha 300",
+			call: "burst alpha 300",
+			output: []string{
+				"tank", "jitter 5ms", "miss 10ms", "ok",
+				"healer", "not connected to game server",
+			},
+			err: nil,
+		},
+		{line: "burst fire bow", call: "burst bow 0", output: nil, err: nil},
+		{line: "burst list", call: "", output: []string{"alpha", "bow"},
+			err: nil},
+		{line: "burst fire", call: "", output: nil, err: ErrUsage},
+		{line: "burst fire alpha boss", call: "", output: nil,
+			err: strconv.ErrSyntax},
+		{line: "display show hello", call: "display hello", output: nil,
+			err: nil},
+		{line: "display list", call: "", output: []string{"hello", "ranks"},
+			err: nil},
+		{line: "display show", call: "", output: nil, err: ErrUsage},
+		{line: "status ghost", call: "", output: nil, err: bot.ErrUnknownBot},
+		{line: "dance", call: "", output: nil, err: ErrUnknownCommand},
+		{line: "say tank", call: "", output: nil, err: ErrUsage},
+		{line: "scenario run", call: "", output: nil, err: ErrUsage},
+		{line: "packets show tank", call: "", output: nil, err: ErrUsage},
+		{line: "quit", call: "", output: nil, err: errQuit},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			fake := newController()
+			var out output
+			err := New(fake, &out).Execute(context.Background(), test.line)
+			if test.err != nil {
+				require.True(t, errors.Is(err, test.err), err)
+
+				return
+			}
+			require.NoError(t, err)
+			if test.call != "" {
+				require.Equal(t, []string{test.call}, fake.calls)
+			}
+			for _, part := range test.output {
+				require.Contains(t, out.String(), part)
+			}
+		})
+	}
+}
+
+func TestConsole_MoveInvalid(t *testing.T) {
+	fake := newController()
+	c := New(fake, &output{}) //nolint:exhaustruct
+
+	err := c.Execute(context.Background(), "move tank 1 north 3")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "north")
+	require.Empty(t, fake.calls)
+}
+
+func TestConsole_PacketsTail(t *testing.T) {
+	fake := newController()
+	var out output
+	c := New(fake, &out)
+	ctx := context.Background()
+
+	require.NoError(t, c.Execute(ctx, "packets tail tank"))
+	require.NoError(t, c.Execute(ctx, "packets tail tank"),
+		"second tail of bot is ignored")
+	require.Equal(t, []string{"tail tank"}, fake.calls)
+	err := c.Execute(ctx, "packets tail ghost")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+
+	fake.packets <- control.Packet{
+		Bot:  "tank",
+		Time: time.Date(2024, 1, 1, 10, 20, 30, 0, time.UTC),
+		ID:   0x04,
+		Data: []byte{1, 2, 0xff},
+	}
+	want := "tank 10:20:30.000 0x04 3 bytes: 01 02 ff\n"
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), want) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	require.Contains(t, out.String(), want)
+
+	require.NoError(t, c.Execute(ctx, "packets stop tank"))
+	fake.mutex.Lock()
+	require.True(t, fake.removed)
+	fake.mutex.Unlock()
+}
+
+func TestConsole_Run(t *testing.T) {
+	fake := newController()
+	var out output
+	c := New(fake, &out)
+	in := strings.NewReader("start tank\ndance\npackets tail tank\nquit\n" +
+		"stop tank\n")
+
+	require.NoError(t, c.Run(context.Background(), in))
+	require.Equal(t, []string{"start tank", "tail tank"}, fake.calls,
+		"nothing runs after quit")
+	require.Contains(t, out.String(), "Error: unknown command: dance\n")
+	require.True(t, fake.removed, "tails are stopped")
+	require.Equal(t, []string{"start tank", "dance", "packets tail tank",
+		"quit"}, c.history.Lines())
+
+	require.NoError(t, New(fake, &out).Run(context.Background(),
+		strings.NewReader("bots")), "end of input ends console")
+}
+
+func TestConsole_RunUntilShutdown(t *testing.T) {
+	in, typed := io.Pipe()
+	defer typed.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	var out output
+	ended := make(chan error)
+	go func() {
+		ended <- New(newController(), &out).Run(ctx, in)
+	}()
+
+	cancel()
+	require.NoError(t, <-ended, "console ends while line is read")
+}
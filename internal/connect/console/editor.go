@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Keys of terminal in raw mode.
+const (
+	keyCtrlD     = 4
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Editor reads lines from terminal in raw mode. Tab completes last word,
+// up and down arrows go through history.
+type Editor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	prompt   string
+	complete func(line string) []string
+	history  *History
+}
+
+func NewEditor(
+	in io.Reader,
+	out io.Writer,
+	prompt string,
+	complete func(line string) []string,
+	history *History,
+) *Editor {
+	return &Editor{
+		in:       bufio.NewReader(in),
+		out:      out,
+		prompt:   prompt,
+		complete: complete,
+		history:  history,
+	}
+}
+
+// ReadLine returns next line. Ctrl-D on empty line ends input like end of
+// file.
+func (e *Editor) ReadLine() (string, error) {
+	fmt.Fprint(e.out, e.prompt)
+	var line []rune
+	lines := e.history.Lines()
+	browsed := len(lines)
+
+	for {
+		key, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch key {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\n")
+
+			return string(line), nil
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\n")
+
+				return "", io.EOF
+			}
+		case keyBackspace, keyCtrlH:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				fmt.Fprint(e.out, "\b \b")
+			}
+		case keyCtrlU:
+			line = nil
+			e.redraw(line)
+		case keyTab:
+			line = e.completeLine(line)
+		case keyEscape:
+			switch e.arrow() {
+			case 'A':
+				browsed = max(browsed-1, 0)
+			case 'B':
+				browsed = min(browsed+1, len(lines))
+			default:
+				continue
+			}
+			line = nil
+			if browsed < len(lines) {
+				line = []rune(lines[browsed])
+			}
+			e.redraw(line)
+		default:
+			if unicode.IsPrint(key) {
+				line = append(line, key)
+				fmt.Fprint(e.out, string(key))
+			}
+		}
+	}
+}
+
+// arrow reads rest of escape sequence and returns its final letter, zero
+// for sequences which aren't arrows.
+func (e *Editor) arrow() rune {
+	bracket, _, err := e.in.ReadRune()
+	if err != nil || bracket != '[' {
+		return 0
+	}
+	letter, _, err := e.in.ReadRune()
+	if err != nil {
+		return 0
+	}
+
+	return letter
+}
+
+// redraw prints prompt and line again over current line.
+func (e *Editor) redraw(line []rune) {
+	fmt.Fprintf(e.out, "\r\x1b[K%s%s", e.prompt, string(line))
+}
+
+// completeLine completes last word of line. Single variant is taken
+// whole, several variants are shown unless they share longer prefix.
+func (e *Editor) completeLine(line []rune) []rune {
+	text := string(line)
+	variants := e.complete(text)
+	if len(variants) == 0 {
+		return line
+	}
+
+	last := ""
+	if fields := strings.Fields(text); len(fields) > 0 &&
+		!strings.HasSuffix(text, " ") {
+		last = fields[len(fields)-1]
+	}
+	completion := commonPrefix(variants)
+	if len(variants) == 1 {
+		completion += " "
+	}
+	if len(completion) <= len(last) {
+		fmt.Fprintf(e.out, "\n%s\n", strings.Join(variants, "  "))
+		e.redraw(line)
+
+		return line
+	}
+
+	line = []rune(strings.TrimSuffix(text, last) + completion)
+	e.redraw(line)
+
+	return line
+}
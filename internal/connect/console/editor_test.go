@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	up   = "\x1b[A"
+	down = "\x1b[B"
+)
+
+func TestEditor_ReadLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  string
+	}{
+		{name: "enter", input: "bots\r", line: "bots"},
+		{name: "new line", input: "bots\n", line: "bots"},
+		{name: "backspace", input: "botx\x7fs\r", line: "bots"},
+		{name: "ctrl h", input: "\x08bo\x08ots\r", line: "bots"},
+		{name: "clear line", input: "stop tank\x15bots\r", line: "bots"},
+		{name: "control keys", input: "bo\x01ts\r", line: "bots"},
+		{name: "ctrl d in line", input: "bo\x04ts\r", line: "bots"},
+		{name: "complete command", input: "star\t\r", line: "start "},
+		{name: "complete bot", input: "stop t\t\r", line: "stop tank "},
+		{name: "complete prefix", input: "s\tt\r", line: "st"},
+		{name: "many variants", input: "st\t\r", line: "st"},
+		{name: "no variants", input: "say tank hi\t\r", line: "say tank hi"},
+		{name: "history up", input: up + "\r", line: "status tank"},
+		{name: "history top", input: up + up + up + "\r", line: "bots"},
+		{name: "history down", input: up + up + down + "\r",
+			line: "status tank"},
+		{name: "history bottom", input: up + down + down + "\r", line: ""},
+		{name: "other escape", input: "\x1b[Cbots\r", line: "bots"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := New(newController(), &output{}) //nolint:exhaustruct
+			c.history.Add("bots")
+			c.history.Add("status tank")
+			var out bytes.Buffer
+			e := NewEditor(strings.NewReader(test.input), &out, prompt,
+				c.Complete, c.history)
+
+			line, err := e.ReadLine()
+			require.NoError(t, err)
+			require.Equal(t, test.line, line)
+			require.True(t, strings.HasPrefix(out.String(), prompt))
+		})
+	}
+}
+
+func TestEditor_Output(t *testing.T) {
+	c := New(newController(), &output{}) //nolint:exhaustruct
+	var out bytes.Buffer
+	e := NewEditor(strings.NewReader("st\tx\x7f\r"), &out, "> ", c.Complete,
+		c.history)
+
+	line, err := e.ReadLine()
+	require.NoError(t, err)
+	require.Equal(t, "st", line)
+	require.Equal(t, "> st\nstart  status  stop\n\r\x1b[K> stx\b \b\n",
+		out.String())
+}
+
+func TestEditor_EOF(t *testing.T) {
+	c := New(newController(), &output{}) //nolint:exhaustruct
+	for _, input := range []string{"\x04", "bots"} {
+		e := NewEditor(strings.NewReader(input), io.Discard, prompt,
+			c.Complete, c.history)
+
+		_, err := e.ReadLine()
+		require.True(t, errors.Is(err, io.EOF), err)
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"slices"
+	"sync"
+)
+
+// DefaultHistorySize is how many lines console remembers.
+const DefaultHistorySize = 500
+
+// History is list of entered lines, oldest first.
+type History struct {
+	mutex sync.Mutex
+	lines []string
+	limit int
+}
+
+func NewHistory(limit int) *History {
+	return &History{mutex: sync.Mutex{}, lines: nil, limit: max(limit, 1)}
+}
+
+// Add remembers line. Empty lines and repeats of last line are skipped,
+// oldest lines are forgotten when history is full.
+func (h *History) Add(line string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if line == "" || (len(h.lines) > 0 && h.lines[len(h.lines)-1] == line) {
+		return
+	}
+	h.lines = append(h.lines, line)
+	if len(h.lines) > h.limit {
+		h.lines = slices.Delete(h.lines, 0, len(h.lines)-h.limit)
+	}
+}
+
+// Lines returns remembered lines, oldest first.
+func (h *History) Lines() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return slices.Clone(h.lines)
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package console
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_Add(t *testing.T) {
+	h := NewHistory(3)
+	for _, line := range []string{"bots", "", "bots", "status tank",
+		"bots", "quit"} {
+		h.Add(line)
+	}
+
+	require.Equal(t, []string{"status tank", "bots", "quit"}, h.Lines())
+
+	lines := h.Lines()
+	lines[0] = "changed"
+	require.Equal(t, "status tank", h.Lines()[0], "lines are copied")
+	require.Empty(t, NewHistory(0).Lines())
+}
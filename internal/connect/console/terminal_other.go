@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !(linux || darwin)
+
+package console
+
+import (
+	"errors"
+	"os"
+)
+
+var errNoRawMode = errors.New("raw terminal mode is not supported")
+
+// makeRaw isn't supported, so console reads whole lines.
+func makeRaw(*os.File) (func(), error) {
+	return nil, errNoRawMode
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build linux || darwin
+
+package console
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw turns off line buffering and echo of terminal, so console sees
+// every key. Signal keys keep working. Returned function restores
+// terminal.
+func makeRaw(file *os.File) (func(), error) {
+	fd := file.Fd()
+	var old syscall.Termios
+	if err := ioctl(fd, getTermios, &old); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(fd, setTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_ = ioctl(fd, setTermios, &old)
+	}, nil
+}
+
+func ioctl(fd, request uintptr, termios *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request,
+		uintptr(unsafe.Pointer(termios))) //nolint:gosec
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/burst"
+	"github.com/melg8/connect/internal/connect/config"
+)
+
+var ErrUnknownBurst = errors.New("unknown burst")
+
+// BurstResult is planned impact of burst and reports of its actions.
+type BurstResult struct {
+	Impact  time.Time
+	Reports []burst.Report
+}
+
+// Bursts returns names of bursts in order of config.
+func (c *Controller) Bursts() []string {
+	names := make([]string, 0, len(c.bursts))
+	for _, settings := range c.bursts {
+		names = append(names, settings.Name)
+	}
+
+	return names
+}
+
+// Burst fires burst of config with name at target as soon as all its bots
+// can make it and returns when all actions are done.
+func (c *Controller) Burst(
+	ctx context.Context,
+	name string,
+	targetID int32,
+) (BurstResult, error) {
+	var none BurstResult
+
+	settings, err := c.burst(name)
+	if err != nil {
+		return none, err
+	}
+	actions, err := burst.FromConfig(settings, c.agents, targetID)
+	if err != nil {
+		return none, err
+	}
+	margin := settings.Margin.Duration
+	if margin == 0 {
+		margin = burst.DefaultMargin
+	}
+
+	impact := burst.Earliest(time.Now(), actions, margin)
+
+	return BurstResult{
+		Impact:  impact,
+		Reports: burst.Run(ctx, impact, actions),
+	}, nil
+}
+
+func (c *Controller) burst(name string) (config.Burst, error) {
+	for _, settings := range c.bursts {
+		if settings.Name == name {
+			return settings, nil
+		}
+	}
+
+	var none config.Burst
+
+	return none, fmt.Errorf("%w: %s", ErrUnknownBurst, name)
+}
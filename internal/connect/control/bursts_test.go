@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/burst"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_Burst(t *testing.T) {
+	c := newController(t, nil, []config.Burst{
+		{
+			Name:   "bow",
+			Margin: config.Duration{Duration: time.Millisecond},
+			Actions: []config.BurstAction{
+				{Login: "tank", Kind: "emote", SocialID: 7}, //nolint:exhaustruct
+			},
+		},
+		{
+			Name:   "nuke",
+			Margin: config.Duration{Duration: 0},
+			Actions: []config.BurstAction{
+				{Login: "tank", Kind: "spell", SkillID: 1}, //nolint:exhaustruct
+			},
+		},
+	}, "tank")
+	ctx := context.Background()
+	require.Equal(t, []string{"bow", "nuke"}, c.Bursts())
+
+	started := time.Now()
+	result, err := c.Burst(ctx, "bow", 0)
+	require.NoError(t, err)
+	require.False(t, result.Impact.Before(started.Add(time.Millisecond)))
+	require.Len(t, result.Reports, 1)
+	require.Equal(t, "tank", result.Reports[0].Bot)
+	require.True(t, errors.Is(result.Reports[0].Err, connection.ErrNotInGame),
+		result.Reports[0].Err)
+
+	_, err = c.Burst(ctx, "nuke", 300)
+	require.True(t, errors.Is(err, burst.ErrNotInGame), err)
+	_, err = c.Burst(ctx, "unknown", 0)
+	require.True(t, errors.Is(err, ErrUnknownBurst), err)
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/chat"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/scenario"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Status is connection state of bot together with state of its character
+// on board.
+type Status struct {
+	Bot       bot.Status
+	Character board.State
+}
+
+// Controller is control API of running instance. Console and other user
+// interfaces go through it, so they act on bots same way.
+type Controller struct {
+	mutex      sync.Mutex
+	supervisor *bot.Supervisor
+	agents     map[string]*agent.Agent
+	states     *board.Board
+	scenarios  []config.Scenario
+	bursts     []config.Burst
+	displays   []config.Display
+	// groups are scenarios which were run, by names.
+	groups map[string]*scenario.Group
+	// walks are last walks of bots started by Move, by bot names.
+	walks map[string]walk
+	// running are goroutines of scenarios and walks which were run.
+	running sync.WaitGroup
+}
+
+// walk is bot going to destination in background.
+type walk struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns controller of bots, scenarios, bursts and displays of config.
+func New(
+	supervisor *bot.Supervisor,
+	agents map[string]*agent.Agent,
+	states *board.Board,
+	cfg *config.Config,
+) *Controller {
+	return &Controller{
+		mutex:      sync.Mutex{},
+		supervisor: supervisor,
+		agents:     agents,
+		states:     states,
+		scenarios:  cfg.Scenarios,
+		bursts:     cfg.Bursts,
+		displays:   cfg.Displays,
+		groups:     make(map[string]*scenario.Group),
+		walks:      make(map[string]walk),
+		running:    sync.WaitGroup{},
+	}
+}
+
+// Bots returns statuses of all bots in order they were added.
+func (c *Controller) Bots() []Status {
+	statuses := c.supervisor.Statuses()
+	result := make([]Status, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, c.status(status))
+	}
+
+	return result
+}
+
+// Status returns status of bot with name.
+func (c *Controller) Status(name string) (Status, error) {
+	status, err := c.supervisor.Status(name)
+	if err != nil {
+		var none Status
+
+		return none, err
+	}
+
+	return c.status(status), nil
+}
+
+func (c *Controller) status(status bot.Status) Status {
+	character, ok := c.states.Get(status.Name)
+	if !ok {
+		character = board.State{Name: status.Name} //nolint:exhaustruct
+	}
+
+	return Status{Bot: status, Character: character}
+}
+
+// Start logs bot in.
+func (c *Controller) Start(ctx context.Context, name string) error {
+	return c.supervisor.Start(ctx, name)
+}
+
+// Stop logs bot out.
+func (c *Controller) Stop(name string) error {
+	return c.supervisor.Stop(name)
+}
+
+// Restart logs bot out and in again.
+func (c *Controller) Restart(ctx context.Context, name string) error {
+	return c.supervisor.Restart(ctx, name)
+}
+
+// Say sends message of bot to general chat.
+func (c *Controller) Say(name, text string) error {
+	a, err := c.agent(name)
+	if err != nil {
+		return err
+	}
+
+	return a.Chat.Say(chat.All, text)
+}
+
+// Move sends bot to destination around obstacles. It returns once bot
+// starts walking, failures on the way are logged. Previous walk of bot is
+// stopped first, so bot walks only one way.
+func (c *Controller) Move(
+	ctx context.Context,
+	name string,
+	destination world.Position,
+) error {
+	a, err := c.agent(name)
+	if err != nil {
+		return err
+	}
+	if !a.Link.Connected() {
+		return fmt.Errorf("%w: %s", connection.ErrNotInGame, name)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Walks don't start after shutdown, so Wait doesn't miss them.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if previous, ok := c.walks[name]; ok {
+		previous.cancel()
+		<-previous.done
+	}
+	walkCtx, cancel := context.WithCancel(ctx)
+	current := walk{cancel: cancel, done: make(chan struct{})}
+	c.walks[name] = current
+
+	c.running.Add(1)
+	go func() {
+		defer c.running.Done()
+		defer close(current.done)
+		defer cancel()
+
+		err := a.WalkTo(walkCtx, destination)
+		if err != nil && walkCtx.Err() == nil {
+			log.Printf("Error moving %s: %v\n", name, err)
+		}
+	}()
+
+	return nil
+}
+
+func (c *Controller) agent(name string) (*agent.Agent, error) {
+	a, ok := c.agents[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", bot.ErrUnknownBot, name)
+	}
+
+	return a, nil
+}
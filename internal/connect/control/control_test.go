@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+// newController returns controller of bots which are never started.
+func newController(
+	t *testing.T,
+	scenarios []config.Scenario,
+	bursts []config.Burst,
+	names ...string,
+) *Controller {
+	t.Helper()
+
+	supervisor := bot.NewSupervisor()
+	states := board.New()
+	agents := make(map[string]*agent.Agent, len(names))
+	for _, name := range names {
+		a := agent.New(name, geodata.Open(""))
+		agents[name] = a
+		require.NoError(t, supervisor.Add(bot.New(name, nil, a.NewSession)))
+		states.Join(name, a.World, a.Combat).SetOnline(false)
+	}
+
+	cfg := config.Default()
+	cfg.Scenarios = scenarios
+	cfg.Bursts = bursts
+
+	return New(supervisor, agents, states, cfg)
+}
+
+func TestController_Bots(t *testing.T) {
+	c := newController(t, nil, nil, "tank", "healer")
+
+	statuses := c.Bots()
+	require.Len(t, statuses, 2)
+	require.Equal(t, "tank", statuses[0].Bot.Name)
+	require.Equal(t, bot.Disconnected, statuses[0].Bot.State)
+	require.Equal(t, "tank", statuses[0].Character.Name)
+	require.False(t, statuses[0].Character.Online)
+	require.Equal(t, "healer", statuses[1].Bot.Name)
+
+	status, err := c.Status("healer")
+	require.NoError(t, err)
+	require.Equal(t, "healer", status.Character.Name)
+
+	_, err = c.Status("dps")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+}
+
+func TestController_Actions(t *testing.T) {
+	c := newController(t, nil, nil, "tank")
+	ctx := context.Background()
+	far := world.Position{X: 1, Y: 2, Z: 3}
+
+	err := c.Stop("tank")
+	require.True(t, errors.Is(err, bot.ErrNotRunning), err)
+	err = c.Start(ctx, "dps")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+	err = c.Restart(ctx, "dps")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+
+	err = c.Say("tank", "hello")
+	require.True(t, errors.Is(err, connection.ErrNotInGame), err)
+	err = c.Say("dps", "hello")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+
+	err = c.Move(ctx, "tank", far)
+	require.True(t, errors.Is(err, connection.ErrNotInGame), err)
+	err = c.Move(ctx, "dps", far)
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+}
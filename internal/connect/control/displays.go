@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/formation"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+var ErrUnknownDisplay = errors.New("unknown display")
+
+// Displays returns names of displays in order of config.
+func (c *Controller) Displays() []string {
+	names := make([]string, 0, len(c.displays))
+	for _, settings := range c.displays {
+		names = append(names, settings.Name)
+	}
+
+	return names
+}
+
+// Display sends bots of display of config with name to their places and
+// returns when everyone is in place.
+func (c *Controller) Display(ctx context.Context, name string) error {
+	settings, err := c.display(name)
+	if err != nil {
+		return err
+	}
+	display, layout, err := formation.FromConfig(settings, c.agents)
+	if err != nil {
+		return err
+	}
+	anchor := world.Position{
+		X: settings.Anchor.X,
+		Y: settings.Anchor.Y,
+		Z: settings.Anchor.Z,
+	}
+
+	return display.Show(ctx, anchor, settings.Heading, layout)
+}
+
+func (c *Controller) display(name string) (config.Display, error) {
+	for _, settings := range c.displays {
+		if settings.Name == name {
+			return settings, nil
+		}
+	}
+
+	var none config.Display
+
+	return none, fmt.Errorf("%w: %s", ErrUnknownDisplay, name)
+}
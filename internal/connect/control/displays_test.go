@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/formation"
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_Display(t *testing.T) {
+	c := newController(t, nil, nil, "tank")
+	c.displays = []config.Display{
+		{
+			Name:    "line",
+			Shape:   "line",
+			Text:    "",
+			Anchor:  config.Point{X: 1, Y: 2, Z: 3},
+			Heading: 0,
+			Spacing: 40,
+			Performers: []config.Performer{
+				{Login: "tank", Group: ""},
+			},
+		},
+		{
+			Name:    "leased",
+			Shape:   "circle",
+			Text:    "",
+			Anchor:  config.Point{X: 0, Y: 0, Z: 0},
+			Heading: 0,
+			Spacing: 40,
+			Performers: []config.Performer{
+				{Login: "healer", Group: ""},
+			},
+		},
+	}
+	ctx := context.Background()
+	require.Equal(t, []string{"line", "leased"}, c.Displays())
+
+	err := c.Display(ctx, "line")
+	require.True(t, errors.Is(err, movement.ErrUnknownPosition), err)
+	err = c.Display(ctx, "leased")
+	require.True(t, errors.Is(err, formation.ErrNoPerformers), err)
+	err = c.Display(ctx, "unknown")
+	require.True(t, errors.Is(err, ErrUnknownDisplay), err)
+}
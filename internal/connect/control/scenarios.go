@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/scenario"
+)
+
+var (
+	ErrUnknownScenario = errors.New("unknown scenario")
+	ErrScenarioRunning = errors.New("scenario is already running")
+	ErrScenarioIdle    = errors.New("scenario is not running")
+)
+
+// ScenarioStatus is scenario of config and its last run. Progress is empty
+// for scenario which never ran.
+type ScenarioStatus struct {
+	Name     string
+	Plan     string
+	Running  bool
+	Progress []scenario.Progress
+}
+
+// Scenarios returns statuses of scenarios in order of config.
+func (c *Controller) Scenarios() []ScenarioStatus {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result := make([]ScenarioStatus, 0, len(c.scenarios))
+	for _, settings := range c.scenarios {
+		status := ScenarioStatus{
+			Name:     settings.Name,
+			Plan:     settings.Plan,
+			Running:  false,
+			Progress: nil,
+		}
+		if group, ok := c.groups[settings.Name]; ok {
+			status.Running = running(group)
+			status.Progress = group.Progress()
+		}
+		result = append(result, status)
+	}
+
+	return result
+}
+
+// RunScenarios runs all scenarios of config. Scenarios which need bots of
+// other instances are skipped.
+func (c *Controller) RunScenarios(ctx context.Context) {
+	for _, settings := range c.scenarios {
+		if err := c.RunScenario(ctx, settings.Name); err != nil {
+			log.Printf("Skipping scenario %s: %v\n", settings.Name, err)
+		}
+	}
+}
+
+// RunScenario starts scenario of config with name, it runs until it
+// finishes, is stopped or context is done.
+func (c *Controller) RunScenario(ctx context.Context, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Scenarios don't start after shutdown, so Wait doesn't miss them.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	settings, err := c.scenario(name)
+	if err != nil {
+		return err
+	}
+	if group, ok := c.groups[name]; ok && running(group) {
+		return fmt.Errorf("%w: %s", ErrScenarioRunning, name)
+	}
+	group, err := scenario.FromConfig(settings, c.agents)
+	if err != nil {
+		return err
+	}
+	c.groups[name] = group
+
+	c.running.Add(1)
+	go func() {
+		defer c.running.Done()
+
+		if err := group.Run(ctx, behavior.DefaultTickInterval); err != nil {
+			log.Printf("Scenario %s stopped: %v\n", name, err)
+
+			return
+		}
+		log.Printf("Scenario %s finished\n", name)
+	}()
+
+	return nil
+}
+
+// Wait returns when all scenarios and walks which were run are finished. It
+// is called after context of scenarios and walks is done.
+func (c *Controller) Wait() {
+	// Scenario or walk which is starting now sees done context or is added
+	// before mutex is released.
+	c.mutex.Lock()
+	c.mutex.Unlock() //nolint:staticcheck
+
+	c.running.Wait()
+}
+
+// StopScenario aborts running scenario.
+func (c *Controller) StopScenario(name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, err := c.scenario(name); err != nil {
+		return err
+	}
+	group, ok := c.groups[name]
+	if !ok || !running(group) {
+		return fmt.Errorf("%w: %s", ErrScenarioIdle, name)
+	}
+	group.Abort()
+
+	return nil
+}
+
+// scenario returns scenario of config with name, mutex must be held.
+func (c *Controller) scenario(name string) (config.Scenario, error) {
+	for _, settings := range c.scenarios {
+		if settings.Name == name {
+			return settings, nil
+		}
+	}
+
+	var none config.Scenario
+
+	return none, fmt.Errorf("%w: %s", ErrUnknownScenario, name)
+}
+
+func running(group *scenario.Group) bool {
+	state := group.State()
+
+	return state == scenario.Active || state == scenario.Paused
+}
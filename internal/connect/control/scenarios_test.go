@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/scenario"
+	"github.com/stretchr/testify/require"
+)
+
+func farm(name string, roles map[string]string) config.Scenario {
+	return config.Scenario{
+		Name:  name,
+		Plan:  "farm",
+		Level: "independent",
+		Roles: roles,
+		Points: map[string]config.Point{
+			"gather": {X: 1, Y: 2, Z: 3},
+			"spot":   {X: 4, Y: 5, Z: 6},
+		},
+		Duration: config.Duration{Duration: 0},
+		Loop:     false,
+	}
+}
+
+// waitIdle waits until scenario stops running.
+func waitIdle(t *testing.T, c *Controller, name string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, status := range c.Scenarios() {
+			if status.Name == name && !status.Running {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("scenario %s is still running", name)
+}
+
+func TestController_Scenarios(t *testing.T) {
+	c := newController(t, []config.Scenario{
+		farm("elpies", map[string]string{"tank": "tank"}),
+		farm("remote", map[string]string{"other": "tank"}),
+	}, nil, "tank")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.Equal(t, []ScenarioStatus{
+		{Name: "elpies", Plan: "farm", Running: false, Progress: nil},
+		{Name: "remote", Plan: "farm", Running: false, Progress: nil},
+	}, c.Scenarios())
+
+	c.RunScenarios(ctx)
+	statuses := c.Scenarios()
+	require.True(t, statuses[0].Running)
+	require.Equal(t, []scenario.Progress{{Name: "tank", Role: "tank",
+		Phase: "gather", Round: 0, Waiting: false}}, statuses[0].Progress)
+	require.False(t, statuses[1].Running, "scenario without bots is skipped")
+
+	err := c.RunScenario(ctx, "elpies")
+	require.True(t, errors.Is(err, ErrScenarioRunning), err)
+	err = c.RunScenario(ctx, "dance")
+	require.True(t, errors.Is(err, ErrUnknownScenario), err)
+
+	require.NoError(t, c.StopScenario("elpies"))
+	waitIdle(t, c, "elpies")
+	err = c.StopScenario("elpies")
+	require.True(t, errors.Is(err, ErrScenarioIdle), err)
+	err = c.StopScenario("dance")
+	require.True(t, errors.Is(err, ErrUnknownScenario), err)
+
+	require.NoError(t, c.RunScenario(ctx, "elpies"), "stopped scenario runs again")
+
+	cancel()
+	c.Wait()
+	require.False(t, c.Scenarios()[0].Running, "scenarios end on shutdown")
+	err = c.RunScenario(ctx, "elpies")
+	require.True(t, errors.Is(err, context.Canceled), err)
+}
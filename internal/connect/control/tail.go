@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"slices"
+	"time"
+)
+
+// tailBuffer is how many packets tail keeps for slow reader.
+const tailBuffer = 64
+
+// Packet is game server packet received by bot.
+type Packet struct {
+	Bot  string
+	Time time.Time
+	ID   byte
+	Data []byte
+}
+
+// Tail returns channel of packets bot receives and function which stops
+// tail. Packets are dropped for reader which doesn't keep up, so bot never
+// waits for it.
+func (c *Controller) Tail(name string) (<-chan Packet, func(), error) {
+	a, err := c.agent(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	packets := make(chan Packet, tailBuffer)
+	remove := a.Dispatcher.AddTap(func(id byte, data []byte) {
+		select {
+		case packets <- Packet{
+			Bot:  name,
+			Time: a.World.Now(),
+			ID:   id,
+			Data: slices.Clone(data),
+		}:
+		default:
+		}
+	})
+
+	return packets, remove, nil
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_Tail(t *testing.T) {
+	c := newController(t, nil, nil, "tank")
+	dispatcher := c.agents["tank"].Dispatcher
+
+	packets, stop, err := c.Tail("tank")
+	require.NoError(t, err)
+	data := []byte{1, 2, 3}
+	require.NoError(t, dispatcher.Dispatch(0x99, data))
+	data[0] = 9
+
+	packet := <-packets
+	require.Equal(t, "tank", packet.Bot)
+	require.Equal(t, byte(0x99), packet.ID)
+	require.Equal(t, []byte{1, 2, 3}, packet.Data, "body is copied")
+
+	for range 2 * tailBuffer {
+		require.NoError(t, dispatcher.Dispatch(0x99, nil))
+	}
+	require.Len(t, packets, tailBuffer, "slow reader misses packets")
+
+	stop()
+	for range tailBuffer {
+		<-packets
+	}
+	require.NoError(t, dispatcher.Dispatch(0x99, nil))
+	require.Empty(t, packets)
+
+	_, _, err = c.Tail("dps")
+	require.True(t, errors.Is(err, bot.ErrUnknownBot), err)
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package crypt
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// GameCipher is xor cipher of game server connection. Each byte is mixed
+// with previous encrypted byte and key, after every packet first 4 bytes of
+// key are increased by packet size. Server and client directions use own
+// copies of key.
+type GameCipher struct {
+	key []byte
+}
+
+// C4 game server sends only first 4 bytes of key in KeyPacket, rest of key
+// is same for every connection.
+var gameKeySuffix = [4]byte{0xA1, 0x6C, 0x54, 0x87}
+
+func NewGameCipher(key []byte) (*GameCipher, error) {
+	if len(key) != 8 && len(key) != 16 {
+		return nil, errors.New("game key must be 8 or 16 bytes")
+	}
+
+	return &GameCipher{key: append([]byte{}, key...)}, nil
+}
+
+// NewGameCipherFromKeyPacket builds cipher from 4 key bytes of KeyPacket.
+func NewGameCipherFromKeyPacket(key [4]byte) *GameCipher {
+	full := make([]byte, 0, 8)
+	full = append(full, key[:]...)
+	full = append(full, gameKeySuffix[:]...)
+
+	return &GameCipher{key: full}
+}
+
+func (c *GameCipher) shiftKey(size int) {
+	old := binary.LittleEndian.Uint32(c.key[:4])
+	binary.LittleEndian.PutUint32(c.key[:4], old+uint32(size)) //nolint:gosec
+}
+
+func (c *GameCipher) DecryptInplace(data []byte) {
+	mask := len(c.key) - 1
+	previous := byte(0)
+	for i, encrypted := range data {
+		data[i] = encrypted ^ c.key[i&mask] ^ previous
+		previous = encrypted
+	}
+	c.shiftKey(len(data))
+}
+
+func (c *GameCipher) EncryptInplace(data []byte) {
+	mask := len(c.key) - 1
+	previous := byte(0)
+	for i, open := range data {
+		previous = open ^ c.key[i&mask] ^ previous
+		data[i] = previous
+	}
+	c.shiftKey(len(data))
+}
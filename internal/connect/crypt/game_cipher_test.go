@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package crypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameCipher_RoundTrip(t *testing.T) {
+	key := [4]byte{0x01, 0x02, 0x03, 0x04}
+	encryptor := NewGameCipherFromKeyPacket(key)
+	decryptor := NewGameCipherFromKeyPacket(key)
+
+	for _, message := range []string{"first packet", "second", "third one"} {
+		data := []byte(message)
+		encryptor.EncryptInplace(data)
+		require.NotEqual(t, []byte(message), data)
+
+		decryptor.DecryptInplace(data)
+		require.Equal(t, []byte(message), data)
+	}
+}
+
+func TestGameCipher_KeyShiftsBySize(t *testing.T) {
+	cipher, err := NewGameCipher([]byte{0xFE, 0, 0, 0, 0, 0, 0, 0})
+	require.NoError(t, err)
+
+	cipher.EncryptInplace(make([]byte, 3))
+	require.Equal(t, []byte{0x01, 0x01, 0, 0}, cipher.key[:4])
+}
+
+func TestGameCipher_KnownBytes(t *testing.T) {
+	cipher, err := NewGameCipher([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	require.NoError(t, err)
+
+	data := []byte{0x10, 0x20, 0x30}
+	cipher.EncryptInplace(data)
+	// 0x10^0x01=0x11, 0x20^0x02^0x11=0x33, 0x30^0x03^0x33=0x00
+	require.Equal(t, []byte{0x11, 0x33, 0x00}, data)
+}
+
+func TestNewGameCipher_InvalidKey(t *testing.T) {
+	_, err := NewGameCipher([]byte{1, 2, 3})
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package crypt
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// ModulusSize is size of RSA modulus of auth server in bytes.
+	ModulusSize = 128
+	rsaExponent = 65537
+	halfModulus = 0x40
+)
+
+// UnscrambleModulus restores RSA modulus of auth server, Init packet sends
+// it scrambled.
+func UnscrambleModulus(scrambled []byte) ([]byte, error) {
+	if len(scrambled) != ModulusSize {
+		return nil, fmt.Errorf("invalid modulus len: %d bytes, expected %d",
+			len(scrambled), ModulusSize)
+	}
+
+	modulus := make([]byte, ModulusSize)
+	copy(modulus, scrambled)
+	for i := range halfModulus {
+		modulus[halfModulus+i] ^= modulus[i]
+	}
+	for i := range 4 {
+		modulus[0x0d+i] ^= modulus[0x34+i]
+	}
+	for i := range halfModulus {
+		modulus[i] ^= modulus[halfModulus+i]
+	}
+	for i := range 4 {
+		modulus[i], modulus[0x4d+i] = modulus[0x4d+i], modulus[i]
+	}
+
+	return modulus, nil
+}
+
+// ScrambleModulus is reverse of UnscrambleModulus, it is how auth server
+// prepares modulus for Init packet.
+func ScrambleModulus(modulus []byte) ([]byte, error) {
+	if len(modulus) != ModulusSize {
+		return nil, fmt.Errorf("invalid modulus len: %d bytes, expected %d",
+			len(modulus), ModulusSize)
+	}
+
+	scrambled := make([]byte, ModulusSize)
+	copy(scrambled, modulus)
+	for i := range 4 {
+		scrambled[i], scrambled[0x4d+i] = scrambled[0x4d+i], scrambled[i]
+	}
+	for i := range halfModulus {
+		scrambled[i] ^= scrambled[halfModulus+i]
+	}
+	for i := range 4 {
+		scrambled[0x0d+i] ^= scrambled[0x34+i]
+	}
+	for i := range halfModulus {
+		scrambled[halfModulus+i] ^= scrambled[i]
+	}
+
+	return scrambled, nil
+}
+
+// EncryptRSA encrypts block with public key of auth server. Server expects
+// raw RSA without padding, so it is done by hand.
+func EncryptRSA(modulus, block []byte) ([]byte, error) {
+	if len(block) != len(modulus) {
+		return nil, fmt.Errorf("invalid block len: %d bytes, expected %d",
+			len(block), len(modulus))
+	}
+
+	n := new(big.Int).SetBytes(modulus)
+	m := new(big.Int).SetBytes(block)
+	if m.Cmp(n) >= 0 {
+		return nil, errors.New("block is too big for modulus")
+	}
+	c := new(big.Int).Exp(m, big.NewInt(rsaExponent), n)
+
+	return c.FillBytes(make([]byte, len(modulus))), nil
+}
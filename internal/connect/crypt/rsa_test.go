@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package crypt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrambleModulus_RoundTrip(t *testing.T) {
+	modulus := make([]byte, ModulusSize)
+	for i := range modulus {
+		modulus[i] = byte(i * 7)
+	}
+
+	scrambled, err := ScrambleModulus(modulus)
+	require.NoError(t, err)
+	require.NotEqual(t, modulus, scrambled)
+
+	restored, err := UnscrambleModulus(scrambled)
+	require.NoError(t, err)
+	require.Equal(t, modulus, restored)
+
+	_, err = UnscrambleModulus(modulus[:64])
+	require.Error(t, err)
+	_, err = ScrambleModulus(modulus[:64])
+	require.Error(t, err)
+}
+
+func TestEncryptRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, ModulusSize*8)
+	require.NoError(t, err)
+	modulus := key.N.FillBytes(make([]byte, ModulusSize))
+
+	block := make([]byte, ModulusSize)
+	copy(block[0x5e:], "user")
+	encrypted, err := EncryptRSA(modulus, block)
+	require.NoError(t, err)
+	require.Len(t, encrypted, ModulusSize)
+
+	c := new(big.Int).SetBytes(encrypted)
+	decrypted := new(big.Int).Exp(c, key.D, key.N)
+	require.Equal(t, block, decrypted.FillBytes(make([]byte, ModulusSize)))
+
+	_, err = EncryptRSA(modulus, block[:16])
+	require.Error(t, err)
+	tooBig := make([]byte, ModulusSize)
+	for i := range tooBig {
+		tooBig[i] = 0xff
+	}
+	_, err = EncryptRSA(modulus, tooBig)
+	require.Error(t, err)
+}
@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/control"
+)
+
+const (
+	// DefaultRefresh is how often status is checked for changes.
+	DefaultRefresh    = 500 * time.Millisecond
+	readHeaderTimeout = 10 * time.Second
+)
+
+//go:embed dashboard.html
+var page []byte
+
+// Controller is control API dashboard goes through, control.Controller is
+// one.
+type Controller interface {
+	Bots() []control.Status
+	Start(ctx context.Context, name string) error
+	Stop(name string) error
+	Restart(ctx context.Context, name string) error
+	Scenarios() []control.ScenarioStatus
+}
+
+// Bot is row of dashboard. Scenario and phase are empty for bot outside of
+// running scenarios.
+type Bot struct {
+	Name       string    `json:"name"`
+	State      string    `json:"state"`
+	Since      time.Time `json:"since"`
+	Reconnects int       `json:"reconnects"`
+	Error      string    `json:"error"`
+	Online     bool      `json:"online"`
+	Character  string    `json:"character"`
+	CurHP      int32     `json:"cur_hp"`
+	MaxHP      int32     `json:"max_hp"`
+	CurMP      int32     `json:"cur_mp"`
+	MaxMP      int32     `json:"max_mp"`
+	X          int32     `json:"x"`
+	Y          int32     `json:"y"`
+	Z          int32     `json:"z"`
+	Scenario   string    `json:"scenario"`
+	Phase      string    `json:"phase"`
+}
+
+// Snapshot returns rows of all bots in order they were added.
+func Snapshot(controller Controller) []Bot {
+	type place struct{ scenario, phase string }
+	places := make(map[string]place)
+	for _, status := range controller.Scenarios() {
+		if !status.Running {
+			continue
+		}
+		for _, progress := range status.Progress {
+			places[progress.Name] = place{status.Name, progress.Phase}
+		}
+	}
+
+	statuses := controller.Bots()
+	result := make([]Bot, 0, len(statuses))
+	for _, status := range statuses {
+		state, character := status.Bot, status.Character
+		row := Bot{
+			Name:       state.Name,
+			State:      state.State.String(),
+			Since:      state.Since,
+			Reconnects: state.Reconnects,
+			Error:      "",
+			Online:     character.Online,
+			Character:  character.Character,
+			CurHP:      character.CurHP,
+			MaxHP:      character.MaxHP,
+			CurMP:      character.CurMP,
+			MaxMP:      character.MaxMP,
+			X:          character.Position.X,
+			Y:          character.Position.Y,
+			Z:          character.Position.Z,
+			Scenario:   places[state.Name].scenario,
+			Phase:      places[state.Name].phase,
+		}
+		if state.Err != nil {
+			row.Error = state.Err.Error()
+		}
+		result = append(result, row)
+	}
+
+	return result
+}
+
+// Server serves web page with live status of bots and buttons to start and
+// stop them. Page is embedded in binary and gets status over websocket.
+type Server struct {
+	controller Controller
+	refresh    time.Duration
+}
+
+func New(controller Controller) *Server {
+	return &Server{controller: controller, refresh: DefaultRefresh}
+}
+
+// SetRefresh changes how often status is checked for changes.
+func (s *Server) SetRefresh(refresh time.Duration) {
+	s.refresh = refresh
+}
+
+// Handler returns routes of dashboard. Bots started from dashboard and
+// live updates run until context is done.
+func (s *Server) Handler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+	mux.HandleFunc("GET /bots", s.bots)
+	mux.HandleFunc("GET /live", func(w http.ResponseWriter, r *http.Request) {
+		s.live(ctx, w, r)
+	})
+	mux.HandleFunc("POST /bots/{name}/{action}",
+		func(w http.ResponseWriter, r *http.Request) {
+			s.act(ctx, w, r)
+		})
+
+	return mux
+}
+
+// Run serves dashboard on address until context is done.
+func (s *Server) Run(ctx context.Context, address string) error {
+	var config net.ListenConfig
+	listener, err := config.Listen(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{ //nolint:exhaustruct
+		Handler:           s.Handler(ctx),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = server.Close()
+	})
+	defer stop()
+
+	log.Printf("Dashboard is on http://%s\n", listener.Addr())
+	err = server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Server) bots(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Snapshot(s.controller))
+}
+
+// act starts, stops or restarts bot.
+func (s *Server) act(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if !sameOrigin(r) {
+		http.Error(w, "cross origin request", http.StatusForbidden)
+
+		return
+	}
+
+	name := r.PathValue("name")
+	var err error
+	switch r.PathValue("action") {
+	case "start":
+		err = s.controller.Start(ctx, name)
+	case "stop":
+		err = s.controller.Stop(name)
+	case "restart":
+		err = s.controller.Restart(ctx, name)
+	default:
+		http.NotFound(w, r)
+
+		return
+	}
+
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, bot.ErrUnknownBot):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, bot.ErrAlreadyRunning),
+		errors.Is(err, bot.ErrNotRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// live sends status over websocket at once and after each change.
+func (s *Server) live(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if !sameOrigin(r) {
+		http.Error(w, "cross origin request", http.StatusForbidden)
+
+		return
+	}
+	conn, err := Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(s.refresh)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		data, err := json.Marshal(Snapshot(s.controller))
+		if err != nil {
+			log.Printf("Error encoding dashboard status: %v\n", err)
+
+			return
+		}
+		if !bytes.Equal(data, last) {
+			if err := conn.WriteText(data); err != nil {
+				return
+			}
+			last = data
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sameOrigin reports if request comes from page of dashboard or from
+// tool without origin, so other sites can't control bots from browser.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+
+	return err == nil && parsed.Host == r.Host
+}
@@ -0,0 +1,325 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/bot"
+	"github.com/melg8/connect/internal/connect/control"
+	"github.com/melg8/connect/internal/connect/scenario"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Controller = (*control.Controller)(nil)
+
+// controller has tank in world and healer which lost connection.
+type controller struct {
+	mutex sync.Mutex
+	hp    int32
+	calls []string
+}
+
+func (c *controller) Bots() []control.Status {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return []control.Status{
+		{
+			Bot: bot.Status{
+				Name:       "tank",
+				State:      bot.InWorld,
+				Since:      time.Unix(1000, 0).UTC(),
+				Err:        nil,
+				Reconnects: 0,
+			},
+			Character: board.State{ //nolint:exhaustruct
+				Name:      "tank",
+				Character: "Tank",
+				Online:    true,
+				Position:  world.Position{X: 1, Y: 2, Z: 3},
+				CurHP:     c.hp,
+				MaxHP:     100,
+				CurMP:     20,
+				MaxMP:     40,
+			},
+		},
+		{
+			Bot: bot.Status{
+				Name:       "healer",
+				State:      bot.Disconnected,
+				Since:      time.Unix(2000, 0).UTC(),
+				Err:        errors.New("connection reset"),
+				Reconnects: 3,
+			},
+			Character: board.State{Name: "healer"}, //nolint:exhaustruct
+		},
+	}
+}
+
+func (c *controller) call(action, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.calls = append(c.calls, action+" "+name)
+	switch {
+	case name != "tank" && name != "healer":
+		return fmt.Errorf("%w: %s", bot.ErrUnknownBot, name)
+	case action == "start" && name == "tank":
+		return fmt.Errorf("%w: %s", bot.ErrAlreadyRunning, name)
+	default:
+		return nil
+	}
+}
+
+func (c *controller) Start(_ context.Context, name string) error {
+	return c.call("start", name)
+}
+
+func (c *controller) Stop(name string) error {
+	return c.call("stop", name)
+}
+
+func (c *controller) Restart(_ context.Context, name string) error {
+	return c.call("restart", name)
+}
+
+func (c *controller) Scenarios() []control.ScenarioStatus {
+	return []control.ScenarioStatus{
+		{Name: "farm", Plan: "farm", Running: true, Progress: []scenario.Progress{
+			{Name: "tank", Role: "tank", Phase: "gather", Round: 0,
+				Waiting: false},
+		}},
+		{Name: "old", Plan: "farm", Running: false, Progress: []scenario.Progress{
+			{Name: "healer", Role: "healer", Phase: "return", Round: 0,
+				Waiting: false},
+		}},
+	}
+}
+
+func newServer(t *testing.T) (*controller, *httptest.Server) {
+	t.Helper()
+
+	fake := &controller{mutex: sync.Mutex{}, hp: 50, calls: nil}
+	s := New(fake)
+	s.SetRefresh(time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(s.Handler(ctx))
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+
+	return fake, server
+}
+
+func wantBots() []Bot {
+	return []Bot{
+		{
+			Name:       "tank",
+			State:      "InWorld",
+			Since:      time.Unix(1000, 0).UTC(),
+			Reconnects: 0,
+			Error:      "",
+			Online:     true,
+			Character:  "Tank",
+			CurHP:      50,
+			MaxHP:      100,
+			CurMP:      20,
+			MaxMP:      40,
+			X:          1,
+			Y:          2,
+			Z:          3,
+			Scenario:   "farm",
+			Phase:      "gather",
+		},
+		{
+			Name:       "healer",
+			State:      "Disconnected",
+			Since:      time.Unix(2000, 0).UTC(),
+			Reconnects: 3,
+			Error:      "connection reset",
+			Online:     false,
+			Character:  "",
+			CurHP:      0,
+			MaxHP:      0,
+			CurMP:      0,
+			MaxMP:      0,
+			X:          0,
+			Y:          0,
+			Z:          0,
+			Scenario:   "",
+			Phase:      "",
+		},
+	}
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, url, nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return response.StatusCode, string(body)
+}
+
+func TestSnapshot(t *testing.T) {
+	fake := &controller{mutex: sync.Mutex{}, hp: 50, calls: nil}
+	require.Equal(t, wantBots(), Snapshot(fake))
+}
+
+func TestServer_Page(t *testing.T) {
+	_, server := newServer(t)
+
+	status, body := get(t, server.URL+"/")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, "<table>")
+
+	status, _ = get(t, server.URL+"/missing")
+	require.Equal(t, http.StatusNotFound, status)
+
+	status, body = get(t, server.URL+"/bots")
+	require.Equal(t, http.StatusOK, status)
+	var bots []Bot
+	require.NoError(t, json.Unmarshal([]byte(body), &bots))
+	require.Equal(t, wantBots(), bots)
+}
+
+func TestServer_Act(t *testing.T) {
+	tests := []struct {
+		path   string
+		origin string
+		status int
+		call   string
+	}{
+		{path: "/bots/healer/start", origin: "", status: http.StatusNoContent,
+			call: "start healer"},
+		{path: "/bots/tank/stop", origin: "", status: http.StatusNoContent,
+			call: "stop tank"},
+		{path: "/bots/tank/restart", origin: "", status: http.StatusNoContent,
+			call: "restart tank"},
+		{path: "/bots/tank/start", origin: "", status: http.StatusConflict,
+			call: "start tank"},
+		{path: "/bots/ghost/stop", origin: "", status: http.StatusNotFound,
+			call: "stop ghost"},
+		{path: "/bots/tank/dance", origin: "", status: http.StatusNotFound,
+			call: ""},
+		{path: "/bots/tank/stop", origin: "http://evil.example",
+			status: http.StatusForbidden, call: ""},
+		{path: "/bots/tank/stop", origin: "same", status: http.StatusNoContent,
+			call: "stop tank"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path+" "+test.origin, func(t *testing.T) {
+			fake, server := newServer(t)
+			request, err := http.NewRequestWithContext(context.Background(),
+				http.MethodPost, server.URL+test.path, nil)
+			require.NoError(t, err)
+			if test.origin == "same" {
+				test.origin = server.URL
+			}
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			require.Equal(t, test.status, response.StatusCode)
+			if test.call == "" {
+				require.Empty(t, fake.calls)
+			} else {
+				require.Equal(t, []string{test.call}, fake.calls)
+			}
+		})
+	}
+}
+
+func TestServer_Live(t *testing.T) {
+	fake, server := newServer(t)
+	c := dial(t, server, "/live")
+
+	var bots []Bot
+	require.NoError(t, json.Unmarshal(c.receive().payload, &bots))
+	require.Equal(t, wantBots(), bots)
+
+	fake.mutex.Lock()
+	fake.hp = 10
+	fake.mutex.Unlock()
+	require.NoError(t, json.Unmarshal(c.receive().payload, &bots))
+	require.Equal(t, int32(10), bots[0].CurHP, "change is sent")
+
+	c.send(opClose, "")
+	require.Equal(t, byte(opClose), c.receive().opcode)
+}
+
+func TestServer_LiveCrossOrigin(t *testing.T) {
+	_, server := newServer(t)
+	request, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, server.URL+"/live", nil)
+	require.NoError(t, err)
+	request.Header.Set("Origin", "http://evil.example")
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestServer_Run(t *testing.T) {
+	var config net.ListenConfig
+	listener, err := config.Listen(context.Background(), "tcp", "localhost:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fake := &controller{mutex: sync.Mutex{}, hp: 50, calls: nil}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- New(fake).Run(ctx, address)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		request, err := http.NewRequestWithContext(context.Background(),
+			http.MethodGet, "http://"+address+"/bots", nil)
+		require.NoError(t, err)
+		response, err := http.DefaultClient.Do(request)
+		if err == nil {
+			require.NoError(t, response.Body.Close())
+
+			break
+		}
+		require.True(t, time.Now().Before(deadline), err)
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	require.NoError(t, <-errs, "shutdown isn't error")
+
+	err = New(fake).Run(context.Background(), "localhost")
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "port"), err)
+}
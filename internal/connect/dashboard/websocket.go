@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dashboard
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// websocketGUID is appended to key of client to make accept key, see
+	// RFC 6455.
+	websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	// maxMessage is biggest message accepted from browser, dashboard only
+	// needs control frames from it.
+	maxMessage   = 64 * 1024
+	writeTimeout = 10 * time.Second
+)
+
+// Opcodes of websocket frames.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+var (
+	ErrNotWebSocket = errors.New("request is not websocket upgrade")
+	ErrUnmasked     = errors.New("frame of client isn't masked")
+	ErrTooBig       = errors.New("websocket message is too big")
+)
+
+// Conn is server side of websocket connection. Writes may be done from
+// several goroutines, reads from one.
+type Conn struct {
+	// mutex orders writes of frames.
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Upgrade turns http request into websocket connection. Bad request is
+// answered with error status.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	key := r.Header.Get("Sec-Websocket-Key")
+	if !headerHas(r.Header, "Connection", "upgrade") ||
+		!headerHas(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-Websocket-Version") != "13" || key == "" {
+		http.Error(w, ErrNotWebSocket.Error(), http.StatusBadRequest)
+
+		return nil, ErrNotWebSocket
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection can't be upgraded",
+			http.StatusInternalServerError)
+
+		return nil, ErrNotWebSocket
+	}
+
+	conn, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take connection: %w", err)
+	}
+	fmt.Fprintf(buffer, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", acceptKey(key))
+	if err := buffer.Flush(); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to answer handshake: %w", err)
+	}
+
+	return &Conn{mutex: sync.Mutex{}, conn: conn, reader: buffer.Reader}, nil
+}
+
+// headerHas reports if comma separated header contains token.
+func headerHas(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// acceptKey returns answer to key of client.
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WriteText sends text message.
+func (c *Conn) WriteText(data []byte) error {
+	return c.write(opText, data)
+}
+
+func (c *Conn) write(opcode byte, payload []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return writeFrame(c.conn, opcode, payload, nil)
+}
+
+// ReadMessage returns next data message. Pings are answered, close of
+// client is answered and returned as io.EOF.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		f, err := readFrame(c.reader)
+		if err != nil {
+			return nil, err
+		}
+		if !f.masked {
+			return nil, ErrUnmasked
+		}
+
+		switch f.opcode {
+		case opPing:
+			if err := c.write(opPong, f.payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			_ = c.write(opClose, f.payload[:min(len(f.payload), 2)])
+
+			return nil, io.EOF
+		default:
+			message = append(message, f.payload...)
+			if len(message) > maxMessage {
+				return nil, ErrTooBig
+			}
+			if f.final {
+				return message, nil
+			}
+		}
+	}
+}
+
+// Close sends close frame and closes connection.
+func (c *Conn) Close() error {
+	_ = c.write(opClose, []byte{0x03, 0xe8})
+
+	return c.conn.Close()
+}
+
+// frame is single websocket frame with unmasked payload.
+type frame struct {
+	final   bool
+	opcode  byte
+	masked  bool
+	payload []byte
+}
+
+// writeFrame writes final frame, payload is masked with mask of four bytes
+// when it is given. Only clients mask frames.
+func writeFrame(w io.Writer, opcode byte, payload, mask []byte) error {
+	header := []byte{0x80 | opcode, 0}
+	size := len(payload)
+	switch {
+	case size < 126:
+		header[1] = byte(size)
+	case size <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header,
+			uint16(size)) //nolint:gosec
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header,
+			uint64(size)) //nolint:gosec
+	}
+
+	if mask != nil {
+		header[1] |= 0x80
+		header = append(header, mask...)
+		masked := make([]byte, size)
+		for i, b := range payload {
+			masked[i] = b ^ mask[i%4]
+		}
+		payload = masked
+	}
+
+	_, err := w.Write(append(header, payload...))
+
+	return err
+}
+
+// readFrame reads single frame and unmasks its payload.
+func readFrame(r io.Reader) (frame, error) {
+	var none frame
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return none, err
+	}
+
+	result := frame{
+		final:   header[0]&0x80 != 0,
+		opcode:  header[0] & 0x0f,
+		masked:  header[1]&0x80 != 0,
+		payload: nil,
+	}
+	size := uint64(header[1] & 0x7f)
+	switch size {
+	case 126:
+		var extended uint16
+		if err := binary.Read(r, binary.BigEndian, &extended); err != nil {
+			return none, err
+		}
+		size = uint64(extended)
+	case 127:
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return none, err
+		}
+	}
+	if size > maxMessage {
+		return none, ErrTooBig
+	}
+
+	mask := make([]byte, 4)
+	if result.masked {
+		if _, err := io.ReadFull(r, mask); err != nil {
+			return none, err
+		}
+	}
+	result.payload = make([]byte, size)
+	if _, err := io.ReadFull(r, result.payload); err != nil {
+		return none, err
+	}
+	for i := range result.payload {
+		result.payload[i] ^= mask[i%4]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dashboard
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testMask = []byte{1, 2, 3, 4}
+
+// client is browser side of websocket connection.
+type client struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, server *httptest.Server, path string) *client {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	request, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	request.Header.Set("Connection", "keep-alive, Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	require.NoError(t, request.Write(conn))
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=",
+		response.Header.Get("Sec-WebSocket-Accept"))
+
+	return &client{t: t, conn: conn, reader: reader}
+}
+
+func (c *client) send(opcode byte, payload string) {
+	c.t.Helper()
+
+	require.NoError(c.t, writeFrame(c.conn, opcode, []byte(payload), testMask))
+}
+
+func (c *client) receive() frame {
+	c.t.Helper()
+
+	f, err := readFrame(c.reader)
+	require.NoError(c.t, err)
+	require.False(c.t, f.masked, "server doesn't mask frames")
+
+	return f
+}
+
+func TestAcceptKey(t *testing.T) {
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=",
+		acceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestUpgrade_NotWebSocket(t *testing.T) {
+	var err error
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err = Upgrade(w, r)
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.True(t, errors.Is(err, ErrNotWebSocket), err)
+}
+
+func TestConn(t *testing.T) {
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn, err := Upgrade(w, r)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+			defer conn.Close()
+
+			for {
+				message, err := conn.ReadMessage()
+				if err != nil {
+					errs <- err
+
+					return
+				}
+				if err := conn.WriteText(message); err != nil {
+					errs <- err
+
+					return
+				}
+			}
+		}))
+	defer server.Close()
+
+	c := dial(t, server, "/")
+	c.send(opText, "hello")
+	f := c.receive()
+	require.Equal(t, byte(opText), f.opcode)
+	require.Equal(t, "hello", string(f.payload))
+
+	c.send(opPing, "beat")
+	f = c.receive()
+	require.Equal(t, byte(opPong), f.opcode)
+	require.Equal(t, "beat", string(f.payload))
+
+	// Text frame which isn't final and its continuation.
+	_, err := c.conn.Write([]byte{opText, 0x80 | 2, 1, 2, 3, 4, 'h' ^ 1, 'i' ^ 2})
+	require.NoError(t, err)
+	c.send(0x0, "!")
+	f = c.receive()
+	require.Equal(t, "hi!", string(f.payload), "fragments are joined")
+
+	c.send(opClose, "\x03\xe8")
+	f = c.receive()
+	require.Equal(t, byte(opClose), f.opcode)
+	require.True(t, errors.Is(<-errs, io.EOF))
+}
+
+func TestConn_Unmasked(t *testing.T) {
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn, err := Upgrade(w, r)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+			defer conn.Close()
+
+			_, err = conn.ReadMessage()
+			errs <- err
+		}))
+	defer server.Close()
+
+	c := dial(t, server, "/")
+	require.NoError(t, writeFrame(c.conn, opText, []byte("hi"), nil))
+	require.True(t, errors.Is(<-errs, ErrUnmasked))
+}
+
+func TestFrame(t *testing.T) {
+	for _, size := range []int{0, 125, 126, 0xffff, 0x10000} {
+		for _, mask := range [][]byte{nil, testMask} {
+			payload := bytes.Repeat([]byte{'x'}, size)
+			var buffer bytes.Buffer
+			require.NoError(t, writeFrame(&buffer, opText, payload, mask))
+
+			f, err := readFrame(&buffer)
+			require.NoError(t, err)
+			require.Equal(t, frame{
+				final:   true,
+				opcode:  opText,
+				masked:  mask != nil,
+				payload: payload,
+			}, f)
+		}
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, writeFrame(&buffer, opText,
+		[]byte(strings.Repeat("x", maxMessage+1)), nil))
+	_, err := readFrame(&buffer)
+	require.True(t, errors.Is(err, ErrTooBig))
+}
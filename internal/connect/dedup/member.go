@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Member is bot which receives entity packets through stage. It is
+// world.View which combines own character of bot with shared world.
+type Member struct {
+	*world.Overlay
+
+	world *world.World
+	stage *Stage
+}
+
+// Keep is dispatch.Filter which takes entity packets about other objects
+// from bot. They are decoded by stage once for all bots.
+func (m *Member) Keep(id byte, data []byte) bool {
+	objectID, shared := world.EntityObjectID(id, data)
+	if !shared || objectID == m.world.SelfID() {
+		return true
+	}
+	m.stage.process(m, id, data, objectID)
+
+	return false
+}
+
+// Leave forgets objects seen by bot, it is called when bot leaves world.
+func (m *Member) Leave() {
+	m.stage.leave(m)
+}
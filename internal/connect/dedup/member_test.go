@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"testing"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMember_Keep(t *testing.T) {
+	stage, _ := newTestStage()
+	bot := newTestBot(t, stage, 1)
+
+	tests := []struct {
+		name string
+		id   byte
+		data []byte
+		keep bool
+	}{
+		{
+			name: "not entity packet",
+			id:   fromgameserver.StatusUpdateID,
+			data: []byte{0x05, 0, 0, 0, 0, 0, 0, 0},
+			keep: true,
+		},
+		{
+			name: "own character",
+			id:   fromgameserver.MoveToLocationID,
+			data: []byte{0x01, 0, 0, 0, 0, 0, 0, 0},
+			keep: true,
+		},
+		{
+			name: "other creature",
+			id:   fromgameserver.MoveToLocationID,
+			data: encode(t, moveNpc(5)),
+			keep: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.keep, bot.member.Keep(test.id, test.data))
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"hash/fnv"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// DefaultWindow is long enough for copies of one update to reach all bots
+// of process and short enough not to hide real repeated updates.
+const DefaultWindow = 250 * time.Millisecond
+
+const packetIDs = 256
+
+type key struct {
+	id       byte
+	objectID int32
+	hash     uint64
+}
+
+type counters struct {
+	processed  atomic.Uint64
+	suppressed atomic.Uint64
+}
+
+// Stage drops copies of entity packets received by several bots of process
+// within time window. First copy is decoded into shared world model, bots
+// see other objects through it.
+//
+// Objects stay in shared world while at least one bot sees them: DeleteObject
+// only means object left view of bot which received it.
+type Stage struct {
+	window     time.Duration
+	now        func() time.Time
+	world      *world.World
+	dispatcher *dispatch.Dispatcher
+
+	mutex     sync.Mutex
+	seen      map[key]time.Time
+	viewers   map[int32]map[*Member]struct{}
+	lastSweep time.Time
+
+	counters [packetIDs]counters
+}
+
+func New(window time.Duration) *Stage {
+	shared := world.New()
+	dispatcher := dispatch.NewDispatcher()
+	shared.Register(dispatcher)
+
+	return &Stage{
+		window:     window,
+		now:        time.Now,
+		world:      shared,
+		dispatcher: dispatcher,
+		mutex:      sync.Mutex{},
+		seen:       make(map[key]time.Time),
+		viewers:    make(map[int32]map[*Member]struct{}),
+		lastSweep:  time.Time{},
+		counters:   [packetIDs]counters{},
+	}
+}
+
+// World returns shared world model with objects seen by any bot.
+func (s *Stage) World() *world.World {
+	return s.world
+}
+
+// Join adds bot with its own world model to stage.
+func (s *Stage) Join(model *world.World) *Member {
+	member := &Member{
+		Overlay: world.NewOverlay(model, s.World),
+		world:   model,
+		stage:   s,
+	}
+
+	return member
+}
+
+// process passes packet to shared world unless copy of it was processed
+// within window. Stage is locked only to look up and update its records,
+// packets are hashed and decoded without lock, so bots don't wait for each
+// other.
+func (s *Stage) process(member *Member, id byte, data []byte, objectID int32) {
+	if id == fromgameserver.DeleteObjectID {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.forget(member, objectID, data)
+
+		return
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+	packetKey := key{id: id, objectID: objectID, hash: hash.Sum64()}
+	if !s.first(member, packetKey) {
+		s.counters[id].suppressed.Add(1)
+
+		return
+	}
+
+	s.apply(id, data)
+}
+
+// first records that member sees object of packet and reports if packet is
+// first copy within window.
+func (s *Stage) first(member *Member, packetKey key) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.watch(member, packetKey.objectID)
+
+	now := s.now()
+	s.sweep(now)
+	if seen, ok := s.seen[packetKey]; ok && now.Sub(seen) < s.window {
+		return false
+	}
+	s.seen[packetKey] = now
+
+	return true
+}
+
+func (s *Stage) apply(id byte, data []byte) {
+	s.counters[id].processed.Add(1)
+	if err := s.dispatcher.Dispatch(id, data); err != nil {
+		log.Printf("Error handling shared packet: %v\n", err)
+	}
+}
+
+func (s *Stage) watch(member *Member, objectID int32) {
+	viewers, ok := s.viewers[objectID]
+	if !ok {
+		viewers = make(map[*Member]struct{})
+		s.viewers[objectID] = viewers
+	}
+	viewers[member] = struct{}{}
+}
+
+// forget removes bot from viewers of object, object is deleted from shared
+// world when nobody sees it. It is called under lock, so deletion isn't
+// applied after other bot started to watch object.
+func (s *Stage) forget(member *Member, objectID int32, data []byte) {
+	viewers := s.viewers[objectID]
+	delete(viewers, member)
+	if len(viewers) > 0 {
+		s.counters[fromgameserver.DeleteObjectID].suppressed.Add(1)
+
+		return
+	}
+
+	delete(s.viewers, objectID)
+	s.apply(fromgameserver.DeleteObjectID, data)
+}
+
+// sweep forgets expired keys, at most once per window.
+func (s *Stage) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.window {
+		return
+	}
+	s.lastSweep = now
+
+	for packetKey, seen := range s.seen {
+		if now.Sub(seen) >= s.window {
+			delete(s.seen, packetKey)
+		}
+	}
+}
+
+// leave forgets all objects seen by bot.
+func (s *Stage) leave(member *Member) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for objectID, viewers := range s.viewers {
+		if _, ok := viewers[member]; !ok {
+			continue
+		}
+		delete(viewers, member)
+		if len(viewers) == 0 {
+			delete(s.viewers, objectID)
+			s.world.Remove(objectID)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const npcID = 1000
+
+type testClock struct {
+	time time.Time
+}
+
+func (c *testClock) now() time.Time {
+	return c.time
+}
+
+type testBot struct {
+	tb         testing.TB
+	id         int32
+	member     *Member
+	dispatcher *dispatch.Dispatcher
+}
+
+func newTestStage() (*Stage, *testClock) {
+	clock := &testClock{time: time.Unix(1000, 0)}
+	stage := New(DefaultWindow)
+	stage.now = clock.now
+
+	return stage, clock
+}
+
+func newTestBot(tb testing.TB, stage *Stage, id int32) *testBot {
+	tb.Helper()
+
+	model := world.New()
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	member := stage.Join(model)
+	dispatcher.AddFilter(member.Keep)
+
+	bot := &testBot{tb: tb, id: id, member: member, dispatcher: dispatcher}
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = id
+	bot.feed(fromgameserver.UserInfoID, info)
+
+	return bot
+}
+
+func encode(tb testing.TB, p crypt.Serializable) []byte {
+	tb.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(tb, p.ToBytes(writer))
+
+	return writer.Bytes()
+}
+
+func (b *testBot) feed(id byte, p crypt.Serializable) {
+	b.tb.Helper()
+
+	require.NoError(b.tb, b.dispatcher.Dispatch(id, encode(b.tb, p)))
+}
+
+func npcInfo(x int32) *fromgameserver.NpcInfo {
+	info := &fromgameserver.NpcInfo{} //nolint:exhaustruct
+	info.ObjectID = npcID
+	info.X = x
+	info.RunSpeed = 100
+
+	return info
+}
+
+func moveNpc(x int32) *fromgameserver.MoveToLocation {
+	return &fromgameserver.MoveToLocation{
+		ObjectID: npcID,
+		DestX:    x,
+		DestY:    0,
+		DestZ:    0,
+		X:        0,
+		Y:        0,
+		Z:        0,
+	}
+}
+
+func deleteNpc() *fromgameserver.DeleteObject {
+	return &fromgameserver.DeleteObject{ObjectID: npcID}
+}
+
+func TestStage_SuppressesCopies(t *testing.T) {
+	stage, clock := newTestStage()
+	bots := []*testBot{
+		newTestBot(t, stage, 1),
+		newTestBot(t, stage, 2),
+		newTestBot(t, stage, 3),
+	}
+
+	for _, bot := range bots {
+		bot.feed(fromgameserver.NpcInfoID, npcInfo(10))
+	}
+	for _, bot := range bots {
+		_, decoded := bot.member.world.Npc(npcID)
+		require.False(t, decoded, "bots don't decode shared packets")
+
+		entity, ok := bot.member.ByObjectID(npcID)
+		require.True(t, ok)
+		require.Equal(t, int32(10), entity.At(clock.now()).X)
+	}
+
+	stats := stage.Stats()
+	require.Equal(t, uint64(1), stats.Processed)
+	require.Equal(t, uint64(2), stats.Suppressed)
+
+	// Same update after window is real update.
+	clock.time = clock.time.Add(DefaultWindow)
+	bots[0].feed(fromgameserver.NpcInfoID, npcInfo(10))
+	require.Equal(t, uint64(2), stage.Stats().Processed)
+
+	// Different payload is never suppressed.
+	bots[1].feed(fromgameserver.MoveToLocationID, moveNpc(500))
+	bots[2].feed(fromgameserver.MoveToLocationID, moveNpc(600))
+	require.Equal(t, uint64(4), stage.Stats().Processed)
+}
+
+func TestStage_OwnPacketsAreNotShared(t *testing.T) {
+	stage, _ := newTestStage()
+	bot := newTestBot(t, stage, 1)
+
+	bot.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: 1,
+		X:        70,
+		Y:        0,
+		Z:        0,
+		Heading:  0,
+	})
+
+	self, ok := bot.member.Self()
+	require.True(t, ok)
+	require.Equal(t, int32(70), self.Position.X)
+	require.Equal(t, uint64(0), stage.Stats().Total())
+}
+
+func TestStage_DeleteWhenNobodySees(t *testing.T) {
+	stage, clock := newTestStage()
+	first := newTestBot(t, stage, 1)
+	second := newTestBot(t, stage, 2)
+	first.feed(fromgameserver.NpcInfoID, npcInfo(10))
+	second.feed(fromgameserver.NpcInfoID, npcInfo(10))
+
+	first.feed(fromgameserver.DeleteObjectID, deleteNpc())
+	_, ok := stage.World().ByObjectID(npcID)
+	require.True(t, ok, "second bot still sees npc")
+
+	clock.time = clock.time.Add(time.Second)
+	second.feed(fromgameserver.DeleteObjectID, deleteNpc())
+	_, ok = stage.World().ByObjectID(npcID)
+	require.False(t, ok)
+
+	counters := stage.Stats().ByPacket[fromgameserver.DeleteObjectID]
+	require.Equal(t, Counters{Processed: 1, Suppressed: 1}, counters)
+}
+
+func TestStage_Leave(t *testing.T) {
+	stage, _ := newTestStage()
+	first := newTestBot(t, stage, 1)
+	second := newTestBot(t, stage, 2)
+	first.feed(fromgameserver.NpcInfoID, npcInfo(10))
+	second.feed(fromgameserver.NpcInfoID, npcInfo(10))
+
+	first.member.Leave()
+	_, ok := stage.World().ByObjectID(npcID)
+	require.True(t, ok)
+
+	second.member.Leave()
+	_, ok = stage.World().ByObjectID(npcID)
+	require.False(t, ok)
+}
+
+func TestStage_SweepsExpiredKeys(t *testing.T) {
+	stage, clock := newTestStage()
+	bot := newTestBot(t, stage, 1)
+	for x := range int32(10) {
+		bot.feed(fromgameserver.MoveToLocationID, moveNpc(x))
+	}
+	require.Len(t, stage.seen, 10)
+
+	clock.time = clock.time.Add(DefaultWindow)
+	bot.feed(fromgameserver.MoveToLocationID, moveNpc(100))
+	require.Len(t, stage.seen, 1)
+}
+
+func TestStage_DecodesWithoutLock(t *testing.T) {
+	stage, _ := newTestStage()
+	first := newTestBot(t, stage, 1)
+	second := newTestBot(t, stage, 2)
+	decoding := make(chan struct{})
+	release := make(chan struct{})
+	stage.dispatcher.Handle(fromgameserver.NpcInfoID, func([]byte) error {
+		close(decoding)
+		<-release
+
+		return nil
+	})
+
+	data := encode(t, npcInfo(10))
+	done := make(chan error)
+	go func() {
+		done <- first.dispatcher.Dispatch(fromgameserver.NpcInfoID, data)
+	}()
+	<-decoding
+
+	// Other bot isn't blocked while first packet is decoded.
+	second.feed(fromgameserver.MoveToLocationID, moveNpc(500))
+	require.Equal(t, uint64(2), stage.Stats().Processed)
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestStage_BrokenSharedPacket(t *testing.T) {
+	stage, _ := newTestStage()
+	bot := newTestBot(t, stage, 1)
+
+	// Stage logs error of shared world, bot itself isn't affected.
+	require.NoError(t, bot.dispatcher.Dispatch(fromgameserver.NpcInfoID,
+		[]byte{0x02, 0x00, 0x00, 0x00}))
+	require.Equal(t, uint64(1), stage.Stats().Processed)
+}
+
+// BenchmarkSiege compares decoding of packets about same creatures received
+// by every bot of siege with and without stage.
+func BenchmarkSiege(b *testing.B) {
+	const bots = 36
+	const creatures = 100
+
+	moves := make([][]byte, 0, creatures)
+	for i := range int32(creatures) {
+		move := moveNpc(i)
+		move.ObjectID = npcID + i
+		moves = append(moves, encode(b, move))
+	}
+	infos := make([]*fromgameserver.NpcInfo, 0, creatures)
+	for i := range int32(creatures) {
+		info := npcInfo(i)
+		info.ObjectID = npcID + i
+		infos = append(infos, info)
+	}
+
+	run := func(b *testing.B, withStage bool) {
+		b.Helper()
+
+		stage := New(DefaultWindow)
+		group := make([]*testBot, 0, bots)
+		for i := range int32(bots) {
+			bot := &testBot{tb: b, id: i + 1, member: nil, dispatcher: nil}
+			model := world.New()
+			bot.dispatcher = dispatch.NewDispatcher()
+			model.Register(bot.dispatcher)
+			if withStage {
+				bot.member = stage.Join(model)
+				bot.dispatcher.AddFilter(bot.member.Keep)
+			}
+			for _, info := range infos {
+				bot.feed(fromgameserver.NpcInfoID, info)
+			}
+			group = append(group, bot)
+		}
+
+		b.ResetTimer()
+		for range b.N {
+			for _, move := range moves {
+				for _, bot := range group {
+					_ = bot.dispatcher.Dispatch(
+						fromgameserver.MoveToLocationID, move)
+				}
+			}
+		}
+	}
+
+	for _, withStage := range []bool{false, true} {
+		b.Run(fmt.Sprintf("stage=%v", withStage), func(b *testing.B) {
+			run(b, withStage)
+		})
+	}
+}
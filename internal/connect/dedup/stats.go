@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Counters tells how many entity packets were decoded and how many copies
+// were dropped without decoding.
+type Counters struct {
+	Processed  uint64
+	Suppressed uint64
+}
+
+// Total returns number of packets passed through stage.
+func (c Counters) Total() uint64 {
+	return c.Processed + c.Suppressed
+}
+
+// SuppressedRatio returns part of packets which weren't decoded.
+func (c Counters) SuppressedRatio() float64 {
+	if c.Total() == 0 {
+		return 0
+	}
+
+	return float64(c.Suppressed) / float64(c.Total())
+}
+
+func (c Counters) add(other Counters) Counters {
+	return Counters{
+		Processed:  c.Processed + other.Processed,
+		Suppressed: c.Suppressed + other.Suppressed,
+	}
+}
+
+// Stats is state of counters of stage at some moment.
+type Stats struct {
+	Counters
+
+	ByPacket map[byte]Counters
+}
+
+// Stats returns counters of stage in total and for every packet id seen.
+func (s *Stage) Stats() Stats {
+	stats := Stats{
+		Counters: Counters{Processed: 0, Suppressed: 0},
+		ByPacket: make(map[byte]Counters),
+	}
+	for i := range s.counters {
+		counters := Counters{
+			Processed:  s.counters[i].processed.Load(),
+			Suppressed: s.counters[i].suppressed.Load(),
+		}
+		if counters.Total() == 0 {
+			continue
+		}
+		stats.ByPacket[byte(i)] = counters
+		stats.Counters = stats.add(counters)
+	}
+
+	return stats
+}
+
+func (s Stats) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("processed %d, suppressed %d (%.1f%%)",
+		s.Processed, s.Suppressed, s.SuppressedRatio()*100))
+
+	ids := make([]int, 0, len(s.ByPacket))
+	for id := range s.ByPacket {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		counters := s.ByPacket[byte(id)]
+		sb.WriteString(fmt.Sprintf("; %#02x: %d/%d",
+			id, counters.Processed, counters.Suppressed))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounters(t *testing.T) {
+	require.InDelta(t, 0, Counters{}.SuppressedRatio(), 0) //nolint:exhaustruct
+
+	counters := Counters{Processed: 1, Suppressed: 3}
+	require.Equal(t, uint64(4), counters.Total())
+	require.InDelta(t, 0.75, counters.SuppressedRatio(), 1e-9)
+}
+
+func TestStats_String(t *testing.T) {
+	stats := Stats{
+		Counters: Counters{Processed: 3, Suppressed: 1},
+		ByPacket: map[byte]Counters{
+			0x16: {Processed: 1, Suppressed: 1},
+			0x01: {Processed: 2, Suppressed: 0},
+		},
+	}
+
+	require.Equal(t,
+		"processed 3, suppressed 1 (25.0%); 0x01: 2/0; 0x16: 1/1",
+		stats.String())
+}
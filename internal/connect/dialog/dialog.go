@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrNoLink = errors.New("no such link in dialog")
+
+// LinkKind tells which packet clicked link sends.
+type LinkKind int
+
+const (
+	// Bypass sends command to server by RequestBypassToServer.
+	Bypass LinkKind = iota
+	// Page asks another html page by RequestLinkHtml.
+	Page
+)
+
+// Link is clickable link or button of dialog.
+type Link struct {
+	Kind LinkKind
+	// Text is visible text of link or label of button.
+	Text string
+	// Target is bypass command or path of page.
+	Target string
+	Button bool
+}
+
+// parseAction parses action attribute like "bypass -h npc_1_Chat 1" or
+// "link quests/1.htm".
+func parseAction(action string) (LinkKind, string, bool) {
+	words := strings.Fields(action)
+	if len(words) < 2 {
+		return Bypass, "", false
+	}
+
+	switch strings.ToLower(words[0]) {
+	case "bypass":
+		words = words[1:]
+		if words[0] == "-h" {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			return Bypass, "", false
+		}
+
+		return Bypass, strings.Join(words, " "), true
+	case "link":
+		return Page, strings.Join(words[1:], " "), true
+	default:
+		return Bypass, "", false
+	}
+}
+
+// Fill replaces variables of inputs like $name in target with values.
+func (l Link) Fill(values map[string]string) Link {
+	for name, value := range values {
+		l.Target = strings.ReplaceAll(l.Target, "$"+name, value)
+	}
+
+	return l
+}
+
+// Dialog is html page of npc parsed into tree with its links, buttons and
+// input fields.
+type Dialog struct {
+	NpcObjectID int32
+	ItemID      int32
+	Root        *Node
+	Links       []Link
+	// Inputs are variable names of edit fields and combo boxes, values of
+	// them are put into targets by Link.Fill.
+	Inputs []string
+}
+
+// Parse parses html page of npc.
+func Parse(npcObjectID, itemID int32, page string) *Dialog {
+	dialog := &Dialog{
+		NpcObjectID: npcObjectID,
+		ItemID:      itemID,
+		Root:        ParseHTML(page),
+		Links:       nil,
+		Inputs:      nil,
+	}
+
+	dialog.Root.Walk(func(node *Node) {
+		switch node.Tag {
+		case "a", "button":
+			kind, target, ok := parseAction(node.Attrs["action"])
+			if !ok {
+				return
+			}
+			text := node.Content()
+			if node.Tag == "button" {
+				text = strings.TrimSpace(node.Attrs["value"])
+			}
+			dialog.Links = append(dialog.Links, Link{
+				Kind:   kind,
+				Text:   text,
+				Target: target,
+				Button: node.Tag == "button",
+			})
+		case "edit", "multiedit", "combobox":
+			if name := node.Attrs["var"]; name != "" {
+				dialog.Inputs = append(dialog.Inputs, name)
+			}
+		}
+	})
+
+	return dialog
+}
+
+// Text returns visible text of page.
+func (d *Dialog) Text() string {
+	return d.Root.Content()
+}
+
+// ByText returns link with visible text, case is ignored. Exact match is
+// preferred, otherwise first link containing text is returned.
+func (d *Dialog) ByText(text string) (Link, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, link := range d.Links {
+		if strings.ToLower(link.Text) == text {
+			return link, nil
+		}
+	}
+	for _, link := range d.Links {
+		if strings.Contains(strings.ToLower(link.Text), text) {
+			return link, nil
+		}
+	}
+
+	var none Link
+
+	return none, fmt.Errorf("%w: text %q", ErrNoLink, text)
+}
+
+// ByTarget returns first link with bypass command or page matching regular
+// expression.
+func (d *Dialog) ByTarget(pattern string) (Link, error) {
+	var none Link
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return none, fmt.Errorf("invalid link pattern: %w", err)
+	}
+
+	for _, link := range d.Links {
+		if expression.MatchString(link.Target) {
+			return link, nil
+		}
+	}
+
+	return none, fmt.Errorf("%w: target %q", ErrNoLink, pattern)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const gatekeeper = `<html><body>Gatekeeper Roxxy:<br>
+<a action="bypass -h npc_300_Chat 1">Teleport</a><br>
+<a action="bypass -h npc_300_Quest">Quest</a><br>
+<a action="link teleports/talking_island.htm">Talking Island Village</a><br>
+<a action="bypass -h npc_300_goto 1">Gludio Castle Town - 7000 Adena</a><br>
+<a>Plain text</a>
+<edit var="count" width=50>
+<button value="Buy" action="bypass -h npc_300_Buy $count" width=60>
+</body></html>`
+
+func TestParse(t *testing.T) {
+	dialog := Parse(300, 0, gatekeeper)
+
+	require.Equal(t, int32(300), dialog.NpcObjectID)
+	require.Equal(t, []Link{
+		{Kind: Bypass, Text: "Teleport", Target: "npc_300_Chat 1", Button: false},
+		{Kind: Bypass, Text: "Quest", Target: "npc_300_Quest", Button: false},
+		{
+			Kind:   Page,
+			Text:   "Talking Island Village",
+			Target: "teleports/talking_island.htm",
+			Button: false,
+		},
+		{
+			Kind:   Bypass,
+			Text:   "Gludio Castle Town - 7000 Adena",
+			Target: "npc_300_goto 1",
+			Button: false,
+		},
+		{Kind: Bypass, Text: "Buy", Target: "npc_300_Buy $count", Button: true},
+	}, dialog.Links)
+	require.Equal(t, []string{"count"}, dialog.Inputs)
+	require.Contains(t, dialog.Text(), "Gatekeeper Roxxy:\nTeleport\nQuest")
+}
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		action string
+		kind   LinkKind
+		target string
+		ok     bool
+	}{
+		{
+			action: "bypass -h npc_1_Chat 1",
+			kind:   Bypass,
+			target: "npc_1_Chat 1",
+			ok:     true,
+		},
+		{action: "bypass _bbshome", kind: Bypass, target: "_bbshome", ok: true},
+		{action: "link a.htm", kind: Page, target: "a.htm", ok: true},
+		{action: "bypass -h", kind: Bypass, target: "", ok: false},
+		{action: "bypass", kind: Bypass, target: "", ok: false},
+		{action: "open x", kind: Bypass, target: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.action, func(t *testing.T) {
+			kind, target, ok := parseAction(test.action)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.kind, kind)
+			require.Equal(t, test.target, target)
+		})
+	}
+}
+
+func TestDialog_ByText(t *testing.T) {
+	dialog := Parse(300, 0, gatekeeper)
+
+	link, err := dialog.ByText("quest")
+	require.NoError(t, err)
+	require.Equal(t, "npc_300_Quest", link.Target)
+
+	link, err = dialog.ByText("Gludio")
+	require.NoError(t, err)
+	require.Equal(t, "npc_300_goto 1", link.Target)
+
+	_, err = dialog.ByText("Giran")
+	require.True(t, errors.Is(err, ErrNoLink))
+}
+
+func TestDialog_ByTarget(t *testing.T) {
+	dialog := Parse(300, 0, gatekeeper)
+
+	link, err := dialog.ByTarget(`_goto \d+$`)
+	require.NoError(t, err)
+	require.Equal(t, "Gludio Castle Town - 7000 Adena", link.Text)
+
+	_, err = dialog.ByTarget("multisell")
+	require.True(t, errors.Is(err, ErrNoLink))
+	_, err = dialog.ByTarget("(")
+	require.Error(t, err)
+}
+
+func TestLink_Fill(t *testing.T) {
+	link := Link{Kind: Bypass, Text: "Buy", Target: "Buy $count", Button: true}
+	filled := link.Fill(map[string]string{"count": "10"})
+	require.Equal(t, "Buy 10", filled.Target)
+	require.Equal(t, "Buy $count", link.Target)
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"html"
+	"strings"
+)
+
+// voidTags never have closing tag in pages of game server.
+var voidTags = map[string]bool{
+	"br":        true,
+	"img":       true,
+	"button":    true,
+	"edit":      true,
+	"multiedit": true,
+	"combobox":  true,
+	"hr":        true,
+}
+
+// Node is element or text of html page. Text nodes have empty tag.
+type Node struct {
+	Tag      string
+	Attrs    map[string]string
+	Text     string
+	Children []*Node
+}
+
+func element(tag string, attrs map[string]string) *Node {
+	return &Node{Tag: tag, Attrs: attrs, Text: "", Children: nil}
+}
+
+// ParseHTML builds tree of page. Parser is tolerant like game client:
+// unclosed elements end with their parent and stray closing tags are
+// ignored. Root node has empty tag and holds top elements.
+func ParseHTML(source string) *Node {
+	root := element("", nil)
+	stack := []*Node{root}
+	top := func() *Node { return stack[len(stack)-1] }
+
+	for len(source) > 0 {
+		start := strings.IndexByte(source, '<')
+		if start != 0 {
+			if start < 0 {
+				start = len(source)
+			}
+			text := html.UnescapeString(source[:start])
+			top().Children = append(top().Children,
+				&Node{Tag: "", Attrs: nil, Text: text, Children: nil})
+			source = source[start:]
+
+			continue
+		}
+
+		if strings.HasPrefix(source, "<!--") {
+			end := strings.Index(source, "-->")
+			if end < 0 {
+				break
+			}
+			source = source[end+len("-->"):]
+
+			continue
+		}
+
+		end := tagEnd(source)
+		tag := source[1:end]
+		source = source[min(end+1, len(source)):]
+
+		if closing, ok := strings.CutPrefix(tag, "/"); ok {
+			name := strings.ToLower(strings.TrimSpace(closing))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].Tag == name {
+					stack = stack[:i]
+
+					break
+				}
+			}
+
+			continue
+		}
+
+		selfClosing := strings.HasSuffix(tag, "/")
+		name, attrs := parseTag(strings.TrimSuffix(tag, "/"))
+		if name == "" {
+			continue
+		}
+		node := element(name, attrs)
+		top().Children = append(top().Children, node)
+		if !selfClosing && !voidTags[name] {
+			stack = append(stack, node)
+		}
+	}
+
+	return root
+}
+
+// tagEnd returns index of '>' closing tag at start of source, quoted
+// values may contain '>'.
+func tagEnd(source string) int {
+	var quote byte
+	for i := 1; i < len(source); i++ {
+		switch {
+		case quote != 0:
+			if source[i] == quote {
+				quote = 0
+			}
+		case source[i] == '"' || source[i] == '\'':
+			quote = source[i]
+		case source[i] == '>':
+			return i
+		}
+	}
+
+	return len(source)
+}
+
+// parseTag splits content of tag into lower case name and attributes.
+func parseTag(tag string) (string, map[string]string) {
+	tag = strings.TrimSpace(tag)
+	nameEnd := strings.IndexAny(tag, " \t\r\n")
+	if nameEnd < 0 {
+		return strings.ToLower(tag), map[string]string{}
+	}
+
+	name := strings.ToLower(tag[:nameEnd])
+	attrs := make(map[string]string)
+	rest := tag[nameEnd:]
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			return name, attrs
+		}
+
+		keyEnd := strings.IndexAny(rest, "= \t\r\n")
+		if keyEnd < 0 {
+			attrs[strings.ToLower(rest)] = ""
+
+			return name, attrs
+		}
+		key := strings.ToLower(rest[:keyEnd])
+		rest = strings.TrimLeft(rest[keyEnd:], " \t\r\n")
+		if !strings.HasPrefix(rest, "=") {
+			attrs[key] = ""
+
+			continue
+		}
+
+		var value string
+		value, rest = attributeValue(strings.TrimLeft(rest[1:], " \t\r\n"))
+		attrs[key] = html.UnescapeString(value)
+	}
+}
+
+// attributeValue cuts quoted or bare value from start of rest.
+func attributeValue(rest string) (string, string) {
+	if rest == "" {
+		return "", ""
+	}
+	if quote := rest[0]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(rest[1:], quote)
+		if end < 0 {
+			return rest[1:], ""
+		}
+
+		return rest[1 : end+1], rest[end+2:]
+	}
+
+	end := strings.IndexAny(rest, " \t\r\n")
+	if end < 0 {
+		return rest, ""
+	}
+
+	return rest[:end], rest[end:]
+}
+
+// Walk calls visit for node and all its descendants in document order.
+func (n *Node) Walk(visit func(node *Node)) {
+	visit(n)
+	for _, child := range n.Children {
+		child.Walk(visit)
+	}
+}
+
+// FindAll returns descendants with tag in document order.
+func (n *Node) FindAll(tag string) []*Node {
+	var found []*Node
+	n.Walk(func(node *Node) {
+		if node != n && node.Tag == tag {
+			found = append(found, node)
+		}
+	})
+
+	return found
+}
+
+// Content returns visible text of node with collapsed whitespace, line
+// breaks and paragraphs become new lines.
+func (n *Node) Content() string {
+	var builder strings.Builder
+	n.Walk(func(node *Node) {
+		switch node.Tag {
+		case "":
+			builder.WriteString(node.Text)
+		case "br", "p", "tr":
+			builder.WriteString("\n")
+		}
+	})
+
+	lines := strings.Split(builder.String(), "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tags(nodes []*Node) []string {
+	result := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node.Tag)
+	}
+
+	return result
+}
+
+func TestParseHTML(t *testing.T) {
+	root := ParseHTML(`<html><body>Hello&amp;bye<br>` +
+		`<!-- hidden <a> --><A HREF='x' action="bypass -h a>b">Go</a>` +
+		`<button value=Ok action="link x.htm" width=60/></body></html>`)
+
+	require.Equal(t, []string{"html"}, tags(root.Children))
+	body := root.Children[0].Children[0]
+	require.Equal(t, "body", body.Tag)
+	require.Equal(t, []string{"", "br", "a", "button"}, tags(body.Children))
+	require.Equal(t, "Hello&bye", body.Children[0].Text)
+
+	link := body.Children[2]
+	require.Equal(t, map[string]string{
+		"href":   "x",
+		"action": "bypass -h a>b",
+	}, link.Attrs)
+	require.Equal(t, "Go", link.Content())
+
+	button := body.Children[3]
+	require.Equal(t, map[string]string{
+		"value":  "Ok",
+		"action": "link x.htm",
+		"width":  "60",
+	}, button.Attrs)
+}
+
+func TestParseHTML_Tolerant(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{name: "empty", source: "", expected: []string{}},
+		{name: "stray closing", source: "</b><i>x</i>", expected: []string{"i"}},
+		{
+			name:     "unclosed",
+			source:   "<font color=LEVEL>x<table>",
+			expected: []string{"font"},
+		},
+		{name: "broken tag", source: "<a action=", expected: []string{"a"}},
+		{name: "empty tag", source: "<>x", expected: []string{""}},
+		{name: "open comment", source: "<!-- x", expected: []string{}},
+		{name: "bare attribute", source: "<td nowrap>", expected: []string{"td"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := ParseHTML(test.source)
+			require.Equal(t, test.expected, tags(root.Children))
+		})
+	}
+}
+
+func TestNode_Content(t *testing.T) {
+	root := ParseHTML("<html><body>  Trader  Lumi:<br>\n" +
+		"Buy <font color=LEVEL>armor</font>?<br><br>" +
+		"<table><tr><td>A</td></tr><tr><td>B</td></tr></table></body></html>")
+
+	require.Equal(t, "Trader Lumi:\nBuy armor?\nA\nB", root.Content())
+	require.Equal(t, []string{"td", "td"}, tags(root.FindAll("td")))
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+)
+
+// DefaultPageTimeout is how long next page is waited. Character may run
+// to npc before talking, so it is longer than answers to other requests.
+const DefaultPageTimeout = 10 * time.Second
+
+var (
+	ErrNoDialog = errors.New("no dialog is open")
+	ErrNoPage   = errors.New("server didn't send page")
+)
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Window is dialog window of npc, it holds last page sent by server.
+type Window struct {
+	mutex       sync.Mutex
+	sender      Sender
+	current     *Dialog
+	pageTimeout time.Duration
+	// changed is closed and replaced when page arrives.
+	changed chan struct{}
+}
+
+func New(sender Sender) *Window {
+	return &Window{
+		mutex:       sync.Mutex{},
+		sender:      sender,
+		current:     nil,
+		pageTimeout: DefaultPageTimeout,
+		changed:     make(chan struct{}),
+	}
+}
+
+// Register subscribes window to html pages.
+func (w *Window) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.NpcHtmlMessageID, w.handlePage)
+}
+
+func (w *Window) handlePage(data []byte) error {
+	message, err := fromgameserver.NewNpcHtmlMessageFromBytes(data)
+	if err != nil {
+		return err
+	}
+	dialog := Parse(message.NpcObjectID, message.ItemID, message.HTML)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.current = dialog
+	close(w.changed)
+	w.changed = make(chan struct{})
+
+	return nil
+}
+
+// SetPageTimeout changes how long Await waits for page.
+func (w *Window) SetPageTimeout(timeout time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.pageTimeout = timeout
+}
+
+// Clear closes dialog.
+func (w *Window) Clear() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.current = nil
+}
+
+// Current returns last page of dialog.
+func (w *Window) Current() (*Dialog, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.current, w.current != nil
+}
+
+// Await does action, like talk to npc or click, and waits for page sent in
+// answer.
+func (w *Window) Await(
+	ctx context.Context,
+	action func() error,
+) (*Dialog, error) {
+	w.mutex.Lock()
+	changed := w.changed
+	timeout := w.pageTimeout
+	w.mutex.Unlock()
+
+	if err := action(); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, ErrNoPage
+	case <-changed:
+	}
+
+	dialog, _ := w.Current()
+
+	return dialog, nil
+}
+
+// Click sends link of dialog to server. Server answers with next page or
+// does action of link without answer.
+func (w *Window) Click(link Link) error {
+	if link.Kind == Page {
+		return w.sender.WritePacket(
+			&togameserver.RequestLinkHtml{Link: link.Target})
+	}
+
+	return w.sender.WritePacket(
+		&togameserver.RequestBypassToServer{Command: link.Target})
+}
+
+// find returns link of current dialog chosen by find.
+func (w *Window) find(find func(dialog *Dialog) (Link, error)) (Link, error) {
+	var none Link
+	dialog, ok := w.Current()
+	if !ok {
+		return none, ErrNoDialog
+	}
+
+	return find(dialog)
+}
+
+// ClickText clicks link or button of current dialog by its visible text.
+func (w *Window) ClickText(text string) error {
+	link, err := w.find(func(dialog *Dialog) (Link, error) {
+		return dialog.ByText(text)
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Click(link)
+}
+
+// ClickTarget clicks link of current dialog with bypass command or page
+// matching regular expression.
+func (w *Window) ClickTarget(pattern string) error {
+	link, err := w.find(func(dialog *Dialog) (Link, error) {
+		return dialog.ByTarget(pattern)
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Click(link)
+}
+
+// Choose clicks link by visible text and waits for next page.
+func (w *Window) Choose(ctx context.Context, text string) (*Dialog, error) {
+	dialog, err := w.Await(ctx, func() error { return w.ClickText(text) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to choose %q: %w", text, err)
+	}
+
+	return dialog, nil
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dialog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+// server records packets of client and answers them like game server.
+type server struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+	answer  func(p crypt.Serializable)
+}
+
+func (s *server) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.packets = append(s.packets, p)
+	if s.answer != nil {
+		go s.answer(p)
+	}
+
+	return nil
+}
+
+func (s *server) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+type fixture struct {
+	t          *testing.T
+	server     *server
+	dispatcher *dispatch.Dispatcher
+	window     *Window
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	server := &server{mutex: sync.Mutex{}, packets: nil, answer: nil}
+	dispatcher := dispatch.NewDispatcher()
+	window := New(server)
+	window.Register(dispatcher)
+
+	return &fixture{
+		t:          t,
+		server:     server,
+		dispatcher: dispatcher,
+		window:     window,
+	}
+}
+
+// page encodes html page as game server does.
+func page(html string) []byte {
+	writer := packet.NewWriter()
+	message := &fromgameserver.NpcHtmlMessage{
+		NpcObjectID: 300,
+		HTML:        html,
+		ItemID:      0,
+	}
+	if err := message.ToBytes(writer); err != nil {
+		panic(err)
+	}
+
+	return writer.Bytes()
+}
+
+func (f *fixture) show(html string) {
+	f.t.Helper()
+
+	require.NoError(f.t,
+		f.dispatcher.Dispatch(fromgameserver.NpcHtmlMessageID, page(html)))
+}
+
+func TestWindow_Click(t *testing.T) {
+	f := newFixture(t)
+	err := f.window.ClickText("Teleport")
+	require.True(t, errors.Is(err, ErrNoDialog))
+
+	f.show(gatekeeper)
+	dialog, ok := f.window.Current()
+	require.True(t, ok)
+	require.Equal(t, int32(300), dialog.NpcObjectID)
+
+	require.NoError(t, f.window.ClickText("Teleport"))
+	require.NoError(t, f.window.ClickText("Talking Island"))
+	require.NoError(t, f.window.ClickTarget("goto"))
+	require.True(t, errors.Is(f.window.ClickText("Giran"), ErrNoLink))
+	require.True(t, errors.Is(f.window.ClickTarget("giran"), ErrNoLink))
+
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestBypassToServer{Command: "npc_300_Chat 1"},
+		&togameserver.RequestLinkHtml{Link: "teleports/talking_island.htm"},
+		&togameserver.RequestBypassToServer{Command: "npc_300_goto 1"},
+	}, f.server.sent())
+
+	f.window.Clear()
+	_, ok = f.window.Current()
+	require.False(t, ok)
+}
+
+func TestWindow_Choose(t *testing.T) {
+	f := newFixture(t)
+	f.show(gatekeeper)
+	f.server.answer = func(crypt.Serializable) {
+		_ = f.dispatcher.Dispatch(fromgameserver.NpcHtmlMessageID,
+			page(`<a action="bypass -h npc_300_goto 2">Gludin</a>`))
+	}
+
+	next, err := f.window.Choose(context.Background(), "Teleport")
+	require.NoError(t, err)
+	require.Equal(t, "Gludin", next.Links[0].Text)
+}
+
+func TestWindow_ChooseErrors(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.window.Choose(context.Background(), "Teleport")
+	require.True(t, errors.Is(err, ErrNoDialog))
+
+	f.show(gatekeeper)
+	f.window.SetPageTimeout(time.Millisecond)
+	_, err = f.window.Choose(context.Background(), "Teleport")
+	require.True(t, errors.Is(err, ErrNoPage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.window.SetPageTimeout(time.Minute)
+	_, err = f.window.Choose(ctx, "Teleport")
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestWindow_BrokenPage(t *testing.T) {
+	f := newFixture(t)
+	require.Error(t, f.dispatcher.Dispatch(fromgameserver.NpcHtmlMessageID,
+		[]byte{1}))
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dispatch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ExtendedID is id of game server packets which have second two byte id
+// after first one.
+const ExtendedID = 0xfe
+
+var errNoExtendedID = errors.New("extended packet has no sub id")
+
+// Handler processes body of game server packet, body doesn't include
+// packet id.
+type Handler func(data []byte) error
+
+// Filter decides if packet should be passed to handlers. Filters see raw
+// body, so they can drop packet before it is decoded.
+type Filter func(id byte, data []byte) bool
+
+// Tap sees every packet before it is filtered and handled, even packets
+// without handlers. Tap must be quick, it runs in reader of bot.
+type Tap func(id byte, data []byte)
+
+// Dispatcher routes game server packets to handlers registered for their
+// ids. Packets without handlers are ignored.
+type Dispatcher struct {
+	mutex    sync.RWMutex
+	handlers map[byte][]Handler
+	filters  []Filter
+	taps     map[int]Tap
+	nextTap  int
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		mutex:    sync.RWMutex{},
+		handlers: make(map[byte][]Handler),
+		filters:  nil,
+		taps:     make(map[int]Tap),
+		nextTap:  0,
+	}
+}
+
+// AddTap adds tap, like packet log of console, and returns function which
+// removes it.
+func (d *Dispatcher) AddTap(tap Tap) func() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	key := d.nextTap
+	d.nextTap++
+	d.taps[key] = tap
+
+	return func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		delete(d.taps, key)
+	}
+}
+
+// AddFilter adds filter which runs before handlers. Filters run in order of
+// addition, packet is dropped by first filter which rejects it.
+func (d *Dispatcher) AddFilter(filter Filter) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.filters = append(d.filters, filter)
+}
+
+// Handle adds handler for packet id. Handlers are called in order of
+// registration.
+func (d *Dispatcher) Handle(id byte, handler Handler) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.handlers[id] = append(d.handlers[id], handler)
+}
+
+// HandleExtended adds handler for extended packet with sub id. Handler gets
+// body after sub id.
+func (d *Dispatcher) HandleExtended(subID uint16, handler Handler) {
+	d.Handle(ExtendedID, func(data []byte) error {
+		if len(data) < 2 {
+			return errNoExtendedID
+		}
+		if binary.LittleEndian.Uint16(data) != subID {
+			return nil
+		}
+
+		return handler(data[2:])
+	})
+}
+
+// Handles reports if any handler is registered for packet id.
+func (d *Dispatcher) Handles(id byte) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return len(d.handlers[id]) > 0
+}
+
+// Dispatch calls all handlers of packet id, errors of handlers are joined.
+func (d *Dispatcher) Dispatch(id byte, data []byte) error {
+	d.mutex.RLock()
+	handlers := d.handlers[id]
+	filters := d.filters
+	var taps []Tap
+	for _, tap := range d.taps {
+		taps = append(taps, tap)
+	}
+	d.mutex.RUnlock()
+
+	for _, tap := range taps {
+		tap(id, data)
+	}
+
+	if len(handlers) == 0 {
+		return nil
+	}
+	for _, filter := range filters {
+		if !filter(id, data) {
+			return nil
+		}
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(data); err != nil {
+			errs = append(errs, fmt.Errorf("packet %#02x: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
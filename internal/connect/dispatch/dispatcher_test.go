@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package dispatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	dispatcher := NewDispatcher()
+	var calls []string
+	dispatcher.Handle(0x01, func(data []byte) error {
+		calls = append(calls, "first:"+string(data))
+
+		return nil
+	})
+	dispatcher.Handle(0x01, func(data []byte) error {
+		calls = append(calls, "second:"+string(data))
+
+		return nil
+	})
+
+	require.True(t, dispatcher.Handles(0x01))
+	require.False(t, dispatcher.Handles(0x02))
+
+	require.NoError(t, dispatcher.Dispatch(0x01, []byte("a")))
+	require.NoError(t, dispatcher.Dispatch(0x02, []byte("b")))
+	require.Equal(t, []string{"first:a", "second:a"}, calls)
+}
+
+func TestDispatcher_DispatchErrors(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	called := 0
+
+	dispatcher := NewDispatcher()
+	dispatcher.Handle(0x0e, func(_ []byte) error {
+		called++
+
+		return errFirst
+	})
+	dispatcher.Handle(0x0e, func(_ []byte) error {
+		called++
+
+		return errSecond
+	})
+
+	err := dispatcher.Dispatch(0x0e, nil)
+	require.Equal(t, 2, called)
+	require.True(t, errors.Is(err, errFirst))
+	require.True(t, errors.Is(err, errSecond))
+	require.Contains(t, err.Error(), "packet 0x0e")
+}
+
+func TestDispatcher_HandleExtended(t *testing.T) {
+	dispatcher := NewDispatcher()
+	var calls []string
+	dispatcher.HandleExtended(0x12, func(data []byte) error {
+		calls = append(calls, "0x12:"+string(data))
+
+		return nil
+	})
+	dispatcher.HandleExtended(0x0112, func(data []byte) error {
+		calls = append(calls, "0x0112:"+string(data))
+
+		return nil
+	})
+
+	require.True(t, dispatcher.Handles(ExtendedID))
+	require.NoError(t, dispatcher.Dispatch(ExtendedID, []byte{0x12, 0x00, 'a'}))
+	require.NoError(t, dispatcher.Dispatch(ExtendedID, []byte{0x12, 0x01, 'b'}))
+	require.NoError(t, dispatcher.Dispatch(ExtendedID, []byte{0x13, 0x00}))
+	require.Equal(t, []string{"0x12:a", "0x0112:b"}, calls)
+
+	err := dispatcher.Dispatch(ExtendedID, []byte{0x12})
+	require.True(t, errors.Is(err, errNoExtendedID))
+}
+
+func TestDispatcher_Filters(t *testing.T) {
+	dispatcher := NewDispatcher()
+	handled := 0
+	dispatcher.Handle(0x01, func(_ []byte) error {
+		handled++
+
+		return nil
+	})
+
+	var seen []string
+	dispatcher.AddFilter(func(_ byte, data []byte) bool {
+		seen = append(seen, "first:"+string(data))
+
+		return string(data) != "drop"
+	})
+	dispatcher.AddFilter(func(_ byte, data []byte) bool {
+		seen = append(seen, "second:"+string(data))
+
+		return true
+	})
+
+	require.NoError(t, dispatcher.Dispatch(0x01, []byte("keep")))
+	require.NoError(t, dispatcher.Dispatch(0x01, []byte("drop")))
+	require.NoError(t, dispatcher.Dispatch(0x02, []byte("unhandled")))
+
+	require.Equal(t, 1, handled)
+	require.Equal(t, []string{"first:keep", "second:keep", "first:drop"}, seen)
+}
+
+func TestDispatcher_Taps(t *testing.T) {
+	dispatcher := NewDispatcher()
+	dispatcher.Handle(0x01, func(_ []byte) error { return nil })
+	dispatcher.AddFilter(func(_ byte, _ []byte) bool { return false })
+
+	var seen []byte
+	remove := dispatcher.AddTap(func(id byte, _ []byte) {
+		seen = append(seen, id)
+	})
+	require.NoError(t, dispatcher.Dispatch(0x01, nil))
+	require.NoError(t, dispatcher.Dispatch(0x02, nil))
+	remove()
+	require.NoError(t, dispatcher.Dispatch(0x03, nil))
+
+	require.Equal(t, []byte{0x01, 0x02}, seen,
+		"taps see filtered and unhandled packets")
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package eyes
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// DefaultRadius keeps bots of cluster close enough to see mostly same
+	// objects, game shows objects in few thousands units around character.
+	DefaultRadius = 1500
+	// DefaultInterval is how often clusters follow movement of bots.
+	DefaultInterval = time.Second
+)
+
+// Cluster is group of bots near each other, only eyes decodes shared
+// packets for it.
+type Cluster struct {
+	Eyes    string
+	Members []string
+}
+
+// Coordinator splits online bots into visibility clusters and chooses eyes
+// for each of them. Eyes stay with the same bot while it is in world and
+// near its cluster, bots left behind get eyes of their own.
+type Coordinator struct {
+	mutex   sync.RWMutex
+	radius  float64
+	members []*Member
+}
+
+func NewCoordinator(radius float64) *Coordinator {
+	return &Coordinator{
+		mutex:   sync.RWMutex{},
+		radius:  radius,
+		members: nil,
+	}
+}
+
+// Join adds bot with its own world model to shared observation.
+func (c *Coordinator) Join(name string, model *world.World) *Member {
+	member := &Member{
+		Overlay:     nil,
+		name:        name,
+		world:       model,
+		coordinator: c,
+		online:      false,
+		source:      nil,
+		skipping:    atomic.Bool{},
+	}
+	member.Overlay = world.NewOverlay(model, member.view)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.members = append(c.members, member)
+
+	return member
+}
+
+// Run rebalances clusters with interval until context is done.
+func (c *Coordinator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Rebalance()
+		}
+	}
+}
+
+type cluster struct {
+	eyes   *Member
+	center world.Position
+}
+
+// Rebalance recalculates clusters from current positions of bots.
+func (c *Coordinator) Rebalance() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	positions := make(map[*Member]world.Position, len(c.members))
+	for _, member := range c.members {
+		if !member.online {
+			continue
+		}
+		if self, ok := member.world.Self(); ok {
+			positions[member] = self.At(member.world.Now())
+		}
+	}
+
+	var clusters []cluster
+	nearest := func(position world.Position) *cluster {
+		var best *cluster
+		bestDistance := c.radius
+		for i := range clusters {
+			distance := clusters[i].center.Distance(position)
+			if distance <= bestDistance {
+				best, bestDistance = &clusters[i], distance
+			}
+		}
+
+		return best
+	}
+
+	sources := make(map[*Member]*Member, len(c.members))
+	// Current eyes keep their role unless they met other eyes.
+	for _, member := range c.members {
+		position, ok := positions[member]
+		if !ok || member.source != member || nearest(position) != nil {
+			continue
+		}
+		clusters = append(clusters, cluster{eyes: member, center: position})
+		sources[member] = member
+	}
+	for _, member := range c.members {
+		position, ok := positions[member]
+		if !ok || sources[member] != nil {
+			continue
+		}
+		if found := nearest(position); found != nil {
+			sources[member] = found.eyes
+
+			continue
+		}
+		clusters = append(clusters, cluster{eyes: member, center: position})
+		sources[member] = member
+	}
+
+	for _, member := range c.members {
+		c.apply(member, sources[member])
+	}
+}
+
+// apply switches member to new source of shared packets. Bot which becomes
+// eyes takes over world model of its previous eyes, server doesn't resend
+// objects which client is supposed to know already. Must be called with
+// mutex of coordinator held.
+func (c *Coordinator) apply(member *Member, source *Member) {
+	previous := member.source
+	member.source = source
+
+	if source == member && previous != member && previous != nil {
+		// Import before shared packets are let through, otherwise fresh
+		// updates could be overwritten by older state of previous eyes.
+		member.world.Import(previous.world)
+		log.Printf("Eyes of %s handed over to %s\n",
+			previous.name, member.name)
+	}
+	member.skipping.Store(source != nil && source != member)
+}
+
+// Clusters returns current clusters in order bots joined coordinator.
+func (c *Coordinator) Clusters() []Cluster {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var clusters []Cluster
+	index := make(map[*Member]int)
+	for _, member := range c.members {
+		if member.source == member {
+			index[member] = len(clusters)
+			clusters = append(clusters, Cluster{
+				Eyes:    member.name,
+				Members: nil,
+			})
+		}
+	}
+	for _, member := range c.members {
+		if i, ok := index[member.source]; ok {
+			clusters[i].Members = append(clusters[i].Members, member.name)
+		}
+	}
+
+	return clusters
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package eyes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const npcID = 1000
+
+type testBot struct {
+	t          *testing.T
+	id         int32
+	member     *Member
+	dispatcher *dispatch.Dispatcher
+}
+
+func newTestBot(
+	t *testing.T,
+	coordinator *Coordinator,
+	name string,
+	id int32,
+	x int32,
+) *testBot {
+	t.Helper()
+
+	model := world.New()
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	member := coordinator.Join(name, model)
+	dispatcher.AddFilter(member.Keep)
+
+	bot := &testBot{t: t, id: id, member: member, dispatcher: dispatcher}
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = id
+	info.Name = name
+	info.X = x
+	bot.feed(fromgameserver.UserInfoID, info)
+
+	return bot
+}
+
+func (b *testBot) feed(id byte, p crypt.Serializable) {
+	b.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(b.t, p.ToBytes(writer))
+	require.NoError(b.t, b.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (b *testBot) seeNpc(x int32) {
+	b.t.Helper()
+
+	info := &fromgameserver.NpcInfo{} //nolint:exhaustruct
+	info.ObjectID = npcID
+	info.X = x
+	b.feed(fromgameserver.NpcInfoID, info)
+}
+
+func (b *testBot) stopAt(x int32) {
+	b.t.Helper()
+
+	b.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: b.id,
+		X:        x,
+		Y:        0,
+		Z:        0,
+		Heading:  0,
+	})
+}
+
+func (b *testBot) seesNpc() bool {
+	_, ok := b.member.ByObjectID(npcID)
+
+	return ok
+}
+
+func TestCoordinator_SharedView(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	eyes := newTestBot(t, coordinator, "eyes", 1, 0)
+	other := newTestBot(t, coordinator, "other", 2, 100)
+	eyes.member.SetOnline(true)
+	other.member.SetOnline(true)
+
+	require.True(t, eyes.member.Eyes())
+	require.False(t, other.member.Eyes())
+	require.Equal(t, []Cluster{
+		{Eyes: "eyes", Members: []string{"eyes", "other"}},
+	}, coordinator.Clusters())
+
+	eyes.seeNpc(50)
+	other.seeNpc(50)
+	_, decoded := other.member.World().Npc(npcID)
+	require.False(t, decoded, "other bot skips shared packets")
+	require.True(t, other.seesNpc())
+
+	// Own movement is always decoded.
+	other.stopAt(200)
+	self, ok := other.member.Self()
+	require.True(t, ok)
+	require.Equal(t, int32(200), self.Position.X)
+
+	nearby := other.member.Nearby(500)
+	require.Len(t, nearby, 2)
+	require.Equal(t, int32(npcID), nearby[0].ID())
+	require.Equal(t, int32(1), nearby[1].ID())
+	player, ok := nearby[1].(world.Player)
+	require.True(t, ok)
+	require.False(t, player.Self)
+
+	entity, ok := other.member.ByObjectID(1)
+	require.True(t, ok)
+	require.False(t, entity.(world.Player).Self) //nolint:forcetypeassert
+
+	require.Len(t, eyes.member.Nearby(500), 1)
+}
+
+func TestCoordinator_HandoverOnDisconnect(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	eyes := newTestBot(t, coordinator, "eyes", 1, 0)
+	other := newTestBot(t, coordinator, "other", 2, 100)
+	eyes.member.SetOnline(true)
+	other.member.SetOnline(true)
+	eyes.seeNpc(50)
+
+	eyes.member.SetOnline(false)
+	require.True(t, other.member.Eyes())
+	require.False(t, eyes.member.Eyes())
+
+	_, decoded := other.member.World().Npc(npcID)
+	require.True(t, decoded, "new eyes takes over world of old one")
+	require.False(t, eyes.member.skipping.Load())
+	require.Equal(t, []Cluster{
+		{Eyes: "other", Members: []string{"other"}},
+	}, coordinator.Clusters())
+}
+
+func TestCoordinator_HandoverWhenEyesMovesAway(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	eyes := newTestBot(t, coordinator, "eyes", 1, 0)
+	first := newTestBot(t, coordinator, "first", 2, 100)
+	second := newTestBot(t, coordinator, "second", 3, 200)
+	for _, bot := range []*testBot{eyes, first, second} {
+		bot.member.SetOnline(true)
+	}
+	eyes.seeNpc(50)
+
+	eyes.stopAt(10000)
+	coordinator.Rebalance()
+
+	require.True(t, eyes.member.Eyes())
+	require.True(t, first.member.Eyes())
+	require.False(t, second.member.Eyes())
+	require.True(t, second.seesNpc())
+	require.Equal(t, []Cluster{
+		{Eyes: "eyes", Members: []string{"eyes"}},
+		{Eyes: "first", Members: []string{"first", "second"}},
+	}, coordinator.Clusters())
+}
+
+func TestCoordinator_EyesMeet(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	left := newTestBot(t, coordinator, "left", 1, 0)
+	right := newTestBot(t, coordinator, "right", 2, 10000)
+	left.member.SetOnline(true)
+	right.member.SetOnline(true)
+	require.Len(t, coordinator.Clusters(), 2)
+
+	right.stopAt(500)
+	coordinator.Rebalance()
+	require.Equal(t, []Cluster{
+		{Eyes: "left", Members: []string{"left", "right"}},
+	}, coordinator.Clusters())
+}
+
+func TestCoordinator_OfflineBotsDecodeEverything(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	bot := newTestBot(t, coordinator, "bot", 1, 0)
+	bot.seeNpc(10)
+
+	require.False(t, bot.member.Eyes())
+	require.Empty(t, coordinator.Clusters())
+	_, decoded := bot.member.World().Npc(npcID)
+	require.True(t, decoded)
+	require.Equal(t, "bot", bot.member.Name())
+}
+
+func TestCoordinator_Run(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	bot := newTestBot(t, coordinator, "bot", 1, 0)
+	// Mark bot online without rebalance, so only Run can make it eyes.
+	coordinator.mutex.Lock()
+	bot.member.online = true
+	coordinator.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		coordinator.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for !bot.member.Eyes() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	require.True(t, bot.member.Eyes())
+
+	cancel()
+	<-done
+}
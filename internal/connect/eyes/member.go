@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package eyes
+
+import (
+	"sync/atomic"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Member is bot taking part in shared observation. It is world.View which
+// combines own character of bot with entities seen by eyes of its cluster.
+type Member struct {
+	*world.Overlay
+
+	name        string
+	world       *world.World
+	coordinator *Coordinator
+
+	// Fields below are guarded by mutex of coordinator.
+	online bool
+	source *Member
+
+	// skipping is true when other bot decodes shared packets for this one.
+	skipping atomic.Bool
+}
+
+func (m *Member) Name() string {
+	return m.name
+}
+
+// World returns own world model of bot.
+func (m *Member) World() *world.World {
+	return m.world
+}
+
+// Eyes reports if member decodes shared packets for its cluster.
+func (m *Member) Eyes() bool {
+	m.coordinator.mutex.RLock()
+	defer m.coordinator.mutex.RUnlock()
+
+	return m.source == m
+}
+
+// SetOnline marks bot as being in world, only online bots are observers.
+// Cluster of bot is recalculated at once, so eyes of disconnected bot are
+// handed over before its world is cleared.
+func (m *Member) SetOnline(online bool) {
+	m.coordinator.mutex.Lock()
+	changed := m.online != online
+	m.online = online
+	m.coordinator.mutex.Unlock()
+
+	if changed {
+		m.coordinator.Rebalance()
+	}
+}
+
+// Keep is dispatch.Filter which drops shared packets when eyes of cluster is
+// other bot. Packets about own character are always kept.
+func (m *Member) Keep(id byte, data []byte) bool {
+	if !m.skipping.Load() {
+		return true
+	}
+
+	objectID, shared := world.EntityObjectID(id, data)
+
+	return !shared || objectID == m.world.SelfID()
+}
+
+// view returns world model used for entities other than own character.
+func (m *Member) view() *world.World {
+	m.coordinator.mutex.RLock()
+	defer m.coordinator.mutex.RUnlock()
+
+	if m.source == nil {
+		return m.world
+	}
+
+	return m.source.world
+}
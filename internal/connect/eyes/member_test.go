@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package eyes
+
+import (
+	"testing"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMember_Keep(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	newTestBot(t, coordinator, "eyes", 1, 0).member.SetOnline(true)
+	bot := newTestBot(t, coordinator, "bot", 2, 0)
+	bot.member.SetOnline(true)
+
+	body := func(values ...int32) []byte {
+		writer := packet.NewWriter()
+		for _, value := range values {
+			require.NoError(t, writer.WriteInt32(value))
+		}
+
+		return writer.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		id   byte
+		data []byte
+		keep bool
+	}{
+		{
+			name: "not shared packet",
+			id:   fromgameserver.StatusUpdateID,
+			data: body(5, 0),
+			keep: true,
+		},
+		{
+			name: "other creature moves",
+			id:   fromgameserver.MoveToLocationID,
+			data: body(5, 0, 0, 0, 0, 0, 0),
+			keep: false,
+		},
+		{
+			name: "self moves",
+			id:   fromgameserver.MoveToLocationID,
+			data: body(2, 0, 0, 0, 0, 0, 0),
+			keep: true,
+		},
+		{
+			name: "char info with object id after location",
+			id:   fromgameserver.CharInfoID,
+			data: body(0, 0, 0, 0, 5),
+			keep: false,
+		},
+		{
+			name: "dropped item",
+			id:   fromgameserver.DropItemID,
+			data: body(2, 7),
+			keep: false,
+		},
+		{
+			name: "too short to find object id",
+			id:   fromgameserver.DropItemID,
+			data: body(2),
+			keep: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.keep, bot.member.Keep(test.id, test.data))
+		})
+	}
+}
+
+func TestMember_SetOnlineTwice(t *testing.T) {
+	coordinator := NewCoordinator(DefaultRadius)
+	bot := newTestBot(t, coordinator, "bot", 1, 0)
+
+	bot.member.SetOnline(true)
+	bot.member.SetOnline(true)
+	require.True(t, bot.member.Eyes())
+	require.True(t, bot.member.Keep(fromgameserver.NpcInfoID, []byte{}))
+}
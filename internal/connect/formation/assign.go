@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"math"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Assign returns index of target for each start so total travel on
+// ground is the smallest possible. Paths of such assignment never cross,
+// since swapping targets of crossing paths would make them shorter. There
+// must be at least as many targets as starts.
+func Assign(starts, targets []world.Position) []int {
+	// Hungarian method, rows are starts and columns are targets, both
+	// counted from one and zero column is fake.
+	rows, columns := len(starts), len(targets)
+	rowPotential := make([]float64, rows+1)
+	columnPotential := make([]float64, columns+1)
+	owner := make([]int, columns+1)
+	way := make([]int, columns+1)
+
+	for row := 1; row <= rows; row++ {
+		owner[0] = row
+		column := 0
+		slack := make([]float64, columns+1)
+		for index := range slack {
+			slack[index] = math.Inf(1)
+		}
+		used := make([]bool, columns+1)
+		for owner[column] != 0 {
+			used[column] = true
+			current := owner[column]
+			delta, next := math.Inf(1), 0
+			for index := 1; index <= columns; index++ {
+				if used[index] {
+					continue
+				}
+				cost := starts[current-1].Distance(targets[index-1]) -
+					rowPotential[current] - columnPotential[index]
+				if cost < slack[index] {
+					slack[index], way[index] = cost, column
+				}
+				if slack[index] < delta {
+					delta, next = slack[index], index
+				}
+			}
+			for index := range columns + 1 {
+				if used[index] {
+					rowPotential[owner[index]] += delta
+					columnPotential[index] -= delta
+				} else {
+					slack[index] -= delta
+				}
+			}
+			column = next
+		}
+		for column != 0 {
+			previous := way[column]
+			owner[column] = owner[previous]
+			column = previous
+		}
+	}
+
+	result := make([]int, rows)
+	for column := 1; column <= columns; column++ {
+		if owner[column] != 0 {
+			result[owner[column]-1] = column - 1
+		}
+	}
+
+	return result
+}
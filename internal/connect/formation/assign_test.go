@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPositions(random *rand.Rand, count int) []world.Position {
+	result := make([]world.Position, count)
+	for index := range result {
+		result[index] = world.Position{
+			X: random.Int32N(1000),
+			Y: random.Int32N(1000),
+			Z: 0,
+		}
+	}
+
+	return result
+}
+
+func travel(starts, targets []world.Position, assignment []int) float64 {
+	var result float64
+	for index, target := range assignment {
+		result += starts[index].Distance(targets[target])
+	}
+
+	return result
+}
+
+// shortest returns shortest total travel by trying all assignments.
+func shortest(starts, targets []world.Position) float64 {
+	best := math.Inf(1)
+	assignment := make([]int, len(starts))
+	used := make([]bool, len(targets))
+	var try func(index int)
+	try = func(index int) {
+		if index == len(starts) {
+			best = min(best, travel(starts, targets, assignment))
+
+			return
+		}
+		for target := range targets {
+			if used[target] {
+				continue
+			}
+			used[target] = true
+			assignment[index] = target
+			try(index + 1)
+			used[target] = false
+		}
+	}
+	try(0)
+
+	return best
+}
+
+// crosses reports if segments ab and cd properly intersect.
+func crosses(a, b, c, d world.Position) bool {
+	side := func(p, q, r world.Position) int64 {
+		return int64(q.X-p.X)*int64(r.Y-p.Y) - int64(q.Y-p.Y)*int64(r.X-p.X)
+	}
+
+	return side(a, b, c)*side(a, b, d) < 0 && side(c, d, a)*side(c, d, b) < 0
+}
+
+func TestAssign(t *testing.T) {
+	starts := []world.Position{{X: 0, Y: 0, Z: 0}, {X: 100, Y: 0, Z: 0}}
+	targets := []world.Position{
+		{X: 100, Y: 100, Z: 0},
+		{X: 500, Y: 500, Z: 0},
+		{X: 0, Y: 100, Z: 0},
+	}
+	require.Equal(t, []int{2, 0}, Assign(starts, targets))
+	require.Empty(t, Assign(nil, targets))
+}
+
+func TestAssign_Shortest(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2)) //nolint:gosec
+	for range 50 {
+		starts := randomPositions(random, 5)
+		targets := randomPositions(random, 6)
+		assignment := Assign(starts, targets)
+		require.InDelta(t, shortest(starts, targets),
+			travel(starts, targets, assignment), 1e-6)
+	}
+}
+
+func TestAssign_NoCrossing(t *testing.T) {
+	random := rand.New(rand.NewPCG(3, 4)) //nolint:gosec
+	starts := randomPositions(random, 60)
+	targets := randomPositions(random, 60)
+	assignment := Assign(starts, targets)
+
+	for i := range starts {
+		for j := i + 1; j < len(starts); j++ {
+			require.False(t, crosses(starts[i], targets[assignment[i]],
+				starts[j], targets[assignment[j]]), "%d and %d", i, j)
+		}
+	}
+}
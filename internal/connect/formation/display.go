@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// DefaultPoll is how often display checks if performers are in place.
+const DefaultPoll = 250 * time.Millisecond
+
+var (
+	ErrNoPlace      = errors.New("layout has no place for performer")
+	ErrNoPerformers = errors.New("display has no bots in this instance")
+)
+
+// Performer is bot of display. Group keeps performers in their part of
+// layout, so bots of one class stand in their own rank.
+type Performer struct {
+	Member Member
+	Group  string
+}
+
+// Display puts large group of bots in layout for screenshots. Unlike march
+// it doesn't keep shape on the way, each performer goes straight to its
+// place and places are chosen so total travel is shortest.
+type Display struct {
+	performers []Performer
+	poll       time.Duration
+	wait       func(ctx context.Context, duration time.Duration) error
+}
+
+func NewDisplay(performers []Performer) *Display {
+	return &Display{
+		performers: performers,
+		poll:       DefaultPoll,
+		wait:       sleep,
+	}
+}
+
+// sleep waits for duration or until context is done.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Plan returns place of each performer when anchor of layout is at
+// position and layout faces heading.
+func (d *Display) Plan(
+	anchor world.Position,
+	heading int32,
+	layout Layout,
+) ([]world.Position, error) {
+	starts, err := d.positions()
+	if err != nil {
+		return nil, err
+	}
+	places := layout.Place(anchor, heading)
+	if len(d.performers) > len(places) {
+		return nil, fmt.Errorf("%w: %d places for %d members",
+			ErrTooManyMembers, len(places), len(d.performers))
+	}
+
+	pools := make(map[string][]int)
+	for index := range places {
+		pools[layout.group(index)] = append(pools[layout.group(index)], index)
+	}
+	members := make(map[string][]int)
+	for index, performer := range d.performers {
+		group := performer.Group
+		if layout.Groups == nil {
+			group = ""
+		}
+		members[group] = append(members[group], index)
+	}
+
+	result := make([]world.Position, len(d.performers))
+	for group, indexes := range members {
+		pool := pools[group]
+		if len(indexes) > len(pool) {
+			return nil, fmt.Errorf("%w: %d places for %d members of %q",
+				ErrNoPlace, len(pool), len(indexes), group)
+		}
+		from := make([]world.Position, len(indexes))
+		for i, index := range indexes {
+			from[i] = starts[index]
+		}
+		to := make([]world.Position, len(pool))
+		for i, index := range pool {
+			to[i] = places[index]
+		}
+		for i, target := range Assign(from, to) {
+			result[indexes[i]] = to[target]
+		}
+	}
+
+	return result, nil
+}
+
+// Show sends performers to their places and returns when everyone is in
+// place. Performer which stands still away from its place gets its order
+// again, so lost orders don't stall display.
+func (d *Display) Show(
+	ctx context.Context,
+	anchor world.Position,
+	heading int32,
+	layout Layout,
+) error {
+	targets, err := d.Plan(anchor, heading, layout)
+	if err != nil {
+		return err
+	}
+
+	// last are positions at previous check, they are nil before first
+	// orders.
+	var last []world.Position
+	for {
+		positions, err := d.positions()
+		if err != nil {
+			return err
+		}
+		done := true
+		for index, target := range targets {
+			if positions[index].Distance3D(target) <= arrivedDistance {
+				continue
+			}
+			done = false
+			if last != nil && positions[index] != last[index] {
+				continue
+			}
+			err := d.performers[index].Member.MoveTo(target)
+			if err != nil {
+				return fmt.Errorf("member %d: %w", index, err)
+			}
+		}
+		if done {
+			return nil
+		}
+		last = positions
+		if err := d.wait(ctx, d.poll); err != nil {
+			return err
+		}
+	}
+}
+
+// FromConfig returns display of performers of config and its layout. Bots
+// leased by other instances are left out.
+func FromConfig(
+	cfg config.Display,
+	agents map[string]*agent.Agent,
+) (*Display, Layout, error) {
+	performers := make([]Performer, 0, len(cfg.Performers))
+	for _, settings := range cfg.Performers {
+		a, ok := agents[settings.Login]
+		if !ok {
+			continue
+		}
+		performers = append(performers,
+			Performer{Member: a.Mover, Group: settings.Group})
+	}
+	if len(performers) == 0 {
+		return nil, Anyone(Formation{Name: "", Offsets: nil}), ErrNoPerformers
+	}
+
+	layout, err := layoutOf(cfg, performers)
+	if err != nil {
+		return nil, layout, err
+	}
+
+	return NewDisplay(performers), layout, nil
+}
+
+// layoutOf returns layout of config for performers. Ranks go in order in
+// which their groups first appear among performers.
+func layoutOf(cfg config.Display, performers []Performer) (Layout, error) {
+	switch cfg.Shape {
+	case "ranks":
+		var ranks []Rank
+		sizes := make(map[string]int)
+		for _, performer := range performers {
+			if _, ok := sizes[performer.Group]; !ok {
+				ranks = append(ranks, Rank{Group: performer.Group, Size: 0})
+			}
+			sizes[performer.Group]++
+		}
+		for index := range ranks {
+			ranks[index].Size = sizes[ranks[index].Group]
+		}
+
+		return Ranks(ranks, cfg.Spacing), nil
+	case "text":
+		formation, err := Text(cfg.Text, cfg.Spacing)
+
+		return Anyone(formation), err
+	default:
+		formation, err := ByName(cfg.Shape, len(performers), cfg.Spacing)
+
+		return Anyone(formation), err
+	}
+}
+
+func (d *Display) positions() ([]world.Position, error) {
+	result := make([]world.Position, len(d.performers))
+	for index, performer := range d.performers {
+		position, ok := performer.Member.Position()
+		if !ok {
+			return nil, fmt.Errorf("member %d: %w", index,
+				movement.ErrUnknownPosition)
+		}
+		result[index] = position
+	}
+
+	return result, nil
+}
+
+// group returns group which takes place with index.
+func (l Layout) group(index int) string {
+	if index >= len(l.Groups) {
+		return ""
+	}
+
+	return l.Groups[index]
+}
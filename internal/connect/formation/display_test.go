@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+// walker is member which needs several checks to reach its destination
+// and loses orders it is told to.
+type walker struct {
+	teleporter
+	lost        int
+	steps       int
+	destination *world.Position
+}
+
+func (m *walker) MoveTo(destination world.Position) error {
+	m.orders = append(m.orders, destination)
+	if m.lost > 0 {
+		m.lost--
+
+		return nil
+	}
+	m.destination = &destination
+
+	return nil
+}
+
+// walk moves walker one step closer to destination.
+func (m *walker) walk() {
+	if m.destination == nil {
+		return
+	}
+	m.steps--
+	if m.steps <= 0 {
+		m.position = *m.destination
+
+		return
+	}
+	m.position = interpolate(m.position, *m.destination, 0.5)
+}
+
+func newTestDisplay(performers ...Performer) (*Display, *[]time.Duration) {
+	display := NewDisplay(performers)
+	var waits []time.Duration
+	display.wait = func(ctx context.Context, duration time.Duration) error {
+		waits = append(waits, duration)
+		for _, performer := range performers {
+			if member, ok := performer.Member.(*walker); ok {
+				member.walk()
+			}
+		}
+
+		return ctx.Err()
+	}
+
+	return display, &waits
+}
+
+func TestDisplay_Plan(t *testing.T) {
+	mage := newTeleporter(0, 100, 100)
+	warrior := newTeleporter(0, -100, 100)
+	other := newTeleporter(0, 0, 100)
+	display, _ := newTestDisplay(
+		Performer{Member: mage, Group: "mage"},
+		Performer{Member: warrior, Group: "warrior"},
+		Performer{Member: other, Group: "warrior"},
+	)
+	anchor := world.Position{X: 1000, Y: 0, Z: 0}
+
+	targets, err := display.Plan(anchor, east, Ranks([]Rank{
+		{Group: "mage", Size: 1},
+		{Group: "warrior", Size: 2},
+	}, 50))
+	require.NoError(t, err)
+	require.Equal(t, []world.Position{
+		{X: 1000, Y: 0, Z: 0},
+		{X: 950, Y: -25, Z: 0},
+		{X: 950, Y: 25, Z: 0},
+	}, targets, "groups keep to their ranks")
+
+	targets, err = display.Plan(anchor, east, Anyone(Line(3, 100)))
+	require.NoError(t, err)
+	require.Equal(t, []world.Position{
+		{X: 1000, Y: 100, Z: 0},
+		{X: 1000, Y: -100, Z: 0},
+		{X: 1000, Y: 0, Z: 0},
+	}, targets, "anyone takes nearest place")
+}
+
+func TestDisplay_PlanErrors(t *testing.T) {
+	member := newTeleporter(0, 0, 100)
+	display, _ := newTestDisplay(
+		Performer{Member: member, Group: "mage"},
+		Performer{Member: newTeleporter(0, 0, 100), Group: "mage"},
+	)
+	anchor := world.Position{X: 0, Y: 0, Z: 0}
+
+	_, err := display.Plan(anchor, east, Anyone(Line(1, 50)))
+	require.True(t, errors.Is(err, ErrTooManyMembers), err)
+
+	_, err = display.Plan(anchor, east, Ranks([]Rank{
+		{Group: "mage", Size: 1},
+		{Group: "warrior", Size: 1},
+	}, 50))
+	require.True(t, errors.Is(err, ErrNoPlace), err)
+
+	member.known = false
+	_, err = display.Plan(anchor, east, Anyone(Line(2, 50)))
+	require.True(t, errors.Is(err, movement.ErrUnknownPosition), err)
+}
+
+func TestDisplay_Show(t *testing.T) {
+	fast := newTeleporter(0, 0, 100)
+	slow := &walker{
+		teleporter:  *newTeleporter(0, 500, 100),
+		lost:        1,
+		steps:       2,
+		destination: nil,
+	}
+	display, waits := newTestDisplay(
+		Performer{Member: fast, Group: ""},
+		Performer{Member: slow, Group: ""},
+	)
+
+	err := display.Show(context.Background(), world.Position{X: 0, Y: 0, Z: 0},
+		east, Anyone(Line(2, 100)))
+	require.NoError(t, err)
+	require.Equal(t, []world.Position{{X: 0, Y: -50, Z: 0}}, fast.orders)
+	require.Equal(t, []world.Position{
+		{X: 0, Y: 50, Z: 0},
+		{X: 0, Y: 50, Z: 0},
+	}, slow.orders, "lost order is sent again")
+	require.Equal(t, []time.Duration{DefaultPoll, DefaultPoll, DefaultPoll},
+		*waits)
+}
+
+func TestDisplay_ShowErrors(t *testing.T) {
+	member := newTeleporter(0, 0, 100)
+	display, _ := newTestDisplay(Performer{Member: member, Group: ""})
+	anchor := world.Position{X: 500, Y: 0, Z: 0}
+
+	member.err = errors.New("closed")
+	err := display.Show(context.Background(), anchor, east,
+		Anyone(Line(1, 50)))
+	require.True(t, errors.Is(err, member.err), err)
+
+	stuck := &walker{
+		teleporter:  *newTeleporter(0, 0, 100),
+		lost:        0,
+		steps:       0,
+		destination: nil,
+	}
+	display, _ = newTestDisplay(Performer{Member: stuck, Group: ""})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = display.Show(ctx, anchor, east, Anyone(Line(1, 50)))
+	require.True(t, errors.Is(err, context.Canceled), err)
+}
+
+func TestFromConfig(t *testing.T) {
+	agents := make(map[string]*agent.Agent)
+	for _, name := range []string{"mage", "warrior", "archer"} {
+		agents[name] = agent.New(name, geodata.Open(""))
+	}
+	cfg := config.Display{
+		Name:    "show",
+		Shape:   "ranks",
+		Text:    "",
+		Anchor:  config.Point{X: 0, Y: 0, Z: 0},
+		Heading: 0,
+		Spacing: 50,
+		Performers: []config.Performer{
+			{Login: "mage", Group: "casters"},
+			{Login: "warrior", Group: "fighters"},
+			{Login: "leased", Group: "casters"},
+			{Login: "archer", Group: "casters"},
+		},
+	}
+
+	display, layout, err := FromConfig(cfg, agents)
+	require.NoError(t, err)
+	require.Equal(t, []Performer{
+		{Member: agents["mage"].Mover, Group: "casters"},
+		{Member: agents["warrior"].Mover, Group: "fighters"},
+		{Member: agents["archer"].Mover, Group: "casters"},
+	}, display.performers)
+	require.Equal(t, []string{"casters", "casters", "fighters"},
+		layout.Groups)
+
+	cfg.Shape = "circle"
+	_, layout, err = FromConfig(cfg, agents)
+	require.NoError(t, err)
+	require.Equal(t, Anyone(Circle(3, 50)), layout)
+
+	cfg.Shape = "text"
+	cfg.Text = "hi"
+	_, layout, err = FromConfig(cfg, agents)
+	require.NoError(t, err)
+	text, err := Text("hi", 50)
+	require.NoError(t, err)
+	require.Equal(t, Anyone(text), layout)
+
+	cfg.Text = "#"
+	_, _, err = FromConfig(cfg, agents)
+	require.True(t, errors.Is(err, ErrUnknownGlyph), err)
+
+	_, _, err = FromConfig(cfg, nil)
+	require.True(t, errors.Is(err, ErrNoPerformers), err)
+}
+
+func TestSleep(t *testing.T) {
+	require.NoError(t, sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.True(t, errors.Is(sleep(ctx, time.Hour), context.Canceled))
+}
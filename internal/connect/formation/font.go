@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// filledCell marks place of member in bitmap.
+const filledCell = '#'
+
+var ErrUnknownGlyph = errors.New("font has no glyph")
+
+// glyphs is font of text layouts, each glyph is three cells wide and five
+// cells high.
+var glyphs = map[rune][5]string{
+	'A': {".#.", "#.#", "###", "#.#", "#.#"},
+	'B': {"##.", "#.#", "##.", "#.#", "##."},
+	'C': {".##", "#..", "#..", "#..", ".##"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'F': {"###", "#..", "##.", "#..", "#.."},
+	'G': {".##", "#..", "#.#", "#.#", ".##"},
+	'H': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I': {"###", ".#.", ".#.", ".#.", "###"},
+	'J': {"..#", "..#", "..#", "#.#", ".#."},
+	'K': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L': {"#..", "#..", "#..", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {".#.", "#.#", "#.#", "#.#", ".#."},
+	'P': {"##.", "#.#", "##.", "#..", "#.."},
+	'Q': {".#.", "#.#", "#.#", "##.", ".##"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S': {".##", "#..", ".#.", "..#", "##."},
+	'T': {"###", ".#.", ".#.", ".#.", ".#."},
+	'U': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V': {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W': {"#.#", "#.#", "###", "###", "#.#"},
+	'X': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"##.", "..#", ".#.", "#..", "###"},
+	'3': {"##.", "..#", ".#.", "..#", "##."},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "##.", "..#", "##."},
+	'6': {".##", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "##."},
+	' ': {"...", "...", "...", "...", "..."},
+}
+
+// Bitmap places members on filled cells of bitmap centered at anchor. Top
+// row is in front, so shape reads right when seen from behind anchor.
+func Bitmap(name string, rows []string, spacing float64) Formation {
+	var width int
+	for _, row := range rows {
+		width = max(width, len([]rune(row)))
+	}
+	centerRow := float64(len(rows)-1) / 2
+	centerColumn := float64(width-1) / 2
+
+	var offsets []Offset
+	for index, row := range rows {
+		for column, cell := range []rune(row) {
+			if cell != filledCell {
+				continue
+			}
+			offsets = append(offsets, Offset{
+				Forward: (centerRow - float64(index)) * spacing,
+				Right:   (float64(column) - centerColumn) * spacing,
+			})
+		}
+	}
+
+	return Formation{Name: name, Offsets: offsets}
+}
+
+// Text places members on letters of text, letters are case insensitive
+// and separated by empty column.
+func Text(text string, spacing float64) (Formation, error) {
+	rows := make([]string, len(glyphs[' ']))
+	for index, letter := range []rune(strings.ToUpper(text)) {
+		glyph, ok := glyphs[letter]
+		if !ok {
+			return Formation{Name: "", Offsets: nil},
+				fmt.Errorf("%w: %q", ErrUnknownGlyph, letter)
+		}
+		for row, line := range glyph {
+			if index > 0 {
+				rows[row] += "."
+			}
+			rows[row] += line
+		}
+	}
+
+	return Bitmap("text", rows, spacing), nil
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitmap(t *testing.T) {
+	formation := Bitmap("corner", []string{
+		"#.#",
+		"#",
+	}, 10)
+	require.Equal(t, "corner", formation.Name)
+	require.Equal(t, []Offset{
+		{Forward: 5, Right: -10},
+		{Forward: 5, Right: 10},
+		{Forward: -5, Right: -10},
+	}, formation.Offsets)
+}
+
+func TestText(t *testing.T) {
+	formation, err := Text("hi", 10)
+	require.NoError(t, err)
+	require.Equal(t, "text", formation.Name)
+	// H has 11 cells and I has 9.
+	require.Len(t, formation.Offsets, 20)
+	require.Equal(t, Offset{Forward: 20, Right: -30}, formation.Offsets[0])
+
+	_, err = Text("a!", 10)
+	require.True(t, errors.Is(err, ErrUnknownGlyph), err)
+}
+
+func TestGlyphs(t *testing.T) {
+	for letter, glyph := range glyphs {
+		for _, row := range glyph {
+			require.Len(t, row, 3, string(letter))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// headingToRadians converts game heading to angle, full circle is 65536
+// units.
+const headingToRadians = 2 * math.Pi / 65536
+
+// Offset is place of member relative to anchor of formation: forward along
+// heading of formation and right of it. Negative values are behind and
+// left.
+type Offset struct {
+	Forward float64
+	Right   float64
+}
+
+// Formation is shape of group, first offset usually belongs to leader.
+type Formation struct {
+	Name    string
+	Offsets []Offset
+}
+
+// Line places members side by side across direction of movement.
+func Line(size int, spacing float64) Formation {
+	offsets := make([]Offset, size)
+	for i := range offsets {
+		offsets[i] = Offset{
+			Forward: 0,
+			Right:   (float64(i) - float64(size-1)/2) * spacing,
+		}
+	}
+
+	return Formation{Name: "line", Offsets: offsets}
+}
+
+// Column places members one after another behind first one.
+func Column(size int, spacing float64) Formation {
+	offsets := make([]Offset, size)
+	for i := range offsets {
+		offsets[i] = Offset{Forward: -float64(i) * spacing, Right: 0}
+	}
+
+	return Formation{Name: "column", Offsets: offsets}
+}
+
+// Wedge places first member at tip and others on both sides behind it,
+// alternating right and left.
+func Wedge(size int, spacing float64) Formation {
+	offsets := make([]Offset, size)
+	for i := range offsets {
+		rank := float64((i + 1) / 2)
+		side := 1.0
+		if i%2 == 0 {
+			side = -1
+		}
+		offsets[i] = Offset{Forward: -rank * spacing, Right: side * rank * spacing}
+	}
+
+	return Formation{Name: "wedge", Offsets: offsets}
+}
+
+// Custom is formation with arbitrary offsets.
+func Custom(name string, offsets []Offset) Formation {
+	return Formation{Name: name, Offsets: offsets}
+}
+
+// ByName returns one of predefined formations.
+func ByName(name string, size int, spacing float64) (Formation, error) {
+	switch name {
+	case "line":
+		return Line(size, spacing), nil
+	case "column":
+		return Column(size, spacing), nil
+	case "wedge":
+		return Wedge(size, spacing), nil
+	case "grid":
+		return Grid(size, int(math.Ceil(math.Sqrt(float64(size)))), spacing),
+			nil
+	case "circle":
+		return Circle(size, spacing), nil
+	default:
+		return Formation{Name: "", Offsets: nil},
+			fmt.Errorf("unknown formation %q", name)
+	}
+}
+
+// Place returns world positions of members when anchor of formation is at
+// position and formation faces heading.
+func (f Formation) Place(
+	anchor world.Position,
+	heading int32,
+) []world.Position {
+	angle := float64(heading) * headingToRadians
+	forwardX, forwardY := math.Cos(angle), math.Sin(angle)
+	// Y axis of world points south, so right of east is south.
+	rightX, rightY := -forwardY, forwardX
+
+	positions := make([]world.Position, len(f.Offsets))
+	for i, offset := range f.Offsets {
+		x := offset.Forward*forwardX + offset.Right*rightX
+		y := offset.Forward*forwardY + offset.Right*rightY
+		positions[i] = world.Position{
+			X: anchor.X + int32(math.Round(x)),
+			Y: anchor.Y + int32(math.Round(y)),
+			Z: anchor.Z,
+		}
+	}
+
+	return positions
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	east  = 0
+	south = 16384
+	west  = 32768
+)
+
+func TestFormations(t *testing.T) {
+	tests := []struct {
+		name      string
+		formation Formation
+		expected  []Offset
+	}{
+		{
+			name:      "line",
+			formation: Line(3, 50),
+			expected: []Offset{
+				{Forward: 0, Right: -50},
+				{Forward: 0, Right: 0},
+				{Forward: 0, Right: 50},
+			},
+		},
+		{
+			name:      "column",
+			formation: Column(3, 50),
+			expected: []Offset{
+				{Forward: 0, Right: 0},
+				{Forward: -50, Right: 0},
+				{Forward: -100, Right: 0},
+			},
+		},
+		{
+			name:      "wedge",
+			formation: Wedge(5, 50),
+			expected: []Offset{
+				{Forward: 0, Right: 0},
+				{Forward: -50, Right: 50},
+				{Forward: -50, Right: -50},
+				{Forward: -100, Right: 100},
+				{Forward: -100, Right: -100},
+			},
+		},
+		{
+			name:      "custom",
+			formation: Custom("pair", []Offset{{Forward: 10, Right: 20}}),
+			expected:  []Offset{{Forward: 10, Right: 20}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.formation.Offsets)
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	names := []string{"line", "column", "wedge", "grid", "circle"}
+	for _, name := range names {
+		formation, err := ByName(name, 4, 30)
+		require.NoError(t, err)
+		require.Equal(t, name, formation.Name)
+		require.Len(t, formation.Offsets, 4)
+	}
+
+	_, err := ByName("square", 4, 30)
+	require.Error(t, err)
+}
+
+func TestFormation_Place(t *testing.T) {
+	formation := Custom("test", []Offset{
+		{Forward: 0, Right: 0},
+		{Forward: 100, Right: 0},
+		{Forward: 0, Right: 100},
+	})
+	anchor := world.Position{X: 1000, Y: 2000, Z: -50}
+
+	tests := []struct {
+		name     string
+		heading  int32
+		expected []world.Position
+	}{
+		{
+			name:    "east",
+			heading: east,
+			expected: []world.Position{
+				{X: 1000, Y: 2000, Z: -50},
+				{X: 1100, Y: 2000, Z: -50},
+				{X: 1000, Y: 2100, Z: -50},
+			},
+		},
+		{
+			name:    "south",
+			heading: south,
+			expected: []world.Position{
+				{X: 1000, Y: 2000, Z: -50},
+				{X: 1000, Y: 2100, Z: -50},
+				{X: 900, Y: 2000, Z: -50},
+			},
+		},
+		{
+			name:    "west",
+			heading: west,
+			expected: []world.Position{
+				{X: 1000, Y: 2000, Z: -50},
+				{X: 900, Y: 2000, Z: -50},
+				{X: 1000, Y: 1900, Z: -50},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, formation.Place(anchor, test.heading))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"math"
+)
+
+// Grid places members in rows of columns, first row is in front. Last row
+// may be shorter and is centered.
+func Grid(size, columns int, spacing float64) Formation {
+	columns = max(columns, 1)
+	offsets := make([]Offset, 0, size)
+	for row := 0; row*columns < size; row++ {
+		width := min(columns, size-row*columns)
+		for column := range width {
+			offsets = append(offsets, Offset{
+				Forward: -float64(row) * spacing,
+				Right:   (float64(column) - float64(width-1)/2) * spacing,
+			})
+		}
+	}
+
+	return Formation{Name: "grid", Offsets: offsets}
+}
+
+// Circle places members around anchor with spacing between neighbours,
+// first member is in front of anchor and others follow clockwise.
+func Circle(size int, spacing float64) Formation {
+	offsets := make([]Offset, size)
+	if size == 1 {
+		return Formation{Name: "circle", Offsets: offsets}
+	}
+
+	radius := float64(size) * spacing / (2 * math.Pi)
+	for i := range offsets {
+		angle := 2 * math.Pi * float64(i) / float64(size)
+		offsets[i] = Offset{
+			Forward: radius * math.Cos(angle),
+			Right:   radius * math.Sin(angle),
+		}
+	}
+
+	return Formation{Name: "circle", Offsets: offsets}
+}
+
+// Layout is formation of display. Groups go along with offsets and tell
+// which group of performers takes each place, layout without groups lets
+// anyone take any place.
+type Layout struct {
+	Formation
+	Groups []string
+}
+
+// Anyone returns layout where any performer takes any place of formation.
+func Anyone(formation Formation) Layout {
+	return Layout{Formation: formation, Groups: nil}
+}
+
+// Rank is line of layout taken by group of performers, like bots of one
+// class.
+type Rank struct {
+	Group string
+	Size  int
+}
+
+// Ranks places ranks one behind another, first rank is in front.
+func Ranks(ranks []Rank, spacing float64) Layout {
+	var offsets []Offset
+	var groups []string
+	for index, rank := range ranks {
+		for _, offset := range Line(rank.Size, spacing).Offsets {
+			offset.Forward = -float64(index) * spacing
+			offsets = append(offsets, offset)
+			groups = append(groups, rank.Group)
+		}
+	}
+
+	return Layout{
+		Formation: Formation{Name: "ranks", Offsets: offsets},
+		Groups:    groups,
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrid(t *testing.T) {
+	require.Equal(t, []Offset{
+		{Forward: 0, Right: -50},
+		{Forward: 0, Right: 0},
+		{Forward: 0, Right: 50},
+		{Forward: -50, Right: -25},
+		{Forward: -50, Right: 25},
+	}, Grid(5, 3, 50).Offsets)
+	require.Len(t, Grid(2, 0, 50).Offsets, 2)
+	require.Empty(t, Grid(0, 3, 50).Offsets)
+}
+
+func TestCircle(t *testing.T) {
+	circle := Circle(8, 50)
+	require.Len(t, circle.Offsets, 8)
+	radius := 8 * 50 / (2 * math.Pi)
+	for _, offset := range circle.Offsets {
+		require.InDelta(t, radius, math.Hypot(offset.Forward, offset.Right),
+			1e-9)
+	}
+	require.InDelta(t, radius, circle.Offsets[0].Forward, 1e-9)
+	require.InDelta(t, radius, circle.Offsets[2].Right, 1e-9,
+		"clockwise")
+
+	require.Equal(t, []Offset{{Forward: 0, Right: 0}}, Circle(1, 50).Offsets)
+}
+
+func TestRanks(t *testing.T) {
+	layout := Ranks([]Rank{
+		{Group: "warrior", Size: 2},
+		{Group: "mage", Size: 1},
+	}, 50)
+	require.Equal(t, []Offset{
+		{Forward: 0, Right: -25},
+		{Forward: 0, Right: 25},
+		{Forward: -50, Right: 0},
+	}, layout.Offsets)
+	require.Equal(t, []string{"warrior", "warrior", "mage"}, layout.Groups)
+	require.Equal(t, "mage", layout.group(2))
+
+	anyone := Anyone(Line(2, 50))
+	require.Nil(t, anyone.Groups)
+	require.Empty(t, anyone.group(1))
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// DefaultStepLength is distance anchor of formation moves between
+	// orders to members. Short steps keep formation tight, long steps send
+	// fewer packets.
+	DefaultStepLength = 200
+	// arrivedDistance is how close member must be to its place to get no
+	// order at all.
+	arrivedDistance = 10
+)
+
+var (
+	ErrEmptyRoute      = errors.New("route is empty")
+	ErrTooManyMembers  = errors.New("formation has fewer places than members")
+	ErrUnknownSpeed    = errors.New("speed of member is unknown")
+	errInvalidStepSize = errors.New("step length must be positive")
+)
+
+// Member is bot moving in formation, movement.Mover is one.
+type Member interface {
+	Position() (world.Position, bool)
+	Speed() float64
+	MoveTo(destination world.Position) error
+	WaitArrival(ctx context.Context) error
+}
+
+// Step is part of route: places of all members when anchor of formation
+// is at end of step.
+type Step struct {
+	Anchor  world.Position
+	Heading int32
+	Targets []world.Position
+}
+
+// Plan splits route of anchor into steps not longer than step length, so
+// formation turns at corners of route. First step gathers members around
+// start of route.
+func (f Formation) Plan(route []world.Position, stepLength float64) (
+	[]Step,
+	error,
+) {
+	if len(route) == 0 {
+		return nil, ErrEmptyRoute
+	}
+	if stepLength <= 0 {
+		return nil, errInvalidStepSize
+	}
+
+	heading := int32(0)
+	if len(route) > 1 {
+		heading = movement.Heading(route[0], route[1])
+	}
+	steps := []Step{f.step(route[0], heading)}
+	for i := 1; i < len(route); i++ {
+		from, to := route[i-1], route[i]
+		if from == to {
+			continue
+		}
+		heading := movement.Heading(from, to)
+		count := int(math.Ceil(from.Distance3D(to) / stepLength))
+		for part := 1; part <= count; part++ {
+			steps = append(steps,
+				f.step(interpolate(from, to, float64(part)/float64(count)), heading))
+		}
+	}
+
+	return steps, nil
+}
+
+func (f Formation) step(anchor world.Position, heading int32) Step {
+	return Step{
+		Anchor:  anchor,
+		Heading: heading,
+		Targets: f.Place(anchor, heading),
+	}
+}
+
+func interpolate(from, to world.Position, fraction float64) world.Position {
+	between := func(a, b int32) int32 {
+		return a + int32(math.Round(float64(b-a)*fraction))
+	}
+
+	return world.Position{
+		X: between(from.X, to.X),
+		Y: between(from.Y, to.Y),
+		Z: between(from.Z, to.Z),
+	}
+}
+
+// March moves group of members in formation along route. Next step starts
+// when all members reach their places, so faster members wait for others
+// and group moves at speed of its slowest member.
+type March struct {
+	formation  Formation
+	members    []Member
+	stepLength float64
+}
+
+// NewMarch places members by order of offsets of formation.
+func NewMarch(formation Formation, members []Member) (*March, error) {
+	if len(members) > len(formation.Offsets) {
+		return nil, fmt.Errorf("%w: %d places for %d members",
+			ErrTooManyMembers, len(formation.Offsets), len(members))
+	}
+
+	return &March{
+		formation:  formation,
+		members:    members,
+		stepLength: DefaultStepLength,
+	}, nil
+}
+
+// SetStepLength changes distance between orders to members.
+func (m *March) SetStepLength(length float64) {
+	m.stepLength = length
+}
+
+// Run moves formation along route and returns when all members reach
+// their places at end of route.
+func (m *March) Run(ctx context.Context, route []world.Position) error {
+	steps, err := m.formation.Plan(route, m.stepLength)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		if err := m.order(step); err != nil {
+			return err
+		}
+		if err := m.waitArrival(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// order sends members to their places of step.
+func (m *March) order(step Step) error {
+	for i, member := range m.members {
+		position, ok := member.Position()
+		if !ok {
+			return fmt.Errorf("member %d: %w", i, movement.ErrUnknownPosition)
+		}
+		if member.Speed() <= 0 {
+			return fmt.Errorf("member %d: %w", i, ErrUnknownSpeed)
+		}
+
+		target := step.Targets[i]
+		if position.Distance3D(target) <= arrivedDistance {
+			continue
+		}
+		if err := member.MoveTo(target); err != nil {
+			return fmt.Errorf("member %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// waitArrival waits until all members stop.
+func (m *March) waitArrival(ctx context.Context) error {
+	for i, member := range m.members {
+		if err := member.WaitArrival(ctx); err != nil {
+			return fmt.Errorf("member %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package formation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/movement"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Member = (*movement.Mover)(nil)
+
+// teleporter is member which is at its destination right after order.
+// Orders and waits of all members of march are written to shared journal.
+type teleporter struct {
+	position world.Position
+	known    bool
+	speed    float64
+	orders   []world.Position
+	err      error
+	name     string
+	journal  *[]string
+}
+
+func newTeleporter(x, y int32, speed float64) *teleporter {
+	return &teleporter{
+		position: world.Position{X: x, Y: y, Z: 0},
+		known:    true,
+		speed:    speed,
+		orders:   nil,
+		err:      nil,
+		name:     "",
+		journal:  &[]string{},
+	}
+}
+
+func (m *teleporter) Position() (world.Position, bool) {
+	return m.position, m.known
+}
+
+func (m *teleporter) Speed() float64 {
+	return m.speed
+}
+
+func (m *teleporter) MoveTo(destination world.Position) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.orders = append(m.orders, destination)
+	m.position = destination
+	*m.journal = append(*m.journal, "move "+m.name)
+
+	return nil
+}
+
+func (m *teleporter) WaitArrival(ctx context.Context) error {
+	*m.journal = append(*m.journal, "wait "+m.name)
+
+	return ctx.Err()
+}
+
+func newTestMarch(
+	t *testing.T,
+	formation Formation,
+	members ...*teleporter,
+) (*March, *[]string) {
+	t.Helper()
+
+	journal := &[]string{}
+	list := make([]Member, len(members))
+	for i, member := range members {
+		member.name = strconv.Itoa(i)
+		member.journal = journal
+		list[i] = member
+	}
+	march, err := NewMarch(formation, list)
+	require.NoError(t, err)
+
+	return march, journal
+}
+
+func TestFormation_Plan(t *testing.T) {
+	formation := Column(2, 100)
+	route := []world.Position{
+		{X: 0, Y: 0, Z: 0},
+		{X: 500, Y: 0, Z: 0},
+		{X: 500, Y: 0, Z: 0},
+		{X: 500, Y: 200, Z: 100},
+	}
+
+	steps, err := formation.Plan(route, 200)
+	require.NoError(t, err)
+
+	var anchors []world.Position
+	var headings []int32
+	for _, step := range steps {
+		anchors = append(anchors, step.Anchor)
+		headings = append(headings, step.Heading)
+		require.Equal(t, formation.Place(step.Anchor, step.Heading),
+			step.Targets)
+	}
+	require.Equal(t, []world.Position{
+		{X: 0, Y: 0, Z: 0},
+		{X: 167, Y: 0, Z: 0},
+		{X: 333, Y: 0, Z: 0},
+		{X: 500, Y: 0, Z: 0},
+		{X: 500, Y: 100, Z: 50},
+		{X: 500, Y: 200, Z: 100},
+	}, anchors)
+	require.Equal(t, []int32{east, east, east, east, south, south}, headings)
+}
+
+func TestFormation_PlanErrors(t *testing.T) {
+	_, err := Line(2, 10).Plan(nil, 100)
+	require.True(t, errors.Is(err, ErrEmptyRoute))
+
+	_, err = Line(2, 10).Plan([]world.Position{{X: 0, Y: 0, Z: 0}}, 0)
+	require.Error(t, err)
+
+	steps, err := Line(2, 10).Plan([]world.Position{{X: 5, Y: 5, Z: 5}}, 100)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+}
+
+func TestMarch_WaitsForArrival(t *testing.T) {
+	fast := newTeleporter(0, 0, 200)
+	slow := newTeleporter(-100, 0, 100)
+	march, journal := newTestMarch(t, Column(2, 100), fast, slow)
+
+	march.SetStepLength(300)
+	route := []world.Position{{X: 0, Y: 0, Z: 0}, {X: 600, Y: 0, Z: 0}}
+	require.NoError(t, march.Run(context.Background(), route))
+
+	// Members are already in places at start, next steps start after both
+	// of them arrive.
+	require.Equal(t, []string{
+		"wait 0", "wait 1",
+		"move 0", "move 1", "wait 0", "wait 1",
+		"move 0", "move 1", "wait 0", "wait 1",
+	}, *journal)
+	require.Equal(t, []world.Position{
+		{X: 300, Y: 0, Z: 0}, {X: 600, Y: 0, Z: 0},
+	}, fast.orders)
+	require.Equal(t, []world.Position{
+		{X: 200, Y: 0, Z: 0}, {X: 500, Y: 0, Z: 0},
+	}, slow.orders)
+}
+
+func TestMarch_LaggingMemberIsOrdered(t *testing.T) {
+	leader := newTeleporter(0, 0, 100)
+	straggler := newTeleporter(-1000, 0, 100)
+	march, journal := newTestMarch(t, Column(2, 100), leader, straggler)
+
+	route := []world.Position{{X: 0, Y: 0, Z: 0}}
+	require.NoError(t, march.Run(context.Background(), route))
+	require.Equal(t, []string{"move 1", "wait 0", "wait 1"}, *journal)
+	require.Empty(t, leader.orders)
+	require.Equal(t, []world.Position{{X: -100, Y: 0, Z: 0}}, straggler.orders)
+}
+
+func TestMarch_Errors(t *testing.T) {
+	route := []world.Position{{X: 0, Y: 0, Z: 0}, {X: 100, Y: 0, Z: 0}}
+
+	_, err := NewMarch(Line(1, 10), []Member{
+		newTeleporter(0, 0, 1), newTeleporter(0, 0, 1),
+	})
+	require.True(t, errors.Is(err, ErrTooManyMembers))
+
+	unknown := newTeleporter(0, 0, 100)
+	unknown.known = false
+	march, _ := newTestMarch(t, Line(1, 10), unknown)
+	err = march.Run(context.Background(), route)
+	require.True(t, errors.Is(err, movement.ErrUnknownPosition))
+
+	still := newTeleporter(0, 0, 0)
+	march, _ = newTestMarch(t, Line(1, 10), still)
+	err = march.Run(context.Background(), route)
+	require.True(t, errors.Is(err, ErrUnknownSpeed))
+
+	broken := newTeleporter(0, 0, 100)
+	broken.err = errors.New("offline")
+	march, _ = newTestMarch(t, Line(1, 10), broken)
+	err = march.Run(context.Background(), route)
+	require.True(t, errors.Is(err, broken.err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	march, _ = newTestMarch(t, Line(1, 10), newTeleporter(0, 0, 100))
+	err = march.Run(ctx, route)
+	require.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// World is split into regions of 32768x32768 units, region files are named
+// by region numbers like 22_22.l2j. Cell of region is 16x16 units.
+const (
+	cellSize   = 16
+	cellShift  = 4
+	tileXMin   = 11
+	tileYMin   = 10
+	tileXZero  = 20
+	tileYZero  = 18
+	tileShift  = 15
+	worldXMin  = (tileXMin - tileXZero) << tileShift
+	worldYMin  = (tileYMin - tileYZero) << tileShift
+	fileFormat = "%d_%d.l2j"
+)
+
+type regionKey struct {
+	x int
+	y int
+}
+
+// loadedRegion keeps mapped file of region, region is nil if there is no
+// geodata for it.
+type loadedRegion struct {
+	region *region
+	unmap  func() error
+}
+
+// Geodata answers questions about terrain from L2J geodata files. Regions
+// are loaded on first use and shared by all bots of process. Places without
+// geodata are treated as flat and open.
+type Geodata struct {
+	dir     string
+	mutex   sync.RWMutex
+	regions map[regionKey]loadedRegion
+}
+
+// Open uses geodata files from directory, empty directory means no
+// geodata at all.
+func Open(dir string) *Geodata {
+	return &Geodata{
+		dir:     dir,
+		mutex:   sync.RWMutex{},
+		regions: make(map[regionKey]loadedRegion),
+	}
+}
+
+// Close unmaps all loaded regions.
+func (g *Geodata) Close() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var errs []error
+	for key, loaded := range g.regions {
+		if err := loaded.unmap(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(g.regions, key)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Preload loads region files from directory at once, otherwise they are
+// loaded when bots come to them.
+func (g *Geodata) Preload() (int, error) {
+	if g.dir == "" {
+		return 0, nil
+	}
+
+	entries, err := os.ReadDir(g.dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read geodata dir: %w", err)
+	}
+
+	loaded := 0
+	for _, entry := range entries {
+		var key regionKey
+		_, err := fmt.Sscanf(entry.Name(), fileFormat, &key.x, &key.y)
+		if err != nil || entry.Name() != fmt.Sprintf(fileFormat, key.x, key.y) {
+			continue
+		}
+		if g.region(key) != nil {
+			loaded++
+		}
+	}
+
+	return loaded, nil
+}
+
+func (g *Geodata) region(key regionKey) *region {
+	g.mutex.RLock()
+	loaded, ok := g.regions[key]
+	g.mutex.RUnlock()
+	if ok {
+		return loaded.region
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if loaded, ok := g.regions[key]; ok {
+		return loaded.region
+	}
+	loaded = g.load(key)
+	g.regions[key] = loaded
+
+	return loaded.region
+}
+
+// load maps region file, problems are logged once and region is treated as
+// one without geodata.
+func (g *Geodata) load(key regionKey) loadedRegion {
+	empty := loadedRegion{region: nil, unmap: func() error { return nil }}
+	if g.dir == "" {
+		return empty
+	}
+
+	path := filepath.Join(g.dir, fmt.Sprintf(fileFormat, key.x, key.y))
+	data, unmap, err := mapFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return empty
+	}
+	if err != nil {
+		log.Printf("Error loading geodata: %v\n", err)
+
+		return empty
+	}
+
+	parsed, err := parseRegion(data)
+	if err != nil {
+		log.Printf("Error loading geodata %s: %v\n", path, err)
+		_ = unmap()
+
+		return empty
+	}
+
+	return loadedRegion{region: parsed, unmap: unmap}
+}
+
+// cell is position in geodata grid.
+type cell struct {
+	x int
+	y int
+}
+
+func cellOf(position world.Position) cell {
+	return cell{
+		x: int(position.X-worldXMin) >> cellShift,
+		y: int(position.Y-worldYMin) >> cellShift,
+	}
+}
+
+// center returns world position of center of cell at height z.
+func (c cell) center(z int32) world.Position {
+	return world.Position{
+		X: int32(c.x<<cellShift) + worldXMin + cellSize/2, //nolint:gosec
+		Y: int32(c.y<<cellShift) + worldYMin + cellSize/2, //nolint:gosec
+		Z: z,
+	}
+}
+
+// layer returns surface of cell closest to height z.
+func (g *Geodata) layer(c cell, z int32) layer {
+	if c.x < 0 || c.y < 0 {
+		return layer{height: z, nswe: All}
+	}
+
+	key := regionKey{x: c.x/regionCells + tileXMin, y: c.y/regionCells + tileYMin}
+	found := g.region(key)
+	if found == nil {
+		return layer{height: z, nswe: All}
+	}
+
+	return found.nearest(c.x%regionCells, c.y%regionCells, z)
+}
+
+// Height returns height of ground closest to position.
+func (g *Geodata) Height(position world.Position) int32 {
+	return g.layer(cellOf(position), position.Z).height
+}
+
+// NSWE returns directions in which character can leave position.
+func (g *Geodata) NSWE(position world.Position) byte {
+	return g.layer(cellOf(position), position.Z).nswe
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests put geodata into region 22_22, its first cell starts at 65536 131072.
+const (
+	testRegionX = 22
+	testRegionY = 22
+)
+
+func writeRegion(t *testing.T, dir string, x, y int, data []byte) {
+	t.Helper()
+
+	path := filepath.Join(dir, fmt.Sprintf(fileFormat, x, y))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// openTest opens geodata with test region built by builder.
+func openTest(t *testing.T, builder *regionBuilder) *Geodata {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeRegion(t, dir, testRegionX, testRegionY, builder.bytes())
+	geo := Open(dir)
+	t.Cleanup(func() { require.NoError(t, geo.Close()) })
+
+	return geo
+}
+
+// at returns world position of center of cell of test region.
+func at(x, y int, z int32) world.Position {
+	return cell{
+		x: (testRegionX-tileXMin)*regionCells + x,
+		y: (testRegionY-tileYMin)*regionCells + y,
+	}.center(z)
+}
+
+func TestCell(t *testing.T) {
+	position := world.Position{X: 65536 + 40, Y: 131072 + 17, Z: 5}
+
+	require.Equal(t, world.Position{X: 65536 + 40, Y: 131072 + 24, Z: 5},
+		cellOf(position).center(5))
+	require.Equal(t, at(2, 1, 5), cellOf(position).center(5))
+	require.Equal(t, cellOf(at(7, 9, 0)), cellOf(cellOf(at(7, 9, 0)).center(0)))
+}
+
+func TestGeodata(t *testing.T) {
+	geo := openTest(t, newRegionBuilder().
+		set(1, 1, testLayer{height: 200, nswe: North}).
+		set(2, 2, testLayer{height: -80, nswe: All},
+			testLayer{height: 320, nswe: East}))
+
+	tests := []struct {
+		name   string
+		at     world.Position
+		height int32
+		nswe   byte
+	}{
+		{name: "flat", at: at(100, 100, 30), height: 0, nswe: All},
+		{name: "complex", at: at(1, 1, 0), height: 200, nswe: North},
+		{name: "lower layer", at: at(2, 2, 0), height: -80, nswe: All},
+		{name: "upper layer", at: at(2, 2, 300), height: 320, nswe: East},
+		{
+			name:   "missing region",
+			at:     world.Position{X: 0, Y: 0, Z: -120},
+			height: -120,
+			nswe:   All,
+		},
+		{
+			name:   "outside of world",
+			at:     world.Position{X: -400000, Y: -400000, Z: 7},
+			height: 7,
+			nswe:   All,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.height, geo.Height(test.at))
+			require.Equal(t, test.nswe, geo.NSWE(test.at))
+		})
+	}
+}
+
+func TestGeodata_Preload(t *testing.T) {
+	dir := t.TempDir()
+	writeRegion(t, dir, 22, 22, newRegionBuilder().bytes())
+	writeRegion(t, dir, 22, 23, newRegionBuilder().bytes())
+	writeRegion(t, dir, 23, 23, []byte{1, 2, 3})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"),
+		[]byte("text"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "22_22.l2j.bak"),
+		[]byte("text"), 0o600))
+
+	geo := Open(dir)
+	loaded, err := geo.Preload()
+	require.NoError(t, err)
+	require.Equal(t, 2, loaded)
+	require.Len(t, geo.regions, 3)
+
+	require.NoError(t, geo.Close())
+	require.Empty(t, geo.regions)
+}
+
+func TestGeodata_PreloadErrors(t *testing.T) {
+	loaded, err := Open("").Preload()
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing")).Preload()
+	require.Error(t, err)
+}
+
+func TestGeodata_Shared(t *testing.T) {
+	geo := openTest(t, newRegionBuilder().
+		set(1, 1, testLayer{height: 200, nswe: All}))
+
+	done := make(chan int32)
+	for range 8 {
+		go func() { done <- geo.Height(at(1, 1, 0)) }()
+	}
+	for range 8 {
+		require.Equal(t, int32(200), <-done)
+	}
+	require.Len(t, geo.regions, 1)
+}
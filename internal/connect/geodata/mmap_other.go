@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package geodata
+
+import "os"
+
+// mapFile reads whole file, memory mapping isn't used on this system.
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	require.NoError(t, os.WriteFile(path, []byte{1, 2, 3}, 0o600))
+
+	data, unmap, err := mapFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, data)
+	require.NoError(t, unmap())
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	data, unmap, err = mapFile(empty)
+	require.NoError(t, err)
+	require.Empty(t, data)
+	require.NoError(t, unmap())
+
+	_, _, err = mapFile(filepath.Join(dir, "missing"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package geodata
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mapFile maps file into memory read only. Pages are shared by all users of
+// file, so many processes with same geodata don't keep own copies.
+func mapFile(path string) ([]byte, func() error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	if int64(int(size)) != size {
+		return nil, nil, fmt.Errorf("file %s is too big to map", path)
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size),
+		syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map %s: %w", path, err)
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// MaxStepHeight is highest step character can climb between cells.
+	MaxStepHeight = 64
+	// eyeHeight lifts line of sight above ground.
+	eyeHeight = 40
+	// maxSearchNodes limits work of single path search, so bots asking for
+	// unreachable places don't take all processor time.
+	maxSearchNodes = 50000
+	diagonalCost   = math.Sqrt2
+)
+
+// ErrNoPath is returned when destination can't be reached.
+var ErrNoPath = errors.New("no path found")
+
+// step returns layer of neighbor cell if character can go there from layer
+// of cell by one orthogonal or diagonal step.
+func (g *Geodata) step(from cell, surface layer, dx, dy int) (layer, bool) {
+	if dx != 0 && dy != 0 {
+		horizontal, ok := g.step(from, surface, dx, 0)
+		if !ok {
+			return layer{}, false //nolint:exhaustruct
+		}
+		vertical, ok := g.step(from, surface, 0, dy)
+		if !ok {
+			return layer{}, false //nolint:exhaustruct
+		}
+		_, ok = g.step(cell{x: from.x + dx, y: from.y}, horizontal, 0, dy)
+		if !ok {
+			return layer{}, false //nolint:exhaustruct
+		}
+
+		return g.step(cell{x: from.x, y: from.y + dy}, vertical, dx, 0)
+	}
+
+	if surface.nswe&direction(dx, dy) == 0 {
+		return layer{}, false //nolint:exhaustruct
+	}
+	next := g.layer(cell{x: from.x + dx, y: from.y + dy}, surface.height)
+	if abs(next.height-surface.height) > MaxStepHeight {
+		return layer{}, false //nolint:exhaustruct
+	}
+
+	return next, true
+}
+
+func direction(dx, dy int) byte {
+	var result byte
+	switch {
+	case dx > 0:
+		result |= East
+	case dx < 0:
+		result |= West
+	}
+	switch {
+	case dy > 0:
+		result |= South
+	case dy < 0:
+		result |= North
+	}
+
+	return result
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// line returns cells between two cells like Bresenham line, neighbor cells
+// of result differ by one orthogonal or diagonal step.
+func line(from, to cell) []cell {
+	dx, dy := to.x-from.x, to.y-from.y
+	sx, sy := sign(dx), sign(dy)
+	dx, dy = dx*sx, dy*sy
+
+	cells := []cell{from}
+	current := from
+	errorTerm := dx - dy
+	for current != to {
+		doubled := 2 * errorTerm
+		if doubled > -dy {
+			errorTerm -= dy
+			current.x += sx
+		}
+		if doubled < dx {
+			errorTerm += dx
+			current.y += sy
+		}
+		cells = append(cells, current)
+	}
+
+	return cells
+}
+
+// CanMoveTo reports if character can walk straight from one position to
+// another.
+func (g *Geodata) CanMoveTo(from, to world.Position) bool {
+	cells := line(cellOf(from), cellOf(to))
+	surface := g.layer(cells[0], from.Z)
+	for i := 1; i < len(cells); i++ {
+		next, ok := g.step(cells[i-1], surface,
+			cells[i].x-cells[i-1].x, cells[i].y-cells[i-1].y)
+		if !ok {
+			return false
+		}
+		surface = next
+	}
+
+	return true
+}
+
+// LineOfSight reports if ground doesn't hide one position from another.
+func (g *Geodata) LineOfSight(from, to world.Position) bool {
+	cells := line(cellOf(from), cellOf(to))
+	fromZ := float64(g.Height(from) + eyeHeight)
+	toZ := float64(g.Height(to) + eyeHeight)
+	for i, current := range cells {
+		fraction := 0.0
+		if len(cells) > 1 {
+			fraction = float64(i) / float64(len(cells)-1)
+		}
+		lineZ := fromZ + (toZ-fromZ)*fraction
+		surface := g.layer(current, int32(lineZ))
+		if float64(surface.height) > lineZ {
+			return false
+		}
+	}
+
+	return true
+}
+
+type node struct {
+	cell    cell
+	surface layer
+	cost    float64
+	score   float64
+	parent  *node
+	index   int
+}
+
+type openSet []*node
+
+func (s openSet) Len() int           { return len(s) }
+func (s openSet) Less(i, j int) bool { return s[i].score < s[j].score }
+
+func (s openSet) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].index, s[j].index = i, j
+}
+
+func (s *openSet) Push(value any) {
+	item := value.(*node) //nolint:forcetypeassert
+	item.index = len(*s)
+	*s = append(*s, item)
+}
+
+func (s *openSet) Pop() any {
+	old := *s
+	item := old[len(old)-1]
+	*s = old[:len(old)-1]
+
+	return item
+}
+
+// nodeKey tells apart layers of same cell, like bridge and ground below.
+type nodeKey struct {
+	cell   cell
+	height int32
+}
+
+func heuristic(from, to cell) float64 {
+	dx := math.Abs(float64(to.x - from.x))
+	dy := math.Abs(float64(to.y - from.y))
+
+	return math.Max(dx, dy) + (diagonalCost-1)*math.Min(dx, dy)
+}
+
+// FindPath returns waypoints from one position to another, last waypoint
+// is destination. Straight line is returned if it is walkable.
+func (g *Geodata) FindPath(from, to world.Position) ([]world.Position, error) {
+	if g.CanMoveTo(from, to) {
+		return []world.Position{to}, nil
+	}
+
+	cells, err := g.search(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.smooth(from, to, cells), nil
+}
+
+// search is A* over cells of geodata.
+func (g *Geodata) search(from, to world.Position) ([]world.Position, error) {
+	start, goal := cellOf(from), cellOf(to)
+	first := &node{
+		cell:    start,
+		surface: g.layer(start, from.Z),
+		cost:    0,
+		score:   heuristic(start, goal),
+		parent:  nil,
+		index:   0,
+	}
+	open := &openSet{first}
+	known := map[nodeKey]*node{{cell: start, height: first.surface.height}: first}
+	closed := make(map[nodeKey]bool)
+
+	for open.Len() > 0 && len(closed) < maxSearchNodes {
+		current := heap.Pop(open).(*node) //nolint:forcetypeassert
+		key := nodeKey{cell: current.cell, height: current.surface.height}
+		if closed[key] {
+			continue
+		}
+		closed[key] = true
+
+		if current.cell == goal {
+			return unwind(current), nil
+		}
+
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				surface, ok := g.step(current.cell, current.surface, dx, dy)
+				if !ok {
+					continue
+				}
+
+				next := cell{x: current.cell.x + dx, y: current.cell.y + dy}
+				nextKey := nodeKey{cell: next, height: surface.height}
+				if closed[nextKey] {
+					continue
+				}
+				cost := current.cost + 1
+				if dx != 0 && dy != 0 {
+					cost = current.cost + diagonalCost
+				}
+				if old, ok := known[nextKey]; ok && old.cost <= cost {
+					continue
+				}
+
+				candidate := &node{
+					cell:    next,
+					surface: surface,
+					cost:    cost,
+					score:   cost + heuristic(next, goal),
+					parent:  current,
+					index:   0,
+				}
+				known[nextKey] = candidate
+				heap.Push(open, candidate)
+			}
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+func unwind(last *node) []world.Position {
+	var path []world.Position
+	for current := last; current != nil; current = current.parent {
+		path = append(path, current.cell.center(current.surface.height))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// smooth drops waypoints which can be skipped by walking straight, path of
+// cells becomes few turns.
+func (g *Geodata) smooth(
+	from world.Position,
+	to world.Position,
+	cells []world.Position,
+) []world.Position {
+	cells[len(cells)-1] = to
+
+	var waypoints []world.Position
+	anchor := from
+	for i := 1; i < len(cells); i++ {
+		if !g.CanMoveTo(anchor, cells[i]) {
+			anchor = cells[i-1]
+			waypoints = append(waypoints, anchor)
+		}
+	}
+
+	return append(waypoints, to)
+}
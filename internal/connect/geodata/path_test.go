@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+// wall is column of high cells at x from y to y+length.
+func wall(builder *regionBuilder, x, y, length int) *regionBuilder {
+	for i := range length {
+		builder.set(x, y+i, testLayer{height: 1000, nswe: All})
+	}
+
+	return builder
+}
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to cell
+		expected []cell
+	}{
+		{
+			name:     "same cell",
+			from:     cell{x: 1, y: 1},
+			to:       cell{x: 1, y: 1},
+			expected: []cell{{x: 1, y: 1}},
+		},
+		{
+			name:     "diagonal",
+			from:     cell{x: 0, y: 0},
+			to:       cell{x: 2, y: -2},
+			expected: []cell{{x: 0, y: 0}, {x: 1, y: -1}, {x: 2, y: -2}},
+		},
+		{
+			name: "shallow",
+			from: cell{x: 0, y: 0},
+			to:   cell{x: -4, y: 1},
+			expected: []cell{
+				{x: 0, y: 0}, {x: -1, y: 0}, {x: -2, y: 0},
+				{x: -3, y: 1}, {x: -4, y: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, line(test.from, test.to))
+		})
+	}
+}
+
+func TestGeodata_CanMoveTo(t *testing.T) {
+	geo := openTest(t, wall(newRegionBuilder(), 10, 0, 20).
+		set(20, 5, testLayer{height: 0, nswe: All &^ East}).
+		set(30, 5, testLayer{height: 64, nswe: All}).
+		set(31, 5, testLayer{height: 128, nswe: All}).
+		set(32, 5, testLayer{height: 200, nswe: All}))
+
+	tests := []struct {
+		name     string
+		from, to world.Position
+		expected bool
+	}{
+		{name: "open", from: at(0, 30, 0), to: at(40, 40, 0), expected: true},
+		{name: "wall", from: at(5, 5, 0), to: at(15, 5, 0), expected: false},
+		{name: "along wall", from: at(9, 0, 0), to: at(9, 19, 0), expected: true},
+		{name: "no exit", from: at(20, 5, 0), to: at(25, 5, 0), expected: false},
+		{name: "other exit", from: at(20, 5, 0), to: at(15, 5, 0), expected: true},
+		{name: "stairs", from: at(29, 5, 0), to: at(31, 5, 0), expected: true},
+		{name: "too steep", from: at(29, 5, 0), to: at(33, 5, 0), expected: false},
+		{
+			name:     "diagonal corner",
+			from:     at(9, 20, 0),
+			to:       at(11, 18, 0),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, geo.CanMoveTo(test.from, test.to))
+		})
+	}
+}
+
+func TestGeodata_LineOfSight(t *testing.T) {
+	geo := openTest(t, wall(newRegionBuilder(), 10, 0, 20).
+		set(10, 40, testLayer{height: 24, nswe: All}))
+
+	require.True(t, geo.LineOfSight(at(0, 30, 0), at(40, 30, 0)))
+	require.False(t, geo.LineOfSight(at(5, 5, 0), at(15, 5, 0)))
+	require.True(t, geo.LineOfSight(at(5, 40, 0), at(15, 40, 0)))
+	require.True(t, geo.LineOfSight(at(5, 5, 0), at(5, 5, 0)))
+}
+
+func TestGeodata_FindPath(t *testing.T) {
+	geo := openTest(t, wall(newRegionBuilder(), 10, 0, 20))
+	from, to := at(5, 5, 0), at(15, 5, 0)
+
+	path, err := geo.FindPath(from, to)
+	require.NoError(t, err)
+	require.Greater(t, len(path), 1)
+	require.Equal(t, to, path[len(path)-1])
+
+	current := from
+	for _, waypoint := range path {
+		require.True(t, geo.CanMoveTo(current, waypoint),
+			"%v -> %v", current, waypoint)
+		current = waypoint
+	}
+	// Smoothed path goes around end of wall instead of cell by cell.
+	require.LessOrEqual(t, len(path), 3)
+}
+
+func TestGeodata_FindPathStraight(t *testing.T) {
+	geo := openTest(t, newRegionBuilder())
+
+	path, err := geo.FindPath(at(5, 5, 0), at(100, 70, 0))
+	require.NoError(t, err)
+	require.Equal(t, []world.Position{at(100, 70, 0)}, path)
+}
+
+func TestGeodata_FindPathNoPath(t *testing.T) {
+	builder := newRegionBuilder()
+	for i := range 5 {
+		for _, surrounding := range [][2]int{
+			{50 + i, 50}, {50 + i, 54}, {50, 50 + i}, {54, 50 + i},
+		} {
+			builder.set(surrounding[0], surrounding[1],
+				testLayer{height: 1000, nswe: All})
+		}
+	}
+	geo := openTest(t, builder)
+
+	_, err := geo.FindPath(at(10, 10, 0), at(52, 52, 0))
+	require.True(t, errors.Is(err, ErrNoPath))
+}
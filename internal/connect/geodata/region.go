@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Region file of L2J geodata is sequence of blocks without header. Block
+// covers 8x8 cells, it is flat with single height, complex with height of
+// every cell, or multilayer with several heights of every cell.
+const (
+	blockFlat       = 0
+	blockComplex    = 1
+	blockMultilayer = 2
+
+	blockCells   = 8
+	regionBlocks = 256
+	regionCells  = blockCells * regionBlocks
+	blocksCount  = regionBlocks * regionBlocks
+	cellsInBlock = blockCells * blockCells
+	cellDataLen  = 2
+)
+
+// Directions in which character can leave cell.
+const (
+	East  = 1 << 0
+	West  = 1 << 1
+	South = 1 << 2
+	North = 1 << 3
+	All   = East | West | South | North
+)
+
+var errBrokenRegion = errors.New("broken geodata region")
+
+// layer is walkable surface of cell.
+type layer struct {
+	height int32
+	nswe   byte
+}
+
+// region is parsed view of region file, data stays in mapped file and is
+// decoded on access.
+type region struct {
+	data    []byte
+	offsets []int32
+}
+
+func parseRegion(data []byte) (*region, error) {
+	offsets := make([]int32, blocksCount)
+	offset := 0
+	for i := range offsets {
+		if offset >= len(data) {
+			return nil, fmt.Errorf("%w: block %d is missing",
+				errBrokenRegion, i)
+		}
+		offsets[i] = int32(offset) //nolint:gosec
+
+		size, err := blockSize(data, offset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: block %d: %w", errBrokenRegion, i, err)
+		}
+		offset += size
+	}
+	if offset != len(data) {
+		return nil, fmt.Errorf("%w: %d extra bytes",
+			errBrokenRegion, len(data)-offset)
+	}
+
+	return &region{data: data, offsets: offsets}, nil
+}
+
+// blockSize returns size of block at offset including type byte.
+func blockSize(data []byte, offset int) (int, error) {
+	size := 0
+	switch data[offset] {
+	case blockFlat:
+		size = 1 + cellDataLen
+	case blockComplex:
+		size = 1 + cellsInBlock*cellDataLen
+	case blockMultilayer:
+		size = 1
+		for range cellsInBlock {
+			if offset+size >= len(data) {
+				return 0, errors.New("layers are truncated")
+			}
+			layers := int(data[offset+size])
+			if layers == 0 {
+				return 0, errors.New("cell without layers")
+			}
+			size += 1 + layers*cellDataLen
+		}
+	default:
+		return 0, fmt.Errorf("unknown block type %d", data[offset])
+	}
+	if offset+size > len(data) {
+		return 0, errors.New("block is truncated")
+	}
+
+	return size, nil
+}
+
+func decodeCell(data []byte) layer {
+	value := int16(binary.LittleEndian.Uint16(data)) //nolint:gosec
+
+	return layer{
+		height: int32(value&^0x0f) >> 1,
+		nswe:   byte(value & 0x0f),
+	}
+}
+
+// nearest returns layer of cell closest to height z.
+func (r *region) nearest(cellX, cellY int, z int32) layer {
+	blockIndex := cellX/blockCells*regionBlocks + cellY/blockCells
+	offset := int(r.offsets[blockIndex])
+	cellIndex := cellX%blockCells*blockCells + cellY%blockCells
+
+	switch r.data[offset] {
+	case blockFlat:
+		value := binary.LittleEndian.Uint16(r.data[offset+1:])
+
+		return layer{height: int32(int16(value)), nswe: All} //nolint:gosec
+	case blockComplex:
+		return decodeCell(r.data[offset+1+cellIndex*cellDataLen:])
+	default:
+		return r.nearestLayer(offset+1, cellIndex, z)
+	}
+}
+
+func (r *region) nearestLayer(offset, cellIndex int, z int32) layer {
+	for range cellIndex {
+		offset += 1 + int(r.data[offset])*cellDataLen
+	}
+
+	layers := int(r.data[offset])
+	best := decodeCell(r.data[offset+1:])
+	for i := 1; i < layers; i++ {
+		candidate := decodeCell(r.data[offset+1+i*cellDataLen:])
+		if abs(candidate.height-z) < abs(best.height-z) {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+func abs(value int32) int32 {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package geodata
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testLayer is surface of cell in test region, heights must be multiple of 8
+// to survive encoding.
+type testLayer struct {
+	height int16
+	nswe   byte
+}
+
+// regionBuilder makes region file where all blocks are flat at zero
+// height except blocks with cells set by test.
+type regionBuilder struct {
+	cells map[cell][]testLayer
+}
+
+func newRegionBuilder() *regionBuilder {
+	return &regionBuilder{cells: make(map[cell][]testLayer)}
+}
+
+func (b *regionBuilder) set(x, y int, layers ...testLayer) *regionBuilder {
+	b.cells[cell{x: x, y: y}] = layers
+
+	return b
+}
+
+func encodeCell(surface testLayer) []byte {
+	height := uint16(surface.height<<1) & 0xfff0 //nolint:gosec
+	value := height | uint16(surface.nswe)
+
+	return binary.LittleEndian.AppendUint16(nil, value)
+}
+
+func (b *regionBuilder) block(blockX, blockY int) []byte {
+	multilayer := false
+	custom := false
+	for y := range blockCells {
+		for x := range blockCells {
+			layers, ok := b.cells[cell{
+				x: blockX*blockCells + x,
+				y: blockY*blockCells + y,
+			}]
+			custom = custom || ok
+			multilayer = multilayer || len(layers) > 1
+		}
+	}
+	if !custom {
+		return []byte{blockFlat, 0, 0}
+	}
+
+	data := []byte{blockComplex}
+	if multilayer {
+		data[0] = blockMultilayer
+	}
+	for x := range blockCells {
+		for y := range blockCells {
+			layers, ok := b.cells[cell{
+				x: blockX*blockCells + x,
+				y: blockY*blockCells + y,
+			}]
+			if !ok {
+				layers = []testLayer{{height: 0, nswe: All}}
+			}
+			if multilayer {
+				data = append(data, byte(len(layers)))
+			}
+			for _, surface := range layers {
+				data = append(data, encodeCell(surface)...)
+			}
+		}
+	}
+
+	return data
+}
+
+func (b *regionBuilder) bytes() []byte {
+	var data []byte
+	for blockX := range regionBlocks {
+		for blockY := range regionBlocks {
+			data = append(data, b.block(blockX, blockY)...)
+		}
+	}
+
+	return data
+}
+
+func TestParseRegion(t *testing.T) {
+	data := newRegionBuilder().
+		set(1, 2, testLayer{height: -40, nswe: East | North}).
+		set(9, 9, testLayer{height: 0, nswe: All},
+			testLayer{height: 400, nswe: West}).
+		bytes()
+	data[len(data)-2] = 0x10 // last flat block is 16 units high
+
+	parsed, err := parseRegion(data)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		x, y     int
+		z        int32
+		expected layer
+	}{
+		{
+			name:     "flat",
+			x:        100,
+			y:        100,
+			z:        0,
+			expected: layer{height: 0, nswe: All},
+		},
+		{
+			name:     "flat with height",
+			x:        regionCells - 1,
+			y:        regionCells - 1,
+			z:        0,
+			expected: layer{height: 16, nswe: All},
+		},
+		{
+			name:     "complex",
+			x:        1,
+			y:        2,
+			z:        0,
+			expected: layer{height: -40, nswe: East | North},
+		},
+		{
+			name:     "complex default cell",
+			x:        1,
+			y:        3,
+			z:        0,
+			expected: layer{height: 0, nswe: All},
+		},
+		{
+			name:     "lower layer",
+			x:        9,
+			y:        9,
+			z:        100,
+			expected: layer{height: 0, nswe: All},
+		},
+		{
+			name:     "upper layer",
+			x:        9,
+			y:        9,
+			z:        300,
+			expected: layer{height: 400, nswe: West},
+		},
+		{
+			name:     "single layer of multilayer block",
+			x:        10,
+			y:        9,
+			z:        300,
+			expected: layer{height: 0, nswe: All},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected,
+				parsed.nearest(test.x, test.y, test.z))
+		})
+	}
+}
+
+func TestParseRegion_Errors(t *testing.T) {
+	valid := newRegionBuilder().bytes()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "missing block", data: valid[:len(valid)-3]},
+		{name: "truncated block", data: valid[:len(valid)-1]},
+		{name: "extra bytes", data: append(append([]byte{}, valid...), 0)},
+		{name: "unknown type", data: append([]byte{7}, valid[1:]...)},
+		{
+			name: "cell without layers",
+			data: append([]byte{blockMultilayer, 0}, valid[3:]...),
+		},
+		{
+			name: "truncated layers",
+			data: []byte{blockMultilayer, 1, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseRegion(test.data)
+			require.True(t, errors.Is(err, errBrokenRegion))
+		})
+	}
+}
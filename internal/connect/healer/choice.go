@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package healer
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/combat"
+)
+
+// patient is bot which needs heal, HP includes heals on their way.
+type patient struct {
+	state board.State
+	hp    int32
+	share float64
+	// emergency is true when patient is about to die.
+	emergency bool
+	tank      bool
+}
+
+// nextHeal returns heal for bot about to die, then for tank, then for bot
+// with lowest HP share, mutex must be held.
+func (h *Healer) nextHeal(
+	team, all []board.State,
+	mana int32,
+) (Task, bool) {
+	var patients []patient
+	for _, state := range team {
+		if state.CurHP <= 0 || state.MaxHP <= 0 {
+			continue
+		}
+		hp := min(state.CurHP+h.incoming(state.ObjectID, all), state.MaxHP)
+		share := float64(hp) / float64(state.MaxHP)
+		if share >= h.settings.Threshold {
+			continue
+		}
+		patients = append(patients, patient{
+			state:     state,
+			hp:        hp,
+			share:     share,
+			emergency: share < h.settings.Emergency,
+			tank:      slices.Contains(h.settings.Tanks, state.Name),
+		})
+	}
+	if len(patients) == 0 {
+		return Task{}, false //nolint:exhaustruct
+	}
+
+	first := slices.MinFunc(patients, func(a, b patient) int {
+		switch {
+		case a.emergency != b.emergency && a.emergency:
+			return -1
+		case a.emergency != b.emergency:
+			return 1
+		case a.tank != b.tank && a.tank:
+			return -1
+		case a.tank != b.tank:
+			return 1
+		case a.share < b.share:
+			return -1
+		case a.share > b.share:
+			return 1
+		default:
+			return 0
+		}
+	})
+	heal, ok := h.chooseHeal(first, mana)
+	if !ok {
+		return Task{}, false //nolint:exhaustruct
+	}
+
+	return Task{
+		SkillID:  heal.SkillID,
+		Target:   first.state.Name,
+		TargetID: first.state.ObjectID,
+		Power:    heal.Power,
+	}, true
+}
+
+// incoming returns HP of heals which are cast at target now by this healer
+// and by other healers on board, mutex must be held.
+func (h *Healer) incoming(objectID int32, all []board.State) int32 {
+	var result int32
+	for _, skill := range h.pending[objectID] {
+		result += skill.power
+	}
+	for _, state := range all {
+		if state.Name == h.name || state.CastTarget != objectID {
+			continue
+		}
+		index := slices.IndexFunc(h.settings.Heals, func(heal Heal) bool {
+			return heal.SkillID == state.Casting
+		})
+		if index >= 0 {
+			result += h.settings.Heals[index].Power
+		}
+	}
+
+	return result
+}
+
+// chooseHeal returns fastest heal for patient about to die, otherwise
+// biggest heal which doesn't overheal, or smallest one if all of them do.
+// Heals which cost more than mana are skipped.
+func (h *Healer) chooseHeal(target patient, mana int32) (Heal, bool) {
+	var ready []Heal
+	for _, heal := range h.settings.Heals {
+		if heal.MP <= mana && h.caster.Ready(heal.SkillID) {
+			ready = append(ready, heal)
+		}
+	}
+	if len(ready) == 0 {
+		return Heal{}, false //nolint:exhaustruct
+	}
+
+	if target.emergency {
+		return slices.MinFunc(ready, func(a, b Heal) int {
+			if a.CastTime != b.CastTime {
+				return cmp.Compare(a.CastTime, b.CastTime)
+			}
+
+			return cmp.Compare(b.Power, a.Power)
+		}), true
+	}
+
+	deficit := target.state.MaxHP - target.hp
+	fitting := slices.DeleteFunc(slices.Clone(ready), func(heal Heal) bool {
+		return heal.Power > deficit
+	})
+	if len(fitting) == 0 {
+		return slices.MinFunc(ready, func(a, b Heal) int {
+			return cmp.Compare(a.Power, b.Power)
+		}), true
+	}
+
+	return slices.MaxFunc(fitting, func(a, b Heal) int {
+		return cmp.Compare(a.Power, b.Power)
+	}), true
+}
+
+// nextBuff returns buff which is missing on bot of team or expires soon,
+// mutex must be held.
+func (h *Healer) nextBuff(
+	team []board.State,
+	now time.Time,
+	mana int32,
+) (Task, bool) {
+	for _, buff := range h.settings.Buffs {
+		if buff.MP > mana || !h.caster.Ready(buff.SkillID) {
+			continue
+		}
+		for _, state := range team {
+			if state.CurHP <= 0 || h.buffed(state, buff, now) {
+				continue
+			}
+
+			return Task{
+				SkillID:  buff.SkillID,
+				Target:   state.Name,
+				TargetID: state.ObjectID,
+				Power:    0,
+			}, true
+		}
+	}
+
+	return Task{}, false //nolint:exhaustruct
+}
+
+// buffed reports if buff lasts long enough on bot or is on its way to it,
+// mutex must be held.
+func (h *Healer) buffed(state board.State, buff Buff, now time.Time) bool {
+	casting := func(skill pending) bool {
+		return skill.skillID == buff.SkillID
+	}
+	if slices.ContainsFunc(h.pending[state.ObjectID], casting) {
+		return true
+	}
+
+	lasting := func(effect combat.Effect) bool {
+		return effect.SkillID == buff.SkillID &&
+			effect.Left(now) > buff.Refresh
+	}
+
+	return slices.ContainsFunc(state.Effects, lasting)
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package healer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/connection"
+)
+
+const (
+	// DefaultInterval is how often healer looks at its team.
+	DefaultInterval = 200 * time.Millisecond
+	// DefaultThreshold is HP share below which bot is healed.
+	DefaultThreshold = 0.8
+	// DefaultEmergency is HP share below which fastest heal is cast.
+	DefaultEmergency = 0.35
+	// landingSettle is how long landed heal or buff is still counted, server
+	// sends new HP and effects of target shortly after skill lands.
+	landingSettle = time.Second
+)
+
+// ErrNothingToDo is returned when team needs neither heals nor buffs.
+var ErrNothingToDo = errors.New("team needs no support")
+
+// Heal is heal skill of healer. Power is HP it restores, cast time decides
+// which heal is cast when target is about to die. MP is mana skill costs.
+type Heal struct {
+	SkillID  int32
+	Power    int32
+	MP       int32
+	CastTime time.Duration
+}
+
+// Buff is buff healer keeps on team. It is cast again when it is missing or
+// expires sooner than refresh time. MP is mana skill costs.
+type Buff struct {
+	SkillID int32
+	MP      int32
+	Refresh time.Duration
+}
+
+// Settings are skills and priorities of healer.
+type Settings struct {
+	Heals     []Heal
+	Buffs     []Buff
+	Threshold float64
+	Emergency float64
+	// Tanks are names of bots healed before others.
+	Tanks []string
+	// Team are names of bots healer looks after, empty team means all bots
+	// of board, so other parties are healed too.
+	Team []string
+}
+
+// Caster casts skills and knows their cooldowns, it is implemented by
+// combat.
+type Caster interface {
+	Cast(ctx context.Context, request combat.Request) (combat.Cast, error)
+	Ready(skillID int32) bool
+}
+
+// Task is heal or buff healer casts next.
+type Task struct {
+	SkillID  int32
+	Target   string
+	TargetID int32
+	// Power is HP heal restores, it is zero for buffs.
+	Power int32
+}
+
+// pending is skill of healer on its way to target. Until is zero while
+// skill is cast.
+type pending struct {
+	skillID int32
+	power   int32
+	until   time.Time
+}
+
+// Healer keeps team alive by HP of bots from board rather than party
+// window, so it sees all bots of process.
+type Healer struct {
+	mutex    sync.Mutex
+	name     string
+	states   *board.Board
+	caster   Caster
+	settings Settings
+	// pending are own skills by target object ids.
+	pending map[int32][]pending
+	now     func() time.Time
+}
+
+// New returns healer of bot with name, name is used to tell own casts from
+// casts of other healers on board.
+func New(
+	name string,
+	states *board.Board,
+	caster Caster,
+	settings Settings,
+) *Healer {
+	return &Healer{
+		mutex:    sync.Mutex{},
+		name:     name,
+		states:   states,
+		caster:   caster,
+		settings: settings,
+		pending:  make(map[int32][]pending),
+		now:      time.Now,
+	}
+}
+
+// SetClock replaces clock of healer, it should be clock of world model.
+func (h *Healer) SetClock(now func() time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.now = now
+}
+
+// Next returns what healer should cast now. Heals go before buffs, skills
+// which cost more MP than healer has are skipped.
+func (h *Healer) Next() (Task, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := h.now()
+	h.forget(now)
+	all := h.states.All()
+	team := h.team(all)
+	mana := h.mana(all)
+	if task, ok := h.nextHeal(team, all, mana); ok {
+		return task, true
+	}
+
+	return h.nextBuff(team, now, mana)
+}
+
+// mana returns MP of healer. Healer which MP isn't known yet isn't stopped
+// from casting.
+func (h *Healer) mana(all []board.State) int32 {
+	index := slices.IndexFunc(all, func(state board.State) bool {
+		return state.Name == h.name
+	})
+	if index < 0 || all[index].MaxMP <= 0 {
+		return math.MaxInt32
+	}
+
+	return all[index].CurMP
+}
+
+// Act casts next heal or buff and waits until it lands.
+func (h *Healer) Act(ctx context.Context) error {
+	task, ok := h.Next()
+	if !ok {
+		return ErrNothingToDo
+	}
+
+	h.mutex.Lock()
+	h.pending[task.TargetID] = append(h.pending[task.TargetID], pending{
+		skillID: task.SkillID,
+		power:   task.Power,
+		until:   time.Time{},
+	})
+	h.mutex.Unlock()
+
+	_, err := h.caster.Cast(ctx, combat.Request{
+		SkillID:  task.SkillID,
+		TargetID: task.TargetID,
+		Ctrl:     false,
+		Shift:    false,
+	})
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.settle(task, err == nil)
+
+	return err
+}
+
+// Run acts with interval until context is done. Failed casts are logged
+// and tried again.
+func (h *Healer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := h.Act(ctx)
+		if err != nil && !errors.Is(err, ErrNothingToDo) &&
+			!errors.Is(err, connection.ErrNotInGame) && ctx.Err() == nil {
+			log.Printf("Error healing by %s: %v\n", h.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Node returns behavior tree leaf which casts next heal or buff. It fails
+// at once when team needs nothing, so tree can do something else.
+func (h *Healer) Node() behavior.Node {
+	return behavior.NewSequence(
+		behavior.NewCondition(func(*behavior.Tick) bool {
+			_, ok := h.Next()
+
+			return ok
+		}),
+		behavior.NewTask(func(ctx context.Context, _ behavior.Tick) error {
+			return h.Act(ctx)
+		}),
+	)
+}
+
+// settle marks own skill as landed or drops it if cast failed, mutex must
+// be held.
+func (h *Healer) settle(task Task, landed bool) {
+	skills := h.pending[task.TargetID]
+	index := slices.IndexFunc(skills, func(skill pending) bool {
+		return skill.skillID == task.SkillID && skill.until.IsZero()
+	})
+	if index < 0 {
+		return
+	}
+	if landed {
+		skills[index].until = h.now().Add(landingSettle)
+
+		return
+	}
+	h.pending[task.TargetID] = slices.Delete(skills, index, index+1)
+}
+
+// forget drops own skills which target already shows, mutex must be held.
+func (h *Healer) forget(now time.Time) {
+	for target, skills := range h.pending {
+		skills = slices.DeleteFunc(skills, func(skill pending) bool {
+			return !skill.until.IsZero() && now.After(skill.until)
+		})
+		if len(skills) == 0 {
+			delete(h.pending, target)
+
+			continue
+		}
+		h.pending[target] = skills
+	}
+}
+
+// team returns online bots healer looks after.
+func (h *Healer) team(all []board.State) []board.State {
+	var result []board.State
+	for _, state := range all {
+		if !state.Online || state.ObjectID == 0 {
+			continue
+		}
+		if len(h.settings.Team) > 0 &&
+			!slices.Contains(h.settings.Team, state.Name) {
+			continue
+		}
+		result = append(result, state)
+	}
+
+	return result
+}
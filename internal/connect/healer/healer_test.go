@@ -0,0 +1,456 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package healer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/board"
+	"github.com/melg8/connect/internal/connect/combat"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	healID      = 1011
+	greaterID   = 1217
+	battleID    = 1015
+	shieldID    = 1040
+	mightID     = 1068
+	maxHP       = 1000
+	healPower   = 100
+	greaterHeal = 400
+	battleHeal  = 200
+	healMP      = 20
+	greaterMP   = 80
+	battleMP    = 40
+)
+
+var start = time.Unix(1000, 0)
+
+// caster records casts and lands them at once.
+type caster struct {
+	mutex    sync.Mutex
+	casts    []combat.Request
+	cooldown map[int32]bool
+	err      error
+	// during is called while skill is cast.
+	during func()
+}
+
+func newCaster() *caster {
+	return &caster{
+		mutex:    sync.Mutex{},
+		casts:    nil,
+		cooldown: map[int32]bool{},
+		err:      nil,
+		during:   nil,
+	}
+}
+
+func (c *caster) Cast(
+	_ context.Context,
+	request combat.Request,
+) (combat.Cast, error) {
+	c.mutex.Lock()
+	c.casts = append(c.casts, request)
+	during, err := c.during, c.err
+	c.mutex.Unlock()
+
+	if during != nil {
+		during()
+	}
+
+	return combat.Cast{SkillID: request.SkillID}, err //nolint:exhaustruct
+}
+
+func (c *caster) Ready(skillID int32) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return !c.cooldown[skillID]
+}
+
+// teammate is bot on board fed by packets.
+type teammate struct {
+	t          *testing.T
+	objectID   int32
+	dispatcher *dispatch.Dispatcher
+}
+
+func join(t *testing.T, states *board.Board, name string, id int32) *teammate {
+	t.Helper()
+
+	model := world.New()
+	model.SetClock(func() time.Time { return start })
+	fight := combat.New(model, nil)
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	fight.Register(dispatcher)
+	member := states.Join(name, model, fight)
+	member.Register(dispatcher)
+
+	mate := &teammate{t: t, objectID: id, dispatcher: dispatcher}
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = id
+	info.CurHP, info.MaxHP = maxHP, maxHP
+	mate.feed(fromgameserver.UserInfoID, info)
+	member.SetOnline(true)
+
+	return mate
+}
+
+func (m *teammate) feed(id byte, p crypt.Serializable) {
+	m.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(m.t, p.ToBytes(writer))
+	require.NoError(m.t, m.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (m *teammate) hp(value int32) {
+	m.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: m.objectID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurHP, Value: value},
+		},
+	})
+}
+
+func (m *teammate) mp(current, maximum int32) {
+	m.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: m.objectID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurMP, Value: current},
+			{ID: fromgameserver.StatusMaxMP, Value: maximum},
+		},
+	})
+}
+
+func (m *teammate) casts(skillID, targetID int32) {
+	m.feed(fromgameserver.MagicSkillUseID, &fromgameserver.MagicSkillUse{
+		CasterID:   m.objectID,
+		TargetID:   targetID,
+		SkillID:    skillID,
+		SkillLevel: 1,
+		HitTime:    2000,
+		ReuseDelay: 0,
+		X:          0,
+		Y:          0,
+		Z:          0,
+	})
+}
+
+func (m *teammate) effects(effects ...fromgameserver.AbnormalEffect) {
+	m.feed(fromgameserver.AbnormalStatusUpdateID,
+		&fromgameserver.AbnormalStatusUpdate{Effects: effects})
+}
+
+func settings() Settings {
+	return Settings{
+		Heals: []Heal{
+			{SkillID: healID, Power: healPower, MP: healMP,
+				CastTime: 2 * time.Second},
+			{SkillID: greaterID, Power: greaterHeal, MP: greaterMP,
+				CastTime: 5 * time.Second},
+			{SkillID: battleID, Power: battleHeal, MP: battleMP,
+				CastTime: time.Second},
+		},
+		Buffs:     nil,
+		Threshold: DefaultThreshold,
+		Emergency: DefaultEmergency,
+		Tanks:     nil,
+		Team:      nil,
+	}
+}
+
+func newHealer(states *board.Board, c *caster, s Settings) *Healer {
+	h := New("healer", states, c, s)
+	h.SetClock(func() time.Time { return start })
+
+	return h
+}
+
+func TestHealer_ChooseHeal(t *testing.T) {
+	tests := []struct {
+		name      string
+		hp        int32
+		threshold float64
+		cooldown  []int32
+		skillID   int32
+	}{
+		{
+			name:      "healthy",
+			hp:        900,
+			threshold: DefaultThreshold,
+			cooldown:  nil,
+			skillID:   0,
+		},
+		{
+			name:      "biggest fitting",
+			hp:        550,
+			threshold: DefaultThreshold,
+			cooldown:  nil,
+			skillID:   greaterID,
+		},
+		{
+			name:      "small deficit",
+			hp:        750,
+			threshold: DefaultThreshold,
+			cooldown:  nil,
+			skillID:   battleID,
+		},
+		{
+			name:      "fitting on cooldown",
+			hp:        550,
+			threshold: DefaultThreshold,
+			cooldown:  []int32{greaterID},
+			skillID:   battleID,
+		},
+		{
+			name:      "all overheal",
+			hp:        920,
+			threshold: 0.95,
+			cooldown:  nil,
+			skillID:   healID,
+		},
+		{
+			name:      "emergency takes fastest",
+			hp:        100,
+			threshold: DefaultThreshold,
+			cooldown:  nil,
+			skillID:   battleID,
+		},
+		{
+			name:      "emergency without fastest",
+			hp:        100,
+			threshold: DefaultThreshold,
+			cooldown:  []int32{battleID},
+			skillID:   healID,
+		},
+		{
+			name:      "all on cooldown",
+			hp:        100,
+			threshold: DefaultThreshold,
+			cooldown:  []int32{battleID, healID, greaterID},
+			skillID:   0,
+		},
+		{
+			name:      "dead",
+			hp:        0,
+			threshold: DefaultThreshold,
+			cooldown:  nil,
+			skillID:   0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			states := board.New()
+			join(t, states, "tank", 5).hp(test.hp)
+			c := newCaster()
+			for _, skillID := range test.cooldown {
+				c.cooldown[skillID] = true
+			}
+			s := settings()
+			s.Threshold = test.threshold
+
+			task, ok := newHealer(states, c, s).Next()
+			require.Equal(t, test.skillID != 0, ok)
+			require.Equal(t, test.skillID, task.SkillID)
+		})
+	}
+}
+
+func TestHealer_Priority(t *testing.T) {
+	states := board.New()
+	join(t, states, "tank", 5).hp(700)
+	dps := join(t, states, "dps", 6)
+	dps.hp(500)
+	join(t, states, "other", 7).hp(100)
+
+	s := settings()
+	s.Team = []string{"tank", "dps"}
+	task, ok := newHealer(states, newCaster(), s).Next()
+	require.True(t, ok)
+	require.Equal(t, "dps", task.Target, "lowest share of team")
+	require.Equal(t, int32(6), task.TargetID)
+
+	s.Tanks = []string{"tank"}
+	task, _ = newHealer(states, newCaster(), s).Next()
+	require.Equal(t, "tank", task.Target, "tank goes first")
+
+	dps.hp(300)
+	task, _ = newHealer(states, newCaster(), s).Next()
+	require.Equal(t, "dps", task.Target, "bot about to die goes before tank")
+
+	task, _ = newHealer(states, newCaster(), settings()).Next()
+	require.Equal(t, "other", task.Target, "empty team means all bots")
+}
+
+func TestHealer_Mana(t *testing.T) {
+	states := board.New()
+	join(t, states, "tank", 5).hp(550)
+	self := join(t, states, "healer", 9)
+	s := settings()
+	s.Buffs = []Buff{{SkillID: shieldID, MP: 50, Refresh: time.Minute}}
+	h := newHealer(states, newCaster(), s)
+
+	task, _ := h.Next()
+	require.Equal(t, int32(greaterID), task.SkillID,
+		"unknown MP doesn't stop healer")
+
+	self.mp(50, 100)
+	task, _ = h.Next()
+	require.Equal(t, int32(battleID), task.SkillID,
+		"heal which costs too much is skipped")
+
+	self.mp(10, 100)
+	_, ok := h.Next()
+	require.False(t, ok, "nothing to cast without MP")
+}
+
+func TestHealer_InFlight(t *testing.T) {
+	states := board.New()
+	tank := join(t, states, "tank", 5)
+	tank.hp(700)
+	other := join(t, states, "other healer", 6)
+	h := newHealer(states, newCaster(), settings())
+
+	task, ok := h.Next()
+	require.True(t, ok)
+	require.Equal(t, "tank", task.Target)
+
+	other.casts(healID, 5)
+	_, ok = h.Next()
+	require.False(t, ok, "heal of other healer is counted")
+
+	other.casts(mightID, 5)
+	_, ok = h.Next()
+	require.True(t, ok, "only heals are counted")
+}
+
+func TestHealer_Act(t *testing.T) {
+	states := board.New()
+	tank := join(t, states, "tank", 5)
+	tank.hp(650)
+	c := newCaster()
+	now := start
+	h := New("healer", states, c, settings())
+	h.SetClock(func() time.Time { return now })
+
+	var during bool
+	c.during = func() {
+		_, during = h.Next()
+	}
+	require.NoError(t, h.Act(context.Background()))
+	require.Equal(t, []combat.Request{{
+		SkillID:  battleID,
+		TargetID: 5,
+		Ctrl:     false,
+		Shift:    false,
+	}}, c.casts)
+	require.False(t, during, "own heal is counted while it is cast")
+
+	_, ok := h.Next()
+	require.False(t, ok, "landed heal is counted until HP is updated")
+	now = now.Add(2 * landingSettle)
+	_, ok = h.Next()
+	require.True(t, ok, "landed heal is forgotten")
+
+	c.err = combat.ErrInterrupted
+	c.during = nil
+	err := h.Act(context.Background())
+	require.True(t, errors.Is(err, combat.ErrInterrupted), err)
+	_, ok = h.Next()
+	require.True(t, ok, "failed heal isn't counted")
+
+	tank.hp(maxHP)
+	err = h.Act(context.Background())
+	require.True(t, errors.Is(err, ErrNothingToDo), err)
+}
+
+func TestHealer_Buffs(t *testing.T) {
+	states := board.New()
+	tank := join(t, states, "tank", 5)
+	dps := join(t, states, "dps", 6)
+	c := newCaster()
+	s := settings()
+	s.Buffs = []Buff{
+		{SkillID: shieldID, MP: 0, Refresh: time.Minute},
+		{SkillID: mightID, MP: 0, Refresh: time.Minute},
+	}
+	now := start
+	h := New("healer", states, c, s)
+	h.SetClock(func() time.Time { return now })
+
+	tank.effects(
+		fromgameserver.AbnormalEffect{SkillID: shieldID, Level: 1,
+			Duration: 600},
+		fromgameserver.AbnormalEffect{SkillID: mightID, Level: 1,
+			Duration: 30},
+	)
+	dps.effects(fromgameserver.AbnormalEffect{SkillID: shieldID, Level: 1,
+		Duration: fromgameserver.PermanentEffect})
+
+	task, ok := h.Next()
+	require.True(t, ok)
+	require.Equal(t, Task{SkillID: mightID, Target: "dps", TargetID: 6,
+		Power: 0}, task)
+
+	require.NoError(t, h.Act(context.Background()))
+	task, _ = h.Next()
+	require.Equal(t, "tank", task.Target, "buff on its way isn't cast again")
+	require.Equal(t, int32(mightID), task.SkillID,
+		"expiring buff is refreshed")
+
+	c.cooldown[mightID] = true
+	_, ok = h.Next()
+	require.False(t, ok)
+
+	dps.hp(100)
+	task, _ = h.Next()
+	require.Equal(t, int32(battleID), task.SkillID, "heals go first")
+}
+
+func TestHealer_Node(t *testing.T) {
+	states := board.New()
+	tank := join(t, states, "tank", 5)
+	h := newHealer(states, newCaster(), settings())
+	node := h.Node()
+	tick := &behavior.Tick{Now: start, Blackboard: behavior.NewBlackboard()}
+
+	require.Equal(t, behavior.Failure, node.Tick(context.Background(), tick))
+
+	tank.hp(100)
+	deadline := time.Now().Add(time.Second)
+	status := node.Tick(context.Background(), tick)
+	for status == behavior.Running && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		status = node.Tick(context.Background(), tick)
+	}
+	require.Equal(t, behavior.Success, status)
+}
+
+func TestHealer_Run(t *testing.T) {
+	states := board.New()
+	join(t, states, "tank", 5).hp(100)
+	c := newCaster()
+	h := newHealer(states, c, settings())
+	ctx, cancel := context.WithCancel(context.Background())
+	c.during = cancel
+
+	h.Run(ctx, time.Millisecond)
+	require.Len(t, c.casts, 1)
+}
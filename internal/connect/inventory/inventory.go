@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package inventory
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// AdenaID is item id of game money.
+const AdenaID = 57
+
+// Body parts of equipped items, they are slots of RequestUnEquipItem.
+const (
+	SlotUnderwear = 0x0001
+	SlotRightEar  = 0x0002
+	SlotLeftEar   = 0x0004
+	SlotNeck      = 0x0008
+	SlotRightRing = 0x0010
+	SlotLeftRing  = 0x0020
+	SlotHead      = 0x0040
+	SlotRightHand = 0x0080
+	SlotLeftHand  = 0x0100
+	SlotGloves    = 0x0200
+	SlotChest     = 0x0400
+	SlotLegs      = 0x0800
+	SlotFeet      = 0x1000
+	SlotBack      = 0x2000
+	SlotTwoHands  = 0x4000
+	SlotFullArmor = 0x8000
+)
+
+var (
+	ErrNoItem      = errors.New("no such item in inventory")
+	ErrNotEnough   = errors.New("not enough items")
+	ErrNotEquipped = errors.New("nothing is equipped in slot")
+)
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Item is item in inventory of character.
+type Item struct {
+	ObjectID int32
+	ItemID   int32
+	Count    int32
+	Enchant  int32
+	Equipped bool
+	BodyPart int32
+}
+
+func newItem(info fromgameserver.ItemInfo) Item {
+	return Item{
+		ObjectID: info.ObjectID,
+		ItemID:   info.ItemID,
+		Count:    info.Count,
+		Enchant:  int32(info.EnchantLevel),
+		Equipped: info.Equipped != 0,
+		BodyPart: info.BodyPart,
+	}
+}
+
+// Inventory is inventory of own character fed by ItemList and
+// InventoryUpdate.
+type Inventory struct {
+	mutex  sync.RWMutex
+	sender Sender
+	items  map[int32]Item
+	// autoShots are item ids of soulshots used automatically.
+	autoShots map[int32]bool
+}
+
+func New(sender Sender) *Inventory {
+	return &Inventory{
+		mutex:     sync.RWMutex{},
+		sender:    sender,
+		items:     make(map[int32]Item),
+		autoShots: make(map[int32]bool),
+	}
+}
+
+// Register subscribes inventory to item packets.
+func (i *Inventory) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.ItemListID, i.handleList)
+	dispatcher.Handle(fromgameserver.InventoryUpdateID, i.handleUpdate)
+	dispatcher.HandleExtended(fromgameserver.ExAutoSoulShotID,
+		i.handleAutoShot)
+}
+
+func (i *Inventory) handleList(data []byte) error {
+	list, err := fromgameserver.NewItemListFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.items = make(map[int32]Item, len(list.Items))
+	for _, info := range list.Items {
+		i.items[info.ObjectID] = newItem(info)
+	}
+
+	return nil
+}
+
+func (i *Inventory) handleUpdate(data []byte) error {
+	update, err := fromgameserver.NewInventoryUpdateFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for _, change := range update.Changes {
+		if change.Change == fromgameserver.ItemRemoved {
+			delete(i.items, change.Item.ObjectID)
+
+			continue
+		}
+		i.items[change.Item.ObjectID] = newItem(change.Item)
+	}
+
+	return nil
+}
+
+func (i *Inventory) handleAutoShot(data []byte) error {
+	auto, err := fromgameserver.NewExAutoSoulShotFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if auto.Enabled != 0 {
+		i.autoShots[auto.ItemID] = true
+	} else {
+		delete(i.autoShots, auto.ItemID)
+	}
+
+	return nil
+}
+
+// Clear forgets inventory, server sends it again after entering world.
+func (i *Inventory) Clear() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.items = make(map[int32]Item)
+	i.autoShots = make(map[int32]bool)
+}
+
+// Items returns all items ordered by object id.
+func (i *Inventory) Items() []Item {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return sorted(maps.Values(i.items))
+}
+
+func sorted(items func(yield func(Item) bool)) []Item {
+	return slices.SortedFunc(items, func(a, b Item) int {
+		return cmp.Compare(a.ObjectID, b.ObjectID)
+	})
+}
+
+// Item returns item by object id.
+func (i *Inventory) Item(objectID int32) (Item, bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	item, ok := i.items[objectID]
+
+	return item, ok
+}
+
+// ByItemID returns items of item id, like all stacks of potion.
+func (i *Inventory) ByItemID(itemID int32) []Item {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return sorted(func(yield func(Item) bool) {
+		for _, item := range i.items {
+			if item.ItemID == itemID && !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Count returns total count of items with item id.
+func (i *Inventory) Count(itemID int32) int64 {
+	var total int64
+	for _, item := range i.ByItemID(itemID) {
+		total += int64(item.Count)
+	}
+
+	return total
+}
+
+// Equipped returns item worn in body part slot.
+func (i *Inventory) Equipped(bodyPart int32) (Item, bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	for _, item := range i.items {
+		if item.Equipped && item.BodyPart&bodyPart != 0 {
+			return item, true
+		}
+	}
+
+	return Item{}, false //nolint:exhaustruct
+}
+
+// AutoShot reports if soulshot item is used automatically.
+func (i *Inventory) AutoShot(itemID int32) bool {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.autoShots[itemID]
+}
+
+// find returns first item with item id.
+func (i *Inventory) find(itemID int32) (Item, error) {
+	items := i.ByItemID(itemID)
+	if len(items) == 0 {
+		var none Item
+
+		return none, fmt.Errorf("%w: item id %d", ErrNoItem, itemID)
+	}
+
+	return items[0], nil
+}
+
+// Use uses item by object id, equipping gear or drinking potion.
+func (i *Inventory) Use(objectID int32) error {
+	if _, ok := i.Item(objectID); !ok {
+		return fmt.Errorf("%w: object %d", ErrNoItem, objectID)
+	}
+
+	return i.sender.WritePacket(&togameserver.UseItem{ObjectID: objectID})
+}
+
+// UseByItemID uses any item with item id, like potion of known kind.
+func (i *Inventory) UseByItemID(itemID int32) error {
+	item, err := i.find(itemID)
+	if err != nil {
+		return err
+	}
+
+	return i.Use(item.ObjectID)
+}
+
+// UnEquip takes off item worn in body part slot.
+func (i *Inventory) UnEquip(bodyPart int32) error {
+	if _, ok := i.Equipped(bodyPart); !ok {
+		return fmt.Errorf("%w: %#x", ErrNotEquipped, bodyPart)
+	}
+
+	return i.sender.WritePacket(
+		&togameserver.RequestUnEquipItem{BodyPart: bodyPart})
+}
+
+// checkCount returns error if inventory has less than count of item.
+func (i *Inventory) checkCount(objectID, count int32) error {
+	item, ok := i.Item(objectID)
+	if !ok {
+		return fmt.Errorf("%w: object %d", ErrNoItem, objectID)
+	}
+	if count <= 0 || count > item.Count {
+		return fmt.Errorf("%w: %d of %d", ErrNotEnough, count, item.Count)
+	}
+
+	return nil
+}
+
+// Destroy destroys count of item.
+func (i *Inventory) Destroy(objectID, count int32) error {
+	if err := i.checkCount(objectID, count); err != nil {
+		return err
+	}
+
+	return i.sender.WritePacket(&togameserver.RequestDestroyItem{
+		ObjectID: objectID,
+		Count:    count,
+	})
+}
+
+// Drop drops count of item on ground at position.
+func (i *Inventory) Drop(objectID, count int32, at world.Position) error {
+	if err := i.checkCount(objectID, count); err != nil {
+		return err
+	}
+
+	return i.sender.WritePacket(&togameserver.RequestDropItem{
+		ObjectID: objectID,
+		Count:    count,
+		X:        at.X,
+		Y:        at.Y,
+		Z:        at.Z,
+	})
+}
+
+// DropByItemID drops count of any item with item id, like adena, on ground
+// at position.
+func (i *Inventory) DropByItemID(
+	itemID, count int32,
+	at world.Position,
+) error {
+	item, err := i.find(itemID)
+	if err != nil {
+		return err
+	}
+
+	return i.Drop(item.ObjectID, count, at)
+}
+
+// SetAutoShot turns automatic use of soulshot item on or off.
+func (i *Inventory) SetAutoShot(itemID int32, enabled bool) error {
+	if enabled {
+		if _, err := i.find(itemID); err != nil {
+			return err
+		}
+	}
+
+	return i.sender.WritePacket(&togameserver.RequestAutoSoulShot{
+		ItemID:  itemID,
+		Enabled: enabled,
+	})
+}
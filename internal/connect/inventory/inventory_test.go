@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package inventory
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+}
+
+func (s *recordingSender) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.packets = append(s.packets, p)
+
+	return nil
+}
+
+func (s *recordingSender) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+type fixture struct {
+	t          *testing.T
+	sender     *recordingSender
+	dispatcher *dispatch.Dispatcher
+	inventory  *Inventory
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	sender := &recordingSender{mutex: sync.Mutex{}, packets: nil}
+	dispatcher := dispatch.NewDispatcher()
+	inventory := New(sender)
+	inventory.Register(dispatcher)
+
+	f := &fixture{
+		t:          t,
+		sender:     sender,
+		dispatcher: dispatcher,
+		inventory:  inventory,
+	}
+	f.feed(fromgameserver.ItemListID, &fromgameserver.ItemList{
+		ShowWindow: 0,
+		Items: []fromgameserver.ItemInfo{
+			info(1, AdenaID, 1000, 0),
+			info(2, 1060, 20, 0),
+			info(3, 1060, 5, 0),
+			info(4, 2369, 1, SlotRightHand),
+			info(5, 1835, 300, 0),
+		},
+	})
+
+	return f
+}
+
+func (f *fixture) feed(id byte, p crypt.Serializable) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (f *fixture) feedExtended(subID int16, p crypt.Serializable) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, writer.WriteInt16(subID))
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(dispatch.ExtendedID,
+		writer.Bytes()))
+}
+
+// info returns item, it is equipped if body part is given.
+func info(objectID, itemID, count, bodyPart int32) fromgameserver.ItemInfo {
+	var equipped int16
+	if bodyPart != 0 {
+		equipped = 1
+	}
+
+	return fromgameserver.ItemInfo{
+		Type1:          0,
+		ObjectID:       objectID,
+		ItemID:         itemID,
+		Count:          count,
+		Type2:          0,
+		CustomType1:    0,
+		Equipped:       equipped,
+		BodyPart:       bodyPart,
+		EnchantLevel:   0,
+		CustomType2:    0,
+		AugmentationID: 0,
+		Mana:           -1,
+	}
+}
+
+func TestInventoryTracksItems(t *testing.T) {
+	f := newFixture(t)
+	inventory := f.inventory
+
+	require.Len(t, inventory.Items(), 5)
+	require.Equal(t, int64(25), inventory.Count(1060))
+	require.Equal(t, int64(1000), inventory.Count(AdenaID))
+	require.Zero(t, inventory.Count(9999))
+
+	weapon, ok := inventory.Equipped(SlotRightHand)
+	require.True(t, ok)
+	require.Equal(t, int32(4), weapon.ObjectID)
+	_, ok = inventory.Equipped(SlotChest)
+	require.False(t, ok)
+
+	f.feed(fromgameserver.InventoryUpdateID, &fromgameserver.InventoryUpdate{
+		Changes: []fromgameserver.ItemChange{
+			{Change: fromgameserver.ItemModified, Item: info(1, AdenaID, 900, 0)},
+			{Change: fromgameserver.ItemRemoved, Item: info(3, 1060, 5, 0)},
+			{Change: fromgameserver.ItemAdded, Item: info(6, 1146, 1, SlotChest)},
+		},
+	})
+
+	require.Equal(t, int64(900), inventory.Count(AdenaID))
+	require.Equal(t, int64(20), inventory.Count(1060))
+	_, ok = inventory.Item(3)
+	require.False(t, ok)
+	armor, ok := inventory.Equipped(SlotChest)
+	require.True(t, ok)
+	require.Equal(t, int32(1146), armor.ItemID)
+
+	inventory.Clear()
+	require.Empty(t, inventory.Items())
+}
+
+func TestInventoryTracksAutoShots(t *testing.T) {
+	f := newFixture(t)
+
+	f.feedExtended(fromgameserver.ExAutoSoulShotID,
+		&fromgameserver.ExAutoSoulShot{ItemID: 1835, Enabled: 1})
+	require.True(t, f.inventory.AutoShot(1835))
+
+	f.feedExtended(fromgameserver.ExAutoSoulShotID,
+		&fromgameserver.ExAutoSoulShot{ItemID: 1835, Enabled: 0})
+	require.False(t, f.inventory.AutoShot(1835))
+}
+
+func TestInventoryActions(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(inventory *Inventory) error
+		sent   crypt.Serializable
+	}{
+		{
+			name:   "use",
+			action: func(i *Inventory) error { return i.Use(2) },
+			sent:   &togameserver.UseItem{ObjectID: 2},
+		},
+		{
+			name:   "use by item id",
+			action: func(i *Inventory) error { return i.UseByItemID(1060) },
+			sent:   &togameserver.UseItem{ObjectID: 2},
+		},
+		{
+			name:   "unequip",
+			action: func(i *Inventory) error { return i.UnEquip(SlotRightHand) },
+			sent:   &togameserver.RequestUnEquipItem{BodyPart: SlotRightHand},
+		},
+		{
+			name:   "destroy",
+			action: func(i *Inventory) error { return i.Destroy(2, 20) },
+			sent:   &togameserver.RequestDestroyItem{ObjectID: 2, Count: 20},
+		},
+		{
+			name: "drop",
+			action: func(i *Inventory) error {
+				return i.Drop(1, 10, world.Position{X: 1, Y: 2, Z: 3})
+			},
+			sent: &togameserver.RequestDropItem{
+				ObjectID: 1,
+				Count:    10,
+				X:        1,
+				Y:        2,
+				Z:        3,
+			},
+		},
+		{
+			name: "drop by item id",
+			action: func(i *Inventory) error {
+				return i.DropByItemID(AdenaID, 1, world.Position{X: 1, Y: 2, Z: 3})
+			},
+			sent: &togameserver.RequestDropItem{
+				ObjectID: 1,
+				Count:    1,
+				X:        1,
+				Y:        2,
+				Z:        3,
+			},
+		},
+		{
+			name:   "auto shot",
+			action: func(i *Inventory) error { return i.SetAutoShot(1835, true) },
+			sent: &togameserver.RequestAutoSoulShot{
+				ItemID:  1835,
+				Enabled: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			require.NoError(t, test.action(f.inventory))
+			require.Equal(t, []crypt.Serializable{test.sent}, f.sender.sent())
+		})
+	}
+}
+
+func TestInventoryActionsFail(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(inventory *Inventory) error
+		err    error
+	}{
+		{
+			name:   "use unknown",
+			action: func(i *Inventory) error { return i.Use(42) },
+			err:    ErrNoItem,
+		},
+		{
+			name:   "use missing item id",
+			action: func(i *Inventory) error { return i.UseByItemID(9999) },
+			err:    ErrNoItem,
+		},
+		{
+			name:   "unequip empty slot",
+			action: func(i *Inventory) error { return i.UnEquip(SlotFeet) },
+			err:    ErrNotEquipped,
+		},
+		{
+			name:   "destroy too many",
+			action: func(i *Inventory) error { return i.Destroy(2, 21) },
+			err:    ErrNotEnough,
+		},
+		{
+			name: "drop nothing",
+			action: func(i *Inventory) error {
+				return i.Drop(1, 0, world.Position{X: 0, Y: 0, Z: 0})
+			},
+			err: ErrNotEnough,
+		},
+		{
+			name: "drop by unknown item id",
+			action: func(i *Inventory) error {
+				return i.DropByItemID(9999, 1, world.Position{X: 0, Y: 0, Z: 0})
+			},
+			err: ErrNoItem,
+		},
+		{
+			name:   "auto shot without shots",
+			action: func(i *Inventory) error { return i.SetAutoShot(1463, true) },
+			err:    ErrNoItem,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			err := test.action(f.inventory)
+			require.True(t, errors.Is(err, test.err), err)
+			require.Empty(t, f.sender.sent())
+		})
+	}
+}
@@ -0,0 +1,419 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package lease
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTTL = time.Minute
+
+	accountPrefix  = "account-"
+	instancePrefix = "instance-"
+	lockExtension  = ".lock"
+)
+
+var (
+	ErrHeld = errors.New("lease is held by another owner")
+	ErrLost = errors.New("lease was taken over by another owner")
+)
+
+// Holder describes owner of single lease as it is stored in lock file.
+type Holder struct {
+	Key       string    `json:"key"`
+	Owner     string    `json:"owner"`
+	Host      string    `json:"host"`
+	PID       int       `json:"pid"`
+	Token     string    `json:"token"`
+	Acquired  time.Time `json:"acquired"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+func (h *Holder) String() string {
+	return fmt.Sprintf("%s held by %s (pid %d on %s) since %s",
+		h.Key, h.Owner, h.PID, h.Host, h.Acquired.Format(time.DateTime))
+}
+
+// Manager hands out exclusive leases backed by lock files in shared
+// directory. Each program instance uses own manager with unique owner name,
+// so two instances never log into same account at same time.
+type Manager struct {
+	dir   string
+	owner string
+	host  string
+	ttl   time.Duration
+
+	mutex  sync.Mutex
+	leases map[string]*Lease
+}
+
+// Lease is exclusive right to use some key (account, config) until released.
+type Lease struct {
+	manager *Manager
+	path    string
+
+	mutex  sync.Mutex
+	holder Holder
+}
+
+func NewManager(dir, owner string, ttl time.Duration) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create lock dir: %w", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host name: %w", err)
+	}
+
+	return &Manager{
+		dir:    dir,
+		owner:  owner,
+		host:   host,
+		ttl:    ttl,
+		mutex:  sync.Mutex{},
+		leases: make(map[string]*Lease),
+	}, nil
+}
+
+// AcquireAccount leases account login for this instance.
+func (m *Manager) AcquireAccount(login string) (*Lease, error) {
+	return m.acquire(accountPrefix + login)
+}
+
+// AcquireInstance leases config name, so same config can't be started twice.
+func (m *Manager) AcquireInstance(config string) (*Lease, error) {
+	return m.acquire(instancePrefix + config)
+}
+
+func (m *Manager) acquire(key string) (*Lease, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lease := &Lease{
+		manager: m,
+		path:    filepath.Join(m.dir, fileNameFor(key)),
+		mutex:   sync.Mutex{},
+		holder: Holder{
+			Key:       key,
+			Owner:     m.owner,
+			Host:      m.host,
+			PID:       os.Getpid(),
+			Token:     token,
+			Acquired:  now,
+			Heartbeat: now,
+		},
+	}
+
+	err = lease.create()
+	if errors.Is(err, fs.ErrExist) {
+		if err := m.freeStale(lease.path); err != nil {
+			return nil, err
+		}
+		err = lease.create()
+	}
+
+	if errors.Is(err, fs.ErrExist) {
+		return nil, m.heldError(lease.path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lock %s: %w", key, err)
+	}
+
+	m.mutex.Lock()
+	m.leases[key] = lease
+	m.mutex.Unlock()
+
+	return lease, nil
+}
+
+func (m *Manager) heldError(path string) error {
+	holder, err := readHolder(path)
+	if err != nil {
+		return ErrHeld
+	}
+
+	return fmt.Errorf("%w: %s", ErrHeld, holder.String())
+}
+
+// freeStale removes lock file if its holder is crashed process or didn't
+// refresh lease during ttl. Lock file is renamed before removal, so two
+// instances freeing same stale lease can't remove fresh lease of each other.
+func (m *Manager) freeStale(path string) error {
+	holder, err := readHolder(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		// Lock file can be partially written by its creator right now.
+		return nil //nolint:nilerr
+	}
+	if !m.isStale(holder) {
+		return nil
+	}
+
+	stalePath := path + ".stale-" + holder.Token
+	if err := os.Rename(path, stalePath); err != nil {
+		return nil //nolint:nilerr // Someone else freed it first.
+	}
+
+	renamed, err := readHolder(stalePath)
+	if err == nil && renamed.Token != holder.Token {
+		// Took over fresh lease of other instance, give it back.
+		return os.Rename(stalePath, path)
+	}
+
+	log.Printf("Freed stale lease %s\n", holder.String())
+
+	return os.Remove(stalePath)
+}
+
+// isStale reports if holder crashed or stopped refreshing its lease. Pid of
+// crashed process can be reused by other process, so live pid on same host
+// doesn't make old heartbeat fresh.
+func (m *Manager) isStale(holder *Holder) bool {
+	if holder.Host == m.host && !processAlive(holder.PID) {
+		return true
+	}
+
+	return time.Since(holder.Heartbeat) > m.ttl
+}
+
+// Holders reports current holders of all leases in lock dir.
+func (m *Manager) Holders() ([]Holder, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock dir: %w", err)
+	}
+
+	holders := make([]Holder, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != lockExtension {
+			continue
+		}
+		holder, err := readHolder(filepath.Join(m.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		holders = append(holders, *holder)
+	}
+
+	return holders, nil
+}
+
+// Refresh updates heartbeat of every lease held by this manager.
+func (m *Manager) Refresh() error {
+	m.mutex.Lock()
+	leases := make([]*Lease, 0, len(m.leases))
+	for _, lease := range m.leases {
+		leases = append(leases, lease)
+	}
+	m.mutex.Unlock()
+
+	var errs []error
+	for _, lease := range leases {
+		errs = append(errs, lease.Refresh())
+	}
+
+	return errors.Join(errs...)
+}
+
+// KeepAlive refreshes leases every ttl/3 until context is done.
+func (m *Manager) KeepAlive(ctx context.Context) {
+	ticker := time.NewTicker(m.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(); err != nil {
+				log.Printf("Error refreshing leases: %v\n", err)
+			}
+		}
+	}
+}
+
+// ReleaseAll releases every lease held by this manager.
+func (m *Manager) ReleaseAll() error {
+	m.mutex.Lock()
+	leases := make([]*Lease, 0, len(m.leases))
+	for _, lease := range m.leases {
+		leases = append(leases, lease)
+	}
+	m.mutex.Unlock()
+
+	var errs []error
+	for _, lease := range leases {
+		errs = append(errs, lease.Release())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (l *Lease) Holder() Holder {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.holder
+}
+
+func (l *Lease) create() error {
+	data, err := json.Marshal(&l.holder)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+
+	return errors.Join(err, file.Close())
+}
+
+// owned checks that lock file still belongs to this lease.
+func (l *Lease) owned() error {
+	holder, err := readHolder(l.path)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrLost, l.holder.Key, err)
+	}
+	if holder.Token != l.holder.Token {
+		return fmt.Errorf("%w: %s", ErrLost, holder.String())
+	}
+
+	return nil
+}
+
+// Refresh updates heartbeat in lock file. Lock file is replaced by renamed
+// temporary file, so readers never see it partially written.
+func (l *Lease) Refresh() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.owned(); err != nil {
+		return err
+	}
+
+	holder := l.holder
+	holder.Heartbeat = time.Now()
+	if err := replaceHolder(l.path, &holder); err != nil {
+		return err
+	}
+	// Lease could be freed as stale and taken over while it was written.
+	if err := l.owned(); err != nil {
+		return err
+	}
+	l.holder = holder
+
+	return nil
+}
+
+func (l *Lease) Release() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.manager.mutex.Lock()
+	delete(l.manager.leases, l.holder.Key)
+	l.manager.mutex.Unlock()
+
+	if err := l.owned(); err != nil {
+		return err
+	}
+
+	return os.Remove(l.path)
+}
+
+func readHolder(path string) (*Holder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	holder := &Holder{}
+	if err := json.Unmarshal(data, holder); err != nil {
+		return nil, fmt.Errorf("failed to parse lock %s: %w", path, err)
+	}
+
+	return holder, nil
+}
+
+// replaceHolder writes holder to temporary file next to lock file and
+// renames it over lock file.
+func replaceHolder(path string, holder *Holder) error {
+	data, err := json.Marshal(holder)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path),
+		filepath.Base(path)+".refresh-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary lock: %w", err)
+	}
+	_, err = file.Write(data)
+	err = errors.Join(err, file.Close())
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("failed to replace lock %s: %w", holder.Key, err)
+	}
+
+	return nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate lease token: %w", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// fileNameFor keeps readable characters of key and hex encodes the rest, so
+// any login can be used as file name on every platform.
+func fileNameFor(key string) string {
+	const hexValues = "0123456789abcdef"
+
+	var sb strings.Builder
+	for i := range len(key) {
+		b := key[i]
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z',
+			'0' <= b && b <= '9', b == '-', b == '.':
+			sb.WriteByte(b)
+		default:
+			sb.WriteByte('_')
+			sb.WriteByte(hexValues[b>>4])
+			sb.WriteByte(hexValues[b&0xF])
+		}
+	}
+	sb.WriteString(lockExtension)
+
+	return sb.String()
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package lease
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(t *testing.T, dir, owner string) *Manager {
+	t.Helper()
+
+	manager, err := NewManager(dir, owner, DefaultTTL)
+	require.NoError(t, err)
+
+	return manager
+}
+
+func writeLock(t *testing.T, dir, key string, holder Holder) {
+	t.Helper()
+
+	data, err := json.Marshal(&holder)
+	require.NoError(t, err)
+	path := filepath.Join(dir, fileNameFor(key))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestManager_AcquireAccount(t *testing.T) {
+	dir := t.TempDir()
+	main := newTestManager(t, dir, "main")
+	debug := newTestManager(t, dir, "debug")
+
+	lease, err := main.AcquireAccount("tank")
+	require.NoError(t, err)
+	require.Equal(t, "main", lease.Holder().Owner)
+
+	_, err = debug.AcquireAccount("tank")
+	require.True(t, errors.Is(err, ErrHeld))
+	require.Contains(t, err.Error(), "main")
+
+	_, err = debug.AcquireAccount("healer")
+	require.NoError(t, err)
+
+	require.NoError(t, lease.Release())
+	_, err = debug.AcquireAccount("tank")
+	require.NoError(t, err)
+}
+
+func TestManager_AcquireInstance(t *testing.T) {
+	dir := t.TempDir()
+	first := newTestManager(t, dir, "first")
+	second := newTestManager(t, dir, "second")
+
+	_, err := first.AcquireInstance("farm")
+	require.NoError(t, err)
+	_, err = second.AcquireInstance("farm")
+	require.True(t, errors.Is(err, ErrHeld))
+
+	_, err = second.AcquireAccount("farm")
+	require.NoError(t, err, "instance and account keys must not collide")
+}
+
+func TestManager_FreesLeaseOfCrashedProcess(t *testing.T) {
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "main")
+
+	writeLock(t, dir, accountPrefix+"tank", Holder{
+		Key:       accountPrefix + "tank",
+		Owner:     "crashed",
+		Host:      manager.host,
+		PID:       0,
+		Token:     "dead",
+		Acquired:  time.Now(),
+		Heartbeat: time.Now(),
+	})
+
+	lease, err := manager.AcquireAccount("tank")
+	require.NoError(t, err)
+	require.Equal(t, "main", lease.Holder().Owner)
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.stale-*"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestManager_RemoteLeaseExpiresByHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "main")
+
+	remote := Holder{
+		Key:       accountPrefix + "tank",
+		Owner:     "remote",
+		Host:      "other-host",
+		PID:       os.Getpid(),
+		Token:     "remote",
+		Acquired:  time.Now(),
+		Heartbeat: time.Now(),
+	}
+	writeLock(t, dir, remote.Key, remote)
+
+	_, err := manager.AcquireAccount("tank")
+	require.True(t, errors.Is(err, ErrHeld))
+
+	remote.Heartbeat = time.Now().Add(-2 * DefaultTTL)
+	writeLock(t, dir, remote.Key, remote)
+
+	_, err = manager.AcquireAccount("tank")
+	require.NoError(t, err)
+}
+
+func TestManager_LocalLeaseExpiresByHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "main")
+
+	local := Holder{
+		Key:       accountPrefix + "tank",
+		Owner:     "hung",
+		Host:      manager.host,
+		PID:       os.Getpid(),
+		Token:     "hung",
+		Acquired:  time.Now(),
+		Heartbeat: time.Now(),
+	}
+	writeLock(t, dir, local.Key, local)
+
+	_, err := manager.AcquireAccount("tank")
+	require.True(t, errors.Is(err, ErrHeld))
+
+	local.Heartbeat = time.Now().Add(-2 * DefaultTTL)
+	writeLock(t, dir, local.Key, local)
+
+	_, err = manager.AcquireAccount("tank")
+	require.NoError(t, err, "live pid can belong to other process")
+}
+
+func TestManager_Holders(t *testing.T) {
+	dir := t.TempDir()
+	main := newTestManager(t, dir, "main")
+	debug := newTestManager(t, dir, "debug")
+
+	_, err := main.AcquireAccount("tank")
+	require.NoError(t, err)
+	_, err = debug.AcquireAccount("user@mail")
+	require.NoError(t, err)
+
+	holders, err := main.Holders()
+	require.NoError(t, err)
+	require.Len(t, holders, 2)
+
+	owners := map[string]string{}
+	for _, holder := range holders {
+		owners[holder.Key] = holder.Owner
+	}
+	require.Equal(t, map[string]string{
+		accountPrefix + "tank":      "main",
+		accountPrefix + "user@mail": "debug",
+	}, owners)
+}
+
+func TestLease_RefreshDetectsTakeover(t *testing.T) {
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "main")
+
+	lease, err := manager.AcquireAccount("tank")
+	require.NoError(t, err)
+	before := lease.Holder().Heartbeat
+
+	require.NoError(t, manager.Refresh())
+	require.False(t, lease.Holder().Heartbeat.Before(before))
+	matches, err := filepath.Glob(filepath.Join(dir, "*.refresh-*"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	other := lease.Holder()
+	other.Owner = "other"
+	other.Token = "other"
+	writeLock(t, dir, other.Key, other)
+
+	require.True(t, errors.Is(lease.Refresh(), ErrLost))
+	require.True(t, errors.Is(lease.Release(), ErrLost))
+
+	holders, err := manager.Holders()
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	require.Equal(t, "other", holders[0].Owner)
+}
+
+func TestManager_ReleaseAll(t *testing.T) {
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, "main")
+
+	for _, login := range []string{"tank", "healer", "nuker"} {
+		_, err := manager.AcquireAccount(login)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, manager.ReleaseAll())
+
+	holders, err := manager.Holders()
+	require.NoError(t, err)
+	require.Empty(t, holders)
+}
+
+func TestFileNameFor(t *testing.T) {
+	require.Equal(t, "account-tank_5f01.lock", fileNameFor("account-tank_01"))
+	require.Equal(t, "account-a_40b_3ac.lock", fileNameFor("account-a@b:c"))
+}
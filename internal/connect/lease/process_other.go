@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package lease
+
+import "os"
+
+// On windows FindProcess opens process handle and fails for exited process.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+
+	return true
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"math"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// headingUnitsPerDegree converts degrees to game heading, full circle is
+// 65536 units.
+const headingUnitsPerDegree = 65536.0 / 360.0
+
+// Heading returns game heading of direction from one position to another.
+func Heading(from, to world.Position) int32 {
+	dx := float64(to.X) - float64(from.X)
+	dy := float64(to.Y) - float64(from.Y)
+	degrees := math.Atan2(dy, dx) * 180 / math.Pi
+	if degrees < 0 {
+		degrees += 360
+	}
+
+	return int32(degrees * headingUnitsPerDegree)
+}
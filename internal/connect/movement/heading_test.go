@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeading(t *testing.T) {
+	origin := world.Position{X: 0, Y: 0, Z: 0}
+	tests := []struct {
+		name     string
+		to       world.Position
+		expected int32
+	}{
+		{name: "east", to: world.Position{X: 10, Y: 0, Z: 0}, expected: 0},
+		{name: "south", to: world.Position{X: 0, Y: 10, Z: 0}, expected: 16384},
+		{name: "west", to: world.Position{X: -10, Y: 0, Z: 0}, expected: 32768},
+		{name: "north", to: world.Position{X: 0, Y: -10, Z: 0}, expected: 49152},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, Heading(origin, test.to))
+		})
+	}
+}
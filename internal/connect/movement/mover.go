@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// DefaultValidateInterval is how often game client reports position
+	// while character moves.
+	DefaultValidateInterval = time.Second
+	// confirmTimeout limits time position is predicted from request alone,
+	// server ignores requests to move where character can't go.
+	confirmTimeout = time.Second
+	tickInterval   = 100 * time.Millisecond
+)
+
+var ErrUnknownPosition = errors.New("position of character is unknown")
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Mover moves character of bot and predicts its position between packets
+// of server. Movement requested by bot is predicted at once, movement
+// confirmed by server is taken from world model.
+type Mover struct {
+	mutex            sync.Mutex
+	world            *world.World
+	sender           Sender
+	validateInterval time.Duration
+	// pending is requested movement not confirmed by server yet.
+	pending      *world.Motion
+	lastValidate time.Time
+	// validated is true when position after last movement is reported.
+	validated bool
+	changed   chan struct{}
+}
+
+func New(model *world.World, sender Sender) *Mover {
+	return &Mover{
+		mutex:            sync.Mutex{},
+		world:            model,
+		sender:           sender,
+		validateInterval: DefaultValidateInterval,
+		pending:          nil,
+		lastValidate:     time.Time{},
+		validated:        true,
+		changed:          make(chan struct{}),
+	}
+}
+
+// Register subscribes mover to confirmations of movement. It must be
+// called after world is registered, so world is updated first.
+func (m *Mover) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.MoveToLocationID,
+		m.selfHandler(fromgameserver.MoveToLocationID, true))
+	dispatcher.Handle(fromgameserver.StopMoveID,
+		m.selfHandler(fromgameserver.StopMoveID, true))
+	dispatcher.Handle(fromgameserver.ValidateLocationID,
+		m.selfHandler(fromgameserver.ValidateLocationID, false))
+}
+
+// selfHandler wakes up waiters of arrival when packet is about own
+// character. Confirmations replace requested movement with one from server.
+func (m *Mover) selfHandler(id byte, confirms bool) dispatch.Handler {
+	return func(data []byte) error {
+		objectID, ok := world.EntityObjectID(id, data)
+		if !ok || objectID != m.world.SelfID() {
+			return nil
+		}
+
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		if confirms {
+			m.pending = nil
+		}
+		m.notify()
+
+		return nil
+	}
+}
+
+// notify wakes up waiters of arrival, mutex must be held.
+func (m *Mover) notify() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// activePending returns requested movement if server may still confirm
+// it, mutex must be held.
+func (m *Mover) activePending(now time.Time) *world.Motion {
+	if m.pending == nil || now.Sub(m.pending.Started) >= confirmTimeout {
+		return nil
+	}
+
+	return m.pending
+}
+
+// Position returns predicted position of character.
+func (m *Mover) Position() (world.Position, bool) {
+	now := m.world.Now()
+
+	m.mutex.Lock()
+	pending := m.activePending(now)
+	m.mutex.Unlock()
+
+	if pending != nil {
+		return pending.At(now), true
+	}
+
+	self, ok := m.world.Self()
+	if !ok {
+		return world.Position{}, false //nolint:exhaustruct
+	}
+
+	return self.At(now), true
+}
+
+// Speed returns speed of character in units per second, zero if character
+// is unknown.
+func (m *Mover) Speed() float64 {
+	self, ok := m.world.Self()
+	if !ok {
+		return 0
+	}
+
+	return self.Speed()
+}
+
+// motion returns current movement of character, nil if it stands.
+func (m *Mover) motion(now time.Time) *world.Motion {
+	m.mutex.Lock()
+	pending := m.activePending(now)
+	m.mutex.Unlock()
+
+	if pending != nil {
+		return pending
+	}
+
+	self, ok := m.world.Self()
+	if !ok || !self.Moving(now) {
+		return nil
+	}
+
+	return self.Motion
+}
+
+// Moving reports if character moves now.
+func (m *Mover) Moving() bool {
+	return m.motion(m.world.Now()) != nil
+}
+
+// Destination returns where character moves to.
+func (m *Mover) Destination() (world.Position, bool) {
+	motion := m.motion(m.world.Now())
+	if motion == nil {
+		return world.Position{}, false //nolint:exhaustruct
+	}
+
+	return motion.To, true
+}
+
+// MoveTo asks server to move character to destination by straight line.
+func (m *Mover) MoveTo(destination world.Position) error {
+	origin, ok := m.Position()
+	if !ok {
+		return ErrUnknownPosition
+	}
+	self, _ := m.world.Self()
+
+	err := m.sender.WritePacket(&togameserver.MoveBackwardToLocation{
+		TargetX:  destination.X,
+		TargetY:  destination.Y,
+		TargetZ:  destination.Z,
+		OriginX:  origin.X,
+		OriginY:  origin.Y,
+		OriginZ:  origin.Z,
+		MoveType: togameserver.MoveByMouse,
+	})
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pending = &world.Motion{
+		From:    origin,
+		To:      destination,
+		Started: m.world.Now(),
+		Speed:   self.Speed(),
+	}
+	m.validated = false
+	m.notify()
+
+	return nil
+}
+
+// Tick reports position to server at cadence of game client: periodically
+// while character moves and once after it stops.
+func (m *Mover) Tick() error {
+	now := m.world.Now()
+	self, ok := m.world.Self()
+	if !ok {
+		return nil
+	}
+	position, _ := m.Position()
+	heading := self.Heading
+	motion := m.motion(now)
+	if motion != nil {
+		heading = Heading(motion.From, motion.To)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	switch {
+	case motion != nil:
+		if now.Sub(m.lastValidate) < m.validateInterval {
+			return nil
+		}
+		m.validated = false
+	case m.validated:
+		return nil
+	default:
+		m.validated = true
+	}
+	m.lastValidate = now
+
+	return m.sender.WritePacket(&togameserver.ValidatePosition{
+		X:       position.X,
+		Y:       position.Y,
+		Z:       position.Z,
+		Heading: heading,
+		Data:    0,
+	})
+}
+
+// Run calls Tick until context is done.
+func (m *Mover) Run(ctx context.Context) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := m.Tick()
+			if err != nil && !errors.Is(err, connection.ErrNotInGame) {
+				log.Printf("Error validating position: %v\n", err)
+			}
+		}
+	}
+}
+
+// WaitArrival waits until character stops.
+func (m *Mover) WaitArrival(ctx context.Context) error {
+	for {
+		now := m.world.Now()
+		motion := m.motion(now)
+		if motion == nil {
+			return nil
+		}
+
+		m.mutex.Lock()
+		changed := m.changed
+		m.mutex.Unlock()
+
+		wait := motion.Started.Add(motion.Duration()).Sub(now)
+		timer := time.NewTimer(min(max(wait, 0), tickInterval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-changed:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
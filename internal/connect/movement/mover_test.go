@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+const selfID = 7
+
+type recordingSender struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+	err     error
+}
+
+func (s *recordingSender) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.packets = append(s.packets, p)
+
+	return nil
+}
+
+func (s *recordingSender) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+type testClock struct {
+	mutex sync.Mutex
+	time  time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.time
+}
+
+func (c *testClock) advance(duration time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.time = c.time.Add(duration)
+}
+
+type fixture struct {
+	t          *testing.T
+	clock      *testClock
+	sender     *recordingSender
+	dispatcher *dispatch.Dispatcher
+	mover      *Mover
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	clock := &testClock{mutex: sync.Mutex{}, time: time.Unix(1000, 0)}
+	model := world.New()
+	model.SetClock(clock.now)
+	dispatcher := dispatch.NewDispatcher()
+	model.Register(dispatcher)
+	sender := &recordingSender{mutex: sync.Mutex{}, packets: nil, err: nil}
+	mover := New(model, sender)
+	mover.Register(dispatcher)
+
+	f := &fixture{
+		t:          t,
+		clock:      clock,
+		sender:     sender,
+		dispatcher: dispatcher,
+		mover:      mover,
+	}
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = selfID
+	info.RunSpeed = 100
+	info.Heading = 5
+	f.feed(fromgameserver.UserInfoID, info)
+
+	return f
+}
+
+func (f *fixture) feed(id byte, p crypt.Serializable) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func (f *fixture) confirmMove(from, to int32) {
+	f.t.Helper()
+
+	f.feed(fromgameserver.MoveToLocationID, &fromgameserver.MoveToLocation{
+		ObjectID: selfID,
+		DestX:    to,
+		DestY:    0,
+		DestZ:    0,
+		X:        from,
+		Y:        0,
+		Z:        0,
+	})
+}
+
+func (f *fixture) position() world.Position {
+	f.t.Helper()
+
+	position, ok := f.mover.Position()
+	require.True(f.t, ok)
+
+	return position
+}
+
+func TestMover_UnknownPosition(t *testing.T) {
+	sender := &recordingSender{mutex: sync.Mutex{}, packets: nil, err: nil}
+	mover := New(world.New(), sender)
+
+	_, ok := mover.Position()
+	require.False(t, ok)
+	require.Zero(t, mover.Speed())
+	require.Equal(t, ErrUnknownPosition,
+		mover.MoveTo(world.Position{X: 1, Y: 1, Z: 1}))
+	require.NoError(t, mover.Tick())
+	require.Empty(t, sender.sent())
+}
+
+func TestMover_MoveToPredictsBeforeConfirmation(t *testing.T) {
+	f := newFixture(t)
+	require.InDelta(t, 100, f.mover.Speed(), 0)
+
+	require.NoError(t, f.mover.MoveTo(world.Position{X: 1000, Y: 0, Z: 0}))
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.MoveBackwardToLocation{
+			TargetX:  1000,
+			TargetY:  0,
+			TargetZ:  0,
+			OriginX:  0,
+			OriginY:  0,
+			OriginZ:  0,
+			MoveType: togameserver.MoveByMouse,
+		},
+	}, f.sender.sent())
+
+	f.clock.advance(500 * time.Millisecond)
+	require.Equal(t, world.Position{X: 50, Y: 0, Z: 0}, f.position())
+	require.True(t, f.mover.Moving())
+	destination, ok := f.mover.Destination()
+	require.True(t, ok)
+	require.Equal(t, int32(1000), destination.X)
+
+	// Server never confirmed movement, character stays where it was.
+	f.clock.advance(confirmTimeout)
+	require.Equal(t, world.Position{X: 0, Y: 0, Z: 0}, f.position())
+	require.False(t, f.mover.Moving())
+	_, ok = f.mover.Destination()
+	require.False(t, ok)
+}
+
+func TestMover_ConfirmedMovement(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.mover.MoveTo(world.Position{X: 1000, Y: 0, Z: 0}))
+
+	f.clock.advance(100 * time.Millisecond)
+	f.confirmMove(10, 1000)
+	f.clock.advance(2 * time.Second)
+	require.Equal(t, world.Position{X: 210, Y: 0, Z: 0}, f.position())
+
+	f.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: selfID,
+		X:        220,
+		Y:        0,
+		Z:        0,
+		Heading:  0,
+	})
+	require.False(t, f.mover.Moving())
+	require.Equal(t, world.Position{X: 220, Y: 0, Z: 0}, f.position())
+}
+
+func TestMover_OtherCreaturesAreIgnored(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.mover.MoveTo(world.Position{X: 1000, Y: 0, Z: 0}))
+
+	f.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: selfID + 1,
+		X:        0,
+		Y:        0,
+		Z:        0,
+		Heading:  0,
+	})
+	require.True(t, f.mover.Moving())
+}
+
+func TestMover_TickCadence(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.mover.Tick())
+	require.Empty(t, f.sender.sent(), "standing character is validated")
+
+	f.confirmMove(0, 1000)
+	validations := func() []*togameserver.ValidatePosition {
+		var result []*togameserver.ValidatePosition
+		for _, p := range f.sender.sent() {
+			if validate, ok := p.(*togameserver.ValidatePosition); ok {
+				result = append(result, validate)
+			}
+		}
+
+		return result
+	}
+
+	require.NoError(t, f.mover.Tick())
+	f.clock.advance(DefaultValidateInterval / 2)
+	require.NoError(t, f.mover.Tick())
+	require.Len(t, validations(), 1)
+
+	f.clock.advance(DefaultValidateInterval / 2)
+	require.NoError(t, f.mover.Tick())
+	require.Len(t, validations(), 2)
+	require.Equal(t, &togameserver.ValidatePosition{
+		X:       100,
+		Y:       0,
+		Z:       0,
+		Heading: 0,
+		Data:    0,
+	}, validations()[1])
+
+	// After arrival position is reported once more.
+	f.clock.advance(time.Minute)
+	require.NoError(t, f.mover.Tick())
+	require.NoError(t, f.mover.Tick())
+	require.Len(t, validations(), 3)
+	require.Equal(t, int32(1000), validations()[2].X)
+	require.Equal(t, int32(5), validations()[2].Heading)
+}
+
+func TestMover_SendErrors(t *testing.T) {
+	f := newFixture(t)
+	f.sender.err = connection.ErrNotInGame
+
+	err := f.mover.MoveTo(world.Position{X: 1, Y: 0, Z: 0})
+	require.True(t, errors.Is(err, connection.ErrNotInGame))
+	require.False(t, f.mover.Moving())
+
+	f.confirmMove(0, 1000)
+	require.True(t, errors.Is(f.mover.Tick(), connection.ErrNotInGame))
+}
+
+func TestMover_WaitArrival(t *testing.T) {
+	f := newFixture(t)
+	require.NoError(t, f.mover.WaitArrival(context.Background()))
+
+	f.confirmMove(0, 1000)
+	done := make(chan error, 1)
+	go func() {
+		done <- f.mover.WaitArrival(context.Background())
+	}()
+
+	f.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: selfID,
+		X:        5,
+		Y:        0,
+		Z:        0,
+		Heading:  0,
+	})
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waiter isn't woken up by StopMove")
+	}
+}
+
+func TestMover_WaitArrivalCanceled(t *testing.T) {
+	f := newFixture(t)
+	f.confirmMove(0, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		20*time.Millisecond)
+	defer cancel()
+	require.True(t, errors.Is(f.mover.WaitArrival(ctx),
+		context.DeadlineExceeded))
+}
+
+func TestMover_Run(t *testing.T) {
+	f := newFixture(t)
+	f.confirmMove(0, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.mover.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for len(f.sender.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	require.NotEmpty(t, f.sender.sent())
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// arrivalDistance is how far from destination character may stop, server
+// corrects positions of moving characters by few units.
+const arrivalDistance = 16
+
+var ErrNotArrived = errors.New("character didn't reach destination")
+
+// Pathfinder finds waypoints around obstacles, last waypoint is
+// destination.
+type Pathfinder interface {
+	FindPath(from, to world.Position) ([]world.Position, error)
+}
+
+// Walk moves character to destination by path around obstacles, it returns
+// when character stops at destination. Character stopped by server short of
+// any waypoint is reported by ErrNotArrived, rest of path isn't walked.
+func (m *Mover) Walk(
+	ctx context.Context,
+	pathfinder Pathfinder,
+	destination world.Position,
+) error {
+	origin, ok := m.Position()
+	if !ok {
+		return ErrUnknownPosition
+	}
+
+	path, err := pathfinder.FindPath(origin, destination)
+	if err != nil {
+		return fmt.Errorf("failed to find path to %v: %w", destination, err)
+	}
+
+	for _, waypoint := range path {
+		if err := m.MoveTo(waypoint); err != nil {
+			return err
+		}
+		if err := m.WaitArrival(ctx); err != nil {
+			return err
+		}
+		if err := m.arrived(waypoint); err != nil {
+			return fmt.Errorf("failed to walk to %v: %w", destination, err)
+		}
+	}
+
+	return m.arrived(destination)
+}
+
+// arrived checks that character stopped at target. Server stops character
+// early when it is blocked or stunned, next waypoint may be unreachable in
+// straight line from there.
+func (m *Mover) arrived(target world.Position) error {
+	position, ok := m.Position()
+	if !ok {
+		return ErrUnknownPosition
+	}
+	if position.Distance(target) > arrivalDistance {
+		return fmt.Errorf("%w: %v stopped at %v",
+			ErrNotArrived, target, position)
+	}
+
+	return nil
+}
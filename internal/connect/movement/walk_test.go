@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package movement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedPath struct {
+	path []world.Position
+	err  error
+	from world.Position
+}
+
+func (p *fixedPath) FindPath(from, _ world.Position) ([]world.Position, error) {
+	p.from = from
+
+	return p.path, p.err
+}
+
+// walk walks by path while clock of world runs, so movement finishes.
+// Server confirms every requested movement.
+func (f *fixture) walk(
+	pathfinder Pathfinder,
+	destination world.Position,
+) error {
+	f.t.Helper()
+
+	return f.walkWith(pathfinder, destination, f.confirm)
+}
+
+// walkWith walks by path like walk, server answers requested movements with
+// respond.
+func (f *fixture) walkWith(
+	pathfinder Pathfinder,
+	destination world.Position,
+	respond func(move *togameserver.MoveBackwardToLocation),
+) error {
+	f.t.Helper()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- f.mover.Walk(context.Background(), pathfinder, destination)
+	}()
+
+	confirmed := len(f.sender.sent())
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		require.True(f.t, time.Now().Before(deadline), "walk doesn't finish")
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(time.Millisecond):
+		}
+
+		sent := f.sender.sent()
+		for _, p := range sent[confirmed:] {
+			if move, ok := p.(*togameserver.MoveBackwardToLocation); ok {
+				respond(move)
+			}
+		}
+		confirmed = len(sent)
+		f.clock.advance(100 * time.Millisecond)
+	}
+}
+
+func (f *fixture) confirm(move *togameserver.MoveBackwardToLocation) {
+	f.t.Helper()
+
+	f.feed(fromgameserver.MoveToLocationID, &fromgameserver.MoveToLocation{
+		ObjectID: selfID,
+		DestX:    move.TargetX,
+		DestY:    move.TargetY,
+		DestZ:    move.TargetZ,
+		X:        move.OriginX,
+		Y:        move.OriginY,
+		Z:        move.OriginZ,
+	})
+}
+
+func TestMover_Walk(t *testing.T) {
+	f := newFixture(t)
+	f.confirmMove(0, 0)
+	pathfinder := &fixedPath{
+		path: []world.Position{{X: 0, Y: 100, Z: 0}, {X: 100, Y: 100, Z: 0}},
+		err:  nil,
+		from: world.Position{}, //nolint:exhaustruct
+	}
+
+	err := f.walk(pathfinder, world.Position{X: 100, Y: 100, Z: 0})
+	require.NoError(t, err)
+	require.Equal(t, world.Position{}, pathfinder.from) //nolint:exhaustruct
+
+	var targets []world.Position
+	for _, p := range f.sender.sent() {
+		if move, ok := p.(*togameserver.MoveBackwardToLocation); ok {
+			targets = append(targets, world.Position{
+				X: move.TargetX,
+				Y: move.TargetY,
+				Z: move.TargetZ,
+			})
+		}
+	}
+	require.Equal(t, pathfinder.path, targets)
+}
+
+func TestMover_WalkStopsShort(t *testing.T) {
+	f := newFixture(t)
+	f.confirmMove(0, 0)
+	pathfinder := &fixedPath{
+		path: []world.Position{{X: 0, Y: 100, Z: 0}},
+		err:  nil,
+		from: world.Position{}, //nolint:exhaustruct
+	}
+
+	err := f.walk(pathfinder, world.Position{X: 100, Y: 100, Z: 0})
+	require.True(t, errors.Is(err, ErrNotArrived), err)
+	require.Equal(t, world.Position{X: 0, Y: 100, Z: 0}, f.position())
+
+	err = f.walk(pathfinder, world.Position{X: 10, Y: 100, Z: 0})
+	require.NoError(t, err, "character stopped close enough")
+}
+
+func TestMover_WalkStoppedOnWay(t *testing.T) {
+	f := newFixture(t)
+	f.confirmMove(0, 0)
+	pathfinder := &fixedPath{
+		path: []world.Position{{X: 0, Y: 100, Z: 0}, {X: 100, Y: 100, Z: 0}},
+		err:  nil,
+		from: world.Position{}, //nolint:exhaustruct
+	}
+
+	moves := 0
+	err := f.walkWith(pathfinder, world.Position{X: 100, Y: 100, Z: 0},
+		func(*togameserver.MoveBackwardToLocation) {
+			moves++
+			f.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+				ObjectID: selfID,
+				X:        0,
+				Y:        50,
+				Z:        0,
+				Heading:  0,
+			})
+		})
+	require.True(t, errors.Is(err, ErrNotArrived), err)
+	require.Equal(t, 1, moves, "rest of path isn't walked")
+	require.Equal(t, world.Position{X: 0, Y: 50, Z: 0}, f.position())
+}
+
+func TestMover_WalkErrors(t *testing.T) {
+	noPath := errors.New("no path")
+	var origin world.Position
+
+	f := newFixture(t)
+	f.confirmMove(0, 0)
+	err := f.mover.Walk(context.Background(),
+		&fixedPath{path: nil, err: noPath, from: origin},
+		world.Position{X: 1, Y: 1, Z: 1})
+	require.True(t, errors.Is(err, noPath))
+	require.Empty(t, f.sender.sent())
+
+	unknown := New(world.New(), f.sender)
+	err = unknown.Walk(context.Background(),
+		&fixedPath{path: nil, err: nil, from: origin},
+		world.Position{X: 1, Y: 1, Z: 1})
+	require.True(t, errors.Is(err, ErrUnknownPosition))
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+func readInt32s(reader *packet.Reader, values ...*int32) error {
+	for _, value := range values {
+		result, err := reader.ReadInt32()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func writeInt32s(writer *packet.Writer, values ...int32) error {
+	for _, value := range values {
+		if err := writer.WriteInt32(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/melg8/connect/internal/connect/packets/packet"
 )
 
+const GGAuthID = 0x0b
+
 type GGAuthPacket struct {
 	SessionID int32
 	Unknown   int32
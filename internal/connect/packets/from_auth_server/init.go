@@ -13,6 +13,8 @@ import (
 	"github.com/melg8/connect/internal/connect/packets/packet"
 )
 
+const InitID = 0x00
+
 type InitPacket struct {
 	SessionID       int32
 	ProtocolVersion int32
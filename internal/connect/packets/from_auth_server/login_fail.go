@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	LoginFailID     = 0x01
+	AccountKickedID = 0x02
+)
+
+// Reasons of LoginFail.
+const (
+	LoginFailSystemError    int32 = 0x01
+	LoginFailPassWrong      int32 = 0x02
+	LoginFailUserOrPass     int32 = 0x03
+	LoginFailAccessFailed   int32 = 0x04
+	LoginFailAccountInUse   int32 = 0x07
+	LoginFailServerOverload int32 = 0x0f
+	LoginFailMaintenance    int32 = 0x10
+)
+
+// LoginFail rejects account credentials. AccountKicked has same layout and
+// is sent for banned accounts.
+type LoginFail struct {
+	Reason int32
+}
+
+func NewLoginFailFromBytes(data []byte) (*LoginFail, error) {
+	reader := packet.NewReader(data)
+	packet := LoginFail{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *LoginFail) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.Reason)
+}
+
+func (p *LoginFail) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.Reason)
+}
+
+// Permanent reports if same credentials will be rejected again.
+func (p *LoginFail) Permanent() bool {
+	switch p.Reason {
+	case LoginFailPassWrong, LoginFailUserOrPass, LoginFailAccessFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *LoginFail) ToString() string {
+	return fmt.Sprintf("\nLoginFail:\n  Reason: %d", p.Reason)
+}
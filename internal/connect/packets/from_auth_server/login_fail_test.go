@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginFail_RoundTrip(t *testing.T) {
+	original := &LoginFail{Reason: LoginFailAccountInUse}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+	require.Equal(t, []byte{0x07, 0, 0, 0}, writer.Bytes())
+
+	decoded, err := NewLoginFailFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Reason: 7")
+
+	_, err = NewLoginFailFromBytes([]byte{0x07})
+	require.Error(t, err)
+}
+
+func TestLoginFail_Permanent(t *testing.T) {
+	tests := []struct {
+		reason    int32
+		permanent bool
+	}{
+		{reason: LoginFailSystemError, permanent: false},
+		{reason: LoginFailPassWrong, permanent: true},
+		{reason: LoginFailUserOrPass, permanent: true},
+		{reason: LoginFailAccessFailed, permanent: true},
+		{reason: LoginFailAccountInUse, permanent: false},
+		{reason: LoginFailServerOverload, permanent: false},
+		{reason: LoginFailMaintenance, permanent: false},
+	}
+
+	for _, test := range tests {
+		fail := LoginFail{Reason: test.reason}
+		require.Equal(t, test.permanent, fail.Permanent(), test.reason)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"github.com/melg8/connect/internal/connect/helpers"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const LoginOkID = 0x03
+
+// LoginOk accepts account credentials, its keys are sent back with server
+// list requests. Rest of packet is ignored.
+type LoginOk struct {
+	LoginOk1 int32
+	LoginOk2 int32
+}
+
+func NewLoginOkFromBytes(data []byte) (*LoginOk, error) {
+	reader := packet.NewReader(data)
+	packet := LoginOk{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *LoginOk) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.LoginOk1, &p.LoginOk2)
+}
+
+func (p *LoginOk) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.LoginOk1, p.LoginOk2)
+}
+
+func (p *LoginOk) ToString() string {
+	return "\nLoginOk:" +
+		"\n  LoginOk1: " + helpers.HexStringFromInt32(p.LoginOk1) +
+		"\n  LoginOk2: " + helpers.HexStringFromInt32(p.LoginOk2)
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginOk_RoundTrip(t *testing.T) {
+	original := &LoginOk{LoginOk1: 0x11223344, LoginOk2: -5}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	// Server appends fields which aren't decoded.
+	data := append(writer.Bytes(), 0, 0, 0, 0, 0xea, 0x03, 0, 0)
+	decoded, err := NewLoginOkFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "LoginOk1: 11223344")
+}
+
+func TestNewLoginOkFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewLoginOkFromBytes([]byte{0x01, 0, 0, 0, 0x02})
+	require.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PlayFailID = 0x06
+
+// PlayFail refuses login to game server, for example when it is full.
+type PlayFail struct {
+	Reason int8
+}
+
+func NewPlayFailFromBytes(data []byte) (*PlayFail, error) {
+	reader := packet.NewReader(data)
+	packet := PlayFail{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PlayFail) FromBytes(reader *packet.Reader) error {
+	reason, err := reader.ReadInt8()
+	if err != nil {
+		return err
+	}
+	p.Reason = reason
+
+	return nil
+}
+
+func (p *PlayFail) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt8(p.Reason)
+}
+
+func (p *PlayFail) ToString() string {
+	return fmt.Sprintf("\nPlayFail:\n  Reason: %d", p.Reason)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayFail_RoundTrip(t *testing.T) {
+	original := &PlayFail{Reason: 0x0f}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+	require.Equal(t, []byte{0x0f}, writer.Bytes())
+
+	decoded, err := NewPlayFailFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Reason: 15")
+
+	_, err = NewPlayFailFromBytes(nil)
+	require.Error(t, err)
+}
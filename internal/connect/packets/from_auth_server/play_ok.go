@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"github.com/melg8/connect/internal/connect/helpers"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PlayOkID = 0x07
+
+// PlayOk allows login to selected game server with given keys.
+type PlayOk struct {
+	PlayOk1 int32
+	PlayOk2 int32
+}
+
+func NewPlayOkFromBytes(data []byte) (*PlayOk, error) {
+	reader := packet.NewReader(data)
+	packet := PlayOk{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PlayOk) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.PlayOk1, &p.PlayOk2)
+}
+
+func (p *PlayOk) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.PlayOk1, p.PlayOk2)
+}
+
+func (p *PlayOk) ToString() string {
+	return "\nPlayOk:" +
+		"\n  PlayOk1: " + helpers.HexStringFromInt32(p.PlayOk1) +
+		"\n  PlayOk2: " + helpers.HexStringFromInt32(p.PlayOk2)
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayOk_RoundTrip(t *testing.T) {
+	original := &PlayOk{PlayOk1: 7, PlayOk2: -8}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewPlayOkFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "PlayOk1: 00000007")
+}
+
+func TestNewPlayOkFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewPlayOkFromBytes([]byte{0x07, 0, 0, 0})
+	require.Error(t, err)
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ServerListID = 0x04
+
+// GameServer is entry of ServerList, Up is 1 for running servers.
+type GameServer struct {
+	ID         int8
+	IP         [4]byte
+	Port       int32
+	AgeLimit   int8
+	PvP        int8
+	Online     int16
+	MaxPlayers int16
+	Up         int8
+	Flags      int32
+	Brackets   int8
+}
+
+// Address returns host and port for connection to game server.
+func (s *GameServer) Address() string {
+	ip := net.IPv4(s.IP[0], s.IP[1], s.IP[2], s.IP[3])
+
+	return net.JoinHostPort(ip.String(), strconv.Itoa(int(s.Port)))
+}
+
+// ServerList lists game servers of auth server, LastServer is id of server
+// account played on last time.
+type ServerList struct {
+	LastServer int8
+	Servers    []GameServer
+}
+
+func NewServerListFromBytes(data []byte) (*ServerList, error) {
+	reader := packet.NewReader(data)
+	packet := ServerList{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *ServerList) FromBytes(reader *packet.Reader) error {
+	count, err := reader.ReadInt8()
+	if err != nil {
+		return err
+	}
+	if p.LastServer, err = reader.ReadInt8(); err != nil {
+		return err
+	}
+
+	p.Servers = make([]GameServer, 0, max(count, 0))
+	for range count {
+		var server GameServer
+		if err := server.FromBytes(reader); err != nil {
+			return err
+		}
+		p.Servers = append(p.Servers, server)
+	}
+
+	return nil
+}
+
+func (s *GameServer) FromBytes(reader *packet.Reader) error {
+	var err error
+	if s.ID, err = reader.ReadInt8(); err != nil {
+		return err
+	}
+	ip, err := reader.ReadBytes(len(s.IP))
+	if err != nil {
+		return err
+	}
+	copy(s.IP[:], ip)
+	if s.Port, err = reader.ReadInt32(); err != nil {
+		return err
+	}
+	if s.AgeLimit, err = reader.ReadInt8(); err != nil {
+		return err
+	}
+	if s.PvP, err = reader.ReadInt8(); err != nil {
+		return err
+	}
+	if s.Online, err = reader.ReadInt16(); err != nil {
+		return err
+	}
+	if s.MaxPlayers, err = reader.ReadInt16(); err != nil {
+		return err
+	}
+	if s.Up, err = reader.ReadInt8(); err != nil {
+		return err
+	}
+	if s.Flags, err = reader.ReadInt32(); err != nil {
+		return err
+	}
+	s.Brackets, err = reader.ReadInt8()
+
+	return err
+}
+
+func (p *ServerList) ToBytes(writer *packet.Writer) error {
+	if len(p.Servers) > 127 {
+		return fmt.Errorf("too many servers: %d", len(p.Servers))
+	}
+	if err := writer.WriteInt8(int8(len(p.Servers))); err != nil {
+		return err
+	}
+	if err := writer.WriteInt8(p.LastServer); err != nil {
+		return err
+	}
+	for i := range p.Servers {
+		if err := p.Servers[i].ToBytes(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GameServer) ToBytes(writer *packet.Writer) error {
+	steps := []func() error{
+		func() error { return writer.WriteInt8(s.ID) },
+		func() error { return writer.WriteBytes(s.IP[:]) },
+		func() error { return writer.WriteInt32(s.Port) },
+		func() error { return writer.WriteInt8(s.AgeLimit) },
+		func() error { return writer.WriteInt8(s.PvP) },
+		func() error { return writer.WriteInt16(s.Online) },
+		func() error { return writer.WriteInt16(s.MaxPlayers) },
+		func() error { return writer.WriteInt8(s.Up) },
+		func() error { return writer.WriteInt32(s.Flags) },
+		func() error { return writer.WriteInt8(s.Brackets) },
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find returns server with given id.
+func (p *ServerList) Find(id int8) (*GameServer, bool) {
+	for i := range p.Servers {
+		if p.Servers[i].ID == id {
+			return &p.Servers[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func (p *ServerList) ToString() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "\nServerList:\n  LastServer: %d", p.LastServer)
+	for _, server := range p.Servers {
+		fmt.Fprintf(&builder, "\n  Server %d: %s up: %d online: %d/%d",
+			server.ID, server.Address(), server.Up,
+			server.Online, server.MaxPlayers)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerList_RoundTrip(t *testing.T) {
+	original := &ServerList{
+		LastServer: 2,
+		Servers: []GameServer{
+			{
+				ID: 1, IP: [4]byte{127, 0, 0, 1}, Port: 7777,
+				AgeLimit: 0, PvP: 0, Online: 3, MaxPlayers: 100,
+				Up: 1, Flags: 0, Brackets: 0,
+			},
+			{
+				ID: 2, IP: [4]byte{10, 0, 0, 2}, Port: 7778,
+				AgeLimit: 18, PvP: 1, Online: 0, MaxPlayers: 50,
+				Up: 0, Flags: 4, Brackets: 1,
+			},
+		},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+	require.Len(t, writer.Bytes(), 2+2*21)
+
+	decoded, err := NewServerListFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Server 1: 127.0.0.1:7777")
+
+	server, ok := decoded.Find(2)
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.2:7778", server.Address())
+	_, ok = decoded.Find(3)
+	require.False(t, ok)
+}
+
+func TestNewServerListFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewServerListFromBytes([]byte{0x01, 0x01, 0x01, 127, 0, 0})
+	require.Error(t, err)
+}
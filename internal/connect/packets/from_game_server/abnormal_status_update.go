@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	AbnormalStatusUpdateID = 0x7f
+	maxAbnormalEffects     = 256
+	// PermanentEffect is duration of effect which doesn't expire.
+	PermanentEffect = -1
+)
+
+// AbnormalEffect is buff or debuff on own character, duration is in
+// seconds.
+type AbnormalEffect struct {
+	SkillID  int32
+	Level    int16
+	Duration int32
+}
+
+// AbnormalStatusUpdate lists all effects on own character, it replaces
+// previous list.
+type AbnormalStatusUpdate struct {
+	Effects []AbnormalEffect
+}
+
+func NewAbnormalStatusUpdateFromBytes(
+	data []byte,
+) (*AbnormalStatusUpdate, error) {
+	reader := packet.NewReader(data)
+	packet := AbnormalStatusUpdate{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *AbnormalStatusUpdate) FromBytes(reader *packet.Reader) error {
+	var count int16
+	if err := readInt16s(reader, &count); err != nil {
+		return err
+	}
+	if count < 0 || count > maxAbnormalEffects {
+		return fmt.Errorf("invalid abnormal effects count: %d", count)
+	}
+
+	p.Effects = make([]AbnormalEffect, count)
+	for i := range p.Effects {
+		effect := &p.Effects[i]
+		if err := readInt32s(reader, &effect.SkillID); err != nil {
+			return err
+		}
+		if err := readInt16s(reader, &effect.Level); err != nil {
+			return err
+		}
+		if err := readInt32s(reader, &effect.Duration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *AbnormalStatusUpdate) ToBytes(writer *packet.Writer) error {
+	if len(p.Effects) > maxAbnormalEffects {
+		return errors.New("too many abnormal effects")
+	}
+	count := int16(len(p.Effects)) //nolint:gosec
+	if err := writeInt16s(writer, count); err != nil {
+		return err
+	}
+	for _, effect := range p.Effects {
+		if err := writeInt32s(writer, effect.SkillID); err != nil {
+			return err
+		}
+		if err := writeInt16s(writer, effect.Level); err != nil {
+			return err
+		}
+		if err := writeInt32s(writer, effect.Duration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *AbnormalStatusUpdate) ToString() string {
+	var sb strings.Builder
+	sb.WriteString("\nAbnormalStatusUpdate:")
+	for _, effect := range p.Effects {
+		sb.WriteString(fmt.Sprintf("\n  Skill %d level %d: %d s",
+			effect.SkillID, effect.Level, effect.Duration))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbnormalStatusUpdate_RoundTrip(t *testing.T) {
+	original := &AbnormalStatusUpdate{Effects: []AbnormalEffect{
+		{SkillID: 1040, Level: 3, Duration: 1200},
+		{SkillID: 1068, Level: 1, Duration: PermanentEffect},
+	}}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+	require.Len(t, writer.Bytes(), 2+2*10)
+
+	decoded, err := NewAbnormalStatusUpdateFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Skill 1040 level 3: 1200 s")
+}
+
+func TestNewAbnormalStatusUpdateFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int16
+	}{
+		{name: "no count", data: nil},
+		{name: "negative count", data: []int16{-1}},
+		{name: "too many effects", data: []int16{maxAbnormalEffects + 1}},
+		{name: "missing effect", data: []int16{1, 1040}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt16s(writer, test.data...))
+			_, err := NewAbnormalStatusUpdateFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAbnormalStatusUpdate_ToBytesTooManyEffects(t *testing.T) {
+	update := &AbnormalStatusUpdate{
+		Effects: make([]AbnormalEffect, maxAbnormalEffects+1),
+	}
+	require.Error(t, update.ToBytes(packet.NewWriter()))
+}
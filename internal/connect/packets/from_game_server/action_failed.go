@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ActionFailedID = 0x25
+
+// ActionFailed refuses last request of client, like cast of skill which
+// isn't ready. Packet has no body.
+type ActionFailed struct{}
+
+func (p *ActionFailed) FromBytes(_ *packet.Reader) error {
+	return nil
+}
+
+func (p *ActionFailed) ToBytes(_ *packet.Writer) error {
+	return nil
+}
+
+func (p *ActionFailed) ToString() string {
+	return "\nActionFailed:"
+}
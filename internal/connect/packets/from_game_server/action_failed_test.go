@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionFailed(t *testing.T) {
+	failed := &ActionFailed{}
+	writer := packet.NewWriter()
+	require.NoError(t, failed.ToBytes(writer))
+	require.Empty(t, writer.Bytes())
+	require.NoError(t, failed.FromBytes(packet.NewReader(nil)))
+	require.Contains(t, failed.ToString(), "ActionFailed")
+}
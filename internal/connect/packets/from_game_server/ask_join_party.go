@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const AskJoinPartyID = 0x39
+
+// AskJoinParty invites character to party, client answers with
+// RequestAnswerJoinParty.
+type AskJoinParty struct {
+	RequesterName    string
+	ItemDistribution int32
+}
+
+func NewAskJoinPartyFromBytes(data []byte) (*AskJoinParty, error) {
+	reader := packet.NewReader(data)
+	packet := AskJoinParty{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *AskJoinParty) FromBytes(reader *packet.Reader) error {
+	if err := readStrings(reader, &p.RequesterName); err != nil {
+		return err
+	}
+
+	return readInt32s(reader, &p.ItemDistribution)
+}
+
+func (p *AskJoinParty) ToBytes(writer *packet.Writer) error {
+	if err := writeStrings(writer, p.RequesterName); err != nil {
+		return err
+	}
+
+	return writeInt32s(writer, p.ItemDistribution)
+}
+
+func (p *AskJoinParty) ToString() string {
+	return fmt.Sprintf("\nAskJoinParty:\n  RequesterName: %s"+
+		"\n  ItemDistribution: %d", p.RequesterName, p.ItemDistribution)
+}
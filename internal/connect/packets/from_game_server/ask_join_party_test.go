@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskJoinParty_RoundTrip(t *testing.T) {
+	original := &AskJoinParty{RequesterName: "Tank", ItemDistribution: 2}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewAskJoinPartyFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "RequesterName: Tank")
+}
+
+func TestNewAskJoinPartyFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewAskJoinPartyFromBytes([]byte{'T', 0x00, 0x00, 0x00, 0x01})
+	require.Error(t, err)
+}
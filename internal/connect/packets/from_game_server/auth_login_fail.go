@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const AuthLoginFailID = 0x14
+
+// AuthLoginFail rejects AuthLogin, usually because keys from auth server
+// expired or account is already in game.
+type AuthLoginFail struct {
+	Reason int32
+}
+
+func NewAuthLoginFailFromBytes(data []byte) (*AuthLoginFail, error) {
+	reader := packet.NewReader(data)
+	packet := AuthLoginFail{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *AuthLoginFail) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.Reason)
+}
+
+func (p *AuthLoginFail) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.Reason)
+}
+
+func (p *AuthLoginFail) ToString() string {
+	return fmt.Sprintf("\nAuthLoginFail:\n  Reason: %d", p.Reason)
+}
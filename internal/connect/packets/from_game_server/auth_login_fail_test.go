@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthLoginFail_RoundTrip(t *testing.T) {
+	original := &AuthLoginFail{Reason: 2}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewAuthLoginFailFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Reason: 2")
+
+	_, err = NewAuthLoginFailFromBytes([]byte{0x02})
+	require.Error(t, err)
+}
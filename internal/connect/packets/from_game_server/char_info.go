@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	CharInfoID         = 0x03
+	charPaperdollSlots = 11
+)
+
+// CharInfo describes other player visible to client. Fields after private
+// store type are not decoded.
+type CharInfo struct {
+	X        int32
+	Y        int32
+	Z        int32
+	Heading  int32
+	ObjectID int32
+	Name     string
+	Race     int32
+	Sex      int32
+	ClassID  int32
+
+	PaperdollItemIDs [charPaperdollSlots]int32
+
+	PvPFlag       int32
+	Karma         int32
+	MAtkSpd       int32
+	PAtkSpd       int32
+	PvPFlag2      int32
+	Karma2        int32
+	RunSpeed      int32
+	WalkSpeed     int32
+	SwimRunSpeed  int32
+	SwimWalkSpeed int32
+	FlRunSpeed    int32
+	FlWalkSpeed   int32
+	FlyRunSpeed   int32
+	FlyWalkSpeed  int32
+
+	MoveMultiplier        float64
+	AttackSpeedMultiplier float64
+	CollisionRadius       float64
+	CollisionHeight       float64
+
+	HairStyle int32
+	HairColor int32
+	Face      int32
+	Title     string
+	ClanID    int32
+	CrestID   int32
+	AllyID    int32
+	AllyCrest int32
+	Unknown   int32
+
+	Standing     int8
+	Running      int8
+	InCombat     int8
+	AlikeDead    int8
+	Invisible    int8
+	MountType    int8
+	PrivateStore int8
+}
+
+func NewCharInfoFromBytes(data []byte) (*CharInfo, error) {
+	reader := packet.NewReader(data)
+	packet := CharInfo{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *CharInfo) head() []*int32 {
+	return []*int32{
+		&p.X, &p.Y, &p.Z, &p.Heading, &p.ObjectID,
+	}
+}
+
+func (p *CharInfo) appearance() []*int32 {
+	return []*int32{&p.Race, &p.Sex, &p.ClassID}
+}
+
+func (p *CharInfo) speeds() []*int32 {
+	return []*int32{
+		&p.PvPFlag, &p.Karma, &p.MAtkSpd, &p.PAtkSpd, &p.PvPFlag2, &p.Karma2,
+		&p.RunSpeed, &p.WalkSpeed, &p.SwimRunSpeed, &p.SwimWalkSpeed,
+		&p.FlRunSpeed, &p.FlWalkSpeed, &p.FlyRunSpeed, &p.FlyWalkSpeed,
+	}
+}
+
+func (p *CharInfo) sizes() []*float64 {
+	return []*float64{
+		&p.MoveMultiplier, &p.AttackSpeedMultiplier,
+		&p.CollisionRadius, &p.CollisionHeight,
+	}
+}
+
+func (p *CharInfo) looks() []*int32 {
+	return []*int32{&p.HairStyle, &p.HairColor, &p.Face}
+}
+
+func (p *CharInfo) clan() []*int32 {
+	return []*int32{
+		&p.ClanID, &p.CrestID, &p.AllyID, &p.AllyCrest, &p.Unknown,
+	}
+}
+
+func (p *CharInfo) flags() []*int8 {
+	return []*int8{
+		&p.Standing, &p.Running, &p.InCombat, &p.AlikeDead,
+		&p.Invisible, &p.MountType, &p.PrivateStore,
+	}
+}
+
+func (p *CharInfo) FromBytes(reader *packet.Reader) error {
+	steps := []func() error{
+		func() error { return readInt32s(reader, p.head()...) },
+		func() error { return readStrings(reader, &p.Name) },
+		func() error { return readInt32s(reader, p.appearance()...) },
+		func() error {
+			return readInt32s(reader, int32Pointers(p.PaperdollItemIDs[:])...)
+		},
+		func() error { return readInt32s(reader, p.speeds()...) },
+		func() error { return readFloat64s(reader, p.sizes()...) },
+		func() error { return readInt32s(reader, p.looks()...) },
+		func() error { return readStrings(reader, &p.Title) },
+		func() error { return readInt32s(reader, p.clan()...) },
+		func() error { return readInt8s(reader, p.flags()...) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *CharInfo) ToBytes(writer *packet.Writer) error {
+	steps := []func() error{
+		func() error { return writeInt32s(writer, values(p.head())...) },
+		func() error { return writeStrings(writer, p.Name) },
+		func() error { return writeInt32s(writer, values(p.appearance())...) },
+		func() error { return writeInt32s(writer, p.PaperdollItemIDs[:]...) },
+		func() error { return writeInt32s(writer, values(p.speeds())...) },
+		func() error { return writeFloat64s(writer, values(p.sizes())...) },
+		func() error { return writeInt32s(writer, values(p.looks())...) },
+		func() error { return writeStrings(writer, p.Title) },
+		func() error { return writeInt32s(writer, values(p.clan())...) },
+		func() error { return writeInt8s(writer, values(p.flags())...) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *CharInfo) ToString() string {
+	return fmt.Sprintf("\nCharInfo:\n  ObjectID: %d\n  Name: %s"+
+		"\n  Title: %s\n  Location: %d %d %d\n  ClassID: %d",
+		p.ObjectID, p.Name, p.Title, p.X, p.Y, p.Z, p.ClassID)
+}
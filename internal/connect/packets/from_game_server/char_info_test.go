@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharInfo_RoundTrip(t *testing.T) {
+	original := &CharInfo{} //nolint:exhaustruct
+	original.X, original.Y, original.Z = 10, 20, -30
+	original.ObjectID = 268435457
+	original.Name = "Healer"
+	original.Title = "Support"
+	original.PaperdollItemIDs[0] = 6
+	original.RunSpeed = 115
+	original.CollisionHeight = 22.5
+	original.Running = 1
+
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewCharInfoFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Title: Support")
+}
+
+func TestNewCharInfoFromBytes_Truncated(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&CharInfo{}).ToBytes(writer)) //nolint:exhaustruct
+	data := writer.Bytes()
+
+	for _, size := range []int{0, 20, 22, 40, 90, 160, len(data) - 1} {
+		_, err := NewCharInfoFromBytes(data[:size])
+		require.Error(t, err, "size %d", size)
+	}
+}
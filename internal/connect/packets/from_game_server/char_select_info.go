@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const CharSelectInfoID = 0x13
+
+// CharacterSlot is character entry of CharSelectInfo. Layout of entry after
+// account session id differs between protocol revisions, it is kept as is
+// in Details.
+type CharacterSlot struct {
+	Name     string
+	ObjectID int32
+	Details  []byte
+}
+
+// CharSelectInfo lists characters of account in slot order. Every entry
+// repeats login and session id of account, entries are split by them, so
+// packet is decoded without knowing protocol revision.
+type CharSelectInfo struct {
+	Login      string
+	SessionID  int32
+	Characters []CharacterSlot
+}
+
+func NewCharSelectInfoFromBytes(data []byte) (*CharSelectInfo, error) {
+	reader := packet.NewReader(data)
+	packet := CharSelectInfo{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *CharSelectInfo) FromBytes(reader *packet.Reader) error {
+	count, err := reader.ReadInt32()
+	if err != nil {
+		return err
+	}
+	p.Characters = make([]CharacterSlot, 0, max(count, 0))
+	if count <= 0 {
+		return nil
+	}
+
+	data, err := reader.ReadBytes(reader.Len())
+	if err != nil {
+		return err
+	}
+	var first CharacterSlot
+	if err := p.readHead(packet.NewReader(data), &first); err != nil {
+		return err
+	}
+
+	marker, err := p.marker()
+	if err != nil {
+		return err
+	}
+	positions := findAll(data, marker)
+	if len(positions) != int(count) {
+		return fmt.Errorf("found %d of %d characters", len(positions), count)
+	}
+
+	// Entries differ only by name length, so size of tail after marker is
+	// same for all of them and last entry ends with packet.
+	tail := len(data) - positions[len(positions)-1] - len(marker)
+	start := 0
+	for _, position := range positions {
+		end := position + len(marker) + tail
+		if end > len(data) || start > position {
+			return fmt.Errorf("broken character entry at %d", start)
+		}
+		slot, err := p.readSlot(data[start:end])
+		if err != nil {
+			return err
+		}
+		p.Characters = append(p.Characters, slot)
+		start = end
+	}
+
+	return nil
+}
+
+// readHead reads fields of entry before details, login and session id are
+// stored into packet.
+func (p *CharSelectInfo) readHead(
+	reader *packet.Reader,
+	slot *CharacterSlot,
+) error {
+	return runSteps([]func() error{
+		func() error { return readStrings(reader, &slot.Name) },
+		func() error { return readInt32s(reader, &slot.ObjectID) },
+		func() error { return readStrings(reader, &p.Login) },
+		func() error { return readInt32s(reader, &p.SessionID) },
+	})
+}
+
+func (p *CharSelectInfo) readSlot(entry []byte) (CharacterSlot, error) {
+	var slot CharacterSlot
+	login, session := p.Login, p.SessionID
+	reader := packet.NewReader(entry)
+	if err := p.readHead(reader, &slot); err != nil {
+		return slot, err
+	}
+	if p.Login != login || p.SessionID != session {
+		return slot, fmt.Errorf("character %s is of other account", slot.Name)
+	}
+
+	slot.Details = []byte{}
+	if reader.Len() == 0 {
+		return slot, nil
+	}
+	details, err := reader.ReadBytes(reader.Len())
+	slot.Details = details
+
+	return slot, err
+}
+
+// marker is login and session id which follow name of every character.
+func (p *CharSelectInfo) marker() ([]byte, error) {
+	writer := packet.NewWriter()
+	if err := writeStrings(writer, p.Login); err != nil {
+		return nil, err
+	}
+	if err := writeInt32s(writer, p.SessionID); err != nil {
+		return nil, err
+	}
+
+	return writer.Bytes(), nil
+}
+
+func findAll(data, pattern []byte) []int {
+	var positions []int
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], pattern)
+		if index < 0 {
+			return positions
+		}
+		positions = append(positions, offset+index)
+		offset += index + len(pattern)
+	}
+}
+
+func (p *CharSelectInfo) ToBytes(writer *packet.Writer) error {
+	//nolint:gosec // Account has few characters.
+	if err := writer.WriteInt32(int32(len(p.Characters))); err != nil {
+		return err
+	}
+	for _, slot := range p.Characters {
+		err := runSteps([]func() error{
+			func() error { return writeStrings(writer, slot.Name) },
+			func() error { return writeInt32s(writer, slot.ObjectID) },
+			func() error { return writeStrings(writer, p.Login) },
+			func() error { return writeInt32s(writer, p.SessionID) },
+			func() error { return writer.WriteBytes(slot.Details) },
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Slot returns slot of character with given name, names are compared
+// ignoring case like server does.
+func (p *CharSelectInfo) Slot(name string) (int32, bool) {
+	for i, slot := range p.Characters {
+		if strings.EqualFold(slot.Name, name) {
+			return int32(i), true //nolint:gosec
+		}
+	}
+
+	return 0, false
+}
+
+func (p *CharSelectInfo) ToString() string {
+	names := make([]string, 0, len(p.Characters))
+	for _, slot := range p.Characters {
+		names = append(names, slot.Name)
+	}
+
+	return fmt.Sprintf("\nCharSelectInfo:\n  Login: %s\n  SessionID: %d"+
+		"\n  Characters: %s",
+		p.Login, p.SessionID, strings.Join(names, ", "))
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharSelectInfo_RoundTrip(t *testing.T) {
+	details := []byte{0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x07}
+	tests := []struct {
+		name     string
+		original *CharSelectInfo
+	}{
+		{
+			name: "empty account",
+			original: &CharSelectInfo{
+				Login: "", SessionID: 0, Characters: []CharacterSlot{},
+			},
+		},
+		{
+			name: "names of different length",
+			original: &CharSelectInfo{
+				Login:     "user",
+				SessionID: 0x1234,
+				Characters: []CharacterSlot{
+					{Name: "Bob", ObjectID: 1, Details: details},
+					{Name: "Alexander", ObjectID: 2, Details: details},
+					{Name: "Al", ObjectID: 3, Details: details},
+				},
+			},
+		},
+		{
+			name: "no details",
+			original: &CharSelectInfo{
+				Login:     "user",
+				SessionID: 1,
+				Characters: []CharacterSlot{
+					{Name: "Bob", ObjectID: 1, Details: []byte{}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, test.original.ToBytes(writer))
+
+			decoded, err := NewCharSelectInfoFromBytes(writer.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, test.original, decoded)
+		})
+	}
+}
+
+func TestCharSelectInfo_Slot(t *testing.T) {
+	info := CharSelectInfo{
+		Login:     "user",
+		SessionID: 1,
+		Characters: []CharacterSlot{
+			{Name: "Bob", ObjectID: 1, Details: nil},
+			{Name: "Alice", ObjectID: 2, Details: nil},
+		},
+	}
+
+	slot, ok := info.Slot("alice")
+	require.True(t, ok)
+	require.Equal(t, int32(1), slot)
+	_, ok = info.Slot("Eve")
+	require.False(t, ok)
+	require.Contains(t, info.ToString(), "Characters: Bob, Alice")
+}
+
+func TestNewCharSelectInfoFromBytes_Broken(t *testing.T) {
+	info := &CharSelectInfo{
+		Login:     "user",
+		SessionID: 1,
+		Characters: []CharacterSlot{
+			{Name: "Bob", ObjectID: 1, Details: []byte{0x01}},
+		},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, info.ToBytes(writer))
+	data := writer.Bytes()
+
+	// Count claims more characters than packet has.
+	broken := append([]byte{0x02, 0x00, 0x00, 0x00}, data[4:]...)
+	_, err := NewCharSelectInfoFromBytes(broken)
+	require.Error(t, err)
+
+	_, err = NewCharSelectInfoFromBytes(data[:8])
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const CharSelectedID = 0x15
+
+// CharSelected answers CharacterSelected. Only fields up to session id are
+// decoded, rest of packet is ignored.
+type CharSelected struct {
+	Name      string
+	ObjectID  int32
+	Title     string
+	SessionID int32
+}
+
+func NewCharSelectedFromBytes(data []byte) (*CharSelected, error) {
+	reader := packet.NewReader(data)
+	packet := CharSelected{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *CharSelected) FromBytes(reader *packet.Reader) error {
+	return runSteps([]func() error{
+		func() error { return readStrings(reader, &p.Name) },
+		func() error { return readInt32s(reader, &p.ObjectID) },
+		func() error { return readStrings(reader, &p.Title) },
+		func() error { return readInt32s(reader, &p.SessionID) },
+	})
+}
+
+func (p *CharSelected) ToBytes(writer *packet.Writer) error {
+	return runSteps([]func() error{
+		func() error { return writeStrings(writer, p.Name) },
+		func() error { return writeInt32s(writer, p.ObjectID) },
+		func() error { return writeStrings(writer, p.Title) },
+		func() error { return writeInt32s(writer, p.SessionID) },
+	})
+}
+
+func (p *CharSelected) ToString() string {
+	return fmt.Sprintf("\nCharSelected:\n  Name: %s\n  ObjectID: %d"+
+		"\n  Title: %s\n  SessionID: %d",
+		p.Name, p.ObjectID, p.Title, p.SessionID)
+}
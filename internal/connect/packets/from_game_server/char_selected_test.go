@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharSelected_RoundTrip(t *testing.T) {
+	original := &CharSelected{
+		Name: "Bob", ObjectID: 0x10000001, Title: "Hero", SessionID: 5,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	// Rest of character stats isn't decoded.
+	data := append(writer.Bytes(), 0x01, 0x00, 0x00, 0x00)
+	decoded, err := NewCharSelectedFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Name: Bob")
+}
+
+func TestNewCharSelectedFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewCharSelectedFromBytes([]byte{'B', 0x00, 0x00, 0x00, 0x01})
+	require.Error(t, err)
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const CreatureSayID = 0x4a
+
+// CreatureSay is chat message said by creature or sent by server, text
+// type is chat type of Say2.
+type CreatureSay struct {
+	ObjectID int32
+	TextType int32
+	CharName string
+	Text     string
+}
+
+func NewCreatureSayFromBytes(data []byte) (*CreatureSay, error) {
+	reader := packet.NewReader(data)
+	packet := CreatureSay{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *CreatureSay) FromBytes(reader *packet.Reader) error {
+	if err := readInt32s(reader, &p.ObjectID, &p.TextType); err != nil {
+		return err
+	}
+
+	return readStrings(reader, &p.CharName, &p.Text)
+}
+
+func (p *CreatureSay) ToBytes(writer *packet.Writer) error {
+	if err := writeInt32s(writer, p.ObjectID, p.TextType); err != nil {
+		return err
+	}
+
+	return writeStrings(writer, p.CharName, p.Text)
+}
+
+func (p *CreatureSay) ToString() string {
+	return fmt.Sprintf("\nCreatureSay:\n  ObjectID: %d\n  TextType: %d"+
+		"\n  CharName: %s\n  Text: %s",
+		p.ObjectID, p.TextType, p.CharName, p.Text)
+}
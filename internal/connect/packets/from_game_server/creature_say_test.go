@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatureSay_RoundTrip(t *testing.T) {
+	original := &CreatureSay{
+		ObjectID: 7,
+		TextType: 3,
+		CharName: "Tank",
+		Text:     "follow",
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewCreatureSayFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Text: follow")
+}
+
+func TestNewCreatureSayFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewCreatureSayFromBytes([]byte{0x07, 0x00, 0x00, 0x00, 0x03})
+	require.Error(t, err)
+}
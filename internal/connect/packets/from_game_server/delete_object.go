@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"strconv"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const DeleteObjectID = 0x12
+
+// DeleteObject removes object from view of character.
+type DeleteObject struct {
+	ObjectID int32
+}
+
+func NewDeleteObjectFromBytes(data []byte) (*DeleteObject, error) {
+	reader := packet.NewReader(data)
+	packet := DeleteObject{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *DeleteObject) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID)
+}
+
+func (p *DeleteObject) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, 0)
+}
+
+func (p *DeleteObject) ToString() string {
+	return "\nDeleteObject:" +
+		"\n  ObjectID: " + strconv.Itoa(int(p.ObjectID))
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const DropItemID = 0x0c
+
+// DropItem shows item dropped by character or monster in view.
+type DropItem struct {
+	DropperID int32
+	ObjectID  int32
+	ItemID    int32
+	X         int32
+	Y         int32
+	Z         int32
+	Stackable int32
+	Count     int32
+}
+
+func NewDropItemFromBytes(data []byte) (*DropItem, error) {
+	reader := packet.NewReader(data)
+	packet := DropItem{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *DropItem) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.DropperID, &p.ObjectID, &p.ItemID,
+		&p.X, &p.Y, &p.Z, &p.Stackable, &p.Count)
+}
+
+func (p *DropItem) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.DropperID, p.ObjectID, p.ItemID,
+		p.X, p.Y, p.Z, p.Stackable, p.Count, 1)
+}
+
+func (p *DropItem) ToString() string {
+	return fmt.Sprintf("\nDropItem:\n  DropperID: %d\n  ObjectID: %d"+
+		"\n  ItemID: %d\n  Location: %d %d %d\n  Count: %d",
+		p.DropperID, p.ObjectID, p.ItemID, p.X, p.Y, p.Z, p.Count)
+}
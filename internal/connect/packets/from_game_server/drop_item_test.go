@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropItem_RoundTrip(t *testing.T) {
+	original := &DropItem{
+		DropperID: 5,
+		ObjectID:  1,
+		ItemID:    57,
+		X:         10,
+		Y:         -20,
+		Z:         30,
+		Stackable: 1,
+		Count:     1,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewDropItemFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "DropperID: 5")
+}
+
+func TestNewDropItemFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewDropItemFromBytes([]byte{0x05, 0x00, 0x00, 0x00})
+	require.Error(t, err)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+// ExAutoSoulShotID is sub id of extended packet, body starts after it.
+const ExAutoSoulShotID = 0x12
+
+// ExAutoSoulShot confirms that automatic use of soulshot item is turned on
+// or off.
+type ExAutoSoulShot struct {
+	ItemID  int32
+	Enabled int32
+}
+
+func NewExAutoSoulShotFromBytes(data []byte) (*ExAutoSoulShot, error) {
+	reader := packet.NewReader(data)
+	packet := ExAutoSoulShot{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *ExAutoSoulShot) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ItemID, &p.Enabled)
+}
+
+func (p *ExAutoSoulShot) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ItemID, p.Enabled)
+}
+
+func (p *ExAutoSoulShot) ToString() string {
+	return fmt.Sprintf("\nExAutoSoulShot:\n  ItemID: %d\n  Enabled: %d",
+		p.ItemID, p.Enabled)
+}
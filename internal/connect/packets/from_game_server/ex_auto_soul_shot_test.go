@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExAutoSoulShot_RoundTrip(t *testing.T) {
+	original := &ExAutoSoulShot{ItemID: 1835, Enabled: 1}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewExAutoSoulShotFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ItemID: 1835")
+}
+
+func TestNewExAutoSoulShotFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewExAutoSoulShotFromBytes([]byte{0x2b, 0x07, 0x00, 0x00})
+	require.Error(t, err)
+}
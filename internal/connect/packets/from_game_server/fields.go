@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+// Game server packets have long runs of same typed fields, these helpers
+// read and write them in order of arguments.
+
+func readInt32s(reader *packet.Reader, values ...*int32) error {
+	for _, value := range values {
+		result, err := reader.ReadInt32()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func readInt16s(reader *packet.Reader, values ...*int16) error {
+	for _, value := range values {
+		result, err := reader.ReadInt16()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func readInt8s(reader *packet.Reader, values ...*int8) error {
+	for _, value := range values {
+		result, err := reader.ReadInt8()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func readFloat64s(reader *packet.Reader, values ...*float64) error {
+	for _, value := range values {
+		result, err := reader.ReadFloat64()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func readStrings(reader *packet.Reader, values ...*string) error {
+	for _, value := range values {
+		result, err := reader.ReadStringFromUtf16Format()
+		if err != nil {
+			return err
+		}
+		*value = result
+	}
+
+	return nil
+}
+
+func writeInt32s(writer *packet.Writer, values ...int32) error {
+	for _, value := range values {
+		if err := writer.WriteInt32(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeInt16s(writer *packet.Writer, values ...int16) error {
+	for _, value := range values {
+		if err := writer.WriteInt16(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeInt8s(writer *packet.Writer, values ...int8) error {
+	for _, value := range values {
+		if err := writer.WriteInt8(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFloat64s(writer *packet.Writer, values ...float64) error {
+	for _, value := range values {
+		if err := writer.WriteFloat64(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeStrings(writer *packet.Writer, values ...string) error {
+	for _, value := range values {
+		if err := writer.WriteStringAsUtf16(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func int32Pointers(values []int32) []*int32 {
+	pointers := make([]*int32, 0, len(values))
+	for i := range values {
+		pointers = append(pointers, &values[i])
+	}
+
+	return pointers
+}
+
+func values[T any](pointers []*T) []T {
+	result := make([]T, 0, len(pointers))
+	for _, pointer := range pointers {
+		result = append(result, *pointer)
+	}
+
+	return result
+}
+
+func runSteps(steps []func() error) error {
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+func TestFields_RoundTrip(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, writeInt32s(writer, 1, -2))
+	require.NoError(t, writeInt8s(writer, 3))
+	require.NoError(t, writeInt16s(writer, -7))
+	require.NoError(t, writeFloat64s(writer, 4.5))
+	require.NoError(t, writeStrings(writer, "name", ""))
+
+	var a, b int32
+	var c int8
+	var g int16
+	var d float64
+	var e, f string
+	reader := packet.NewReader(writer.Bytes())
+	require.NoError(t, readInt32s(reader, &a, &b))
+	require.NoError(t, readInt8s(reader, &c))
+	require.NoError(t, readInt16s(reader, &g))
+	require.NoError(t, readFloat64s(reader, &d))
+	require.NoError(t, readStrings(reader, &e, &f))
+
+	require.Equal(t, int32(1), a)
+	require.Equal(t, int32(-2), b)
+	require.Equal(t, int8(3), c)
+	require.Equal(t, int16(-7), g)
+	require.InDelta(t, 4.5, d, 0)
+	require.Equal(t, "name", e)
+	require.Equal(t, "", f)
+}
+
+func TestFields_NotEnoughData(t *testing.T) {
+	reader := packet.NewReader([]byte{1, 0, 0, 0})
+	var a, b int32
+	require.Error(t, readInt32s(reader, &a, &b))
+
+	var c int8
+	var g int16
+	require.Error(t, readInt8s(packet.NewReader(nil), &c))
+	require.Error(t, readInt16s(packet.NewReader([]byte{1}), &g))
+
+	var d float64
+	require.Error(t, readFloat64s(packet.NewReader([]byte{1}), &d))
+
+	var e string
+	require.Error(t, readStrings(packet.NewReader([]byte{'a', 0}), &e))
+}
+
+func TestInt32Pointers(t *testing.T) {
+	values := make([]int32, 3)
+	pointers := int32Pointers(values)
+	*pointers[2] = 7
+	require.Equal(t, []int32{0, 0, 7}, values)
+}
+
+func TestValues(t *testing.T) {
+	a, b := int32(1), int32(2)
+	require.Equal(t, []int32{1, 2}, values([]*int32{&a, &b}))
+}
+
+func TestRunSteps(t *testing.T) {
+	calls := 0
+	step := func() error {
+		calls++
+
+		return nil
+	}
+	failing := func() error { return errTest }
+	require.NoError(t, runSteps([]func() error{step, step}))
+	require.Equal(t, 2, calls)
+
+	require.Equal(t, errTest, runSteps([]func() error{failing, step}))
+	require.Equal(t, 2, calls)
+}
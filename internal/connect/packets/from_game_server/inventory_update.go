@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const InventoryUpdateID = 0x27
+
+// Kinds of item changes of InventoryUpdate.
+const (
+	ItemAdded    = 1
+	ItemModified = 2
+	ItemRemoved  = 3
+)
+
+// ItemChange is single changed item of InventoryUpdate.
+type ItemChange struct {
+	Change int16
+	Item   ItemInfo
+}
+
+// InventoryUpdate adds, modifies or removes some items of inventory.
+type InventoryUpdate struct {
+	Changes []ItemChange
+}
+
+func NewInventoryUpdateFromBytes(data []byte) (*InventoryUpdate, error) {
+	reader := packet.NewReader(data)
+	packet := InventoryUpdate{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *InventoryUpdate) FromBytes(reader *packet.Reader) error {
+	var count int16
+	if err := readInt16s(reader, &count); err != nil {
+		return err
+	}
+	if count < 0 || count > maxItems {
+		return fmt.Errorf("invalid item changes count: %d", count)
+	}
+
+	p.Changes = make([]ItemChange, count)
+	for i := range p.Changes {
+		change := &p.Changes[i]
+		if err := readInt16s(reader, &change.Change); err != nil {
+			return err
+		}
+		if err := change.Item.read(reader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *InventoryUpdate) ToBytes(writer *packet.Writer) error {
+	if len(p.Changes) > maxItems {
+		return errors.New("too many item changes")
+	}
+	count := int16(len(p.Changes)) //nolint:gosec
+	if err := writeInt16s(writer, count); err != nil {
+		return err
+	}
+	for i := range p.Changes {
+		change := &p.Changes[i]
+		if err := writeInt16s(writer, change.Change); err != nil {
+			return err
+		}
+		if err := change.Item.write(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *InventoryUpdate) ToString() string {
+	var sb strings.Builder
+	sb.WriteString("\nInventoryUpdate:")
+	for i := range p.Changes {
+		sb.WriteString(fmt.Sprintf("\n  Change %d:", p.Changes[i].Change))
+		sb.WriteString(p.Changes[i].Item.toString())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryUpdate_RoundTrip(t *testing.T) {
+	original := &InventoryUpdate{Changes: []ItemChange{
+		{Change: ItemAdded, Item: testItem(100, 57, 1000)},
+		{Change: ItemRemoved, Item: testItem(101, 1, 1)},
+	}}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewInventoryUpdateFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Change 3:\n  Item 101")
+}
+
+func TestNewInventoryUpdateFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int16
+	}{
+		{name: "no count", data: nil},
+		{name: "negative count", data: []int16{-1}},
+		{name: "too many items", data: []int16{maxItems + 1}},
+		{name: "missing change", data: []int16{1}},
+		{name: "missing item", data: []int16{1, ItemAdded, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt16s(writer, test.data...))
+			_, err := NewInventoryUpdateFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestInventoryUpdate_ToBytesTooManyChanges(t *testing.T) {
+	update := &InventoryUpdate{Changes: make([]ItemChange, maxItems+1)}
+	require.Error(t, update.ToBytes(packet.NewWriter()))
+}
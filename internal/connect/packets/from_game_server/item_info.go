@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+// maxItems limits item count of item packets.
+const maxItems = 4096
+
+// ItemInfo is item of inventory as ItemList and InventoryUpdate send it.
+type ItemInfo struct {
+	Type1          int16
+	ObjectID       int32
+	ItemID         int32
+	Count          int32
+	Type2          int16
+	CustomType1    int16
+	Equipped       int16
+	BodyPart       int32
+	EnchantLevel   int16
+	CustomType2    int16
+	AugmentationID int32
+	Mana           int32
+}
+
+func (i *ItemInfo) read(reader *packet.Reader) error {
+	return runSteps([]func() error{
+		func() error { return readInt16s(reader, &i.Type1) },
+		func() error {
+			return readInt32s(reader, &i.ObjectID, &i.ItemID, &i.Count)
+		},
+		func() error {
+			return readInt16s(reader, &i.Type2, &i.CustomType1, &i.Equipped)
+		},
+		func() error { return readInt32s(reader, &i.BodyPart) },
+		func() error {
+			return readInt16s(reader, &i.EnchantLevel, &i.CustomType2)
+		},
+		func() error {
+			return readInt32s(reader, &i.AugmentationID, &i.Mana)
+		},
+	})
+}
+
+func (i *ItemInfo) write(writer *packet.Writer) error {
+	return runSteps([]func() error{
+		func() error { return writeInt16s(writer, i.Type1) },
+		func() error {
+			return writeInt32s(writer, i.ObjectID, i.ItemID, i.Count)
+		},
+		func() error {
+			return writeInt16s(writer, i.Type2, i.CustomType1, i.Equipped)
+		},
+		func() error { return writeInt32s(writer, i.BodyPart) },
+		func() error {
+			return writeInt16s(writer, i.EnchantLevel, i.CustomType2)
+		},
+		func() error {
+			return writeInt32s(writer, i.AugmentationID, i.Mana)
+		},
+	})
+}
+
+func (i *ItemInfo) toString() string {
+	return fmt.Sprintf("\n  Item %d: ID %d Count %d Enchant %d"+
+		" Equipped %d BodyPart %#x", i.ObjectID, i.ItemID, i.Count,
+		i.EnchantLevel, i.Equipped, i.BodyPart)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func testItem(objectID, itemID, count int32) ItemInfo {
+	return ItemInfo{
+		Type1:          4,
+		ObjectID:       objectID,
+		ItemID:         itemID,
+		Count:          count,
+		Type2:          4,
+		CustomType1:    0,
+		Equipped:       1,
+		BodyPart:       0x4000,
+		EnchantLevel:   3,
+		CustomType2:    0,
+		AugmentationID: 0,
+		Mana:           -1,
+	}
+}
+
+func TestItemInfo_RoundTrip(t *testing.T) {
+	original := testItem(100, 57, 1000)
+	writer := packet.NewWriter()
+	require.NoError(t, original.write(writer))
+	require.Len(t, writer.Bytes(), 36)
+
+	var decoded ItemInfo
+	require.NoError(t, decoded.read(packet.NewReader(writer.Bytes())))
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.toString(), "Item 100: ID 57 Count 1000")
+}
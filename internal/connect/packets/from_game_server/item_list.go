@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ItemListID = 0x1b
+
+// ItemList is whole inventory of character, it replaces previous one.
+type ItemList struct {
+	ShowWindow int16
+	Items      []ItemInfo
+}
+
+func NewItemListFromBytes(data []byte) (*ItemList, error) {
+	reader := packet.NewReader(data)
+	packet := ItemList{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *ItemList) FromBytes(reader *packet.Reader) error {
+	var count int16
+	if err := readInt16s(reader, &p.ShowWindow, &count); err != nil {
+		return err
+	}
+	if count < 0 || count > maxItems {
+		return fmt.Errorf("invalid items count: %d", count)
+	}
+
+	p.Items = make([]ItemInfo, count)
+	for i := range p.Items {
+		if err := p.Items[i].read(reader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ItemList) ToBytes(writer *packet.Writer) error {
+	if len(p.Items) > maxItems {
+		return errors.New("too many items")
+	}
+	count := int16(len(p.Items)) //nolint:gosec
+	if err := writeInt16s(writer, p.ShowWindow, count); err != nil {
+		return err
+	}
+	for i := range p.Items {
+		if err := p.Items[i].write(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ItemList) ToString() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nItemList:\n  ShowWindow: %d", p.ShowWindow))
+	for i := range p.Items {
+		sb.WriteString(p.Items[i].toString())
+	}
+
+	return sb.String()
+}
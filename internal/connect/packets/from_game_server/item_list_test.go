@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemList_RoundTrip(t *testing.T) {
+	original := &ItemList{
+		ShowWindow: 1,
+		Items:      []ItemInfo{testItem(100, 57, 1000), testItem(101, 1, 1)},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewItemListFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Item 101: ID 1 Count 1")
+}
+
+func TestNewItemListFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int16
+	}{
+		{name: "negative count", data: []int16{0, -1}},
+		{name: "too many items", data: []int16{0, maxItems + 1}},
+		{name: "missing item", data: []int16{0, 1, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt16s(writer, test.data...))
+			_, err := NewItemListFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestItemList_ToBytesTooManyItems(t *testing.T) {
+	list := &ItemList{ShowWindow: 0, Items: make([]ItemInfo, maxItems+1)}
+	require.Error(t, list.ToBytes(packet.NewWriter()))
+}
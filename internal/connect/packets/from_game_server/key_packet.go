@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const KeyPacketID = 0x00
+
+// KeyPacket answers ProtocolVersion, Ok is 1 when protocol is accepted.
+// Key is dynamic part of game cipher key, static rest of key is ignored.
+type KeyPacket struct {
+	Ok  int8
+	Key [4]byte
+}
+
+func NewKeyPacketFromBytes(data []byte) (*KeyPacket, error) {
+	reader := packet.NewReader(data)
+	packet := KeyPacket{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *KeyPacket) FromBytes(reader *packet.Reader) error {
+	ok, err := reader.ReadInt8()
+	if err != nil {
+		return err
+	}
+	key, err := reader.ReadBytes(len(p.Key))
+	if err != nil {
+		return err
+	}
+	p.Ok = ok
+	copy(p.Key[:], key)
+
+	return nil
+}
+
+func (p *KeyPacket) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(p.Ok); err != nil {
+		return err
+	}
+
+	return writer.WriteBytes(p.Key[:])
+}
+
+func (p *KeyPacket) Accepted() bool {
+	return p.Ok == 1
+}
+
+func (p *KeyPacket) ToString() string {
+	return fmt.Sprintf("\nKeyPacket:\n  Ok: %d\n  Key: % x", p.Ok, p.Key)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyPacketFromBytes(t *testing.T) {
+	// C4 server sends static part of key and flags after dynamic key.
+	data := []byte{
+		0x01,
+		0x11, 0x22, 0x33, 0x44,
+		0xa1, 0x6c, 0x54, 0x87,
+		0x01, 0x00, 0x00, 0x00,
+	}
+	decoded, err := NewKeyPacketFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, &KeyPacket{Ok: 1, Key: [4]byte{0x11, 0x22, 0x33, 0x44}},
+		decoded)
+	require.True(t, decoded.Accepted())
+	require.Contains(t, decoded.ToString(), "Key: 11 22 33 44")
+
+	rejected := KeyPacket{Ok: 0, Key: [4]byte{}}
+	require.False(t, rejected.Accepted())
+
+	_, err = NewKeyPacketFromBytes([]byte{0x01, 0x11})
+	require.Error(t, err)
+}
+
+func TestKeyPacket_RoundTrip(t *testing.T) {
+	original := &KeyPacket{Ok: 1, Key: [4]byte{1, 2, 3, 4}}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewKeyPacketFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+}
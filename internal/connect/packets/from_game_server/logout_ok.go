@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const LogOutOkID = 0x7e
+
+// LogOutOk confirms Logout, packet has no body.
+type LogOutOk struct{}
+
+func (p *LogOutOk) FromBytes(_ *packet.Reader) error {
+	return nil
+}
+
+func (p *LogOutOk) ToBytes(_ *packet.Writer) error {
+	return nil
+}
+
+func (p *LogOutOk) ToString() string {
+	return "\nLogOutOk:"
+}
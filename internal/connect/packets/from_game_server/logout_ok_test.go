@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogOutOk(t *testing.T) {
+	logout := &LogOutOk{}
+	require.NoError(t, logout.FromBytes(packet.NewReader(nil)))
+	require.NoError(t, logout.ToBytes(packet.NewWriter()))
+	require.Contains(t, logout.ToString(), "LogOutOk")
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"strconv"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const MagicSkillCanceldID = 0x49
+
+// MagicSkillCanceld tells that cast of creature is interrupted, name of
+// packet is spelled like in game.
+type MagicSkillCanceld struct {
+	ObjectID int32
+}
+
+func NewMagicSkillCanceldFromBytes(data []byte) (*MagicSkillCanceld, error) {
+	reader := packet.NewReader(data)
+	packet := MagicSkillCanceld{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *MagicSkillCanceld) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID)
+}
+
+func (p *MagicSkillCanceld) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID)
+}
+
+func (p *MagicSkillCanceld) ToString() string {
+	return "\nMagicSkillCanceld:" +
+		"\n  ObjectID: " + strconv.Itoa(int(p.ObjectID))
+}
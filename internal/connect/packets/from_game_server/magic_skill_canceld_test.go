@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicSkillCanceld_RoundTrip(t *testing.T) {
+	original := &MagicSkillCanceld{ObjectID: 42}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewMagicSkillCanceldFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ObjectID: 42")
+}
+
+func TestNewMagicSkillCanceldFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewMagicSkillCanceldFromBytes([]byte{0x2a})
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	MagicSkillLaunchedID = 0x76
+	maxSkillTargets      = 1024
+)
+
+// MagicSkillLaunched tells that cast skill reached its targets.
+type MagicSkillLaunched struct {
+	CasterID   int32
+	SkillID    int32
+	SkillLevel int32
+	Targets    []int32
+}
+
+func NewMagicSkillLaunchedFromBytes(
+	data []byte,
+) (*MagicSkillLaunched, error) {
+	reader := packet.NewReader(data)
+	packet := MagicSkillLaunched{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *MagicSkillLaunched) FromBytes(reader *packet.Reader) error {
+	var count int32
+	err := readInt32s(reader, &p.CasterID, &p.SkillID, &p.SkillLevel, &count)
+	if err != nil {
+		return err
+	}
+	if count < 0 || count > maxSkillTargets {
+		return fmt.Errorf("invalid skill targets count: %d", count)
+	}
+
+	p.Targets = make([]int32, count)
+
+	return readInt32s(reader, int32Pointers(p.Targets)...)
+}
+
+func (p *MagicSkillLaunched) ToBytes(writer *packet.Writer) error {
+	if len(p.Targets) > maxSkillTargets {
+		return errors.New("too many skill targets")
+	}
+	count := int32(len(p.Targets)) //nolint:gosec
+	err := writeInt32s(writer, p.CasterID, p.SkillID, p.SkillLevel, count)
+	if err != nil {
+		return err
+	}
+
+	return writeInt32s(writer, p.Targets...)
+}
+
+func (p *MagicSkillLaunched) ToString() string {
+	return fmt.Sprintf("\nMagicSkillLaunched:\n  CasterID: %d"+
+		"\n  Skill: %d level %d\n  Targets: %v",
+		p.CasterID, p.SkillID, p.SkillLevel, p.Targets)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicSkillLaunched_RoundTrip(t *testing.T) {
+	original := &MagicSkillLaunched{
+		CasterID:   1,
+		SkillID:    1011,
+		SkillLevel: 3,
+		Targets:    []int32{2, 5},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewMagicSkillLaunchedFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Targets: [2 5]")
+}
+
+func TestNewMagicSkillLaunchedFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int32
+	}{
+		{name: "negative count", data: []int32{1, 2, 3, -1}},
+		{name: "too many targets", data: []int32{1, 2, 3, maxSkillTargets + 1}},
+		{name: "missing target", data: []int32{1, 2, 3, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt32s(writer, test.data...))
+			_, err := NewMagicSkillLaunchedFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMagicSkillLaunched_ToBytesTooManyTargets(t *testing.T) {
+	launched := &MagicSkillLaunched{
+		CasterID:   1,
+		SkillID:    1,
+		SkillLevel: 1,
+		Targets:    make([]int32, maxSkillTargets+1),
+	}
+	require.Error(t, launched.ToBytes(packet.NewWriter()))
+}
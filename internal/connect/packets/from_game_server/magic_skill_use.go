@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const MagicSkillUseID = 0x48
+
+// MagicSkillUse tells that creature started to cast skill. Hit time and
+// reuse delay are in milliseconds.
+type MagicSkillUse struct {
+	CasterID   int32
+	TargetID   int32
+	SkillID    int32
+	SkillLevel int32
+	HitTime    int32
+	ReuseDelay int32
+	X          int32
+	Y          int32
+	Z          int32
+}
+
+func NewMagicSkillUseFromBytes(data []byte) (*MagicSkillUse, error) {
+	reader := packet.NewReader(data)
+	packet := MagicSkillUse{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *MagicSkillUse) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.CasterID, &p.TargetID, &p.SkillID,
+		&p.SkillLevel, &p.HitTime, &p.ReuseDelay, &p.X, &p.Y, &p.Z)
+}
+
+func (p *MagicSkillUse) ToBytes(writer *packet.Writer) error {
+	// Last field is count of ground targets, bots don't use ground skills.
+	return writeInt32s(writer, p.CasterID, p.TargetID, p.SkillID,
+		p.SkillLevel, p.HitTime, p.ReuseDelay, p.X, p.Y, p.Z, 0)
+}
+
+func (p *MagicSkillUse) ToString() string {
+	return fmt.Sprintf("\nMagicSkillUse:\n  CasterID: %d\n  TargetID: %d"+
+		"\n  Skill: %d level %d\n  HitTime: %d\n  ReuseDelay: %d"+
+		"\n  Location: %d %d %d", p.CasterID, p.TargetID, p.SkillID,
+		p.SkillLevel, p.HitTime, p.ReuseDelay, p.X, p.Y, p.Z)
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicSkillUse_RoundTrip(t *testing.T) {
+	original := &MagicSkillUse{
+		CasterID:   1,
+		TargetID:   2,
+		SkillID:    1011,
+		SkillLevel: 3,
+		HitTime:    1500,
+		ReuseDelay: 3000,
+		X:          10,
+		Y:          -20,
+		Z:          30,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewMagicSkillUseFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Skill: 1011 level 3")
+}
+
+func TestNewMagicSkillUseFromBytes_NotEnoughData(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, writeInt32s(writer, 1, 2, 3))
+	_, err := NewMagicSkillUseFromBytes(writer.Bytes())
+	require.Error(t, err)
+}
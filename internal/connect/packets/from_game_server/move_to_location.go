@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const MoveToLocationID = 0x01
+
+// MoveToLocation tells that creature started to move from current location
+// to destination.
+type MoveToLocation struct {
+	ObjectID int32
+	DestX    int32
+	DestY    int32
+	DestZ    int32
+	X        int32
+	Y        int32
+	Z        int32
+}
+
+func NewMoveToLocationFromBytes(data []byte) (*MoveToLocation, error) {
+	reader := packet.NewReader(data)
+	packet := MoveToLocation{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *MoveToLocation) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID,
+		&p.DestX, &p.DestY, &p.DestZ, &p.X, &p.Y, &p.Z)
+}
+
+func (p *MoveToLocation) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID,
+		p.DestX, p.DestY, p.DestZ, p.X, p.Y, p.Z)
+}
+
+func (p *MoveToLocation) ToString() string {
+	return fmt.Sprintf("\nMoveToLocation:\n  ObjectID: %d"+
+		"\n  From: %d %d %d\n  To: %d %d %d",
+		p.ObjectID, p.X, p.Y, p.Z, p.DestX, p.DestY, p.DestZ)
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveToLocation_RoundTrip(t *testing.T) {
+	original := &MoveToLocation{
+		ObjectID: 7,
+		DestX:    100,
+		DestY:    200,
+		DestZ:    -300,
+		X:        1,
+		Y:        2,
+		Z:        3,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewMoveToLocationFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "To: 100 200 -300")
+}
+
+func TestNewMoveToLocationFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewMoveToLocationFromBytes([]byte{0x07, 0x00, 0x00, 0x00})
+	require.Error(t, err)
+}
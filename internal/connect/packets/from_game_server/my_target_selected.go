@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const MyTargetSelectedID = 0xa6
+
+// MyTargetSelected confirms target of character. Color shows level of
+// target compared to character.
+type MyTargetSelected struct {
+	ObjectID int32
+	Color    int16
+}
+
+func NewMyTargetSelectedFromBytes(data []byte) (*MyTargetSelected, error) {
+	reader := packet.NewReader(data)
+	packet := MyTargetSelected{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *MyTargetSelected) FromBytes(reader *packet.Reader) error {
+	if err := readInt32s(reader, &p.ObjectID); err != nil {
+		return err
+	}
+	color, err := reader.ReadInt16()
+	if err != nil {
+		return err
+	}
+	p.Color = color
+
+	return nil
+}
+
+func (p *MyTargetSelected) ToBytes(writer *packet.Writer) error {
+	if err := writeInt32s(writer, p.ObjectID); err != nil {
+		return err
+	}
+
+	return writer.WriteInt16(p.Color)
+}
+
+func (p *MyTargetSelected) ToString() string {
+	return fmt.Sprintf("\nMyTargetSelected:\n  ObjectID: %d\n  Color: %d",
+		p.ObjectID, p.Color)
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMyTargetSelected_RoundTrip(t *testing.T) {
+	original := &MyTargetSelected{ObjectID: 42, Color: -3}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewMyTargetSelectedFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ObjectID: 42")
+}
+
+func TestNewMyTargetSelectedFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewMyTargetSelectedFromBytes([]byte{0x2a, 0, 0, 0, 1})
+	require.Error(t, err)
+	_, err = NewMyTargetSelectedFromBytes([]byte{0x2a})
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const NpcHtmlMessageID = 0x0f
+
+// NpcHtmlMessage opens dialog window of npc with html page. Item id is
+// set when page is shown by item instead of npc.
+type NpcHtmlMessage struct {
+	NpcObjectID int32
+	HTML        string
+	ItemID      int32
+}
+
+func NewNpcHtmlMessageFromBytes(data []byte) (*NpcHtmlMessage, error) {
+	reader := packet.NewReader(data)
+	packet := NpcHtmlMessage{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *NpcHtmlMessage) FromBytes(reader *packet.Reader) error {
+	if err := readInt32s(reader, &p.NpcObjectID); err != nil {
+		return err
+	}
+	if err := readStrings(reader, &p.HTML); err != nil {
+		return err
+	}
+
+	return readInt32s(reader, &p.ItemID)
+}
+
+func (p *NpcHtmlMessage) ToBytes(writer *packet.Writer) error {
+	if err := writeInt32s(writer, p.NpcObjectID); err != nil {
+		return err
+	}
+	if err := writeStrings(writer, p.HTML); err != nil {
+		return err
+	}
+
+	return writeInt32s(writer, p.ItemID)
+}
+
+func (p *NpcHtmlMessage) ToString() string {
+	return fmt.Sprintf("\nNpcHtmlMessage:\n  NpcObjectID: %d\n  HTML: %s"+
+		"\n  ItemID: %d", p.NpcObjectID, p.HTML, p.ItemID)
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNpcHtmlMessage_RoundTrip(t *testing.T) {
+	original := &NpcHtmlMessage{
+		NpcObjectID: 300,
+		HTML:        "<html><body>Hi</body></html>",
+		ItemID:      0,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewNpcHtmlMessageFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "NpcObjectID: 300")
+}
+
+func TestNewNpcHtmlMessageFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewNpcHtmlMessageFromBytes([]byte{0x2c, 0x01, 0x00, 0x00})
+	require.Error(t, err)
+	_, err = NewNpcHtmlMessageFromBytes([]byte{
+		0x2c, 0x01, 0x00, 0x00, 'H', 0x00, 0x00, 0x00,
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	NpcInfoID = 0x16
+	// NpcTypeIDOffset is added by server to template id of npc.
+	NpcTypeIDOffset = 1000000
+)
+
+// NpcInfo describes npc or monster visible to client.
+type NpcInfo struct {
+	ObjectID   int32
+	NpcTypeID  int32
+	Attackable int32
+	X          int32
+	Y          int32
+	Z          int32
+	Heading    int32
+	Unknown    int32
+
+	MAtkSpd       int32
+	PAtkSpd       int32
+	RunSpeed      int32
+	WalkSpeed     int32
+	SwimRunSpeed  int32
+	SwimWalkSpeed int32
+	FlRunSpeed    int32
+	FlWalkSpeed   int32
+	FlyRunSpeed   int32
+	FlyWalkSpeed  int32
+
+	MoveMultiplier        float64
+	AttackSpeedMultiplier float64
+	CollisionRadius       float64
+	CollisionHeight       float64
+
+	RightHand int32
+	Unknown2  int32
+	LeftHand  int32
+
+	NameAbove int8
+	Running   int8
+	InCombat  int8
+	AlikeDead int8
+	Summoned  int8
+
+	Name  string
+	Title string
+}
+
+func NewNpcInfoFromBytes(data []byte) (*NpcInfo, error) {
+	reader := packet.NewReader(data)
+	packet := NpcInfo{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+// TemplateID returns npc template id without server offset.
+func (p *NpcInfo) TemplateID() int32 {
+	return p.NpcTypeID - NpcTypeIDOffset
+}
+
+func (p *NpcInfo) head() []*int32 {
+	return []*int32{
+		&p.ObjectID, &p.NpcTypeID, &p.Attackable,
+		&p.X, &p.Y, &p.Z, &p.Heading, &p.Unknown,
+	}
+}
+
+func (p *NpcInfo) speeds() []*int32 {
+	return []*int32{
+		&p.MAtkSpd, &p.PAtkSpd,
+		&p.RunSpeed, &p.WalkSpeed, &p.SwimRunSpeed, &p.SwimWalkSpeed,
+		&p.FlRunSpeed, &p.FlWalkSpeed, &p.FlyRunSpeed, &p.FlyWalkSpeed,
+	}
+}
+
+func (p *NpcInfo) sizes() []*float64 {
+	return []*float64{
+		&p.MoveMultiplier, &p.AttackSpeedMultiplier,
+		&p.CollisionRadius, &p.CollisionHeight,
+	}
+}
+
+func (p *NpcInfo) hands() []*int32 {
+	return []*int32{&p.RightHand, &p.Unknown2, &p.LeftHand}
+}
+
+func (p *NpcInfo) flags() []*int8 {
+	return []*int8{
+		&p.NameAbove, &p.Running, &p.InCombat, &p.AlikeDead, &p.Summoned,
+	}
+}
+
+func (p *NpcInfo) FromBytes(reader *packet.Reader) error {
+	steps := []func() error{
+		func() error { return readInt32s(reader, p.head()...) },
+		func() error { return readInt32s(reader, p.speeds()...) },
+		func() error { return readFloat64s(reader, p.sizes()...) },
+		func() error { return readInt32s(reader, p.hands()...) },
+		func() error { return readInt8s(reader, p.flags()...) },
+		func() error { return readStrings(reader, &p.Name, &p.Title) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *NpcInfo) ToBytes(writer *packet.Writer) error {
+	steps := []func() error{
+		func() error { return writeInt32s(writer, values(p.head())...) },
+		func() error { return writeInt32s(writer, values(p.speeds())...) },
+		func() error { return writeFloat64s(writer, values(p.sizes())...) },
+		func() error { return writeInt32s(writer, values(p.hands())...) },
+		func() error { return writeInt8s(writer, values(p.flags())...) },
+		func() error { return writeStrings(writer, p.Name, p.Title) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *NpcInfo) ToString() string {
+	return fmt.Sprintf("\nNpcInfo:\n  ObjectID: %d\n  TemplateID: %d"+
+		"\n  Name: %s\n  Location: %d %d %d",
+		p.ObjectID, p.TemplateID(), p.Name, p.X, p.Y, p.Z)
+}
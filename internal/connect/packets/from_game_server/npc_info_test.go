@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNpcInfo_RoundTrip(t *testing.T) {
+	original := &NpcInfo{} //nolint:exhaustruct
+	original.ObjectID = 268435458
+	original.NpcTypeID = NpcTypeIDOffset + 20432
+	original.Attackable = 1
+	original.X, original.Y, original.Z = 10, 20, -30
+	original.RunSpeed, original.WalkSpeed = 160, 40
+	original.MoveMultiplier = 1
+	original.Running = 1
+	original.Name = "Elpy"
+
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewNpcInfoFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Equal(t, int32(20432), decoded.TemplateID())
+	require.Contains(t, decoded.ToString(), "TemplateID: 20432")
+}
+
+func TestNewNpcInfoFromBytes_Truncated(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&NpcInfo{}).ToBytes(writer)) //nolint:exhaustruct
+	data := writer.Bytes()
+
+	for _, size := range []int{0, 32, 72, 104, 116, 121, len(data) - 1} {
+		_, err := NewNpcInfoFromBytes(data[:size])
+		require.Error(t, err, "size %d", size)
+	}
+}
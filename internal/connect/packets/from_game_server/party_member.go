@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+// maxPartyMembers limits member count of PartySmallWindowAll, party of
+// game is never bigger.
+const maxPartyMembers = 9
+
+// PartyMember is line of party window. Race is sent only when member
+// is added to window.
+type PartyMember struct {
+	ObjectID int32
+	Name     string
+	CurCP    int32
+	MaxCP    int32
+	CurHP    int32
+	MaxHP    int32
+	CurMP    int32
+	MaxMP    int32
+	Level    int32
+	ClassID  int32
+	Race     int32
+}
+
+// readStatus reads member fields shared by all party window packets.
+func (m *PartyMember) readStatus(reader *packet.Reader) error {
+	return runSteps([]func() error{
+		func() error { return readInt32s(reader, &m.ObjectID) },
+		func() error { return readStrings(reader, &m.Name) },
+		func() error {
+			return readInt32s(reader, &m.CurCP, &m.MaxCP, &m.CurHP, &m.MaxHP,
+				&m.CurMP, &m.MaxMP, &m.Level, &m.ClassID)
+		},
+	})
+}
+
+func (m *PartyMember) writeStatus(writer *packet.Writer) error {
+	return runSteps([]func() error{
+		func() error { return writeInt32s(writer, m.ObjectID) },
+		func() error { return writeStrings(writer, m.Name) },
+		func() error {
+			return writeInt32s(writer, m.CurCP, m.MaxCP, m.CurHP, m.MaxHP,
+				m.CurMP, m.MaxMP, m.Level, m.ClassID)
+		},
+	})
+}
+
+// readEntry reads member with padding and race which follow it in
+// PartySmallWindowAll and PartySmallWindowAdd.
+func (m *PartyMember) readEntry(reader *packet.Reader) error {
+	var padding int32
+	if err := m.readStatus(reader); err != nil {
+		return err
+	}
+
+	return readInt32s(reader, &padding, &m.Race)
+}
+
+func (m *PartyMember) writeEntry(writer *packet.Writer) error {
+	if err := m.writeStatus(writer); err != nil {
+		return err
+	}
+
+	return writeInt32s(writer, 0, m.Race)
+}
+
+func (m *PartyMember) toString() string {
+	return fmt.Sprintf("\n  Member %d %s: Level %d Class %d"+
+		" HP %d/%d MP %d/%d CP %d/%d", m.ObjectID, m.Name, m.Level, m.ClassID,
+		m.CurHP, m.MaxHP, m.CurMP, m.MaxMP, m.CurCP, m.MaxCP)
+}
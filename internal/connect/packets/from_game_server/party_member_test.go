@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func testPartyMember(objectID int32, name string) PartyMember {
+	return PartyMember{
+		ObjectID: objectID,
+		Name:     name,
+		CurCP:    1,
+		MaxCP:    2,
+		CurHP:    3,
+		MaxHP:    4,
+		CurMP:    5,
+		MaxMP:    6,
+		Level:    7,
+		ClassID:  8,
+		Race:     1,
+	}
+}
+
+func TestPartyMember_Entry(t *testing.T) {
+	original := testPartyMember(10, "Healer")
+	writer := packet.NewWriter()
+	require.NoError(t, original.writeEntry(writer))
+
+	var decoded PartyMember
+	require.NoError(t, decoded.readEntry(packet.NewReader(writer.Bytes())))
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.toString(), "Member 10 Healer: Level 7")
+	require.Contains(t, decoded.toString(), "HP 3/4")
+}
+
+func TestPartyMember_Status(t *testing.T) {
+	original := testPartyMember(10, "Healer")
+	writer := packet.NewWriter()
+	require.NoError(t, original.writeStatus(writer))
+
+	var decoded PartyMember
+	require.NoError(t, decoded.readStatus(packet.NewReader(writer.Bytes())))
+	original.Race = 0
+	require.Equal(t, original, decoded)
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PartySmallWindowAddID = 0x4f
+
+// PartySmallWindowAdd adds new member to party window.
+type PartySmallWindowAdd struct {
+	LeaderID         int32
+	ItemDistribution int32
+	Member           PartyMember
+}
+
+func NewPartySmallWindowAddFromBytes(
+	data []byte,
+) (*PartySmallWindowAdd, error) {
+	reader := packet.NewReader(data)
+	packet := PartySmallWindowAdd{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PartySmallWindowAdd) FromBytes(reader *packet.Reader) error {
+	if err := readInt32s(reader, &p.LeaderID, &p.ItemDistribution); err != nil {
+		return err
+	}
+
+	return p.Member.readEntry(reader)
+}
+
+func (p *PartySmallWindowAdd) ToBytes(writer *packet.Writer) error {
+	if err := writeInt32s(writer, p.LeaderID, p.ItemDistribution); err != nil {
+		return err
+	}
+
+	return p.Member.writeEntry(writer)
+}
+
+func (p *PartySmallWindowAdd) ToString() string {
+	return fmt.Sprintf("\nPartySmallWindowAdd:\n  LeaderID: %d"+
+		"\n  ItemDistribution: %d", p.LeaderID, p.ItemDistribution) +
+		p.Member.toString()
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartySmallWindowAdd_RoundTrip(t *testing.T) {
+	original := &PartySmallWindowAdd{
+		LeaderID:         10,
+		ItemDistribution: 1,
+		Member:           testPartyMember(12, "Archer"),
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewPartySmallWindowAddFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Member 12 Archer")
+}
+
+func TestNewPartySmallWindowAddFromBytes_NotEnoughData(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, writeInt32s(writer, 10, 1, 12))
+	_, err := NewPartySmallWindowAddFromBytes(writer.Bytes())
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PartySmallWindowAllID = 0x4e
+
+// PartySmallWindowAll lists party of character except character itself,
+// it is sent when character joins party.
+type PartySmallWindowAll struct {
+	LeaderID         int32
+	ItemDistribution int32
+	Members          []PartyMember
+}
+
+func NewPartySmallWindowAllFromBytes(
+	data []byte,
+) (*PartySmallWindowAll, error) {
+	reader := packet.NewReader(data)
+	packet := PartySmallWindowAll{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PartySmallWindowAll) FromBytes(reader *packet.Reader) error {
+	var count int32
+	err := readInt32s(reader, &p.LeaderID, &p.ItemDistribution, &count)
+	if err != nil {
+		return err
+	}
+	if count < 0 || count > maxPartyMembers {
+		return fmt.Errorf("invalid party members count: %d", count)
+	}
+
+	p.Members = make([]PartyMember, count)
+	for i := range p.Members {
+		if err := p.Members[i].readEntry(reader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PartySmallWindowAll) ToBytes(writer *packet.Writer) error {
+	if len(p.Members) > maxPartyMembers {
+		return errors.New("too many party members")
+	}
+	count := int32(len(p.Members)) //nolint:gosec
+	err := writeInt32s(writer, p.LeaderID, p.ItemDistribution, count)
+	if err != nil {
+		return err
+	}
+	for i := range p.Members {
+		if err := p.Members[i].writeEntry(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PartySmallWindowAll) ToString() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nPartySmallWindowAll:\n  LeaderID: %d"+
+		"\n  ItemDistribution: %d", p.LeaderID, p.ItemDistribution))
+	for i := range p.Members {
+		sb.WriteString(p.Members[i].toString())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartySmallWindowAll_RoundTrip(t *testing.T) {
+	original := &PartySmallWindowAll{
+		LeaderID:         10,
+		ItemDistribution: 3,
+		Members: []PartyMember{
+			testPartyMember(10, "Tank"),
+			testPartyMember(11, "Healer"),
+		},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewPartySmallWindowAllFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "LeaderID: 10")
+	require.Contains(t, decoded.ToString(), "Member 11 Healer")
+}
+
+func TestNewPartySmallWindowAllFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int32
+	}{
+		{name: "negative count", data: []int32{1, 0, -1}},
+		{name: "too many members", data: []int32{1, 0, maxPartyMembers + 1}},
+		{name: "missing member", data: []int32{1, 0, 1, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt32s(writer, test.data...))
+			_, err := NewPartySmallWindowAllFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPartySmallWindowAll_ToBytesTooManyMembers(t *testing.T) {
+	window := &PartySmallWindowAll{
+		LeaderID:         1,
+		ItemDistribution: 0,
+		Members:          make([]PartyMember, maxPartyMembers+1),
+	}
+	require.Error(t, window.ToBytes(packet.NewWriter()))
+}
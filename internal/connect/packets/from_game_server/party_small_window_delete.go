@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PartySmallWindowDeleteID = 0x51
+
+// PartySmallWindowDelete removes member who left party from party window.
+type PartySmallWindowDelete struct {
+	ObjectID int32
+	Name     string
+}
+
+func NewPartySmallWindowDeleteFromBytes(
+	data []byte,
+) (*PartySmallWindowDelete, error) {
+	reader := packet.NewReader(data)
+	packet := PartySmallWindowDelete{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PartySmallWindowDelete) FromBytes(reader *packet.Reader) error {
+	if err := readInt32s(reader, &p.ObjectID); err != nil {
+		return err
+	}
+
+	return readStrings(reader, &p.Name)
+}
+
+func (p *PartySmallWindowDelete) ToBytes(writer *packet.Writer) error {
+	if err := writeInt32s(writer, p.ObjectID); err != nil {
+		return err
+	}
+
+	return writeStrings(writer, p.Name)
+}
+
+func (p *PartySmallWindowDelete) ToString() string {
+	return fmt.Sprintf("\nPartySmallWindowDelete:\n  ObjectID: %d"+
+		"\n  Name: %s", p.ObjectID, p.Name)
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PartySmallWindowDeleteAllID = 0x50
+
+// PartySmallWindowDeleteAll clears party window when character leaves party
+// or party is dismissed.
+type PartySmallWindowDeleteAll struct{}
+
+func NewPartySmallWindowDeleteAllFromBytes(
+	_ []byte,
+) (*PartySmallWindowDeleteAll, error) {
+	return &PartySmallWindowDeleteAll{}, nil
+}
+
+func (p *PartySmallWindowDeleteAll) FromBytes(_ *packet.Reader) error {
+	return nil
+}
+
+func (p *PartySmallWindowDeleteAll) ToBytes(_ *packet.Writer) error {
+	return nil
+}
+
+func (p *PartySmallWindowDeleteAll) ToString() string {
+	return "\nPartySmallWindowDeleteAll:"
+}
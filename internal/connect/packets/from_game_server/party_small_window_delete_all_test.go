@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartySmallWindowDeleteAll_RoundTrip(t *testing.T) {
+	original := &PartySmallWindowDeleteAll{}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+	require.Empty(t, writer.Bytes())
+
+	decoded, err := NewPartySmallWindowDeleteAllFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.NoError(t, decoded.FromBytes(packet.NewReader(nil)))
+	require.Contains(t, decoded.ToString(), "PartySmallWindowDeleteAll")
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartySmallWindowDelete_RoundTrip(t *testing.T) {
+	original := &PartySmallWindowDelete{ObjectID: 12, Name: "Archer"}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewPartySmallWindowDeleteFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Name: Archer")
+}
+
+func TestNewPartySmallWindowDeleteFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewPartySmallWindowDeleteFromBytes([]byte{0x0c, 0x00, 0x00})
+	require.Error(t, err)
+}
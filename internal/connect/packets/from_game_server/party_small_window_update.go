@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const PartySmallWindowUpdateID = 0x52
+
+// PartySmallWindowUpdate refreshes HP, MP and level of member in party
+// window.
+type PartySmallWindowUpdate struct {
+	Member PartyMember
+}
+
+func NewPartySmallWindowUpdateFromBytes(
+	data []byte,
+) (*PartySmallWindowUpdate, error) {
+	reader := packet.NewReader(data)
+	packet := PartySmallWindowUpdate{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *PartySmallWindowUpdate) FromBytes(reader *packet.Reader) error {
+	return p.Member.readStatus(reader)
+}
+
+func (p *PartySmallWindowUpdate) ToBytes(writer *packet.Writer) error {
+	return p.Member.writeStatus(writer)
+}
+
+func (p *PartySmallWindowUpdate) ToString() string {
+	return "\nPartySmallWindowUpdate:" + p.Member.toString()
+}
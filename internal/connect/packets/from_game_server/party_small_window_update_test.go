@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartySmallWindowUpdate_RoundTrip(t *testing.T) {
+	member := testPartyMember(12, "Archer")
+	member.Race = 0
+	original := &PartySmallWindowUpdate{Member: member}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewPartySmallWindowUpdateFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Member 12 Archer")
+}
+
+func TestNewPartySmallWindowUpdateFromBytes_NotEnoughData(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, writeInt32s(writer, 12))
+	_, err := NewPartySmallWindowUpdateFromBytes(writer.Bytes())
+	require.Error(t, err)
+}
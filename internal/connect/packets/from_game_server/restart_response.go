@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"strconv"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RestartResponseID = 0x5f
+
+// RestartResponse answers RequestRestart, Ok is 1 when restart is allowed.
+type RestartResponse struct {
+	Ok int32
+}
+
+func NewRestartResponseFromBytes(data []byte) (*RestartResponse, error) {
+	reader := packet.NewReader(data)
+	packet := RestartResponse{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *RestartResponse) FromBytes(reader *packet.Reader) error {
+	ok, err := reader.ReadInt32()
+	if err != nil {
+		return err
+	}
+	p.Ok = ok
+
+	return nil
+}
+
+func (p *RestartResponse) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt32(p.Ok)
+}
+
+func (p *RestartResponse) Allowed() bool {
+	return p.Ok == 1
+}
+
+func (p *RestartResponse) ToString() string {
+	return "\nRestartResponse:" +
+		"\n  Ok: " + strconv.Itoa(int(p.Ok))
+}
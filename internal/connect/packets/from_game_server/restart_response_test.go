@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRestartResponseFromBytes(t *testing.T) {
+	response, err := NewRestartResponseFromBytes([]byte{0x01, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	require.True(t, response.Allowed())
+	require.Contains(t, response.ToString(), "Ok: 1")
+
+	response, err = NewRestartResponseFromBytes([]byte{0x00, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	require.False(t, response.Allowed())
+
+	_, err = NewRestartResponseFromBytes([]byte{0x01})
+	require.Error(t, err)
+}
+
+func TestRestartResponse_RoundTrip(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&RestartResponse{Ok: 1}).ToBytes(writer))
+
+	response, err := NewRestartResponseFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), response.Ok)
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	SkillCoolTimeID   = 0xc1
+	maxSkillCoolTimes = 1024
+)
+
+// SkillReuse is cooldown of single skill, times are in seconds.
+type SkillReuse struct {
+	SkillID   int32
+	Level     int32
+	Reuse     int32
+	Remaining int32
+}
+
+// SkillCoolTime lists skills of character which are not ready yet.
+type SkillCoolTime struct {
+	Skills []SkillReuse
+}
+
+func NewSkillCoolTimeFromBytes(data []byte) (*SkillCoolTime, error) {
+	reader := packet.NewReader(data)
+	packet := SkillCoolTime{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *SkillCoolTime) FromBytes(reader *packet.Reader) error {
+	var count int32
+	if err := readInt32s(reader, &count); err != nil {
+		return err
+	}
+	if count < 0 || count > maxSkillCoolTimes {
+		return fmt.Errorf("invalid skill cool times count: %d", count)
+	}
+
+	p.Skills = make([]SkillReuse, count)
+	for i := range p.Skills {
+		skill := &p.Skills[i]
+		err := readInt32s(reader,
+			&skill.SkillID, &skill.Level, &skill.Reuse, &skill.Remaining)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *SkillCoolTime) ToBytes(writer *packet.Writer) error {
+	if len(p.Skills) > maxSkillCoolTimes {
+		return errors.New("too many skill cool times")
+	}
+	count := int32(len(p.Skills)) //nolint:gosec
+	if err := writeInt32s(writer, count); err != nil {
+		return err
+	}
+	for _, skill := range p.Skills {
+		err := writeInt32s(writer,
+			skill.SkillID, skill.Level, skill.Reuse, skill.Remaining)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *SkillCoolTime) ToString() string {
+	var sb strings.Builder
+	sb.WriteString("\nSkillCoolTime:")
+	for _, skill := range p.Skills {
+		sb.WriteString(fmt.Sprintf("\n  Skill %d level %d: %d of %d s",
+			skill.SkillID, skill.Level, skill.Remaining, skill.Reuse))
+	}
+
+	return sb.String()
+}
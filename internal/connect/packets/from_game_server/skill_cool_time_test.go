@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillCoolTime_RoundTrip(t *testing.T) {
+	original := &SkillCoolTime{Skills: []SkillReuse{
+		{SkillID: 1011, Level: 3, Reuse: 30, Remaining: 12},
+		{SkillID: 1012, Level: 1, Reuse: 60, Remaining: 1},
+	}}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewSkillCoolTimeFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Skill 1011 level 3: 12 of 30 s")
+}
+
+func TestNewSkillCoolTimeFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int32
+	}{
+		{name: "negative count", data: []int32{-1}},
+		{name: "too many skills", data: []int32{maxSkillCoolTimes + 1}},
+		{name: "missing skill", data: []int32{1, 1011, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt32s(writer, test.data...))
+			_, err := NewSkillCoolTimeFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSkillCoolTime_ToBytesTooManySkills(t *testing.T) {
+	coolTime := &SkillCoolTime{
+		Skills: make([]SkillReuse, maxSkillCoolTimes+1),
+	}
+	require.Error(t, coolTime.ToBytes(packet.NewWriter()))
+}
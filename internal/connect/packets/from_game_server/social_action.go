@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const SocialActionID = 0x2d
+
+// SocialAction tells that creature plays emote.
+type SocialAction struct {
+	ObjectID int32
+	ActionID int32
+}
+
+func NewSocialActionFromBytes(data []byte) (*SocialAction, error) {
+	reader := packet.NewReader(data)
+	packet := SocialAction{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *SocialAction) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.ActionID)
+}
+
+func (p *SocialAction) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.ActionID)
+}
+
+func (p *SocialAction) ToString() string {
+	return fmt.Sprintf("\nSocialAction:\n  ObjectID: %d\n  ActionID: %d",
+		p.ObjectID, p.ActionID)
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocialAction_RoundTrip(t *testing.T) {
+	original := &SocialAction{ObjectID: 7, ActionID: 3}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewSocialActionFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ActionID: 3")
+}
+
+func TestNewSocialActionFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewSocialActionFromBytes([]byte{0x07, 0, 0, 0})
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const SpawnItemID = 0x0b
+
+// SpawnItem shows item lying on ground when it gets into view.
+type SpawnItem struct {
+	ObjectID  int32
+	ItemID    int32
+	X         int32
+	Y         int32
+	Z         int32
+	Stackable int32
+	Count     int32
+}
+
+func NewSpawnItemFromBytes(data []byte) (*SpawnItem, error) {
+	reader := packet.NewReader(data)
+	packet := SpawnItem{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *SpawnItem) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.ItemID,
+		&p.X, &p.Y, &p.Z, &p.Stackable, &p.Count)
+}
+
+func (p *SpawnItem) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.ItemID,
+		p.X, p.Y, p.Z, p.Stackable, p.Count)
+}
+
+func (p *SpawnItem) ToString() string {
+	return fmt.Sprintf("\nSpawnItem:\n  ObjectID: %d\n  ItemID: %d"+
+		"\n  Location: %d %d %d\n  Count: %d",
+		p.ObjectID, p.ItemID, p.X, p.Y, p.Z, p.Count)
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnItem_RoundTrip(t *testing.T) {
+	original := &SpawnItem{
+		ObjectID:  1,
+		ItemID:    57,
+		X:         10,
+		Y:         -20,
+		Z:         30,
+		Stackable: 1,
+		Count:     1000,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewSpawnItemFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "Count: 1000")
+}
+
+func TestNewSpawnItemFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewSpawnItemFromBytes([]byte{0x01, 0x00, 0x00, 0x00})
+	require.Error(t, err)
+}
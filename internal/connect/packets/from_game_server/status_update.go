@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const StatusUpdateID = 0x0e
+
+// Attribute ids of StatusUpdate.
+const (
+	StatusLevel    = 0x01
+	StatusExp      = 0x02
+	StatusCurHP    = 0x09
+	StatusMaxHP    = 0x0a
+	StatusCurMP    = 0x0b
+	StatusMaxMP    = 0x0c
+	StatusSP       = 0x0d
+	StatusCurLoad  = 0x0e
+	StatusMaxLoad  = 0x0f
+	StatusPvPFlag  = 0x1a
+	StatusKarma    = 0x1b
+	StatusCurCP    = 0x21
+	StatusMaxCP    = 0x22
+	maxStatusCount = 64
+)
+
+type StatusAttribute struct {
+	ID    int32
+	Value int32
+}
+
+// StatusUpdate changes some of attributes of creature, like current HP.
+type StatusUpdate struct {
+	ObjectID   int32
+	Attributes []StatusAttribute
+}
+
+func NewStatusUpdateFromBytes(data []byte) (*StatusUpdate, error) {
+	reader := packet.NewReader(data)
+	packet := StatusUpdate{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *StatusUpdate) FromBytes(reader *packet.Reader) error {
+	var count int32
+	if err := readInt32s(reader, &p.ObjectID, &count); err != nil {
+		return err
+	}
+	if count < 0 || count > maxStatusCount {
+		return fmt.Errorf("invalid status attributes count: %d", count)
+	}
+
+	p.Attributes = make([]StatusAttribute, count)
+	for i := range p.Attributes {
+		attribute := &p.Attributes[i]
+		err := readInt32s(reader, &attribute.ID, &attribute.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *StatusUpdate) ToBytes(writer *packet.Writer) error {
+	if len(p.Attributes) > maxStatusCount {
+		return errors.New("too many status attributes")
+	}
+	count := int32(len(p.Attributes)) //nolint:gosec
+	if err := writeInt32s(writer, p.ObjectID, count); err != nil {
+		return err
+	}
+	for _, attribute := range p.Attributes {
+		err := writeInt32s(writer, attribute.ID, attribute.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Value returns value of attribute if packet has it.
+func (p *StatusUpdate) Value(id int32) (int32, bool) {
+	for _, attribute := range p.Attributes {
+		if attribute.ID == id {
+			return attribute.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+func (p *StatusUpdate) ToString() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nStatusUpdate:\n  ObjectID: %d", p.ObjectID))
+	for _, attribute := range p.Attributes {
+		sb.WriteString(fmt.Sprintf("\n  %#02x: %d",
+			attribute.ID, attribute.Value))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusUpdate_RoundTrip(t *testing.T) {
+	original := &StatusUpdate{
+		ObjectID: 3,
+		Attributes: []StatusAttribute{
+			{ID: StatusCurHP, Value: 50},
+			{ID: StatusMaxHP, Value: 100},
+		},
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewStatusUpdateFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "0x09: 50")
+
+	value, ok := decoded.Value(StatusMaxHP)
+	require.True(t, ok)
+	require.Equal(t, int32(100), value)
+
+	_, ok = decoded.Value(StatusCurMP)
+	require.False(t, ok)
+}
+
+func TestNewStatusUpdateFromBytes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []int32
+	}{
+		{name: "negative count", data: []int32{1, -1}},
+		{name: "too many attributes", data: []int32{1, maxStatusCount + 1}},
+		{name: "missing attribute", data: []int32{1, 2, StatusCurHP, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, writeInt32s(writer, test.data...))
+			_, err := NewStatusUpdateFromBytes(writer.Bytes())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestStatusUpdate_ToBytesTooManyAttributes(t *testing.T) {
+	update := &StatusUpdate{
+		ObjectID:   1,
+		Attributes: make([]StatusAttribute, maxStatusCount+1),
+	}
+	require.Error(t, update.ToBytes(packet.NewWriter()))
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const StopMoveID = 0x47
+
+// StopMove tells that creature stopped at location.
+type StopMove struct {
+	ObjectID int32
+	X        int32
+	Y        int32
+	Z        int32
+	Heading  int32
+}
+
+func NewStopMoveFromBytes(data []byte) (*StopMove, error) {
+	reader := packet.NewReader(data)
+	packet := StopMove{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *StopMove) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.X, &p.Y, &p.Z, &p.Heading)
+}
+
+func (p *StopMove) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.X, p.Y, p.Z, p.Heading)
+}
+
+func (p *StopMove) ToString() string {
+	return fmt.Sprintf("\nStopMove:\n  ObjectID: %d\n  Location: %d %d %d"+
+		"\n  Heading: %d", p.ObjectID, p.X, p.Y, p.Z, p.Heading)
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const TargetSelectedID = 0x29
+
+// TargetSelected tells that other creature selected target, position is of
+// creature.
+type TargetSelected struct {
+	ObjectID int32
+	TargetID int32
+	X        int32
+	Y        int32
+	Z        int32
+}
+
+func NewTargetSelectedFromBytes(data []byte) (*TargetSelected, error) {
+	reader := packet.NewReader(data)
+	packet := TargetSelected{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *TargetSelected) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.TargetID, &p.X, &p.Y, &p.Z)
+}
+
+func (p *TargetSelected) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.TargetID, p.X, p.Y, p.Z)
+}
+
+func (p *TargetSelected) ToString() string {
+	return fmt.Sprintf("\nTargetSelected:\n  ObjectID: %d\n  TargetID: %d"+
+		"\n  X: %d\n  Y: %d\n  Z: %d", p.ObjectID, p.TargetID, p.X, p.Y, p.Z)
+}
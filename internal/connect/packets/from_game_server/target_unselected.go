@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const TargetUnselectedID = 0x2a
+
+// TargetUnselected tells that creature dropped its target, position is of
+// creature.
+type TargetUnselected struct {
+	ObjectID int32
+	X        int32
+	Y        int32
+	Z        int32
+}
+
+func NewTargetUnselectedFromBytes(data []byte) (*TargetUnselected, error) {
+	reader := packet.NewReader(data)
+	packet := TargetUnselected{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *TargetUnselected) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.X, &p.Y, &p.Z)
+}
+
+func (p *TargetUnselected) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.X, p.Y, p.Z)
+}
+
+func (p *TargetUnselected) ToString() string {
+	return fmt.Sprintf("\nTargetUnselected:\n  ObjectID: %d"+
+		"\n  X: %d\n  Y: %d\n  Z: %d", p.ObjectID, p.X, p.Y, p.Z)
+}
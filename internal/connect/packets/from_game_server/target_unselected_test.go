@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetUnselected_RoundTrip(t *testing.T) {
+	original := &TargetUnselected{ObjectID: 7, X: 1, Y: -2, Z: 3}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewTargetUnselectedFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ObjectID: 7")
+}
+
+func TestNewTargetUnselectedFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewTargetUnselectedFromBytes([]byte{0x07, 0, 0, 0, 0x2a, 0, 0, 0})
+	require.Error(t, err)
+}
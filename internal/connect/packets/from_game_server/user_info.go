@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	UserInfoID         = 0x04
+	userPaperdollSlots = 16
+)
+
+// UserInfo describes character controlled by client. Only fields up to
+// collision size are decoded, rest of packet is ignored.
+type UserInfo struct {
+	X        int32
+	Y        int32
+	Z        int32
+	Heading  int32
+	ObjectID int32
+	Name     string
+	Race     int32
+	Sex      int32
+	ClassID  int32
+	Level    int32
+	Exp      int32
+	STR      int32
+	DEX      int32
+	CON      int32
+	INT      int32
+	WIT      int32
+	MEN      int32
+	MaxHP    int32
+	CurHP    int32
+	MaxMP    int32
+	CurMP    int32
+	SP       int32
+	CurLoad  int32
+	MaxLoad  int32
+	Unknown  int32
+
+	PaperdollObjectIDs [userPaperdollSlots]int32
+	PaperdollItemIDs   [userPaperdollSlots]int32
+
+	PAtk          int32
+	PAtkSpd       int32
+	PDef          int32
+	Evasion       int32
+	Accuracy      int32
+	Critical      int32
+	MAtk          int32
+	MAtkSpd       int32
+	PAtkSpd2      int32
+	MDef          int32
+	PvPFlag       int32
+	Karma         int32
+	RunSpeed      int32
+	WalkSpeed     int32
+	SwimRunSpeed  int32
+	SwimWalkSpeed int32
+	FlRunSpeed    int32
+	FlWalkSpeed   int32
+	FlyRunSpeed   int32
+	FlyWalkSpeed  int32
+
+	MoveMultiplier        float64
+	AttackSpeedMultiplier float64
+	CollisionRadius       float64
+	CollisionHeight       float64
+}
+
+func NewUserInfoFromBytes(data []byte) (*UserInfo, error) {
+	reader := packet.NewReader(data)
+	packet := UserInfo{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *UserInfo) head() []*int32 {
+	return []*int32{&p.X, &p.Y, &p.Z, &p.Heading, &p.ObjectID}
+}
+
+func (p *UserInfo) stats() []*int32 {
+	return []*int32{
+		&p.Race, &p.Sex, &p.ClassID, &p.Level, &p.Exp,
+		&p.STR, &p.DEX, &p.CON, &p.INT, &p.WIT, &p.MEN,
+		&p.MaxHP, &p.CurHP, &p.MaxMP, &p.CurMP, &p.SP,
+		&p.CurLoad, &p.MaxLoad, &p.Unknown,
+	}
+}
+
+func (p *UserInfo) combat() []*int32 {
+	return []*int32{
+		&p.PAtk, &p.PAtkSpd, &p.PDef, &p.Evasion, &p.Accuracy, &p.Critical,
+		&p.MAtk, &p.MAtkSpd, &p.PAtkSpd2, &p.MDef, &p.PvPFlag, &p.Karma,
+		&p.RunSpeed, &p.WalkSpeed, &p.SwimRunSpeed, &p.SwimWalkSpeed,
+		&p.FlRunSpeed, &p.FlWalkSpeed, &p.FlyRunSpeed, &p.FlyWalkSpeed,
+	}
+}
+
+func (p *UserInfo) sizes() []*float64 {
+	return []*float64{
+		&p.MoveMultiplier, &p.AttackSpeedMultiplier,
+		&p.CollisionRadius, &p.CollisionHeight,
+	}
+}
+
+func (p *UserInfo) FromBytes(reader *packet.Reader) error {
+	steps := []func() error{
+		func() error { return readInt32s(reader, p.head()...) },
+		func() error { return readStrings(reader, &p.Name) },
+		func() error { return readInt32s(reader, p.stats()...) },
+		func() error {
+			return readInt32s(reader, int32Pointers(p.PaperdollObjectIDs[:])...)
+		},
+		func() error {
+			return readInt32s(reader, int32Pointers(p.PaperdollItemIDs[:])...)
+		},
+		func() error { return readInt32s(reader, p.combat()...) },
+		func() error { return readFloat64s(reader, p.sizes()...) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *UserInfo) ToBytes(writer *packet.Writer) error {
+	steps := []func() error{
+		func() error { return writeInt32s(writer, values(p.head())...) },
+		func() error { return writeStrings(writer, p.Name) },
+		func() error { return writeInt32s(writer, values(p.stats())...) },
+		func() error { return writeInt32s(writer, p.PaperdollObjectIDs[:]...) },
+		func() error { return writeInt32s(writer, p.PaperdollItemIDs[:]...) },
+		func() error { return writeInt32s(writer, values(p.combat())...) },
+		func() error { return writeFloat64s(writer, values(p.sizes())...) },
+	}
+
+	return runSteps(steps)
+}
+
+func (p *UserInfo) ToString() string {
+	return fmt.Sprintf("\nUserInfo:\n  ObjectID: %d\n  Name: %s"+
+		"\n  Location: %d %d %d\n  Level: %d\n  HP: %d/%d\n  MP: %d/%d",
+		p.ObjectID, p.Name, p.X, p.Y, p.Z, p.Level,
+		p.CurHP, p.MaxHP, p.CurMP, p.MaxMP)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserInfo_RoundTrip(t *testing.T) {
+	original := &UserInfo{} //nolint:exhaustruct
+	original.X, original.Y, original.Z = 10, 20, -30
+	original.ObjectID = 268435456
+	original.Name = "Tank"
+	original.Level = 20
+	original.CurHP, original.MaxHP = 300, 400
+	original.PaperdollItemIDs[6] = 2369
+	original.RunSpeed, original.WalkSpeed = 120, 80
+	original.MoveMultiplier = 1.1
+	original.CollisionRadius = 9
+
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewUserInfoFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "HP: 300/400")
+}
+
+func TestNewUserInfoFromBytes_Truncated(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&UserInfo{}).ToBytes(writer)) //nolint:exhaustruct
+	data := writer.Bytes()
+
+	for _, size := range []int{0, 20, 24, 120, 200, len(data) - 1} {
+		_, err := NewUserInfoFromBytes(data[:size])
+		require.Error(t, err, "size %d", size)
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ValidateLocationID = 0x61
+
+// ValidateLocation corrects client side location of creature, creature may
+// still be moving.
+type ValidateLocation struct {
+	ObjectID int32
+	X        int32
+	Y        int32
+	Z        int32
+	Heading  int32
+}
+
+func NewValidateLocationFromBytes(data []byte) (*ValidateLocation, error) {
+	reader := packet.NewReader(data)
+	packet := ValidateLocation{}
+	if err := packet.FromBytes(reader); err != nil {
+		return nil, err
+	}
+
+	return &packet, nil
+}
+
+func (p *ValidateLocation) FromBytes(reader *packet.Reader) error {
+	return readInt32s(reader, &p.ObjectID, &p.X, &p.Y, &p.Z, &p.Heading)
+}
+
+func (p *ValidateLocation) ToBytes(writer *packet.Writer) error {
+	return writeInt32s(writer, p.ObjectID, p.X, p.Y, p.Z, p.Heading)
+}
+
+func (p *ValidateLocation) ToString() string {
+	return fmt.Sprintf("\nValidateLocation:\n  ObjectID: %d"+
+		"\n  Location: %d %d %d\n  Heading: %d",
+		p.ObjectID, p.X, p.Y, p.Z, p.Heading)
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package fromgameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLocation_RoundTrip(t *testing.T) {
+	original := &ValidateLocation{
+		ObjectID: 7,
+		X:        1,
+		Y:        2,
+		Z:        3,
+		Heading:  16384,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, original.ToBytes(writer))
+
+	decoded, err := NewValidateLocationFromBytes(writer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.Contains(t, decoded.ToString(), "ObjectID: 7")
+}
+
+func TestNewValidateLocationFromBytes_NotEnoughData(t *testing.T) {
+	_, err := NewValidateLocationFromBytes([]byte{0x07})
+	require.Error(t, err)
+}
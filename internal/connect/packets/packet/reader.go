@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"math"
 
 	"golang.org/x/text/encoding/unicode"
 )
@@ -54,6 +55,15 @@ func (r *Reader) ReadInt64() (int64, error) {
 	return result, nil
 }
 
+func (r *Reader) ReadFloat64() (float64, error) {
+	value, err := r.ReadInt64()
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Float64frombits(uint64(value)), nil
+}
+
 func (r *Reader) ReadInt32() (int32, error) {
 	var buf [4]byte
 	n, err := r.Read(buf[:])
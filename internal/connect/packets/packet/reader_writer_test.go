@@ -217,3 +217,32 @@ func TestPacketReaderReadStringFromUtf16FormatTooSmallError(t *testing.T) {
 		t.Errorf("Expected error, got nil")
 	}
 }
+
+func TestPacketWriterAndReaderFloat64(t *testing.T) {
+	writer := NewWriter()
+	if err := writer.WriteFloat64(1.1); err != nil {
+		t.Fatal(err)
+	}
+
+	data := writer.Bytes()
+	expected := []byte{0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xf1, 0x3f}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Got different float64 bytes: %x != %x", data, expected)
+	}
+
+	value, err := NewReader(data).ReadFloat64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 1.1 {
+		t.Errorf("Got different float64 value: %f != 1.1", value)
+	}
+}
+
+func TestPacketReaderReadFloat64Error(t *testing.T) {
+	reader := NewReader([]byte{0x01, 0x02, 0x03})
+	_, err := reader.ReadFloat64()
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -6,6 +6,7 @@ package packet
 
 import (
 	"bytes"
+	"math"
 	"unsafe"
 )
 
@@ -28,6 +29,10 @@ func (b *Writer) WriteInt64(value int64) error {
 	return err
 }
 
+func (b *Writer) WriteFloat64(value float64) error {
+	return b.WriteInt64(int64(math.Float64bits(value)))
+}
+
 func (b *Writer) WriteInt32(value int32) error {
 	buf := (*[4]byte)(unsafe.Pointer(&value))
 	_, err := b.Write(buf[:])
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/helpers"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	RequestAuthLoginID = 0x00
+	// CredentialsSize is size of RSA block with login and password.
+	CredentialsSize = 128
+
+	credentialsFlagOffset = 0x5b
+	credentialsFlag       = 0x24
+	loginOffset           = 0x5e
+	loginSize             = 14
+	passwordOffset        = 0x6c
+	passwordSize          = 16
+)
+
+// RequestAuthLogin sends account credentials, Credentials is block from
+// NewCredentials encrypted with RSA public key of Init packet.
+type RequestAuthLogin struct {
+	Credentials []byte
+}
+
+// NewCredentials places login and password where auth server expects them
+// in RSA block, both are padded with zeroes.
+func NewCredentials(login, password string) ([]byte, error) {
+	if len(login) > loginSize {
+		return nil, fmt.Errorf("login is longer than %d bytes", loginSize)
+	}
+	if len(password) > passwordSize {
+		return nil, fmt.Errorf("password is longer than %d bytes",
+			passwordSize)
+	}
+
+	block := make([]byte, CredentialsSize)
+	block[credentialsFlagOffset] = credentialsFlag
+	copy(block[loginOffset:], login)
+	copy(block[passwordOffset:], password)
+
+	return block, nil
+}
+
+func (p *RequestAuthLogin) ToBytes(writer *packet.Writer) error {
+	if len(p.Credentials) != CredentialsSize {
+		return fmt.Errorf("invalid credentials len: %d bytes, expected %d",
+			len(p.Credentials), CredentialsSize)
+	}
+	if err := writer.WriteInt8(RequestAuthLoginID); err != nil {
+		return err
+	}
+
+	return writer.WriteBytes(p.Credentials)
+}
+
+func (p *RequestAuthLogin) ToString() string {
+	return "\nRequestAuthLogin:" +
+		"\n  Credentials: \n" +
+		helpers.HexViewFromWithLineSplit(p.Credentials, 16, "    ")
+}
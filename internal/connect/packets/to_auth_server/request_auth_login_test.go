@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCredentials(t *testing.T) {
+	block, err := NewCredentials("user", "secret")
+	require.NoError(t, err)
+	require.Len(t, block, CredentialsSize)
+	require.Equal(t, byte(0x24), block[0x5b])
+	require.Equal(t, []byte("user\x00"), block[0x5e:0x63])
+	require.Equal(t, []byte("secret\x00"), block[0x6c:0x73])
+
+	_, err = NewCredentials("loginlongerthan14", "secret")
+	require.Error(t, err)
+	_, err = NewCredentials("user", "passwordlongerthan16")
+	require.Error(t, err)
+}
+
+func TestRequestAuthLogin_ToBytes(t *testing.T) {
+	block, err := NewCredentials("user", "secret")
+	require.NoError(t, err)
+	request := &RequestAuthLogin{Credentials: block}
+
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, append([]byte{0x00}, block...), writer.Bytes())
+	require.Contains(t, request.ToString(), "Credentials")
+
+	short := &RequestAuthLogin{Credentials: block[:64]}
+	require.Error(t, short.ToBytes(packet.NewWriter()))
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"github.com/melg8/connect/internal/connect/helpers"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	RequestServerListID = 0x05
+	serverListKind      = 0x04
+)
+
+// RequestServerList asks for game servers with keys from LoginOk.
+type RequestServerList struct {
+	LoginOk1 int32
+	LoginOk2 int32
+}
+
+func (p *RequestServerList) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestServerListID); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.LoginOk1); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.LoginOk2); err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(serverListKind)
+}
+
+func (p *RequestServerList) ToString() string {
+	return "\nRequestServerList:" +
+		"\n  LoginOk1: " + helpers.HexStringFromInt32(p.LoginOk1) +
+		"\n  LoginOk2: " + helpers.HexStringFromInt32(p.LoginOk2)
+}
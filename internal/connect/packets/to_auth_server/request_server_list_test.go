@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestServerList_ToBytes(t *testing.T) {
+	request := &RequestServerList{LoginOk1: 1, LoginOk2: 2}
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x05,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x04,
+	}, writer.Bytes())
+	require.Contains(t, request.ToString(), "LoginOk2: 00000002")
+}
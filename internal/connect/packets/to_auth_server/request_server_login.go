@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/helpers"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestServerLoginID = 0x02
+
+// RequestServerLogin asks for keys to enter game server with given id.
+type RequestServerLogin struct {
+	LoginOk1 int32
+	LoginOk2 int32
+	ServerID int8
+}
+
+func (p *RequestServerLogin) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestServerLoginID); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.LoginOk1); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.LoginOk2); err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(p.ServerID)
+}
+
+func (p *RequestServerLogin) ToString() string {
+	return "\nRequestServerLogin:" +
+		"\n  LoginOk1: " + helpers.HexStringFromInt32(p.LoginOk1) +
+		"\n  LoginOk2: " + helpers.HexStringFromInt32(p.LoginOk2) +
+		fmt.Sprintf("\n  ServerID: %d", p.ServerID)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package toauthserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestServerLogin_ToBytes(t *testing.T) {
+	request := &RequestServerLogin{LoginOk1: 1, LoginOk2: 2, ServerID: 3}
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x02,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x03,
+	}, writer.Bytes())
+	require.Contains(t, request.ToString(), "ServerID: 3")
+}
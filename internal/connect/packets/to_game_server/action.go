@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ActionID = 0x04
+
+// Action is click on object: first click selects it as target, click on
+// selected target talks to it or attacks it. Shift click only selects.
+type Action struct {
+	ObjectID int32
+	OriginX  int32
+	OriginY  int32
+	OriginZ  int32
+	Shift    bool
+}
+
+func (p *Action) ToBytes(writer *packet.Writer) error {
+	err := writePacket(writer, ActionID,
+		p.ObjectID, p.OriginX, p.OriginY, p.OriginZ)
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(int8(flag(p.Shift)))
+}
+
+func (p *Action) ToString() string {
+	return fmt.Sprintf("\nAction:\n  ObjectID: %d\n  Origin: %d %d %d"+
+		"\n  Shift: %t", p.ObjectID, p.OriginX, p.OriginY, p.OriginZ, p.Shift)
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAction_ToBytes(t *testing.T) {
+	action := &Action{
+		ObjectID: 5,
+		OriginX:  1,
+		OriginY:  2,
+		OriginZ:  -1,
+		Shift:    true,
+	}
+
+	writer := packet.NewWriter()
+	require.NoError(t, action.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x04,
+		0x05, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+		0x01,
+	}, writer.Bytes())
+	require.Contains(t, action.ToString(), "ObjectID: 5")
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const AttackRequestID = 0x0a
+
+// AttackRequest attacks object, shift attacks without moving to it.
+type AttackRequest struct {
+	ObjectID int32
+	OriginX  int32
+	OriginY  int32
+	OriginZ  int32
+	Shift    bool
+}
+
+func (p *AttackRequest) ToBytes(writer *packet.Writer) error {
+	err := writePacket(writer, AttackRequestID,
+		p.ObjectID, p.OriginX, p.OriginY, p.OriginZ)
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(int8(flag(p.Shift)))
+}
+
+func (p *AttackRequest) ToString() string {
+	return fmt.Sprintf("\nAttackRequest:\n  ObjectID: %d"+
+		"\n  Origin: %d %d %d\n  Shift: %t",
+		p.ObjectID, p.OriginX, p.OriginY, p.OriginZ, p.Shift)
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttackRequest_ToBytes(t *testing.T) {
+	attack := &AttackRequest{
+		ObjectID: 5,
+		OriginX:  1,
+		OriginY:  2,
+		OriginZ:  3,
+		Shift:    false,
+	}
+
+	writer := packet.NewWriter()
+	require.NoError(t, attack.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x0a,
+		0x05, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0x00,
+	}, writer.Bytes())
+	require.Contains(t, attack.ToString(), "Shift: false")
+}
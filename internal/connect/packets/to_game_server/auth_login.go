@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const AuthLoginID = 0x08
+
+// AuthLogin logs in to game server with keys from LoginOk and PlayOk of
+// auth server, server answers with CharSelectInfo.
+type AuthLogin struct {
+	Login    string
+	PlayOk1  int32
+	PlayOk2  int32
+	LoginOk1 int32
+	LoginOk2 int32
+}
+
+func (p *AuthLogin) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(AuthLoginID); err != nil {
+		return err
+	}
+	if err := writer.WriteStringAsUtf16(p.Login); err != nil {
+		return err
+	}
+	for _, value := range []int32{
+		p.PlayOk2, p.PlayOk1, p.LoginOk1, p.LoginOk2,
+	} {
+		if err := writer.WriteInt32(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *AuthLogin) ToString() string {
+	return fmt.Sprintf("\nAuthLogin:\n  Login: %s\n  PlayOk1: %d"+
+		"\n  PlayOk2: %d\n  LoginOk1: %d\n  LoginOk2: %d",
+		p.Login, p.PlayOk1, p.PlayOk2, p.LoginOk1, p.LoginOk2)
+}
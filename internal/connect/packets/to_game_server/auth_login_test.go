@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthLogin_ToBytes(t *testing.T) {
+	request := &AuthLogin{
+		Login: "ab", PlayOk1: 1, PlayOk2: 2, LoginOk1: 3, LoginOk2: 4,
+	}
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x08,
+		'a', 0x00, 'b', 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, request.ToString(), "Login: ab")
+}
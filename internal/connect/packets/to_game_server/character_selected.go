@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const CharacterSelectedID = 0x0d
+
+// CharacterSelected picks character by its slot in CharSelectInfo, server
+// answers with CharSelected.
+type CharacterSelected struct {
+	Slot int32
+}
+
+func (p *CharacterSelected) ToBytes(writer *packet.Writer) error {
+	if err := writePacket(writer, CharacterSelectedID, p.Slot); err != nil {
+		return err
+	}
+	if err := writer.WriteInt16(0); err != nil {
+		return err
+	}
+
+	for range 3 {
+		if err := writer.WriteInt32(0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *CharacterSelected) ToString() string {
+	return fmt.Sprintf("\nCharacterSelected:\n  Slot: %d", p.Slot)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharacterSelected_ToBytes(t *testing.T) {
+	request := &CharacterSelected{Slot: 2}
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x0d,
+		0x02, 0x00, 0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, request.ToString(), "Slot: 2")
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const EnterWorldID = 0x03
+
+// EnterWorld places selected character into game world, server answers
+// with UserInfo among other packets.
+type EnterWorld struct{}
+
+func (p *EnterWorld) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt8(EnterWorldID)
+}
+
+func (p *EnterWorld) ToString() string {
+	return "\nEnterWorld:"
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnterWorld_ToBytes(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&EnterWorld{}).ToBytes(writer))
+	require.Equal(t, []byte{0x03}, writer.Bytes())
+	require.Contains(t, (&EnterWorld{}).ToString(), "EnterWorld")
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+// writePacket writes packet id followed by int32 fields in order of
+// arguments, most of client packets are just that.
+func writePacket(writer *packet.Writer, id byte, values ...int32) error {
+	if err := writer.WriteByte(id); err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := writer.WriteInt32(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flag converts key modifier like pressed ctrl to packet field.
+func flag(value bool) int32 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePacket(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, writePacket(writer, 0x2a, 1, -1))
+	require.Equal(t, []byte{
+		0x2a,
+		0x01, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+	}, writer.Bytes())
+}
+
+func TestFlag(t *testing.T) {
+	require.Equal(t, int32(1), flag(true))
+	require.Equal(t, int32(0), flag(false))
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const LogoutID = 0x09
+
+// Logout asks server to leave game, server answers with LogOutOk.
+type Logout struct{}
+
+func (p *Logout) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt8(LogoutID)
+}
+
+func (p *Logout) ToString() string {
+	return "\nLogout:"
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	MoveBackwardToLocationID = 0x01
+	// MoveByMouse is movement type of click on ground, other type is
+	// movement by keyboard arrows.
+	MoveByMouse = 1
+)
+
+// MoveBackwardToLocation asks server to move character from origin to
+// target, server answers with MoveToLocation.
+type MoveBackwardToLocation struct {
+	TargetX  int32
+	TargetY  int32
+	TargetZ  int32
+	OriginX  int32
+	OriginY  int32
+	OriginZ  int32
+	MoveType int32
+}
+
+func (p *MoveBackwardToLocation) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, MoveBackwardToLocationID,
+		p.TargetX, p.TargetY, p.TargetZ,
+		p.OriginX, p.OriginY, p.OriginZ, p.MoveType)
+}
+
+func (p *MoveBackwardToLocation) ToString() string {
+	return fmt.Sprintf("\nMoveBackwardToLocation:"+
+		"\n  Origin: %d %d %d\n  Target: %d %d %d",
+		p.OriginX, p.OriginY, p.OriginZ, p.TargetX, p.TargetY, p.TargetZ)
+}
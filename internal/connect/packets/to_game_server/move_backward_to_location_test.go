@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveBackwardToLocation_ToBytes(t *testing.T) {
+	move := &MoveBackwardToLocation{
+		TargetX:  1,
+		TargetY:  2,
+		TargetZ:  3,
+		OriginX:  4,
+		OriginY:  5,
+		OriginZ:  -1,
+		MoveType: MoveByMouse,
+	}
+
+	writer := packet.NewWriter()
+	require.NoError(t, move.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x01,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+		0x05, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+		0x01, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, move.ToString(), "Target: 1 2 3")
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	ProtocolVersionID = 0x00
+	// DefaultProtocol is protocol revision of C4 clients.
+	DefaultProtocol = 656
+)
+
+// ProtocolVersion is first packet to game server, server answers with
+// KeyPacket.
+type ProtocolVersion struct {
+	Version int32
+}
+
+func (p *ProtocolVersion) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, ProtocolVersionID, p.Version)
+}
+
+func (p *ProtocolVersion) ToString() string {
+	return fmt.Sprintf("\nProtocolVersion:\n  Version: %d", p.Version)
+}
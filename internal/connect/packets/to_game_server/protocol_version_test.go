@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolVersion_ToBytes(t *testing.T) {
+	request := &ProtocolVersion{Version: DefaultProtocol}
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{0x00, 0x90, 0x02, 0x00, 0x00}, writer.Bytes())
+	require.Contains(t, request.ToString(), "Version: 656")
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestActionUseID = 0x45
+
+// Some of actions of RequestActionUse.
+const (
+	ActionSit       = 0
+	ActionWalkOrRun = 1
+	ActionPetFollow = 15
+	ActionPetAttack = 16
+)
+
+// RequestActionUse uses action from actions window, like sit or command to
+// pet.
+type RequestActionUse struct {
+	ActionID int32
+	Ctrl     bool
+	Shift    bool
+}
+
+func (p *RequestActionUse) ToBytes(writer *packet.Writer) error {
+	err := writePacket(writer, RequestActionUseID, p.ActionID, flag(p.Ctrl))
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(int8(flag(p.Shift)))
+}
+
+func (p *RequestActionUse) ToString() string {
+	return fmt.Sprintf("\nRequestActionUse:\n  ActionID: %d"+
+		"\n  Ctrl: %t\n  Shift: %t", p.ActionID, p.Ctrl, p.Shift)
+}
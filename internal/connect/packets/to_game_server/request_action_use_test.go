@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestActionUse_ToBytes(t *testing.T) {
+	use := &RequestActionUse{ActionID: ActionSit, Ctrl: false, Shift: true}
+
+	writer := packet.NewWriter()
+	require.NoError(t, use.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x45,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x01,
+	}, writer.Bytes())
+	require.Contains(t, use.ToString(), "Shift: true")
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestAnswerJoinPartyID = 0x2a
+
+// Answers to invitation to party.
+const (
+	PartyDecline int32 = 0
+	PartyAccept  int32 = 1
+)
+
+// RequestAnswerJoinParty answers AskJoinParty.
+type RequestAnswerJoinParty struct {
+	Response int32
+}
+
+func (p *RequestAnswerJoinParty) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, RequestAnswerJoinPartyID, p.Response)
+}
+
+func (p *RequestAnswerJoinParty) ToString() string {
+	return fmt.Sprintf("\nRequestAnswerJoinParty:\n  Response: %d",
+		p.Response)
+}
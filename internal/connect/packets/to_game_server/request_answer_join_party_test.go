@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestAnswerJoinParty_ToBytes(t *testing.T) {
+	answer := &RequestAnswerJoinParty{Response: PartyAccept}
+
+	writer := packet.NewWriter()
+	require.NoError(t, answer.ToBytes(writer))
+	require.Equal(t, []byte{0x2a, 0x01, 0x00, 0x00, 0x00}, writer.Bytes())
+	require.Contains(t, answer.ToString(), "Response: 1")
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const (
+	// ExtendedID is id of client packets which have second two byte id.
+	ExtendedID            = 0xd0
+	RequestAutoSoulShotID = 0x05
+)
+
+// RequestAutoSoulShot turns automatic use of soulshot item on or off,
+// server confirms it with ExAutoSoulShot.
+type RequestAutoSoulShot struct {
+	ItemID  int32
+	Enabled bool
+}
+
+func (p *RequestAutoSoulShot) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteByte(ExtendedID); err != nil {
+		return err
+	}
+	if err := writer.WriteInt16(RequestAutoSoulShotID); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.ItemID); err != nil {
+		return err
+	}
+
+	return writer.WriteInt32(flag(p.Enabled))
+}
+
+func (p *RequestAutoSoulShot) ToString() string {
+	return fmt.Sprintf("\nRequestAutoSoulShot:\n  ItemID: %d\n  Enabled: %t",
+		p.ItemID, p.Enabled)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestAutoSoulShot_ToBytes(t *testing.T) {
+	auto := &RequestAutoSoulShot{ItemID: 1835, Enabled: true}
+
+	writer := packet.NewWriter()
+	require.NoError(t, auto.ToBytes(writer))
+	require.Equal(t, []byte{
+		0xd0, 0x05, 0x00,
+		0x2b, 0x07, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, auto.ToString(), "Enabled: true")
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestBypassToServerID = 0x21
+
+// RequestBypassToServer sends bypass command of npc dialog, like clicked
+// link or button does.
+type RequestBypassToServer struct {
+	Command string
+}
+
+func (p *RequestBypassToServer) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestBypassToServerID); err != nil {
+		return err
+	}
+
+	return writer.WriteStringAsUtf16(p.Command)
+}
+
+func (p *RequestBypassToServer) ToString() string {
+	return "\nRequestBypassToServer:\n  Command: " + p.Command
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBypassToServer_ToBytes(t *testing.T) {
+	bypass := &RequestBypassToServer{Command: "npc_1"}
+
+	writer := packet.NewWriter()
+	require.NoError(t, bypass.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x21,
+		'n', 0x00, 'p', 0x00, 'c', 0x00, '_', 0x00, '1', 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, bypass.ToString(), "Command: npc_1")
+}
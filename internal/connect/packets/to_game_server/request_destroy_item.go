@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestDestroyItemID = 0x59
+
+// RequestDestroyItem destroys count of item.
+type RequestDestroyItem struct {
+	ObjectID int32
+	Count    int32
+}
+
+func (p *RequestDestroyItem) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, RequestDestroyItemID, p.ObjectID, p.Count)
+}
+
+func (p *RequestDestroyItem) ToString() string {
+	return fmt.Sprintf("\nRequestDestroyItem:\n  ObjectID: %d\n  Count: %d",
+		p.ObjectID, p.Count)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDestroyItem_ToBytes(t *testing.T) {
+	destroy := &RequestDestroyItem{ObjectID: 1, Count: 2}
+
+	writer := packet.NewWriter()
+	require.NoError(t, destroy.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x59,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, destroy.ToString(), "Count: 2")
+}
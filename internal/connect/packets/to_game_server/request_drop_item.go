@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestDropItemID = 0x12
+
+// RequestDropItem drops count of item on ground at location.
+type RequestDropItem struct {
+	ObjectID int32
+	Count    int32
+	X        int32
+	Y        int32
+	Z        int32
+}
+
+func (p *RequestDropItem) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, RequestDropItemID,
+		p.ObjectID, p.Count, p.X, p.Y, p.Z)
+}
+
+func (p *RequestDropItem) ToString() string {
+	return fmt.Sprintf("\nRequestDropItem:\n  ObjectID: %d\n  Count: %d"+
+		"\n  Location: %d %d %d", p.ObjectID, p.Count, p.X, p.Y, p.Z)
+}
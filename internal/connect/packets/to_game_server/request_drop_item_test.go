@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDropItem_ToBytes(t *testing.T) {
+	drop := &RequestDropItem{ObjectID: 1, Count: 2, X: 3, Y: 4, Z: -1}
+
+	writer := packet.NewWriter()
+	require.NoError(t, drop.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x12,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+	}, writer.Bytes())
+	require.Contains(t, drop.ToString(), "Location: 3 4 -1")
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestJoinPartyID = 0x29
+
+// Loot modes of party, leader chooses one when inviting first member.
+const (
+	LootFinderKeeps int32 = iota
+	LootRandom
+	LootRandomIncludingSpoil
+	LootByTurn
+	LootByTurnIncludingSpoil
+)
+
+// RequestJoinParty invites character by name to party of inviter.
+type RequestJoinParty struct {
+	Name             string
+	ItemDistribution int32
+}
+
+func (p *RequestJoinParty) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestJoinPartyID); err != nil {
+		return err
+	}
+	if err := writer.WriteStringAsUtf16(p.Name); err != nil {
+		return err
+	}
+
+	return writer.WriteInt32(p.ItemDistribution)
+}
+
+func (p *RequestJoinParty) ToString() string {
+	return fmt.Sprintf("\nRequestJoinParty:\n  Name: %s"+
+		"\n  ItemDistribution: %d", p.Name, p.ItemDistribution)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestJoinParty_ToBytes(t *testing.T) {
+	request := &RequestJoinParty{Name: "Ab", ItemDistribution: LootByTurn}
+
+	writer := packet.NewWriter()
+	require.NoError(t, request.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x29,
+		'A', 0x00, 'b', 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, request.ToString(), "Name: Ab")
+}
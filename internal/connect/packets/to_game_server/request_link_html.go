@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestLinkHtmlID = 0x20
+
+// RequestLinkHtml asks html page linked from npc dialog.
+type RequestLinkHtml struct {
+	Link string
+}
+
+func (p *RequestLinkHtml) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestLinkHtmlID); err != nil {
+		return err
+	}
+
+	return writer.WriteStringAsUtf16(p.Link)
+}
+
+func (p *RequestLinkHtml) ToString() string {
+	return "\nRequestLinkHtml:\n  Link: " + p.Link
+}
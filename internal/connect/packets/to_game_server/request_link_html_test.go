@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLinkHtml_ToBytes(t *testing.T) {
+	link := &RequestLinkHtml{Link: "a.htm"}
+
+	writer := packet.NewWriter()
+	require.NoError(t, link.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x20,
+		'a', 0x00, '.', 0x00, 'h', 0x00, 't', 0x00, 'm', 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, link.ToString(), "Link: a.htm")
+}
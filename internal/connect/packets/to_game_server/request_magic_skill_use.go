@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestMagicSkillUseID = 0x2f
+
+// RequestMagicSkillUse casts skill on current target. Ctrl forces attack
+// on target which isn't enemy, shift casts without moving to target.
+type RequestMagicSkillUse struct {
+	SkillID int32
+	Ctrl    bool
+	Shift   bool
+}
+
+func (p *RequestMagicSkillUse) ToBytes(writer *packet.Writer) error {
+	err := writePacket(writer, RequestMagicSkillUseID, p.SkillID, flag(p.Ctrl))
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteInt8(int8(flag(p.Shift)))
+}
+
+func (p *RequestMagicSkillUse) ToString() string {
+	return fmt.Sprintf("\nRequestMagicSkillUse:\n  SkillID: %d"+
+		"\n  Ctrl: %t\n  Shift: %t", p.SkillID, p.Ctrl, p.Shift)
+}
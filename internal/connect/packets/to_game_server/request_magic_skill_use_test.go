@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMagicSkillUse_ToBytes(t *testing.T) {
+	use := &RequestMagicSkillUse{SkillID: 1011, Ctrl: true, Shift: false}
+
+	writer := packet.NewWriter()
+	require.NoError(t, use.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x2f,
+		0xf3, 0x03, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x00,
+	}, writer.Bytes())
+	require.Contains(t, use.ToString(), "SkillID: 1011")
+}
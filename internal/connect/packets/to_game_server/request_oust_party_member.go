@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestOustPartyMemberID = 0x2c
+
+// RequestOustPartyMember removes member from party, only leader can do it.
+type RequestOustPartyMember struct {
+	Name string
+}
+
+func (p *RequestOustPartyMember) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(RequestOustPartyMemberID); err != nil {
+		return err
+	}
+
+	return writer.WriteStringAsUtf16(p.Name)
+}
+
+func (p *RequestOustPartyMember) ToString() string {
+	return "\nRequestOustPartyMember:\n  Name: " + p.Name
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestOustPartyMember_ToBytes(t *testing.T) {
+	oust := &RequestOustPartyMember{Name: "Ab"}
+
+	writer := packet.NewWriter()
+	require.NoError(t, oust.ToBytes(writer))
+	require.Equal(t, []byte{0x2c, 'A', 0x00, 'b', 0x00, 0x00, 0x00},
+		writer.Bytes())
+	require.Contains(t, oust.ToString(), "Name: Ab")
+}
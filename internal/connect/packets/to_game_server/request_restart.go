@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestRestartID = 0x46
+
+// RequestRestart leaves game world and returns to character selection,
+// server answers with RestartResponse.
+type RequestRestart struct{}
+
+func (p *RequestRestart) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt8(RequestRestartID)
+}
+
+func (p *RequestRestart) ToString() string {
+	return "\nRequestRestart:"
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestSocialActionID = 0x1b
+
+// Social actions of RequestSocialAction, server accepts only these.
+const (
+	SocialGreeting = 2
+	SocialVictory  = 3
+	SocialAdvance  = 4
+	SocialNo       = 5
+	SocialYes      = 6
+	SocialBow      = 7
+	SocialUnaware  = 8
+	SocialWaiting  = 9
+	SocialLaugh    = 10
+	SocialApplaud  = 11
+	SocialDance    = 12
+	SocialSorrow   = 13
+)
+
+// RequestSocialAction asks character to play emote.
+type RequestSocialAction struct {
+	ActionID int32
+}
+
+func (p *RequestSocialAction) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, RequestSocialActionID, p.ActionID)
+}
+
+func (p *RequestSocialAction) ToString() string {
+	return fmt.Sprintf("\nRequestSocialAction:\n  ActionID: %d", p.ActionID)
+}
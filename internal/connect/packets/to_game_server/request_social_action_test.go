@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSocialAction_ToBytes(t *testing.T) {
+	social := &RequestSocialAction{ActionID: SocialVictory}
+
+	writer := packet.NewWriter()
+	require.NoError(t, social.ToBytes(writer))
+	require.Equal(t, []byte{0x1b, 0x03, 0x00, 0x00, 0x00}, writer.Bytes())
+	require.Contains(t, social.ToString(), "ActionID: 3")
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestUnEquipItemID = 0x11
+
+// RequestUnEquipItem takes off item worn in body part slot.
+type RequestUnEquipItem struct {
+	BodyPart int32
+}
+
+func (p *RequestUnEquipItem) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, RequestUnEquipItemID, p.BodyPart)
+}
+
+func (p *RequestUnEquipItem) ToString() string {
+	return fmt.Sprintf("\nRequestUnEquipItem:\n  BodyPart: %#x", p.BodyPart)
+}
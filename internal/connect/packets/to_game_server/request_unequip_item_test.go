@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestUnEquipItem_ToBytes(t *testing.T) {
+	unequip := &RequestUnEquipItem{BodyPart: 0x4000}
+
+	writer := packet.NewWriter()
+	require.NoError(t, unequip.ToBytes(writer))
+	require.Equal(t, []byte{0x11, 0x00, 0x40, 0x00, 0x00}, writer.Bytes())
+	require.Contains(t, unequip.ToString(), "BodyPart: 0x4000")
+}
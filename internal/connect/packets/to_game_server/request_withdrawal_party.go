@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const RequestWithDrawalPartyID = 0x2b
+
+// RequestWithDrawalParty leaves party.
+type RequestWithDrawalParty struct{}
+
+func (p *RequestWithDrawalParty) ToBytes(writer *packet.Writer) error {
+	return writer.WriteInt8(RequestWithDrawalPartyID)
+}
+
+func (p *RequestWithDrawalParty) ToString() string {
+	return "\nRequestWithDrawalParty:"
+}
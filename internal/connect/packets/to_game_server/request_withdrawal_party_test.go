@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestWithDrawalParty_ToBytes(t *testing.T) {
+	writer := packet.NewWriter()
+	require.NoError(t, (&RequestWithDrawalParty{}).ToBytes(writer))
+	require.Equal(t, []byte{0x2b}, writer.Bytes())
+	require.Contains(t, (&RequestWithDrawalParty{}).ToString(),
+		"RequestWithDrawalParty")
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const Say2ID = 0x38
+
+// Chat types of Say2 and CreatureSay.
+const (
+	ChatAll int32 = iota
+	ChatShout
+	ChatTell
+	ChatParty
+	ChatClan
+	ChatGM
+	ChatPetition
+	ChatPetitionReply
+	ChatTrade
+	ChatAlliance
+	ChatAnnouncement
+)
+
+// Say2 sends chat message, target is name of receiver of whisper and is
+// sent only with ChatTell.
+type Say2 struct {
+	Text   string
+	Type   int32
+	Target string
+}
+
+func (p *Say2) ToBytes(writer *packet.Writer) error {
+	if err := writer.WriteInt8(Say2ID); err != nil {
+		return err
+	}
+	if err := writer.WriteStringAsUtf16(p.Text); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32(p.Type); err != nil {
+		return err
+	}
+	if p.Type != ChatTell {
+		return nil
+	}
+
+	return writer.WriteStringAsUtf16(p.Target)
+}
+
+func (p *Say2) ToString() string {
+	return fmt.Sprintf("\nSay2:\n  Text: %s\n  Type: %d\n  Target: %s",
+		p.Text, p.Type, p.Target)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSay2_ToBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *Say2
+		expected []byte
+	}{
+		{
+			name:    "party",
+			request: &Say2{Text: "Hi", Type: ChatParty, Target: "Ab"},
+			expected: []byte{
+				0x38,
+				'H', 0x00, 'i', 0x00, 0x00, 0x00,
+				0x03, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name:    "whisper",
+			request: &Say2{Text: "Hi", Type: ChatTell, Target: "Ab"},
+			expected: []byte{
+				0x38,
+				'H', 0x00, 'i', 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00,
+				'A', 0x00, 'b', 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := packet.NewWriter()
+			require.NoError(t, test.request.ToBytes(writer))
+			require.Equal(t, test.expected, writer.Bytes())
+			require.Contains(t, test.request.ToString(), "Text: Hi")
+		})
+	}
+}
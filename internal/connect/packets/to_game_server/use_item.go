@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"strconv"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const UseItemID = 0x14
+
+// UseItem uses item of inventory: equips gear, drinks potion and so on.
+type UseItem struct {
+	ObjectID int32
+}
+
+func (p *UseItem) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, UseItemID, p.ObjectID)
+}
+
+func (p *UseItem) ToString() string {
+	return "\nUseItem:\n  ObjectID: " + strconv.Itoa(int(p.ObjectID))
+}
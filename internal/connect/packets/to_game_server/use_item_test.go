@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseItem_ToBytes(t *testing.T) {
+	use := &UseItem{ObjectID: 0x0102}
+
+	writer := packet.NewWriter()
+	require.NoError(t, use.ToBytes(writer))
+	require.Equal(t, []byte{0x14, 0x02, 0x01, 0x00, 0x00}, writer.Bytes())
+	require.Contains(t, use.ToString(), "ObjectID: 258")
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"fmt"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+)
+
+const ValidatePositionID = 0x48
+
+// ValidatePosition reports position of character as client sees it, server
+// corrects client with ValidateLocation if they differ too much.
+type ValidatePosition struct {
+	X       int32
+	Y       int32
+	Z       int32
+	Heading int32
+	Data    int32
+}
+
+func (p *ValidatePosition) ToBytes(writer *packet.Writer) error {
+	return writePacket(writer, ValidatePositionID,
+		p.X, p.Y, p.Z, p.Heading, p.Data)
+}
+
+func (p *ValidatePosition) ToString() string {
+	return fmt.Sprintf("\nValidatePosition:\n  Location: %d %d %d"+
+		"\n  Heading: %d", p.X, p.Y, p.Z, p.Heading)
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package togameserver
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePosition_ToBytes(t *testing.T) {
+	validate := &ValidatePosition{X: 1, Y: 2, Z: 3, Heading: 4, Data: 0}
+
+	writer := packet.NewWriter()
+	require.NoError(t, validate.ToBytes(writer))
+	require.Equal(t, []byte{
+		0x48,
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}, writer.Bytes())
+	require.Contains(t, validate.ToString(), "Location: 1 2 3")
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package party
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/connection"
+)
+
+const (
+	// DefaultInterval is how often organizer checks parties.
+	DefaultInterval = 2 * time.Second
+	// inviteRetry is time leader waits for answer before it invites next
+	// character, invitation expires in game if nobody answers.
+	inviteRetry = 15 * time.Second
+	// inviteCooldown is time before character which didn't join party is
+	// invited again, so other characters are tried meanwhile.
+	inviteCooldown = time.Minute
+)
+
+// Composition is party bots form after entering world. Leader and members
+// are character names, loot is mode of togameserver.RequestJoinParty.
+type Composition struct {
+	Leader  string
+	Members []string
+	Loot    int32
+}
+
+type organized struct {
+	party  *Party
+	online bool
+	// pending is character invited by bot at invited time.
+	pending string
+	invited time.Time
+	// lastInvited is when character of bot was invited last time.
+	lastInvited time.Time
+}
+
+// Organizer forms parties of compositions: leader invites members which
+// are in world, members accept invitations only from their leader. Leader
+// has single pending invitation at time, like in game client.
+type Organizer struct {
+	mutex        sync.Mutex
+	now          func() time.Time
+	compositions []Composition
+	bots         map[string]*organized
+}
+
+func NewOrganizer(compositions []Composition) *Organizer {
+	return &Organizer{
+		mutex:        sync.Mutex{},
+		now:          time.Now,
+		compositions: compositions,
+		bots:         make(map[string]*organized),
+	}
+}
+
+// Join adds party of bot playing character to organizer.
+func (o *Organizer) Join(character string, party *Party) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.bots[character] = &organized{
+		party:       party,
+		online:      false,
+		pending:     "",
+		invited:     time.Time{},
+		lastInvited: time.Time{},
+	}
+	for _, composition := range o.compositions {
+		if slices.Contains(composition.Members, character) {
+			leader := composition.Leader
+			party.SetAccept(func(requester string) bool {
+				return requester == leader
+			})
+		}
+	}
+}
+
+// SetOnline marks bot of character as being in world, only bots in world
+// invite and are invited. Character entering world is invited without
+// cooldown.
+func (o *Organizer) SetOnline(character string, online bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if bot, ok := o.bots[character]; ok {
+		if online && !bot.online {
+			bot.lastInvited = time.Time{}
+		}
+		bot.online = online
+	}
+}
+
+// Organize sends next invitation of every party which isn't complete.
+// Character which doesn't join is left for cooldown and next one is
+// invited.
+func (o *Organizer) Organize() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	now := o.now()
+	for _, composition := range o.compositions {
+		leader, ok := o.bots[composition.Leader]
+		if !ok || !leader.online {
+			continue
+		}
+		if leader.pending != "" && !leader.party.Has(leader.pending) &&
+			now.Sub(leader.invited) < inviteRetry {
+			continue
+		}
+
+		leader.pending = ""
+		name, found := o.nextMember(composition, leader.party, now)
+		if !found {
+			continue
+		}
+		err := leader.party.Invite(name, composition.Loot)
+		if errors.Is(err, connection.ErrNotInGame) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error inviting %s to party of %s: %v\n",
+				name, composition.Leader, err)
+		} else {
+			leader.pending = name
+			leader.invited = now
+		}
+		o.bots[name].lastInvited = now
+	}
+}
+
+// nextMember returns first member of composition which is online, not in
+// party of leader yet and wasn't invited within cooldown, mutex must be
+// held.
+func (o *Organizer) nextMember(
+	composition Composition,
+	party *Party,
+	now time.Time,
+) (string, bool) {
+	for _, name := range composition.Members {
+		member, ok := o.bots[name]
+		if ok && member.online && !party.Has(name) &&
+			now.Sub(member.lastInvited) >= inviteCooldown {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Run organizes parties until context is done.
+func (o *Organizer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.Organize()
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package party
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/crypt"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func invitations(sender *recordingSender) []string {
+	var names []string
+	for _, p := range sender.sent() {
+		if invite, ok := p.(*togameserver.RequestJoinParty); ok {
+			names = append(names, invite.Name)
+		}
+	}
+
+	return names
+}
+
+func TestOrganizer(t *testing.T) {
+	now := time.Unix(1000, 0)
+	organizer := NewOrganizer([]Composition{{
+		Leader:  "Tank",
+		Members: []string{"Healer", "Archer"},
+		Loot:    togameserver.LootByTurn,
+	}})
+	organizer.now = func() time.Time { return now }
+
+	leader := newFixture(t)
+	healer := newFixture(t)
+	archer := newFixture(t)
+	organizer.Join("Tank", leader.party)
+	organizer.Join("Healer", healer.party)
+	organizer.Join("Archer", archer.party)
+
+	// Nobody is in world.
+	organizer.Organize()
+	require.Empty(t, leader.sender.sent())
+
+	organizer.SetOnline("Tank", true)
+	organizer.SetOnline("Archer", true)
+	organizer.SetOnline("Unknown", true)
+	organizer.Organize()
+	require.Equal(t, []crypt.Serializable{&togameserver.RequestJoinParty{
+		Name:             "Archer",
+		ItemDistribution: togameserver.LootByTurn,
+	}}, leader.sender.sent())
+
+	// Invitation is pending.
+	organizer.SetOnline("Healer", true)
+	organizer.Organize()
+	require.Equal(t, []string{"Archer"}, invitations(leader.sender))
+
+	// Archer joined, healer is invited at once.
+	leader.feed(fromgameserver.PartySmallWindowAddID,
+		&fromgameserver.PartySmallWindowAdd{
+			LeaderID:         1,
+			ItemDistribution: togameserver.LootByTurn,
+			Member:           member(3, "Archer", 100),
+		})
+	organizer.Organize()
+	require.Equal(t, []string{"Archer", "Healer"}, invitations(leader.sender))
+
+	// Healer didn't answer, invitation is repeated after cooldown.
+	now = now.Add(inviteRetry)
+	organizer.Organize()
+	require.Equal(t, []string{"Archer", "Healer"}, invitations(leader.sender))
+	now = now.Add(inviteCooldown - inviteRetry)
+	organizer.Organize()
+	require.Equal(t, []string{"Archer", "Healer", "Healer"},
+		invitations(leader.sender))
+
+	leader.feed(fromgameserver.PartySmallWindowAddID,
+		&fromgameserver.PartySmallWindowAdd{
+			LeaderID:         1,
+			ItemDistribution: togameserver.LootByTurn,
+			Member:           member(2, "Healer", 100),
+		})
+	organizer.Organize()
+	require.Len(t, invitations(leader.sender), 3)
+}
+
+func TestOrganizer_InvitesNextAfterNoAnswer(t *testing.T) {
+	now := time.Unix(1000, 0)
+	organizer := NewOrganizer([]Composition{{
+		Leader:  "Tank",
+		Members: []string{"Healer", "Archer"},
+		Loot:    0,
+	}})
+	organizer.now = func() time.Time { return now }
+	leader := newFixture(t)
+	organizer.Join("Tank", leader.party)
+	organizer.Join("Healer", newFixture(t).party)
+	organizer.Join("Archer", newFixture(t).party)
+	for _, name := range []string{"Tank", "Healer", "Archer"} {
+		organizer.SetOnline(name, true)
+	}
+
+	organizer.Organize()
+	now = now.Add(inviteRetry)
+	organizer.Organize()
+	require.Equal(t, []string{"Healer", "Archer"}, invitations(leader.sender))
+
+	// Nobody answered, both are in cooldown.
+	now = now.Add(inviteRetry)
+	organizer.Organize()
+	require.Len(t, invitations(leader.sender), 2)
+
+	// Character entering world again is invited at once.
+	organizer.SetOnline("Archer", false)
+	organizer.SetOnline("Archer", true)
+	organizer.Organize()
+	require.Equal(t, []string{"Healer", "Archer", "Archer"},
+		invitations(leader.sender))
+
+	now = now.Add(inviteCooldown)
+	organizer.Organize()
+	require.Equal(t, []string{"Healer", "Archer", "Archer", "Healer"},
+		invitations(leader.sender))
+}
+
+func TestOrganizer_MembersAcceptOnlyLeader(t *testing.T) {
+	organizer := NewOrganizer([]Composition{{
+		Leader:  "Tank",
+		Members: []string{"Healer"},
+		Loot:    0,
+	}})
+	healer := newFixture(t)
+	other := newFixture(t)
+	organizer.Join("Healer", healer.party)
+	organizer.Join("Other", other.party)
+
+	for _, f := range []*fixture{healer, other} {
+		f.feed(fromgameserver.AskJoinPartyID,
+			&fromgameserver.AskJoinParty{
+				RequesterName:    "Tank",
+				ItemDistribution: 0,
+			})
+	}
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestAnswerJoinParty{Response: togameserver.PartyAccept},
+	}, healer.sender.sent())
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestAnswerJoinParty{Response: togameserver.PartyDecline},
+	}, other.sender.sent())
+}
+
+func TestOrganizer_SendErrors(t *testing.T) {
+	organizer := NewOrganizer([]Composition{{
+		Leader:  "Tank",
+		Members: []string{"Healer"},
+		Loot:    0,
+	}})
+	leader := newFixture(t)
+	leader.sender.err = connection.ErrNotInGame
+	organizer.Join("Tank", leader.party)
+	organizer.Join("Healer", newFixture(t).party)
+	organizer.SetOnline("Tank", true)
+	organizer.SetOnline("Healer", true)
+
+	organizer.Organize()
+	leader.sender.err = nil
+	organizer.Organize()
+	require.Equal(t, []string{"Healer"}, invitations(leader.sender))
+}
+
+func TestOrganizer_Run(t *testing.T) {
+	organizer := NewOrganizer([]Composition{{
+		Leader:  "Tank",
+		Members: []string{"Healer"},
+		Loot:    0,
+	}})
+	leader := newFixture(t)
+	organizer.Join("Tank", leader.party)
+	organizer.Join("Healer", newFixture(t).party)
+	organizer.SetOnline("Tank", true)
+	organizer.SetOnline("Healer", true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		organizer.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for len(leader.sender.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	require.Equal(t, []string{"Healer"}, invitations(leader.sender))
+}
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package party
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+)
+
+// Sender sends packets to game server.
+type Sender interface {
+	WritePacket(p crypt.Serializable) error
+}
+
+// Member is other character in party of bot.
+type Member = fromgameserver.PartyMember
+
+// Party is party of single bot as its party window shows it. Own character
+// of bot is not listed in members.
+type Party struct {
+	mutex    sync.RWMutex
+	sender   Sender
+	leaderID int32
+	loot     int32
+	members  []Member
+	// accept decides if invitation from character is accepted.
+	accept func(requester string) bool
+}
+
+func New(sender Sender) *Party {
+	return &Party{
+		mutex:    sync.RWMutex{},
+		sender:   sender,
+		leaderID: 0,
+		loot:     0,
+		members:  nil,
+		accept:   func(string) bool { return false },
+	}
+}
+
+// Register subscribes party to party window packets and invitations.
+func (p *Party) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.AskJoinPartyID, p.handleAsk)
+	dispatcher.Handle(fromgameserver.PartySmallWindowAllID, p.handleAll)
+	dispatcher.Handle(fromgameserver.PartySmallWindowAddID, p.handleAdd)
+	dispatcher.Handle(fromgameserver.PartySmallWindowDeleteAllID,
+		func([]byte) error {
+			p.Clear()
+
+			return nil
+		})
+	dispatcher.Handle(fromgameserver.PartySmallWindowDeleteID,
+		p.handleDelete)
+	dispatcher.Handle(fromgameserver.PartySmallWindowUpdateID,
+		p.handleUpdate)
+}
+
+// SetAccept sets which invitations bot accepts, all invitations are
+// declined by default.
+func (p *Party) SetAccept(accept func(requester string) bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.accept = accept
+}
+
+func (p *Party) handleAsk(data []byte) error {
+	ask, err := fromgameserver.NewAskJoinPartyFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.RLock()
+	accept := p.accept
+	p.mutex.RUnlock()
+
+	response := togameserver.PartyDecline
+	if accept(ask.RequesterName) {
+		response = togameserver.PartyAccept
+	}
+
+	return p.sender.WritePacket(
+		&togameserver.RequestAnswerJoinParty{Response: response})
+}
+
+func (p *Party) handleAll(data []byte) error {
+	window, err := fromgameserver.NewPartySmallWindowAllFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.leaderID = window.LeaderID
+	p.loot = window.ItemDistribution
+	p.members = window.Members
+
+	return nil
+}
+
+func (p *Party) handleAdd(data []byte) error {
+	add, err := fromgameserver.NewPartySmallWindowAddFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.leaderID = add.LeaderID
+	p.loot = add.ItemDistribution
+	index := p.indexOf(add.Member.ObjectID)
+	if index < 0 {
+		p.members = append(p.members, add.Member)
+	} else {
+		p.members[index] = add.Member
+	}
+
+	return nil
+}
+
+func (p *Party) handleDelete(data []byte) error {
+	deleted, err := fromgameserver.NewPartySmallWindowDeleteFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if index := p.indexOf(deleted.ObjectID); index >= 0 {
+		p.members = slices.Delete(p.members, index, index+1)
+	}
+
+	return nil
+}
+
+func (p *Party) handleUpdate(data []byte) error {
+	update, err := fromgameserver.NewPartySmallWindowUpdateFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if index := p.indexOf(update.Member.ObjectID); index >= 0 {
+		update.Member.Race = p.members[index].Race
+		p.members[index] = update.Member
+	}
+
+	return nil
+}
+
+// indexOf returns index of member by object id, mutex must be held.
+func (p *Party) indexOf(objectID int32) int {
+	return slices.IndexFunc(p.members, func(member Member) bool {
+		return member.ObjectID == objectID
+	})
+}
+
+// Clear forgets party, bot is not in party after reconnect.
+func (p *Party) Clear() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.leaderID = 0
+	p.loot = 0
+	p.members = nil
+}
+
+// InParty reports if bot is in party.
+func (p *Party) InParty() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return len(p.members) > 0
+}
+
+// LeaderID returns object id of party leader, zero if bot is not in party.
+func (p *Party) LeaderID() int32 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.leaderID
+}
+
+// Loot returns loot mode of party.
+func (p *Party) Loot() int32 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.loot
+}
+
+// Members returns other characters in party in order they joined.
+func (p *Party) Members() []Member {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return slices.Clone(p.members)
+}
+
+// Has reports if character is in party of bot, names are case insensitive
+// in game.
+func (p *Party) Has(name string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return slices.ContainsFunc(p.members, func(member Member) bool {
+		return strings.EqualFold(member.Name, name)
+	})
+}
+
+// Invite asks character to join party, loot mode is used when party is
+// created by this invitation.
+func (p *Party) Invite(name string, loot int32) error {
+	return p.sender.WritePacket(&togameserver.RequestJoinParty{
+		Name:             name,
+		ItemDistribution: loot,
+	})
+}
+
+// Leave leaves party.
+func (p *Party) Leave() error {
+	return p.sender.WritePacket(&togameserver.RequestWithDrawalParty{})
+}
+
+// Oust removes character from party, bot must be leader.
+func (p *Party) Oust(name string) error {
+	return p.sender.WritePacket(
+		&togameserver.RequestOustPartyMember{Name: name})
+}
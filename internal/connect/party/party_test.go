@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package party
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	togameserver "github.com/melg8/connect/internal/connect/packets/to_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	mutex   sync.Mutex
+	packets []crypt.Serializable
+	err     error
+}
+
+func (s *recordingSender) WritePacket(p crypt.Serializable) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.packets = append(s.packets, p)
+
+	return nil
+}
+
+func (s *recordingSender) sent() []crypt.Serializable {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]crypt.Serializable(nil), s.packets...)
+}
+
+type fixture struct {
+	t          *testing.T
+	sender     *recordingSender
+	dispatcher *dispatch.Dispatcher
+	party      *Party
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	sender := &recordingSender{mutex: sync.Mutex{}, packets: nil, err: nil}
+	dispatcher := dispatch.NewDispatcher()
+	party := New(sender)
+	party.Register(dispatcher)
+
+	return &fixture{
+		t:          t,
+		sender:     sender,
+		dispatcher: dispatcher,
+		party:      party,
+	}
+}
+
+func (f *fixture) feed(id byte, p crypt.Serializable) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func member(objectID int32, name string, hp int32) Member {
+	return Member{
+		ObjectID: objectID,
+		Name:     name,
+		CurCP:    0,
+		MaxCP:    0,
+		CurHP:    hp,
+		MaxHP:    100,
+		CurMP:    0,
+		MaxMP:    0,
+		Level:    20,
+		ClassID:  0,
+		Race:     2,
+	}
+}
+
+func TestParty_Window(t *testing.T) {
+	f := newFixture(t)
+	require.False(t, f.party.InParty())
+
+	f.feed(fromgameserver.PartySmallWindowAllID,
+		&fromgameserver.PartySmallWindowAll{
+			LeaderID:         10,
+			ItemDistribution: togameserver.LootRandom,
+			Members:          []Member{member(10, "Tank", 100)},
+		})
+	require.True(t, f.party.InParty())
+	require.Equal(t, int32(10), f.party.LeaderID())
+	require.Equal(t, togameserver.LootRandom, f.party.Loot())
+	require.True(t, f.party.Has("Tank"))
+	require.True(t, f.party.Has("tANK"))
+	require.False(t, f.party.Has("Tan"))
+
+	f.feed(fromgameserver.PartySmallWindowAddID,
+		&fromgameserver.PartySmallWindowAdd{
+			LeaderID:         10,
+			ItemDistribution: togameserver.LootRandom,
+			Member:           member(12, "Archer", 100),
+		})
+	require.True(t, f.party.Has("Archer"))
+
+	update := member(10, "Tank", 40)
+	update.Race = 0
+	f.feed(fromgameserver.PartySmallWindowUpdateID,
+		&fromgameserver.PartySmallWindowUpdate{Member: update})
+	require.Equal(t, []Member{member(10, "Tank", 40), member(12, "Archer", 100)},
+		f.party.Members())
+
+	// Update of unknown member is ignored.
+	f.feed(fromgameserver.PartySmallWindowUpdateID,
+		&fromgameserver.PartySmallWindowUpdate{Member: member(99, "X", 1)})
+	require.Len(t, f.party.Members(), 2)
+
+	f.feed(fromgameserver.PartySmallWindowDeleteID,
+		&fromgameserver.PartySmallWindowDelete{ObjectID: 10, Name: "Tank"})
+	require.False(t, f.party.Has("Tank"))
+	require.True(t, f.party.InParty())
+
+	f.feed(fromgameserver.PartySmallWindowDeleteAllID,
+		&fromgameserver.PartySmallWindowDeleteAll{})
+	require.False(t, f.party.InParty())
+	require.Zero(t, f.party.LeaderID())
+	require.Empty(t, f.party.Members())
+}
+
+func TestParty_AddExistingMember(t *testing.T) {
+	f := newFixture(t)
+	for _, hp := range []int32{10, 20} {
+		f.feed(fromgameserver.PartySmallWindowAddID,
+			&fromgameserver.PartySmallWindowAdd{
+				LeaderID:         1,
+				ItemDistribution: 0,
+				Member:           member(12, "Archer", hp),
+			})
+	}
+	require.Equal(t, []Member{member(12, "Archer", 20)}, f.party.Members())
+}
+
+func TestParty_Invitations(t *testing.T) {
+	f := newFixture(t)
+	ask := &fromgameserver.AskJoinParty{
+		RequesterName:    "Tank",
+		ItemDistribution: 0,
+	}
+
+	f.feed(fromgameserver.AskJoinPartyID, ask)
+	f.party.SetAccept(func(requester string) bool {
+		return requester == "Tank"
+	})
+	f.feed(fromgameserver.AskJoinPartyID, ask)
+	ask.RequesterName = "Stranger"
+	f.feed(fromgameserver.AskJoinPartyID, ask)
+
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestAnswerJoinParty{Response: togameserver.PartyDecline},
+		&togameserver.RequestAnswerJoinParty{Response: togameserver.PartyAccept},
+		&togameserver.RequestAnswerJoinParty{Response: togameserver.PartyDecline},
+	}, f.sender.sent())
+}
+
+func TestParty_Requests(t *testing.T) {
+	f := newFixture(t)
+
+	require.NoError(t, f.party.Invite("Healer", togameserver.LootByTurn))
+	require.NoError(t, f.party.Oust("Healer"))
+	require.NoError(t, f.party.Leave())
+
+	require.Equal(t, []crypt.Serializable{
+		&togameserver.RequestJoinParty{
+			Name:             "Healer",
+			ItemDistribution: togameserver.LootByTurn,
+		},
+		&togameserver.RequestOustPartyMember{Name: "Healer"},
+		&togameserver.RequestWithDrawalParty{},
+	}, f.sender.sent())
+}
+
+func TestParty_BrokenPackets(t *testing.T) {
+	f := newFixture(t)
+	for _, id := range []byte{
+		fromgameserver.AskJoinPartyID,
+		fromgameserver.PartySmallWindowAllID,
+		fromgameserver.PartySmallWindowAddID,
+		fromgameserver.PartySmallWindowDeleteID,
+		fromgameserver.PartySmallWindowUpdateID,
+	} {
+		require.Error(t, f.dispatcher.Dispatch(id, []byte{1}))
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+)
+
+// threshold is gray level below which dithered pixel is painted.
+const threshold = 0x8000
+
+var ErrEmptyImage = errors.New("image is empty")
+
+// Bitmap is 1-bit picture, set pixels are painted with gold.
+type Bitmap struct {
+	Width  int
+	Height int
+	Pixels []bool
+}
+
+func NewBitmap(width, height int) *Bitmap {
+	return &Bitmap{
+		Width:  width,
+		Height: height,
+		Pixels: make([]bool, width*height),
+	}
+}
+
+// At reports if pixel is set, pixels outside of bitmap are never set.
+func (b *Bitmap) At(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return false
+	}
+
+	return b.Pixels[y*b.Width+x]
+}
+
+func (b *Bitmap) Set(x, y int, value bool) {
+	b.Pixels[y*b.Width+x] = value
+}
+
+// Count returns number of set pixels.
+func (b *Bitmap) Count() int {
+	var result int
+	for _, pixel := range b.Pixels {
+		if pixel {
+			result++
+		}
+	}
+
+	return result
+}
+
+// LoadFile reads png file, see Load.
+func LoadFile(path string, width int) (*Bitmap, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open picture: %w", err)
+	}
+	defer file.Close()
+
+	return Load(file, width)
+}
+
+// Load decodes png, scales it down to width pixels and dithers it. Dark
+// pixels are set.
+func Load(reader io.Reader, width int) (*Bitmap, error) {
+	img, err := png.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode picture: %w", err)
+	}
+	gray, err := Downscale(img, width)
+	if err != nil {
+		return nil, err
+	}
+
+	return Dither(gray), nil
+}
+
+// Downscale returns gray image width pixels wide with aspect of source,
+// each pixel is average of source pixels it covers. Transparent pixels
+// are white. Image is never scaled up.
+func Downscale(img image.Image, width int) (*image.Gray16, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() || width <= 0 {
+		return nil, ErrEmptyImage
+	}
+	width = min(width, bounds.Dx())
+	height := max(1, (bounds.Dy()*width+bounds.Dx()/2)/bounds.Dx())
+
+	result := image.NewGray16(image.Rect(0, 0, width, height))
+	for y := range height {
+		top := bounds.Min.Y + y*bounds.Dy()/height
+		bottom := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, top+1)
+		for x := range width {
+			left := bounds.Min.X + x*bounds.Dx()/width
+			right := max(bounds.Min.X+(x+1)*bounds.Dx()/width, left+1)
+			result.SetGray16(x, y, color.Gray16{
+				Y: average(img, image.Rect(left, top, right, bottom)),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// average returns average gray level of area of image on white background.
+func average(img image.Image, area image.Rectangle) uint16 {
+	var sum, count uint64
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			red, green, blue, alpha := img.At(x, y).RGBA()
+			// Same weights as color.Gray16Model, colors are premultiplied
+			// by alpha, so background shows through by missing alpha.
+			luma := (19595*red + 38470*green + 7471*blue + 1<<15) >> 16
+			sum += uint64(luma + 0xffff - alpha)
+			count++
+		}
+	}
+
+	return uint16(sum / count) //nolint:gosec
+}
+
+// Dither turns gray image into bitmap by Floyd-Steinberg error diffusion.
+func Dither(gray *image.Gray16) *Bitmap {
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	levels := make([]float64, width*height)
+	for y := range height {
+		for x := range width {
+			levels[y*width+x] = float64(gray.Gray16At(bounds.Min.X+x,
+				bounds.Min.Y+y).Y)
+		}
+	}
+
+	spread := func(x, y int, amount float64) {
+		if x >= 0 && x < width && y < height {
+			levels[y*width+x] += amount
+		}
+	}
+
+	result := NewBitmap(width, height)
+	for y := range height {
+		for x := range width {
+			level := levels[y*width+x]
+			painted := level < threshold
+			result.Set(x, y, painted)
+			residual := level
+			if !painted {
+				residual -= 0xffff
+			}
+			spread(x+1, y, residual*7/16)
+			spread(x-1, y+1, residual*3/16)
+			spread(x, y+1, residual*5/16)
+			spread(x+1, y+1, residual/16)
+		}
+	}
+
+	return result
+}
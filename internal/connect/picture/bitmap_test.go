@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// uniform returns gray image of size filled with level.
+func uniform(width, height int, level uint16) *image.Gray16 {
+	img := image.NewGray16(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetGray16(x, y, color.Gray16{Y: level})
+		}
+	}
+
+	return img
+}
+
+func encode(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, img))
+
+	return buffer.Bytes()
+}
+
+func TestBitmap(t *testing.T) {
+	bitmap := NewBitmap(3, 2)
+	bitmap.Set(2, 1, true)
+	bitmap.Set(0, 0, true)
+	bitmap.Set(0, 0, false)
+	require.True(t, bitmap.At(2, 1))
+	require.False(t, bitmap.At(0, 0))
+	require.False(t, bitmap.At(3, 1))
+	require.False(t, bitmap.At(-1, 0))
+	require.Equal(t, 1, bitmap.Count())
+}
+
+func TestDownscale(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	// Left half is black and white stripes, right half is transparent.
+	img.SetNRGBA(0, 0, color.NRGBA{R: 0, G: 0, B: 0, A: 0xff})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+	img.SetNRGBA(0, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+	img.SetNRGBA(1, 1, color.NRGBA{R: 0, G: 0, B: 0, A: 0xff})
+
+	gray, err := Downscale(img, 2)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 2, 1), gray.Bounds())
+	require.Equal(t, uint16(0x7fff), gray.Gray16At(0, 0).Y)
+	require.Equal(t, uint16(0xffff), gray.Gray16At(1, 0).Y,
+		"transparent is white")
+
+	gray, err = Downscale(img, 100)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 4, 2), gray.Bounds(),
+		"image isn't scaled up")
+
+	_, err = Downscale(img, 0)
+	require.True(t, errors.Is(err, ErrEmptyImage), err)
+	_, err = Downscale(image.NewGray(image.Rect(0, 0, 0, 0)), 10)
+	require.True(t, errors.Is(err, ErrEmptyImage), err)
+}
+
+func TestDither(t *testing.T) {
+	tests := []struct {
+		name  string
+		level uint16
+		count int
+	}{
+		{name: "black", level: 0, count: 64},
+		{name: "white", level: 0xffff, count: 0},
+		{name: "gray", level: 0x8000, count: 32},
+		{name: "dark gray", level: 0x4000, count: 48},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bitmap := Dither(uniform(8, 8, test.level))
+			require.Equal(t, 8, bitmap.Width)
+			require.Equal(t, 8, bitmap.Height)
+			require.InDelta(t, test.count, bitmap.Count(), 2)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	data := encode(t, uniform(16, 8, 0))
+	bitmap, err := Load(bytes.NewReader(data), 4)
+	require.NoError(t, err)
+	require.Equal(t, 4, bitmap.Width)
+	require.Equal(t, 2, bitmap.Height)
+	require.Equal(t, 8, bitmap.Count())
+
+	_, err = Load(bytes.NewReader([]byte("not png")), 4)
+	require.Error(t, err)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "black.png")
+	require.NoError(t, os.WriteFile(path, encode(t, uniform(2, 2, 0)),
+		0o600))
+
+	bitmap, err := LoadFile(path, 2)
+	require.NoError(t, err)
+	require.Equal(t, 4, bitmap.Count())
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.png"), 2)
+	require.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"sync"
+
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+// Grid maps pixels of bitmap to world. Pixel columns go east and rows go
+// south from origin, which is top left pixel.
+type Grid struct {
+	Origin world.Position
+	Step   int32
+}
+
+// At returns world position of pixel.
+func (g Grid) At(x, y int) world.Position {
+	return world.Position{
+		X: g.Origin.X + int32(x)*g.Step, //nolint:gosec
+		Y: g.Origin.Y + int32(y)*g.Step, //nolint:gosec
+		Z: g.Origin.Z,
+	}
+}
+
+type pointState int
+
+const (
+	free pointState = iota
+	claimed
+	painted
+	// skipped point is given up after artists failed to paint it.
+	skipped
+)
+
+// Canvas is set of points of picture shared by artists. Points are ordered
+// row by row, every second row backwards, so next point is always near.
+// Each artist paints own band of points and helps others when it is done.
+type Canvas struct {
+	mutex  sync.Mutex
+	points []world.Position
+	states []pointState
+	done   int
+	// given is number of skipped points.
+	given int
+}
+
+func NewCanvas(bitmap *Bitmap, grid Grid) *Canvas {
+	var points []world.Position
+	for y := range bitmap.Height {
+		for column := range bitmap.Width {
+			x := column
+			if y%2 == 1 {
+				x = bitmap.Width - 1 - column
+			}
+			if bitmap.At(x, y) {
+				points = append(points, grid.At(x, y))
+			}
+		}
+	}
+
+	return &Canvas{
+		mutex:  sync.Mutex{},
+		points: points,
+		states: make([]pointState, len(points)),
+		done:   0,
+		given:  0,
+	}
+}
+
+// Claim returns point artist should paint next: first free point of its
+// band or, when its band is done, last free point of band with most work
+// left. Nothing is returned when all points are painted or claimed.
+func (c *Canvas) Claim(artist, artists int) (int, world.Position, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	low, high := c.band(artist, artists)
+	for index := low; index < high; index++ {
+		if c.states[index] == free {
+			return c.claim(index)
+		}
+	}
+
+	busiest, most := -1, 0
+	for band := range artists {
+		low, high := c.band(band, artists)
+		left, last := 0, -1
+		for index := low; index < high; index++ {
+			if c.states[index] == free {
+				left, last = left+1, index
+			}
+		}
+		if left > most {
+			busiest, most = last, left
+		}
+	}
+	if busiest < 0 {
+		var none world.Position
+
+		return 0, none, false
+	}
+
+	return c.claim(busiest)
+}
+
+// band returns range of points of artist, mutex must be held.
+func (c *Canvas) band(artist, artists int) (int, int) {
+	artists = max(artists, 1)
+
+	return artist * len(c.points) / artists,
+		(artist + 1) * len(c.points) / artists
+}
+
+// claim marks point as claimed, mutex must be held.
+func (c *Canvas) claim(index int) (int, world.Position, bool) {
+	c.states[index] = claimed
+
+	return index, c.points[index], true
+}
+
+// Release returns claimed point which wasn't painted, so it is claimed
+// again.
+func (c *Canvas) Release(index int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.states[index] == claimed {
+		c.states[index] = free
+	}
+}
+
+// Finish marks claimed point as painted.
+func (c *Canvas) Finish(index int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.states[index] != painted {
+		c.states[index] = painted
+		c.done++
+	}
+}
+
+// Skip gives up claimed point, it is never claimed again.
+func (c *Canvas) Skip(index int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.states[index] == claimed {
+		c.states[index] = skipped
+		c.given++
+	}
+}
+
+// Skipped returns number of points which were given up.
+func (c *Canvas) Skipped() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.given
+}
+
+// Progress returns number of painted points and number of all points.
+func (c *Canvas) Progress() (int, int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.done, len(c.points)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"testing"
+
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+// filled returns bitmap with all pixels set.
+func filled(width, height int) *Bitmap {
+	bitmap := NewBitmap(width, height)
+	for index := range bitmap.Pixels {
+		bitmap.Pixels[index] = true
+	}
+
+	return bitmap
+}
+
+func testGrid() Grid {
+	return Grid{Origin: world.Position{X: 100, Y: 200, Z: -50}, Step: 10}
+}
+
+func TestGrid_At(t *testing.T) {
+	require.Equal(t, world.Position{X: 100, Y: 200, Z: -50},
+		testGrid().At(0, 0))
+	require.Equal(t, world.Position{X: 120, Y: 210, Z: -50},
+		testGrid().At(2, 1))
+}
+
+func TestNewCanvas(t *testing.T) {
+	bitmap := filled(3, 2)
+	bitmap.Set(1, 0, false)
+	canvas := NewCanvas(bitmap, testGrid())
+
+	require.Equal(t, []world.Position{
+		{X: 100, Y: 200, Z: -50},
+		{X: 120, Y: 200, Z: -50},
+		{X: 120, Y: 210, Z: -50},
+		{X: 110, Y: 210, Z: -50},
+		{X: 100, Y: 210, Z: -50},
+	}, canvas.points, "every second row goes backwards")
+	done, total := canvas.Progress()
+	require.Zero(t, done)
+	require.Equal(t, 5, total)
+}
+
+func TestCanvas_Claim(t *testing.T) {
+	canvas := NewCanvas(filled(6, 1), testGrid())
+
+	claims := func(artist int, count int) []int {
+		var result []int
+		for range count {
+			index, at, ok := canvas.Claim(artist, 2)
+			require.True(t, ok)
+			require.Equal(t, canvas.points[index], at)
+			result = append(result, index)
+		}
+
+		return result
+	}
+
+	require.Equal(t, []int{0, 1}, claims(0, 2))
+	require.Equal(t, []int{3}, claims(1, 1))
+	canvas.Release(1)
+	require.Equal(t, []int{1}, claims(0, 1), "released point is claimed")
+	require.Equal(t, []int{2, 5}, claims(0, 2),
+		"done artist takes end of busiest band")
+	require.Equal(t, []int{4}, claims(1, 1))
+	_, _, ok := canvas.Claim(0, 2)
+	require.False(t, ok)
+
+	canvas.Finish(4)
+	canvas.Finish(4)
+	canvas.Release(4)
+	done, _ := canvas.Progress()
+	require.Equal(t, 1, done, "painted point stays painted")
+	_, _, ok = canvas.Claim(1, 2)
+	require.False(t, ok)
+}
+
+func TestCanvas_Skip(t *testing.T) {
+	canvas := NewCanvas(filled(2, 1), testGrid())
+
+	index, _, ok := canvas.Claim(0, 1)
+	require.True(t, ok)
+	canvas.Skip(index)
+	canvas.Skip(index)
+	canvas.Release(index)
+	require.Equal(t, 1, canvas.Skipped())
+
+	next, _, ok := canvas.Claim(0, 1)
+	require.True(t, ok)
+	require.NotEqual(t, index, next, "skipped point isn't claimed")
+	canvas.Finish(next)
+	canvas.Skip(next)
+	require.Equal(t, 1, canvas.Skipped(), "painted point isn't skipped")
+	_, _, ok = canvas.Claim(0, 1)
+	require.False(t, ok)
+}
+
+func TestCanvas_Empty(t *testing.T) {
+	canvas := NewCanvas(NewBitmap(2, 2), testGrid())
+	_, _, ok := canvas.Claim(0, 1)
+	require.False(t, ok)
+	_, _, ok = canvas.Claim(0, 0)
+	require.False(t, ok)
+}
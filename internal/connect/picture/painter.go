@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// DefaultRetry is pause before artist tries point again after failure,
+	// like after disconnect.
+	DefaultRetry = 5 * time.Second
+	// goldPerPoint is adena dropped on each point of picture.
+	goldPerPoint = 1
+	// progressSteps is how many times progress is logged while painting.
+	progressSteps = 10
+	// maxFailures is how many times artists may fail to paint point before
+	// it is skipped. Failures of artists which are not in game don't count,
+	// point is fine and is painted after reconnect.
+	maxFailures = 3
+)
+
+var (
+	ErrNoArtists     = errors.New("picture has no artists")
+	ErrSkippedPoints = errors.New("points of picture were skipped")
+)
+
+// Artist walks to points and drops gold on them, agent.Agent is one.
+type Artist interface {
+	WalkTo(ctx context.Context, destination world.Position) error
+	DropGold(count int32, at world.Position) error
+}
+
+// Painter paints canvas by group of artists. Artist which fails, like on
+// disconnect, gives its point back and continues after pause, so picture
+// is resumed where it stopped. Point which can't be painted, like one
+// without path to it, is skipped after several failures.
+type Painter struct {
+	mutex   sync.Mutex
+	name    string
+	canvas  *Canvas
+	names   []string
+	artists map[string]Artist
+	retry   time.Duration
+	// logged is last logged step of progress.
+	logged int
+	// failures are numbers of failures by points.
+	failures map[int]int
+}
+
+func NewPainter(
+	name string,
+	canvas *Canvas,
+	artists map[string]Artist,
+) *Painter {
+	names := make([]string, 0, len(artists))
+	for artist := range artists {
+		names = append(names, artist)
+	}
+	slices.Sort(names)
+
+	return &Painter{
+		mutex:    sync.Mutex{},
+		name:     name,
+		canvas:   canvas,
+		names:    names,
+		artists:  artists,
+		retry:    DefaultRetry,
+		logged:   0,
+		failures: make(map[int]int),
+	}
+}
+
+// FromConfig loads picture of config and returns painter with agents
+// which are artists of picture. Artists without agents are left out.
+func FromConfig(
+	cfg config.Picture,
+	agents map[string]*agent.Agent,
+) (*Painter, error) {
+	artists := make(map[string]Artist, len(cfg.Artists))
+	for _, login := range cfg.Artists {
+		if a, ok := agents[login]; ok {
+			artists[login] = a
+		}
+	}
+	if len(artists) == 0 {
+		return nil, ErrNoArtists
+	}
+
+	bitmap, err := LoadFile(cfg.Image, cfg.Width)
+	if err != nil {
+		return nil, err
+	}
+	canvas := NewCanvas(bitmap, Grid{
+		Origin: world.Position{X: cfg.Origin.X, Y: cfg.Origin.Y,
+			Z: cfg.Origin.Z},
+		Step: cfg.Step,
+	})
+
+	return NewPainter(cfg.Name, canvas, artists), nil
+}
+
+// SetRetry changes pause after failure.
+func (p *Painter) SetRetry(retry time.Duration) {
+	p.retry = retry
+}
+
+// Run paints all points of canvas and returns when picture is done. Error
+// tells how many points were skipped.
+func (p *Painter) Run(ctx context.Context) error {
+	if len(p.names) == 0 {
+		return ErrNoArtists
+	}
+
+	var group sync.WaitGroup
+	for index, name := range p.names {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			p.paint(ctx, index, name)
+		}()
+	}
+	group.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if skipped := p.canvas.Skipped(); skipped > 0 {
+		_, total := p.canvas.Progress()
+
+		return fmt.Errorf("%w: %d of %d", ErrSkippedPoints, skipped, total)
+	}
+
+	return nil
+}
+
+// paint claims points for artist until there are none left.
+func (p *Painter) paint(ctx context.Context, index int, name string) {
+	artist := p.artists[name]
+	for ctx.Err() == nil {
+		point, at, ok := p.canvas.Claim(index, len(p.names))
+		if !ok {
+			return
+		}
+
+		err := artist.WalkTo(ctx, at)
+		if err == nil {
+			err = artist.DropGold(goldPerPoint, at)
+		}
+		if err == nil {
+			p.canvas.Finish(point)
+			p.report()
+
+			continue
+		}
+
+		if ctx.Err() != nil {
+			p.canvas.Release(point)
+
+			return
+		}
+		if !errors.Is(err, connection.ErrNotInGame) {
+			log.Printf("Error painting %s by %s: %v\n", p.name, name, err)
+			if p.fail(point) {
+				log.Printf("Skipping point %d %d %d of %s\n", at.X, at.Y,
+					at.Z, p.name)
+				p.canvas.Skip(point)
+
+				continue
+			}
+		}
+		p.canvas.Release(point)
+		timer := time.NewTimer(p.retry)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// fail counts failure to paint point and tells if point should be skipped.
+func (p *Painter) fail(point int) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.failures[point]++
+
+	return p.failures[point] >= maxFailures
+}
+
+// report logs progress of picture each time next step of it is done.
+func (p *Painter) report() {
+	done, total := p.canvas.Progress()
+	step := done * progressSteps / total
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if step > p.logged {
+		p.logged = step
+		log.Printf("Picture %s: %d of %d points\n", p.name, done, total)
+	}
+}
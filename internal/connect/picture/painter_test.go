@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package picture
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/connection"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/melg8/connect/internal/connect/world"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Artist = (*agent.Agent)(nil)
+
+// drops are points with gold shared by fake artists.
+type drops struct {
+	mutex  sync.Mutex
+	points map[world.Position]int
+}
+
+// artist is fake artist which fails given number of times first and
+// can never reach blocked point.
+type artist struct {
+	drops    *drops
+	failures int
+	err      error
+	at       world.Position
+	blocked  *world.Position
+}
+
+func (a *artist) WalkTo(_ context.Context, destination world.Position) error {
+	if a.blocked != nil && *a.blocked == destination {
+		return errors.New("no path")
+	}
+	if a.failures > 0 {
+		a.failures--
+
+		return a.err
+	}
+	a.at = destination
+
+	return nil
+}
+
+func (a *artist) DropGold(count int32, at world.Position) error {
+	if a.at != at {
+		return errors.New("too far")
+	}
+
+	a.drops.mutex.Lock()
+	defer a.drops.mutex.Unlock()
+
+	a.drops.points[at] += int(count)
+
+	return nil
+}
+
+func newArtist(shared *drops, failures int, err error) *artist {
+	return &artist{
+		drops:    shared,
+		failures: failures,
+		err:      err,
+		at:       world.Position{X: 0, Y: 0, Z: 0},
+		blocked:  nil,
+	}
+}
+
+func TestPainter_Run(t *testing.T) {
+	shared := &drops{mutex: sync.Mutex{}, points: map[world.Position]int{}}
+	canvas := NewCanvas(filled(4, 3), testGrid())
+	painter := NewPainter("logo", canvas, map[string]Artist{
+		"tank":   newArtist(shared, 0, nil),
+		"healer": newArtist(shared, 2, connection.ErrNotInGame),
+		"dps":    newArtist(shared, 1, errors.New("no path")),
+	})
+	painter.SetRetry(time.Millisecond)
+
+	require.NoError(t, painter.Run(context.Background()))
+	require.Len(t, shared.points, 12)
+	for at, count := range shared.points {
+		require.Equal(t, 1, count, at)
+	}
+	done, total := canvas.Progress()
+	require.Equal(t, total, done)
+}
+
+func TestPainter_SkipsUnreachablePoint(t *testing.T) {
+	shared := &drops{mutex: sync.Mutex{}, points: map[world.Position]int{}}
+	canvas := NewCanvas(filled(3, 1), testGrid())
+	tank := newArtist(shared, 0, nil)
+	blocked := canvas.points[1]
+	tank.blocked = &blocked
+	painter := NewPainter("logo", canvas, map[string]Artist{"tank": tank})
+	painter.SetRetry(time.Millisecond)
+
+	err := painter.Run(context.Background())
+	require.True(t, errors.Is(err, ErrSkippedPoints), err)
+	require.Equal(t, map[world.Position]int{
+		canvas.points[0]: 1,
+		canvas.points[2]: 1,
+	}, shared.points)
+	require.Equal(t, maxFailures, painter.failures[1])
+	require.Equal(t, 1, canvas.Skipped())
+}
+
+func TestPainter_Canceled(t *testing.T) {
+	shared := &drops{mutex: sync.Mutex{}, points: map[world.Position]int{}}
+	canvas := NewCanvas(filled(2, 2), testGrid())
+	painter := NewPainter("logo", canvas, map[string]Artist{
+		"tank": newArtist(shared, 1000, connection.ErrNotInGame),
+	})
+	painter.SetRetry(time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(),
+		20*time.Millisecond)
+	defer cancel()
+
+	err := painter.Run(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	require.Empty(t, shared.points)
+
+	err = NewPainter("empty", canvas, nil).Run(context.Background())
+	require.True(t, errors.Is(err, ErrNoArtists), err)
+}
+
+func TestFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "black.png")
+	require.NoError(t, os.WriteFile(path, encode(t, uniform(4, 4, 0)),
+		0o600))
+	cfg := config.Picture{
+		Name:    "logo",
+		Image:   path,
+		Width:   2,
+		Origin:  config.Point{X: 1, Y: 2, Z: 3},
+		Step:    20,
+		Artists: []string{"tank", "healer"},
+	}
+	agents := map[string]*agent.Agent{
+		"tank": agent.New("tank", geodata.Open("")),
+	}
+
+	painter, err := FromConfig(cfg, agents)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tank"}, painter.names,
+		"artists without agents are left out")
+	require.Equal(t, []world.Position{
+		{X: 1, Y: 2, Z: 3},
+		{X: 21, Y: 2, Z: 3},
+		{X: 21, Y: 22, Z: 3},
+		{X: 1, Y: 22, Z: 3},
+	}, painter.canvas.points)
+
+	_, err = FromConfig(cfg, nil)
+	require.True(t, errors.Is(err, ErrNoArtists), err)
+
+	cfg.Image = filepath.Join(t.TempDir(), "missing.png")
+	_, err = FromConfig(cfg, agents)
+	require.Error(t, err)
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/world"
+)
+
+const (
+	// DefaultFarmTime is time spent at farm spot when config doesn't set it.
+	DefaultFarmTime = 10 * time.Minute
+	// farmRadius limits how far from bot monsters are looked for.
+	farmRadius = 1500
+	// walkAttempts is how many times blocked walk is tried again.
+	walkAttempts = 3
+	// Names of points farm plan needs.
+	gatherPoint = "gather"
+	spotPoint   = "spot"
+)
+
+var (
+	ErrNoPoint       = errors.New("scenario has no point")
+	ErrMissingMember = errors.New("member of scenario isn't running")
+)
+
+// FarmSettings are places and time of farm plan.
+type FarmSettings struct {
+	Gather world.Position
+	Spot   world.Position
+	Time   time.Duration
+}
+
+// Farm returns plan where bots gather at point, travel to spot together,
+// farm monsters there for some time and return to gathering point.
+func Farm(settings FarmSettings, roles []string, loop bool) *Scenario {
+	steps := func(step Step) map[string]Step {
+		result := make(map[string]Step, len(roles))
+		for _, role := range roles {
+			result[role] = step
+		}
+
+		return result
+	}
+
+	return &Scenario{
+		Name:  "farm",
+		Roles: roles,
+		Phases: []Phase{
+			{
+				Name:   "gather",
+				Steps:  steps(walkStep(gatherPoint, settings.Gather)),
+				Sync:   Barrier,
+				Quorum: 0,
+				Meet:   true,
+			},
+			{
+				Name:   "travel",
+				Steps:  steps(walkStep(spotPoint, settings.Spot)),
+				Sync:   Barrier,
+				Quorum: 0,
+				Meet:   true,
+			},
+			{
+				Name:   "farm",
+				Steps:  steps(huntStep(settings.Time)),
+				Sync:   Barrier,
+				Quorum: 0,
+				Meet:   false,
+			},
+			{
+				Name:   "return",
+				Steps:  steps(walkStep(gatherPoint, settings.Gather)),
+				Sync:   Barrier,
+				Quorum: 0,
+				Meet:   false,
+			},
+		},
+		Loop: loop,
+	}
+}
+
+// inWorld keeps running until character of member enters world.
+func inWorld(member Member) behavior.Node {
+	return behavior.NewAction(func(
+		context.Context,
+		*behavior.Tick,
+	) behavior.Status {
+		if _, ok := member.Agent.World.Self(); !ok {
+			return behavior.Running
+		}
+
+		return behavior.Success
+	})
+}
+
+// walkStep walks member to point, position is kept on blackboard of member
+// under name of point.
+func walkStep(name string, point world.Position) Step {
+	return func(member Member) behavior.Node {
+		member.Blackboard.Set(name, point)
+
+		return behavior.NewSequence(
+			inWorld(member),
+			behavior.NewRetry(walkAttempts,
+				behavior.WalkTo(member.Agent, name)),
+		)
+	}
+}
+
+// huntStep attacks nearest live monster around member until time is over.
+func huntStep(limit time.Duration) Step {
+	return func(member Member) behavior.Node {
+		node := behavior.NewAction(func(
+			context.Context,
+			*behavior.Tick,
+		) behavior.Status {
+			hunt(member.Agent)
+
+			return behavior.Running
+		})
+
+		// Timeout fails when time is over, that is success of phase.
+		return behavior.NewInvert(behavior.NewTimeout(limit, node))
+	}
+}
+
+// hunt attacks nearest live monster unless current target is one. Failed
+// attack is tried again on next tick.
+func hunt(a *agent.Agent) {
+	if alive(a, a.Combat.Target()) {
+		return
+	}
+	for _, entity := range a.View.Nearby(farmRadius) {
+		npc, ok := entity.(world.Npc)
+		if ok && npc.Attackable && npc.CurHP > 0 {
+			_ = a.Combat.Attack(npc.ObjectID, false)
+
+			return
+		}
+	}
+}
+
+func alive(a *agent.Agent, objectID int32) bool {
+	if objectID == 0 {
+		return false
+	}
+	entity, ok := a.View.ByObjectID(objectID)
+	npc, isNpc := entity.(world.Npc)
+
+	return ok && isNpc && npc.Attackable && npc.CurHP > 0
+}
+
+// FromConfig builds group of scenario from config. Agents are looked up by
+// logins of config.
+func FromConfig(
+	cfg config.Scenario,
+	agents map[string]*agent.Agent,
+) (*Group, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, 0, len(cfg.Roles))
+	for login := range cfg.Roles {
+		logins = append(logins, login)
+	}
+	slices.Sort(logins)
+
+	var roles []string
+	members := make([]Member, 0, len(logins))
+	for _, login := range logins {
+		a, ok := agents[login]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingMember, login)
+		}
+		role := cfg.Roles[login]
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+		members = append(members, NewMember(role, a))
+	}
+
+	plan, err := newPlan(cfg, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGroup(plan, level, members)
+}
+
+// newPlan returns built-in plan named in config.
+func newPlan(cfg config.Scenario, roles []string) (*Scenario, error) {
+	switch cfg.Plan {
+	case "farm":
+		gather, err := point(cfg, gatherPoint)
+		if err != nil {
+			return nil, err
+		}
+		spot, err := point(cfg, spotPoint)
+		if err != nil {
+			return nil, err
+		}
+		settings := FarmSettings{
+			Gather: gather,
+			Spot:   spot,
+			Time:   cfg.Duration.Duration,
+		}
+		if settings.Time == 0 {
+			settings.Time = DefaultFarmTime
+		}
+
+		return Farm(settings, roles, cfg.Loop), nil
+	default:
+		return nil, fmt.Errorf("unknown scenario plan %q", cfg.Plan)
+	}
+}
+
+func point(cfg config.Scenario, name string) (world.Position, error) {
+	found, ok := cfg.Points[name]
+	if !ok {
+		var none world.Position
+
+		return none, fmt.Errorf("%w: %s", ErrNoPoint, name)
+	}
+
+	return world.Position{X: found.X, Y: found.Y, Z: found.Z}, nil
+}
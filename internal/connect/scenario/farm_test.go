@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/config"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/stretchr/testify/require"
+)
+
+func farmConfig() config.Scenario {
+	return config.Scenario{
+		Name:  "elpies",
+		Plan:  "farm",
+		Level: "synchronized",
+		Roles: map[string]string{"tank": "tank", "healer": "healer"},
+		Points: map[string]config.Point{
+			"gather": {X: 1, Y: 2, Z: 3},
+			"spot":   {X: 4, Y: 5, Z: 6},
+		},
+		Duration: config.Duration{Duration: 0},
+		Loop:     true,
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	geo := geodata.Open("")
+	agents := map[string]*agent.Agent{
+		"tank":   agent.New("tank", geo),
+		"healer": agent.New("healer", geo),
+	}
+
+	group, err := FromConfig(farmConfig(), agents)
+	require.NoError(t, err)
+	require.Equal(t, "farm", group.scenario.Name)
+	require.Equal(t, Synchronized, group.level)
+	require.True(t, group.scenario.Loop)
+	require.Equal(t, []string{"healer", "tank"}, group.scenario.Roles)
+
+	names := make([]string, 0, len(group.scenario.Phases))
+	for _, phase := range group.scenario.Phases {
+		names = append(names, phase.Name)
+	}
+	require.Equal(t, []string{"gather", "travel", "farm", "return"}, names)
+
+	// Bots out of game wait for their characters before walking.
+	require.NoError(t, group.Tick(context.Background()))
+	require.Equal(t, []Progress{
+		{Name: "healer", Role: "healer", Phase: "gather", Round: 0,
+			Waiting: false},
+		{Name: "tank", Role: "tank", Phase: "gather", Round: 0,
+			Waiting: false},
+	}, group.Progress())
+	require.Equal(t, Active, group.State())
+	group.Abort()
+}
+
+func TestFromConfig_Errors(t *testing.T) {
+	geo := geodata.Open("")
+	agents := map[string]*agent.Agent{
+		"tank":   agent.New("tank", geo),
+		"healer": agent.New("healer", geo),
+	}
+
+	missing := farmConfig()
+	missing.Roles["dps"] = "dps"
+	_, err := FromConfig(missing, agents)
+	require.True(t, errors.Is(err, ErrMissingMember), err)
+
+	noSpot := farmConfig()
+	delete(noSpot.Points, "spot")
+	_, err = FromConfig(noSpot, agents)
+	require.True(t, errors.Is(err, ErrNoPoint), err)
+
+	badLevel := farmConfig()
+	badLevel.Level = "chaotic"
+	_, err = FromConfig(badLevel, agents)
+	require.Error(t, err)
+
+	badPlan := farmConfig()
+	badPlan.Plan = "dance"
+	_, err = FromConfig(badPlan, agents)
+	require.Error(t, err)
+}
+
+func TestFarm_HuntTimeout(t *testing.T) {
+	a := agent.New("tank", geodata.Open(""))
+	step := huntStep(time.Minute)(NewMember("tank", a))
+	start := time.Unix(1000, 0)
+
+	tick := &behavior.Tick{Now: start, Blackboard: a.Blackboard}
+	require.Equal(t, behavior.Running, step.Tick(context.Background(), tick))
+	tick.Now = start.Add(time.Minute)
+	require.Equal(t, behavior.Success, step.Tick(context.Background(), tick),
+		"farm phase ends when time is over")
+}
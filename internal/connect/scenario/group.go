@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/behavior"
+)
+
+var (
+	ErrAborted     = errors.New("scenario is aborted")
+	ErrPhaseFailed = errors.New("member failed phase")
+	ErrUnknownRole = errors.New("role is not part of scenario")
+	ErrNoMembers   = errors.New("group has no members")
+)
+
+// State is state of group run.
+type State int
+
+const (
+	Active State = iota
+	Paused
+	Aborted
+	Finished
+)
+
+func (s State) String() string {
+	switch s {
+	case Active:
+		return "Active"
+	case Paused:
+		return "Paused"
+	case Aborted:
+		return "Aborted"
+	case Finished:
+		return "Finished"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Progress is where member is in scenario.
+type Progress struct {
+	Name  string
+	Role  string
+	Phase string
+	// Round counts passes over phases of looping scenario.
+	Round   int
+	Waiting bool
+}
+
+// runner is member going through phases.
+type runner struct {
+	member  Member
+	node    behavior.Node
+	phase   int
+	round   int
+	waiting bool
+	done    bool
+}
+
+// Group runs scenario for members. Members are ticked one by one in order
+// they are given, so same events give same run.
+type Group struct {
+	mutex    sync.Mutex
+	scenario *Scenario
+	level    Level
+	runners  []*runner
+	state    State
+	now      func() time.Time
+}
+
+func NewGroup(
+	scenario *Scenario,
+	level Level,
+	members []Member,
+) (*Group, error) {
+	if len(members) == 0 {
+		return nil, ErrNoMembers
+	}
+
+	runners := make([]*runner, 0, len(members))
+	for _, member := range members {
+		if !slices.Contains(scenario.Roles, member.Role) {
+			return nil, fmt.Errorf("%w: %s of %s", ErrUnknownRole,
+				member.Role, member.Name)
+		}
+		runners = append(runners, &runner{
+			member:  member,
+			node:    nil,
+			phase:   0,
+			round:   0,
+			waiting: false,
+			done:    len(scenario.Phases) == 0,
+		})
+	}
+
+	return &Group{
+		mutex:    sync.Mutex{},
+		scenario: scenario,
+		level:    level,
+		runners:  runners,
+		state:    Active,
+		now:      time.Now,
+	}, nil
+}
+
+// SetClock replaces clock of group.
+func (g *Group) SetClock(now func() time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.now = now
+}
+
+// Tick ticks steps of members once and lets waiting members go on when
+// sync of their phase is reached. It returns nil while group runs.
+func (g *Group) Tick(ctx context.Context) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch g.state {
+	case Paused:
+		return nil
+	case Aborted:
+		return ErrAborted
+	case Finished:
+		return nil
+	case Active:
+	}
+
+	now := g.now()
+	for _, runner := range g.runners {
+		if runner.done || runner.waiting {
+			continue
+		}
+		if err := g.tickRunner(ctx, runner, now); err != nil {
+			g.stop(Aborted)
+
+			return err
+		}
+	}
+	g.release()
+
+	if !slices.ContainsFunc(g.runners, func(r *runner) bool {
+		return !r.done
+	}) {
+		g.state = Finished
+	}
+
+	return nil
+}
+
+// tickRunner ticks step of member, mutex must be held.
+func (g *Group) tickRunner(
+	ctx context.Context,
+	runner *runner,
+	now time.Time,
+) error {
+	phase := g.scenario.Phases[runner.phase]
+	if runner.node == nil {
+		step, ok := phase.Steps[runner.member.Role]
+		if !ok {
+			runner.waiting = true
+
+			return nil
+		}
+		runner.node = step(runner.member)
+		setTask(runner.member, g.scenario.Name+" "+phase.Name)
+	}
+
+	tick := &behavior.Tick{Now: now, Blackboard: runner.member.Blackboard}
+	switch runner.node.Tick(ctx, tick) {
+	case behavior.Running:
+	case behavior.Success:
+		runner.node.Reset()
+		runner.node = nil
+		runner.waiting = true
+	case behavior.Failure:
+		return fmt.Errorf("%w: %s in %s", ErrPhaseFailed,
+			runner.member.Name, phase.Name)
+	}
+
+	return nil
+}
+
+// position returns index of phase over all rounds.
+func (g *Group) position(runner *runner) int {
+	return runner.round*len(g.scenario.Phases) + runner.phase
+}
+
+// release lets waiting members go on when enough members passed their
+// phase, mutex must be held.
+func (g *Group) release() {
+	for _, runner := range g.runners {
+		if !runner.waiting {
+			continue
+		}
+
+		position := g.position(runner)
+		arrived := 0
+		for _, other := range g.runners {
+			otherPosition := g.position(other)
+			if (other.waiting && otherPosition == position) ||
+				otherPosition > position || other.done {
+				arrived++
+			}
+		}
+		phase := g.scenario.Phases[runner.phase]
+		if arrived < g.scenario.needed(phase, g.level, len(g.runners)) {
+			continue
+		}
+
+		runner.waiting = false
+		runner.phase++
+		if runner.phase == len(g.scenario.Phases) {
+			runner.phase = 0
+			runner.round++
+			runner.done = !g.scenario.Loop
+		}
+		if runner.done {
+			setTask(runner.member, "")
+		}
+	}
+}
+
+// stop abandons running steps, mutex must be held.
+func (g *Group) stop(state State) {
+	g.state = state
+	for _, runner := range g.runners {
+		if runner.node != nil {
+			runner.node.Reset()
+			runner.node = nil
+		}
+		setTask(runner.member, "")
+	}
+}
+
+// setTask tells agent of member what it does, so task survives reconnect
+// of bot.
+func setTask(member Member, task string) {
+	if member.Agent != nil {
+		member.Agent.SetTask(task)
+	}
+}
+
+// Run ticks group with interval until scenario finishes, fails, is
+// aborted or context is done.
+func (g *Group) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.Tick(ctx); err != nil {
+			return err
+		}
+		if g.State() == Finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			g.Abort()
+
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pause stops ticking of steps, they keep their progress.
+func (g *Group) Pause() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state == Active {
+		g.state = Paused
+	}
+}
+
+// Resume continues paused group.
+func (g *Group) Resume() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state == Paused {
+		g.state = Active
+	}
+}
+
+// Abort stops group for good, running steps are abandoned.
+func (g *Group) Abort() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state == Active || g.state == Paused {
+		g.stop(Aborted)
+	}
+}
+
+func (g *Group) State() State {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.state
+}
+
+// Progress returns progress of members in their order.
+func (g *Group) Progress() []Progress {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	result := make([]Progress, 0, len(g.runners))
+	for _, runner := range g.runners {
+		phase := ""
+		if !runner.done {
+			phase = g.scenario.Phases[runner.phase].Name
+		}
+		result = append(result, Progress{
+			Name:    runner.member.Name,
+			Role:    runner.member.Role,
+			Phase:   phase,
+			Round:   runner.round,
+			Waiting: runner.waiting,
+		})
+	}
+
+	return result
+}
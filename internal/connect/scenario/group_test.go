@@ -0,0 +1,408 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/geodata"
+	"github.com/stretchr/testify/require"
+)
+
+const forever = 1 << 30
+
+// counter counts ticks and resets of steps of tests.
+type counter struct {
+	ticks  map[string]int
+	resets map[string]int
+}
+
+func newCounter() *counter {
+	return &counter{ticks: map[string]int{}, resets: map[string]int{}}
+}
+
+// step succeeds after given number of ticks of member, negative number
+// fails on first tick.
+func (c *counter) step(ticks map[string]int) Step {
+	return func(member Member) behavior.Node {
+		ticked := 0
+
+		return &node{
+			tick: func() behavior.Status {
+				c.ticks[member.Name]++
+				ticked++
+				switch {
+				case ticks[member.Name] < 0:
+					return behavior.Failure
+				case ticked >= ticks[member.Name]:
+					return behavior.Success
+				default:
+					return behavior.Running
+				}
+			},
+			reset: func() { c.resets[member.Name]++ },
+		}
+	}
+}
+
+type node struct {
+	tick  func() behavior.Status
+	reset func()
+}
+
+func (n *node) Tick(context.Context, *behavior.Tick) behavior.Status {
+	return n.tick()
+}
+
+func (n *node) Reset() {
+	n.reset()
+}
+
+func members(names ...string) []Member {
+	result := make([]Member, 0, len(names))
+	for _, name := range names {
+		result = append(result, Member{
+			Name:       name,
+			Role:       "fighter",
+			Agent:      nil,
+			Blackboard: behavior.NewBlackboard(),
+		})
+	}
+
+	return result
+}
+
+func phase(name string, sync SyncKind, step Step) Phase {
+	return Phase{
+		Name:   name,
+		Steps:  map[string]Step{"fighter": step},
+		Sync:   sync,
+		Quorum: 0,
+		Meet:   false,
+	}
+}
+
+func phases(group *Group) map[string]string {
+	result := map[string]string{}
+	for _, progress := range group.Progress() {
+		result[progress.Name] = progress.Phase
+	}
+
+	return result
+}
+
+func TestGroup_Sync(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  Level
+		sync   SyncKind
+		quorum int
+		meet   bool
+		// left are ticks at which members enter second phase.
+		left map[string]int
+	}{
+		{
+			name:   "barrier",
+			level:  Synchronized,
+			sync:   Barrier,
+			quorum: 0,
+			meet:   false,
+			left:   map[string]int{"a": 5, "b": 5, "c": 5},
+		},
+		{
+			name:   "quorum",
+			level:  Synchronized,
+			sync:   Quorum,
+			quorum: 2,
+			meet:   false,
+			left:   map[string]int{"a": 3, "b": 3, "c": 5},
+		},
+		{
+			name:   "quorum above members",
+			level:  Synchronized,
+			sync:   Quorum,
+			quorum: 10,
+			meet:   false,
+			left:   map[string]int{"a": 5, "b": 5, "c": 5},
+		},
+		{
+			name:   "no sync",
+			level:  Synchronized,
+			sync:   NoSync,
+			quorum: 0,
+			meet:   false,
+			left:   map[string]int{"a": 1, "b": 3, "c": 5},
+		},
+		{
+			name:   "independent drops barrier",
+			level:  Independent,
+			sync:   Barrier,
+			quorum: 0,
+			meet:   false,
+			left:   map[string]int{"a": 1, "b": 3, "c": 5},
+		},
+		{
+			name:   "semi synchronized drops barrier",
+			level:  SemiSynchronized,
+			sync:   Barrier,
+			quorum: 0,
+			meet:   false,
+			left:   map[string]int{"a": 1, "b": 3, "c": 5},
+		},
+		{
+			name:   "semi synchronized keeps meeting",
+			level:  SemiSynchronized,
+			sync:   Barrier,
+			meet:   true,
+			quorum: 0,
+			left:   map[string]int{"a": 5, "b": 5, "c": 5},
+		},
+		{
+			name:   "hyper synchronized raises quorum",
+			level:  HyperSynchronized,
+			sync:   Quorum,
+			quorum: 1,
+			meet:   false,
+			left:   map[string]int{"a": 5, "b": 5, "c": 5},
+		},
+		{
+			name:   "hyper synchronized raises no sync",
+			level:  HyperSynchronized,
+			sync:   NoSync,
+			quorum: 0,
+			meet:   false,
+			left:   map[string]int{"a": 5, "b": 5, "c": 5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			steps := newCounter()
+			first := phase("first", test.sync,
+				steps.step(map[string]int{"a": 1, "b": 3, "c": 5}))
+			first.Quorum = test.quorum
+			first.Meet = test.meet
+			scenario := &Scenario{
+				Name:  "test",
+				Roles: []string{"fighter"},
+				Phases: []Phase{
+					first,
+					phase("second", NoSync, steps.step(map[string]int{
+						"a": forever, "b": forever, "c": forever,
+					})),
+				},
+				Loop: false,
+			}
+			group, err := NewGroup(scenario, test.level,
+				members("a", "b", "c"))
+			require.NoError(t, err)
+
+			left := map[string]int{}
+			for tick := 1; tick <= 6; tick++ {
+				require.NoError(t, group.Tick(context.Background()))
+				for name, phase := range phases(group) {
+					if _, ok := left[name]; !ok && phase == "second" {
+						left[name] = tick
+					}
+				}
+			}
+			require.Equal(t, test.left, left)
+		})
+	}
+}
+
+func TestGroup_Loop(t *testing.T) {
+	steps := newCounter()
+	scenario := &Scenario{
+		Name:   "test",
+		Roles:  []string{"fighter"},
+		Phases: []Phase{phase("only", NoSync, steps.step(nil))},
+		Loop:   true,
+	}
+	group, err := NewGroup(scenario, Synchronized, members("a"))
+	require.NoError(t, err)
+
+	for range 3 {
+		require.NoError(t, group.Tick(context.Background()))
+	}
+	progress := group.Progress()
+	require.Len(t, progress, 1)
+	require.Equal(t, Progress{
+		Name:    "a",
+		Role:    "fighter",
+		Phase:   "only",
+		Round:   3,
+		Waiting: false,
+	}, progress[0])
+	require.Equal(t, Active, group.State())
+}
+
+func TestGroup_Finish(t *testing.T) {
+	steps := newCounter()
+	scenario := &Scenario{
+		Name:  "test",
+		Roles: []string{"fighter", "healer"},
+		Phases: []Phase{
+			phase("first", Barrier, steps.step(map[string]int{"a": 2})),
+			phase("second", Barrier, steps.step(nil)),
+		},
+		Loop: false,
+	}
+	healer := members("b")
+	healer[0].Role = "healer"
+	group, err := NewGroup(scenario, Synchronized,
+		append(members("a"), healer...))
+	require.NoError(t, err)
+
+	require.NoError(t, group.Tick(context.Background()))
+	require.Equal(t, map[string]string{"a": "first", "b": "first"},
+		phases(group))
+	require.True(t, group.Progress()[1].Waiting,
+		"role without step waits at once")
+
+	require.NoError(t, group.Run(context.Background(), time.Millisecond))
+	require.Equal(t, Finished, group.State())
+	require.Equal(t, map[string]string{"a": "", "b": ""}, phases(group))
+	require.Zero(t, steps.ticks["b"])
+}
+
+func TestGroup_Failure(t *testing.T) {
+	steps := newCounter()
+	scenario := &Scenario{
+		Name:  "test",
+		Roles: []string{"fighter"},
+		Phases: []Phase{phase("first", Barrier, steps.step(map[string]int{
+			"a": forever, "b": -1, "c": forever,
+		}))},
+		Loop: false,
+	}
+	group, err := NewGroup(scenario, Synchronized, members("a", "b", "c"))
+	require.NoError(t, err)
+
+	err = group.Tick(context.Background())
+	require.True(t, errors.Is(err, ErrPhaseFailed), err)
+	require.Equal(t, Aborted, group.State())
+	require.Equal(t, map[string]int{"a": 1, "b": 1}, steps.resets)
+	require.Zero(t, steps.ticks["c"], "group stops at first failure")
+
+	err = group.Tick(context.Background())
+	require.True(t, errors.Is(err, ErrAborted), err)
+}
+
+func TestGroup_PauseResumeAbort(t *testing.T) {
+	steps := newCounter()
+	scenario := &Scenario{
+		Name:  "test",
+		Roles: []string{"fighter"},
+		Phases: []Phase{
+			phase("first", Barrier, steps.step(map[string]int{"a": forever})),
+		},
+		Loop: false,
+	}
+	group, err := NewGroup(scenario, Synchronized, members("a"))
+	require.NoError(t, err)
+
+	require.NoError(t, group.Tick(context.Background()))
+	group.Pause()
+	require.Equal(t, Paused, group.State())
+	require.NoError(t, group.Tick(context.Background()))
+	require.Equal(t, 1, steps.ticks["a"], "paused group doesn't tick")
+	require.Zero(t, steps.resets["a"], "paused step keeps progress")
+
+	group.Resume()
+	require.Equal(t, Active, group.State())
+	require.NoError(t, group.Tick(context.Background()))
+	require.Equal(t, 2, steps.ticks["a"])
+
+	group.Pause()
+	group.Abort()
+	require.Equal(t, Aborted, group.State())
+	require.Equal(t, 1, steps.resets["a"])
+	group.Resume()
+	require.Equal(t, Aborted, group.State(), "aborted group stays aborted")
+	err = group.Tick(context.Background())
+	require.True(t, errors.Is(err, ErrAborted), err)
+}
+
+func TestGroup_RunCanceled(t *testing.T) {
+	steps := newCounter()
+	scenario := &Scenario{
+		Name:  "test",
+		Roles: []string{"fighter"},
+		Phases: []Phase{
+			phase("first", Barrier, steps.step(map[string]int{"a": forever})),
+		},
+		Loop: false,
+	}
+	group, err := NewGroup(scenario, Synchronized, members("a"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = group.Run(ctx, time.Hour)
+	require.True(t, errors.Is(err, context.Canceled), err)
+	require.Equal(t, Aborted, group.State())
+}
+
+func TestNewGroup_Errors(t *testing.T) {
+	scenario := &Scenario{
+		Name:   "test",
+		Roles:  []string{"fighter"},
+		Phases: nil,
+		Loop:   false,
+	}
+
+	_, err := NewGroup(scenario, Synchronized, nil)
+	require.True(t, errors.Is(err, ErrNoMembers), err)
+
+	strangers := members("a")
+	strangers[0].Role = "dancer"
+	_, err = NewGroup(scenario, Synchronized, strangers)
+	require.True(t, errors.Is(err, ErrUnknownRole), err)
+
+	group, err := NewGroup(scenario, Synchronized, members("a"))
+	require.NoError(t, err)
+	require.NoError(t, group.Tick(context.Background()))
+	require.Equal(t, Finished, group.State(), "scenario without phases")
+}
+
+func TestState_String(t *testing.T) {
+	require.Equal(t, "Active", Active.String())
+	require.Equal(t, "Paused", Paused.String())
+	require.Equal(t, "Aborted", Aborted.String())
+	require.Equal(t, "Finished", Finished.String())
+	require.Equal(t, "State(9)", State(9).String())
+}
+
+func TestGroup_SetsTaskOfAgents(t *testing.T) {
+	count := newCounter()
+	a := agent.New("tank", geodata.Open(""))
+	plan := &Scenario{
+		Name:  "farm",
+		Roles: []string{"fighter"},
+		Phases: []Phase{
+			phase("gather", NoSync, count.step(map[string]int{"tank": 1})),
+			phase("fight", NoSync, count.step(map[string]int{"tank": 2})),
+		},
+		Loop: false,
+	}
+	group, err := NewGroup(plan, Independent,
+		[]Member{NewMember("fighter", a)})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, group.Tick(ctx))
+	require.Equal(t, "farm gather", a.Task())
+	require.NoError(t, group.Tick(ctx))
+	require.Equal(t, "farm fight", a.Task())
+
+	group.Abort()
+	require.Empty(t, a.Task())
+}
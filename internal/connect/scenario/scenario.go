@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/melg8/connect/internal/connect/agent"
+	"github.com/melg8/connect/internal/connect/behavior"
+	"github.com/melg8/connect/internal/connect/config"
+)
+
+// Level is coordination level of group from project description. All
+// levels share same model of phases, level only decides which sync points
+// of phases are kept.
+type Level int
+
+const (
+	// Independent bots go through phases on their own.
+	Independent Level = iota
+	// SemiSynchronized bots meet only at phases marked as meeting, like
+	// gathering before leaving town.
+	SemiSynchronized
+	// Synchronized bots wait for each other after every phase.
+	Synchronized
+	// HyperSynchronized group never splits: every phase ends with barrier
+	// of all members, quorums are raised to barriers.
+	HyperSynchronized
+)
+
+func (l Level) String() string {
+	if int(l) >= 0 && int(l) < len(config.Levels) {
+		return config.Levels[l]
+	}
+
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// ParseLevel returns level by its config name.
+func ParseLevel(name string) (Level, error) {
+	index := slices.Index(config.Levels, name)
+	if index < 0 {
+		return Independent, fmt.Errorf("unknown coordination level %q", name)
+	}
+
+	return Level(index), nil
+}
+
+// SyncKind is how members wait for each other at end of phase.
+type SyncKind int
+
+const (
+	// NoSync lets member go on at once.
+	NoSync SyncKind = iota
+	// Barrier waits for all members.
+	Barrier
+	// Quorum waits for given number of members, late ones go on as soon
+	// as they finish.
+	Quorum
+)
+
+// Member is bot taking part in scenario.
+type Member struct {
+	Name string
+	Role string
+	// Agent is nil for members which steps don't need game primitives.
+	Agent      *agent.Agent
+	Blackboard *behavior.Blackboard
+}
+
+// NewMember returns member for agent, blackboard of agent is used by its
+// steps.
+func NewMember(role string, a *agent.Agent) Member {
+	return Member{
+		Name:       a.Name,
+		Role:       role,
+		Agent:      a,
+		Blackboard: a.Blackboard,
+	}
+}
+
+// Step builds behavior of member for phase. It is called every time
+// member enters phase, so nodes don't keep state between rounds.
+type Step func(member Member) behavior.Node
+
+// Phase is part of scenario all members go through, like travel or farm.
+// Roles without step pass phase at once.
+type Phase struct {
+	Name  string
+	Steps map[string]Step
+	Sync  SyncKind
+	// Quorum is number of members Quorum sync waits for.
+	Quorum int
+	// Meet marks phase which keeps its sync on semi synchronized level.
+	Meet bool
+}
+
+// Scenario is plan of group: roles of members and phases they go through.
+type Scenario struct {
+	Name   string
+	Roles  []string
+	Phases []Phase
+	// Loop starts first phase again after last one.
+	Loop bool
+}
+
+// needed returns number of members phase waits for on level.
+func (s *Scenario) needed(phase Phase, level Level, members int) int {
+	switch {
+	case level == Independent:
+		return 0
+	case level == SemiSynchronized && !phase.Meet:
+		return 0
+	case level == HyperSynchronized:
+		return members
+	}
+
+	switch phase.Sync {
+	case Barrier:
+		return members
+	case Quorum:
+		return min(phase.Quorum, members)
+	case NoSync:
+		return 0
+	default:
+		return members
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, level := range []Level{
+		Independent, SemiSynchronized, Synchronized, HyperSynchronized,
+	} {
+		parsed, err := ParseLevel(level.String())
+		require.NoError(t, err)
+		require.Equal(t, level, parsed)
+	}
+
+	require.Equal(t, "semi_synchronized", SemiSynchronized.String())
+	require.Equal(t, "Level(7)", Level(7).String())
+	_, err := ParseLevel("chaotic")
+	require.Error(t, err)
+}
+
+func TestScenario_Needed(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  Level
+		phase  Phase
+		needed int
+	}{
+		{
+			name:   "independent",
+			level:  Independent,
+			phase:  Phase{Sync: Barrier, Meet: true}, //nolint:exhaustruct
+			needed: 0,
+		},
+		{
+			name:   "semi synchronized meeting",
+			level:  SemiSynchronized,
+			phase:  Phase{Sync: Quorum, Quorum: 2, Meet: true}, //nolint:exhaustruct
+			needed: 2,
+		},
+		{
+			name:   "semi synchronized",
+			level:  SemiSynchronized,
+			phase:  Phase{Sync: Barrier}, //nolint:exhaustruct
+			needed: 0,
+		},
+		{
+			name:   "synchronized barrier",
+			level:  Synchronized,
+			phase:  Phase{Sync: Barrier}, //nolint:exhaustruct
+			needed: 4,
+		},
+		{
+			name:   "synchronized quorum",
+			level:  Synchronized,
+			phase:  Phase{Sync: Quorum, Quorum: 3}, //nolint:exhaustruct
+			needed: 3,
+		},
+		{
+			name:   "synchronized no sync",
+			level:  Synchronized,
+			phase:  Phase{Sync: NoSync}, //nolint:exhaustruct
+			needed: 0,
+		},
+		{
+			name:   "hyper synchronized",
+			level:  HyperSynchronized,
+			phase:  Phase{Sync: NoSync}, //nolint:exhaustruct
+			needed: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var scenario Scenario
+			require.Equal(t, test.needed,
+				scenario.needed(test.phase, test.level, 4))
+		})
+	}
+}
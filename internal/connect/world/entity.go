@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import "time"
+
+type Kind int
+
+const (
+	KindPlayer Kind = iota
+	KindNpc
+	KindItem
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindPlayer:
+		return "Player"
+	case KindNpc:
+		return "Npc"
+	case KindItem:
+		return "Item"
+	default:
+		return "Unknown"
+	}
+}
+
+// Entity is any object known to world model. Entities returned by World are
+// copies and don't change after packets arrive.
+type Entity interface {
+	ID() int32
+	Kind() Kind
+	// At returns position of entity at given time, moving entities are
+	// interpolated along their way.
+	At(now time.Time) Position
+}
+
+// Creature is common part of players and npcs.
+type Creature struct {
+	ObjectID int32
+	Name     string
+	Title    string
+	Position Position
+	Heading  int32
+	// Motion is nil if creature isn't moving.
+	Motion *Motion
+
+	Running         bool
+	RunSpeed        int32
+	WalkSpeed       int32
+	MoveMultiplier  float64
+	CollisionRadius float64
+	CollisionHeight float64
+
+	Level int32
+	CurHP int32
+	MaxHP int32
+	CurMP int32
+	MaxMP int32
+	CurCP int32
+	MaxCP int32
+}
+
+func (c Creature) ID() int32 {
+	return c.ObjectID
+}
+
+func (c Creature) At(now time.Time) Position {
+	if c.Motion == nil {
+		return c.Position
+	}
+
+	return c.Motion.At(now)
+}
+
+// Moving reports if creature still moves at given time.
+func (c Creature) Moving(now time.Time) bool {
+	return c.Motion != nil && !c.Motion.Arrived(now)
+}
+
+// Speed returns current movement speed in game units per second.
+func (c Creature) Speed() float64 {
+	speed := c.WalkSpeed
+	if c.Running {
+		speed = c.RunSpeed
+	}
+	multiplier := c.MoveMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	return float64(speed) * multiplier
+}
+
+type Player struct {
+	Creature
+
+	Race    int32
+	Sex     int32
+	ClassID int32
+	// Self is true for character controlled by bot.
+	Self bool
+}
+
+func (p Player) Kind() Kind {
+	return KindPlayer
+}
+
+type Npc struct {
+	Creature
+
+	TemplateID int32
+	Attackable bool
+}
+
+func (n Npc) Kind() Kind {
+	return KindNpc
+}
+
+// Item is item lying on the ground.
+type Item struct {
+	ObjectID  int32
+	ItemID    int32
+	Count     int32
+	Stackable bool
+	Position  Position
+	// DropperID is zero if item spawned without dropper.
+	DropperID int32
+}
+
+func (i Item) ID() int32 {
+	return i.ObjectID
+}
+
+func (i Item) Kind() Kind {
+	return KindItem
+}
+
+func (i Item) At(_ time.Time) Position {
+	return i.Position
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKind_String(t *testing.T) {
+	require.Equal(t, "Player", KindPlayer.String())
+	require.Equal(t, "Npc", KindNpc.String())
+	require.Equal(t, "Item", KindItem.String())
+	require.Equal(t, "Unknown", Kind(42).String())
+}
+
+func TestCreature_Speed(t *testing.T) {
+	creature := Creature{} //nolint:exhaustruct
+	creature.RunSpeed, creature.WalkSpeed = 120, 80
+	require.InDelta(t, 80, creature.Speed(), 1e-9)
+
+	creature.Running = true
+	require.InDelta(t, 120, creature.Speed(), 1e-9)
+
+	creature.MoveMultiplier = 1.5
+	require.InDelta(t, 180, creature.Speed(), 1e-9)
+}
+
+func TestCreature_At(t *testing.T) {
+	start := time.Unix(1000, 0)
+	creature := Creature{} //nolint:exhaustruct
+	creature.Position = Position{X: 5, Y: 5, Z: 5}
+	require.Equal(t, creature.Position, creature.At(start))
+	require.False(t, creature.Moving(start))
+
+	creature.Motion = &Motion{
+		From:    Position{X: 0, Y: 0, Z: 0},
+		To:      Position{X: 100, Y: 0, Z: 0},
+		Started: start,
+		Speed:   100,
+	}
+	later := start.Add(500 * time.Millisecond)
+	require.Equal(t, Position{X: 50, Y: 0, Z: 0}, creature.At(later))
+	require.True(t, creature.Moving(later))
+	require.False(t, creature.Moving(start.Add(time.Second)))
+}
+
+func TestEntities_Kind(t *testing.T) {
+	entities := []Entity{
+		Player{}, //nolint:exhaustruct
+		Npc{},    //nolint:exhaustruct
+		Item{},   //nolint:exhaustruct
+	}
+	kinds := make([]Kind, 0, len(entities))
+	for _, entity := range entities {
+		kinds = append(kinds, entity.Kind())
+	}
+	require.Equal(t, []Kind{KindPlayer, KindNpc, KindItem}, kinds)
+
+	item := Item{} //nolint:exhaustruct
+	item.ObjectID = 3
+	item.Position = Position{X: 1, Y: 2, Z: 3}
+	require.Equal(t, int32(3), item.ID())
+	require.Equal(t, item.Position, item.At(time.Now()))
+}
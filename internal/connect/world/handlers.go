@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+)
+
+// Register subscribes world to game server packets it is built from.
+func (w *World) Register(dispatcher *dispatch.Dispatcher) {
+	dispatcher.Handle(fromgameserver.UserInfoID, w.handleUserInfo)
+	dispatcher.Handle(fromgameserver.StatusUpdateID, w.handleStatusUpdate)
+	dispatcher.Handle(fromgameserver.CharInfoID, w.handleCharInfo)
+	dispatcher.Handle(fromgameserver.NpcInfoID, w.handleNpcInfo)
+	dispatcher.Handle(fromgameserver.SpawnItemID, w.handleSpawnItem)
+	dispatcher.Handle(fromgameserver.DropItemID, w.handleDropItem)
+	dispatcher.Handle(fromgameserver.DeleteObjectID, w.handleDeleteObject)
+	dispatcher.Handle(fromgameserver.MoveToLocationID, w.handleMoveToLocation)
+	dispatcher.Handle(fromgameserver.StopMoveID, w.handleStopMove)
+	dispatcher.Handle(fromgameserver.ValidateLocationID,
+		w.handleValidateLocation)
+}
+
+func (w *World) handleUserInfo(data []byte) error {
+	info, err := fromgameserver.NewUserInfoFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// Other character of account was selected, its entry is stale.
+	if w.selfID != 0 && w.selfID != info.ObjectID {
+		delete(w.players, w.selfID)
+	}
+
+	self, ok := w.players[info.ObjectID]
+	if !ok {
+		self = &Player{} //nolint:exhaustruct
+		// Characters run after entering world.
+		self.Running = true
+		w.players[info.ObjectID] = self
+	}
+	w.selfID = info.ObjectID
+
+	self.ObjectID = info.ObjectID
+	self.Name = info.Name
+	self.Position = Position{X: info.X, Y: info.Y, Z: info.Z}
+	self.Heading = info.Heading
+	self.RunSpeed = info.RunSpeed
+	self.WalkSpeed = info.WalkSpeed
+	self.MoveMultiplier = info.MoveMultiplier
+	self.CollisionRadius = info.CollisionRadius
+	self.CollisionHeight = info.CollisionHeight
+	self.Level = info.Level
+	self.CurHP, self.MaxHP = info.CurHP, info.MaxHP
+	self.CurMP, self.MaxMP = info.CurMP, info.MaxMP
+	self.Race, self.Sex, self.ClassID = info.Race, info.Sex, info.ClassID
+	self.Self = true
+
+	return nil
+}
+
+func (w *World) handleStatusUpdate(data []byte) error {
+	update, err := fromgameserver.NewStatusUpdateFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	creature := w.creature(update.ObjectID)
+	if creature == nil {
+		return nil
+	}
+
+	fields := map[int32]*int32{
+		fromgameserver.StatusLevel: &creature.Level,
+		fromgameserver.StatusCurHP: &creature.CurHP,
+		fromgameserver.StatusMaxHP: &creature.MaxHP,
+		fromgameserver.StatusCurMP: &creature.CurMP,
+		fromgameserver.StatusMaxMP: &creature.MaxMP,
+		fromgameserver.StatusCurCP: &creature.CurCP,
+		fromgameserver.StatusMaxCP: &creature.MaxCP,
+	}
+	for _, attribute := range update.Attributes {
+		if field, ok := fields[attribute.ID]; ok {
+			*field = attribute.Value
+		}
+	}
+
+	return nil
+}
+
+func (w *World) handleCharInfo(data []byte) error {
+	info, err := fromgameserver.NewCharInfoFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	player := &Player{
+		Creature: Creature{
+			ObjectID:        info.ObjectID,
+			Name:            info.Name,
+			Title:           info.Title,
+			Position:        Position{X: info.X, Y: info.Y, Z: info.Z},
+			Heading:         info.Heading,
+			Motion:          nil,
+			Running:         info.Running != 0,
+			RunSpeed:        info.RunSpeed,
+			WalkSpeed:       info.WalkSpeed,
+			MoveMultiplier:  info.MoveMultiplier,
+			CollisionRadius: info.CollisionRadius,
+			CollisionHeight: info.CollisionHeight,
+			Level:           0,
+			CurHP:           0,
+			MaxHP:           0,
+			CurMP:           0,
+			MaxMP:           0,
+			CurCP:           0,
+			MaxCP:           0,
+		},
+		Race:    info.Race,
+		Sex:     info.Sex,
+		ClassID: info.ClassID,
+		Self:    false,
+	}
+	if known, ok := w.players[info.ObjectID]; ok {
+		// Status and motion come from other packets, keep them.
+		player.Motion = known.Motion
+		player.Level = known.Level
+		player.CurHP, player.MaxHP = known.CurHP, known.MaxHP
+		player.CurMP, player.MaxMP = known.CurMP, known.MaxMP
+		player.CurCP, player.MaxCP = known.CurCP, known.MaxCP
+	}
+	w.players[info.ObjectID] = player
+
+	return nil
+}
+
+func (w *World) handleNpcInfo(data []byte) error {
+	info, err := fromgameserver.NewNpcInfoFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	npc := &Npc{
+		Creature: Creature{
+			ObjectID:        info.ObjectID,
+			Name:            info.Name,
+			Title:           info.Title,
+			Position:        Position{X: info.X, Y: info.Y, Z: info.Z},
+			Heading:         info.Heading,
+			Motion:          nil,
+			Running:         info.Running != 0,
+			RunSpeed:        info.RunSpeed,
+			WalkSpeed:       info.WalkSpeed,
+			MoveMultiplier:  info.MoveMultiplier,
+			CollisionRadius: info.CollisionRadius,
+			CollisionHeight: info.CollisionHeight,
+			Level:           0,
+			CurHP:           0,
+			MaxHP:           0,
+			CurMP:           0,
+			MaxMP:           0,
+			CurCP:           0,
+			MaxCP:           0,
+		},
+		TemplateID: info.TemplateID(),
+		Attackable: info.Attackable != 0,
+	}
+	if known, ok := w.npcs[info.ObjectID]; ok {
+		npc.Motion = known.Motion
+		npc.CurHP, npc.MaxHP = known.CurHP, known.MaxHP
+		npc.CurMP, npc.MaxMP = known.CurMP, known.MaxMP
+	}
+	w.npcs[info.ObjectID] = npc
+
+	return nil
+}
+
+func (w *World) handleSpawnItem(data []byte) error {
+	spawn, err := fromgameserver.NewSpawnItemFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.putItem(&Item{
+		ObjectID:  spawn.ObjectID,
+		ItemID:    spawn.ItemID,
+		Count:     spawn.Count,
+		Stackable: spawn.Stackable != 0,
+		Position:  Position{X: spawn.X, Y: spawn.Y, Z: spawn.Z},
+		DropperID: 0,
+	})
+
+	return nil
+}
+
+func (w *World) handleDropItem(data []byte) error {
+	drop, err := fromgameserver.NewDropItemFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.putItem(&Item{
+		ObjectID:  drop.ObjectID,
+		ItemID:    drop.ItemID,
+		Count:     drop.Count,
+		Stackable: drop.Stackable != 0,
+		Position:  Position{X: drop.X, Y: drop.Y, Z: drop.Z},
+		DropperID: drop.DropperID,
+	})
+
+	return nil
+}
+
+func (w *World) putItem(item *Item) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.items[item.ObjectID] = item
+}
+
+func (w *World) handleDeleteObject(data []byte) error {
+	deleted, err := fromgameserver.NewDeleteObjectFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.remove(deleted.ObjectID)
+
+	return nil
+}
+
+func (w *World) handleMoveToLocation(data []byte) error {
+	move, err := fromgameserver.NewMoveToLocationFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	creature := w.creature(move.ObjectID)
+	if creature == nil {
+		return nil
+	}
+
+	from := Position{X: move.X, Y: move.Y, Z: move.Z}
+	creature.Position = from
+	creature.Motion = &Motion{
+		From:    from,
+		To:      Position{X: move.DestX, Y: move.DestY, Z: move.DestZ},
+		Started: w.now(),
+		Speed:   creature.Speed(),
+	}
+
+	return nil
+}
+
+func (w *World) handleStopMove(data []byte) error {
+	stop, err := fromgameserver.NewStopMoveFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	creature := w.creature(stop.ObjectID)
+	if creature == nil {
+		return nil
+	}
+
+	creature.Position = Position{X: stop.X, Y: stop.Y, Z: stop.Z}
+	creature.Heading = stop.Heading
+	creature.Motion = nil
+
+	return nil
+}
+
+// handleValidateLocation corrects position, creature keeps moving to the
+// same destination from corrected position.
+func (w *World) handleValidateLocation(data []byte) error {
+	validate, err := fromgameserver.NewValidateLocationFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	creature := w.creature(validate.ObjectID)
+	if creature == nil {
+		return nil
+	}
+
+	now := w.now()
+	position := Position{X: validate.X, Y: validate.Y, Z: validate.Z}
+	creature.Position = position
+	creature.Heading = validate.Heading
+	if creature.Moving(now) {
+		creature.Motion = &Motion{
+			From:    position,
+			To:      creature.Motion.To,
+			Started: now,
+			Speed:   creature.Motion.Speed,
+		}
+	} else {
+		creature.Motion = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/melg8/connect/internal/connect/crypt"
+	"github.com/melg8/connect/internal/connect/dispatch"
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/melg8/connect/internal/connect/packets/packet"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	selfID   = 100
+	playerID = 200
+	npcID    = 300
+	itemID   = 400
+)
+
+type testClock struct {
+	time time.Time
+}
+
+func (c *testClock) now() time.Time {
+	return c.time
+}
+
+func (c *testClock) advance(duration time.Duration) {
+	c.time = c.time.Add(duration)
+}
+
+type fedWorld struct {
+	t          *testing.T
+	world      *World
+	dispatcher *dispatch.Dispatcher
+	clock      *testClock
+}
+
+func newFedWorld(t *testing.T) *fedWorld {
+	t.Helper()
+
+	clock := &testClock{time: time.Unix(1000, 0)}
+	world := New()
+	world.SetClock(clock.now)
+	dispatcher := dispatch.NewDispatcher()
+	world.Register(dispatcher)
+
+	return &fedWorld{
+		t:          t,
+		world:      world,
+		dispatcher: dispatcher,
+		clock:      clock,
+	}
+}
+
+func (f *fedWorld) feed(id byte, p crypt.Serializable) {
+	f.t.Helper()
+
+	writer := packet.NewWriter()
+	require.NoError(f.t, p.ToBytes(writer))
+	require.NoError(f.t, f.dispatcher.Dispatch(id, writer.Bytes()))
+}
+
+func userInfo(x, y int32) *fromgameserver.UserInfo {
+	info := &fromgameserver.UserInfo{} //nolint:exhaustruct
+	info.ObjectID = selfID
+	info.Name = "Self"
+	info.X, info.Y = x, y
+	info.RunSpeed, info.WalkSpeed = 100, 50
+	info.MoveMultiplier = 1
+	info.CurHP, info.MaxHP = 50, 100
+
+	return info
+}
+
+func charInfo(x, y int32) *fromgameserver.CharInfo {
+	info := &fromgameserver.CharInfo{} //nolint:exhaustruct
+	info.ObjectID = playerID
+	info.Name = "Other"
+	info.X, info.Y = x, y
+	info.RunSpeed, info.WalkSpeed = 100, 50
+	info.Running = 1
+
+	return info
+}
+
+func npcInfo(x, y int32) *fromgameserver.NpcInfo {
+	info := &fromgameserver.NpcInfo{} //nolint:exhaustruct
+	info.ObjectID = npcID
+	info.NpcTypeID = fromgameserver.NpcTypeIDOffset + 20432
+	info.Attackable = 1
+	info.Name = "Elpy"
+	info.X, info.Y = x, y
+	info.RunSpeed, info.WalkSpeed = 160, 40
+
+	return info
+}
+
+func TestWorld_UserInfo(t *testing.T) {
+	fed := newFedWorld(t)
+	_, ok := fed.world.Self()
+	require.False(t, ok)
+
+	fed.feed(fromgameserver.UserInfoID, userInfo(10, 20))
+
+	self, ok := fed.world.Self()
+	require.True(t, ok)
+	require.True(t, self.Self)
+	require.True(t, self.Running)
+	require.Equal(t, "Self", self.Name)
+	require.Equal(t, Position{X: 10, Y: 20, Z: 0}, self.Position)
+	require.Equal(t, int32(50), self.CurHP)
+}
+
+func TestWorld_UserInfoOfOtherCharacter(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(10, 20))
+
+	other := userInfo(30, 40)
+	other.ObjectID = selfID + 1
+	fed.feed(fromgameserver.UserInfoID, other)
+
+	_, ok := fed.world.ByObjectID(selfID)
+	require.False(t, ok)
+
+	self, ok := fed.world.Self()
+	require.True(t, ok)
+	require.Equal(t, int32(selfID+1), self.ObjectID)
+	require.Equal(t, Position{X: 30, Y: 40, Z: 0}, self.Position)
+}
+
+func TestWorld_StatusUpdate(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 0))
+
+	fed.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: selfID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurHP, Value: 75},
+			{ID: fromgameserver.StatusCurCP, Value: 30},
+			{ID: fromgameserver.StatusSP, Value: 1},
+		},
+	})
+	fed.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: npcID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusMaxHP, Value: 80},
+		},
+	})
+	fed.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID:   999,
+		Attributes: nil,
+	})
+
+	self, _ := fed.world.Self()
+	require.Equal(t, int32(75), self.CurHP)
+	require.Equal(t, int32(30), self.CurCP)
+
+	npc, ok := fed.world.Npc(npcID)
+	require.True(t, ok)
+	require.Equal(t, int32(80), npc.MaxHP)
+}
+
+func TestWorld_CharInfoKeepsStatus(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.CharInfoID, charInfo(1, 2))
+	fed.feed(fromgameserver.StatusUpdateID, &fromgameserver.StatusUpdate{
+		ObjectID: playerID,
+		Attributes: []fromgameserver.StatusAttribute{
+			{ID: fromgameserver.StatusCurHP, Value: 10},
+		},
+	})
+	fed.feed(fromgameserver.CharInfoID, charInfo(3, 4))
+
+	player, ok := fed.world.Player(playerID)
+	require.True(t, ok)
+	require.False(t, player.Self)
+	require.True(t, player.Running)
+	require.Equal(t, int32(10), player.CurHP)
+	require.Equal(t, Position{X: 3, Y: 4, Z: 0}, player.Position)
+}
+
+func TestWorld_NpcInfo(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(5, 6))
+
+	npc, ok := fed.world.Npc(npcID)
+	require.True(t, ok)
+	require.Equal(t, int32(20432), npc.TemplateID)
+	require.True(t, npc.Attackable)
+	require.False(t, npc.Running)
+	require.InDelta(t, 40, npc.Speed(), 1e-9)
+}
+
+func TestWorld_ItemsAndDelete(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.SpawnItemID, &fromgameserver.SpawnItem{
+		ObjectID:  itemID,
+		ItemID:    57,
+		X:         1,
+		Y:         2,
+		Z:         3,
+		Stackable: 1,
+		Count:     10,
+	})
+	fed.feed(fromgameserver.DropItemID, &fromgameserver.DropItem{
+		DropperID: npcID,
+		ObjectID:  itemID + 1,
+		ItemID:    1060,
+		X:         4,
+		Y:         5,
+		Z:         6,
+		Stackable: 0,
+		Count:     1,
+	})
+
+	spawned, ok := fed.world.Item(itemID)
+	require.True(t, ok)
+	require.True(t, spawned.Stackable)
+	require.Equal(t, int32(10), spawned.Count)
+
+	dropped, ok := fed.world.Item(itemID + 1)
+	require.True(t, ok)
+	require.Equal(t, int32(npcID), dropped.DropperID)
+
+	fed.feed(fromgameserver.DeleteObjectID,
+		&fromgameserver.DeleteObject{ObjectID: itemID})
+	_, ok = fed.world.Item(itemID)
+	require.False(t, ok)
+}
+
+func TestWorld_Movement(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.CharInfoID, charInfo(0, 0))
+
+	fed.feed(fromgameserver.MoveToLocationID, &fromgameserver.MoveToLocation{
+		ObjectID: playerID,
+		DestX:    1000,
+		DestY:    0,
+		DestZ:    0,
+		X:        0,
+		Y:        0,
+		Z:        0,
+	})
+
+	fed.clock.advance(2 * time.Second)
+	position := func() Position {
+		entity, ok := fed.world.ByObjectID(playerID)
+		require.True(t, ok)
+
+		return entity.At(fed.world.Now())
+	}
+	require.Equal(t, Position{X: 200, Y: 0, Z: 0}, position())
+
+	// Server corrects position, movement continues from there.
+	fed.feed(fromgameserver.ValidateLocationID,
+		&fromgameserver.ValidateLocation{
+			ObjectID: playerID,
+			X:        150,
+			Y:        0,
+			Z:        0,
+			Heading:  0,
+		})
+	fed.clock.advance(time.Second)
+	require.Equal(t, Position{X: 250, Y: 0, Z: 0}, position())
+
+	fed.feed(fromgameserver.StopMoveID, &fromgameserver.StopMove{
+		ObjectID: playerID,
+		X:        260,
+		Y:        0,
+		Z:        0,
+		Heading:  100,
+	})
+	fed.clock.advance(time.Second)
+	require.Equal(t, Position{X: 260, Y: 0, Z: 0}, position())
+
+	player, _ := fed.world.Player(playerID)
+	require.Nil(t, player.Motion)
+	require.Equal(t, int32(100), player.Heading)
+}
+
+func TestWorld_ValidateLocationWhenStanding(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 0))
+	fed.feed(fromgameserver.ValidateLocationID,
+		&fromgameserver.ValidateLocation{
+			ObjectID: npcID,
+			X:        5,
+			Y:        5,
+			Z:        5,
+			Heading:  1,
+		})
+
+	npc, _ := fed.world.Npc(npcID)
+	require.Nil(t, npc.Motion)
+	require.Equal(t, Position{X: 5, Y: 5, Z: 5}, npc.Position)
+}
+
+func TestWorld_MovementOfUnknownObject(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.MoveToLocationID,
+		&fromgameserver.MoveToLocation{}) //nolint:exhaustruct
+	fed.feed(fromgameserver.StopMoveID,
+		&fromgameserver.StopMove{}) //nolint:exhaustruct
+	fed.feed(fromgameserver.ValidateLocationID,
+		&fromgameserver.ValidateLocation{}) //nolint:exhaustruct
+
+	_, ok := fed.world.ByObjectID(0)
+	require.False(t, ok)
+}
+
+func TestWorld_BrokenPackets(t *testing.T) {
+	fed := newFedWorld(t)
+	ids := []byte{
+		fromgameserver.UserInfoID,
+		fromgameserver.StatusUpdateID,
+		fromgameserver.CharInfoID,
+		fromgameserver.NpcInfoID,
+		fromgameserver.SpawnItemID,
+		fromgameserver.DropItemID,
+		fromgameserver.DeleteObjectID,
+		fromgameserver.MoveToLocationID,
+		fromgameserver.StopMoveID,
+		fromgameserver.ValidateLocationID,
+	}
+
+	for _, id := range ids {
+		require.Error(t, fed.dispatcher.Dispatch(id, []byte{0x01}))
+	}
+}
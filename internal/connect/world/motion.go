@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"math"
+	"time"
+)
+
+// Motion is straight movement from one position to another with constant
+// speed in game units per second. Height changes linearly along the way.
+type Motion struct {
+	From    Position
+	To      Position
+	Started time.Time
+	Speed   float64
+}
+
+// Duration returns time needed to reach destination.
+func (m Motion) Duration() time.Duration {
+	distance := m.From.Distance(m.To)
+	if distance == 0 || m.Speed <= 0 {
+		return 0
+	}
+
+	return time.Duration(distance / m.Speed * float64(time.Second))
+}
+
+// Arrived reports if destination is reached at given time.
+func (m Motion) Arrived(now time.Time) bool {
+	return !now.Before(m.Started.Add(m.Duration()))
+}
+
+// At returns position on the way at given time.
+func (m Motion) At(now time.Time) Position {
+	duration := m.Duration()
+	if duration == 0 || !now.Before(m.Started.Add(duration)) {
+		return m.To
+	}
+
+	elapsed := now.Sub(m.Started)
+	if elapsed <= 0 {
+		return m.From
+	}
+
+	fraction := float64(elapsed) / float64(duration)
+
+	return Position{
+		X: interpolate(m.From.X, m.To.X, fraction),
+		Y: interpolate(m.From.Y, m.To.Y, fraction),
+		Z: interpolate(m.From.Z, m.To.Z, fraction),
+	}
+}
+
+func interpolate(from, to int32, fraction float64) int32 {
+	delta := (float64(to) - float64(from)) * fraction
+
+	return from + int32(math.Round(delta))
+}
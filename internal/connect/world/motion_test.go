@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMotion_At(t *testing.T) {
+	start := time.Unix(1000, 0)
+	motion := Motion{
+		From:    Position{X: 0, Y: 0, Z: 0},
+		To:      Position{X: 300, Y: 400, Z: -100},
+		Started: start,
+		Speed:   100,
+	}
+	require.Equal(t, 5*time.Second, motion.Duration())
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		expected Position
+		arrived  bool
+	}{
+		{
+			name:     "before start",
+			elapsed:  -time.Second,
+			expected: Position{X: 0, Y: 0, Z: 0},
+			arrived:  false,
+		},
+		{
+			name:     "on the way",
+			elapsed:  time.Second,
+			expected: Position{X: 60, Y: 80, Z: -20},
+			arrived:  false,
+		},
+		{
+			name:     "arrived",
+			elapsed:  5 * time.Second,
+			expected: Position{X: 300, Y: 400, Z: -100},
+			arrived:  true,
+		},
+		{
+			name:     "after arrival",
+			elapsed:  time.Minute,
+			expected: Position{X: 300, Y: 400, Z: -100},
+			arrived:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := start.Add(test.elapsed)
+			require.Equal(t, test.expected, motion.At(now))
+			require.Equal(t, test.arrived, motion.Arrived(now))
+		})
+	}
+}
+
+func TestMotion_WithoutSpeed(t *testing.T) {
+	motion := Motion{
+		From:    Position{X: 0, Y: 0, Z: 0},
+		To:      Position{X: 10, Y: 0, Z: 0},
+		Started: time.Unix(1000, 0),
+		Speed:   0,
+	}
+	require.Equal(t, time.Duration(0), motion.Duration())
+	require.Equal(t, motion.To, motion.At(motion.Started))
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+)
+
+const objectIDLen = 4
+
+// entityPackets maps ids of packets broadcast to every client which sees
+// object to offset of object id in their body. StatusUpdate isn't here,
+// server sends it only to clients interested in creature.
+var entityPackets = map[byte]int{
+	fromgameserver.CharInfoID:         16,
+	fromgameserver.NpcInfoID:          0,
+	fromgameserver.SpawnItemID:        0,
+	fromgameserver.DropItemID:         4,
+	fromgameserver.DeleteObjectID:     0,
+	fromgameserver.MoveToLocationID:   0,
+	fromgameserver.StopMoveID:         0,
+	fromgameserver.ValidateLocationID: 0,
+}
+
+// EntityObjectID returns object id of broadcast entity packet without
+// decoding it. False is returned for other packets and for packets too short
+// to contain object id.
+func EntityObjectID(id byte, data []byte) (int32, bool) {
+	offset, ok := entityPackets[id]
+	if !ok || len(data) < offset+objectIDLen {
+		return 0, false
+	}
+
+	return int32(binary.LittleEndian.Uint32(data[offset:])), true //nolint:gosec
+}
+
+// Overlay is View which shows own character of bot from its world and
+// everything else from shared world, filled by packets of other bots.
+type Overlay struct {
+	own    *World
+	shared func() *World
+}
+
+func NewOverlay(own *World, shared func() *World) *Overlay {
+	return &Overlay{own: own, shared: shared}
+}
+
+func (o *Overlay) Now() time.Time {
+	return o.own.Now()
+}
+
+func (o *Overlay) Self() (Player, bool) {
+	return o.own.Self()
+}
+
+func (o *Overlay) ByObjectID(id int32) (Entity, bool) {
+	if id == o.own.SelfID() {
+		return o.own.ByObjectID(id)
+	}
+
+	entity, ok := o.shared().ByObjectID(id)
+	if player, isPlayer := entity.(Player); isPlayer {
+		player.Self = false
+		entity = player
+	}
+
+	return entity, ok
+}
+
+func (o *Overlay) PlayerByName(name string) (Player, bool) {
+	if self, ok := o.own.Self(); ok && strings.EqualFold(self.Name, name) {
+		return self, true
+	}
+
+	player, ok := o.shared().PlayerByName(name)
+	if !ok || player.ObjectID == o.own.SelfID() {
+		return Player{}, false //nolint:exhaustruct
+	}
+	player.Self = false
+
+	return player, true
+}
+
+func (o *Overlay) Nearby(radius float64) []Entity {
+	self, ok := o.own.Self()
+	if !ok {
+		return nil
+	}
+
+	return o.Around(self.At(o.own.Now()), radius)
+}
+
+func (o *Overlay) Around(center Position, radius float64) []Entity {
+	return o.shared().AroundExcept(center, radius, o.own.SelfID())
+}
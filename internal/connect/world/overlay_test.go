@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityObjectID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       byte
+		data     []byte
+		objectID int32
+		ok       bool
+	}{
+		{
+			name:     "object id first",
+			id:       fromgameserver.NpcInfoID,
+			data:     []byte{0x01, 0x02, 0x00, 0x00},
+			objectID: 0x0201,
+			ok:       true,
+		},
+		{
+			name:     "object id after dropper",
+			id:       fromgameserver.DropItemID,
+			data:     []byte{0x09, 0, 0, 0, 0x05, 0, 0, 0},
+			objectID: 5,
+			ok:       true,
+		},
+		{
+			name:     "too short",
+			id:       fromgameserver.CharInfoID,
+			data:     make([]byte, 19),
+			objectID: 0,
+			ok:       false,
+		},
+		{
+			name:     "not entity packet",
+			id:       fromgameserver.UserInfoID,
+			data:     make([]byte, 64),
+			objectID: 0,
+			ok:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objectID, ok := EntityObjectID(test.id, test.data)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.objectID, objectID)
+		})
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	shared := newFedWorld(t)
+	shared.feed(fromgameserver.NpcInfoID, npcInfo(0, 50))
+	other := charInfo(0, 0)
+	other.ObjectID = selfID
+	shared.feed(fromgameserver.CharInfoID, other)
+	shared.feed(fromgameserver.CharInfoID, charInfo(0, 100))
+
+	own := newFedWorld(t)
+	overlay := NewOverlay(own.world, func() *World { return shared.world })
+	require.Nil(t, overlay.Nearby(500))
+
+	own.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	require.Equal(t, own.world.Now(), overlay.Now())
+
+	self, ok := overlay.Self()
+	require.True(t, ok)
+	require.True(t, self.Self)
+
+	entity, ok := overlay.ByObjectID(selfID)
+	require.True(t, ok)
+	require.Equal(t, "Self", entity.(Player).Name) //nolint:forcetypeassert
+
+	entity, ok = overlay.ByObjectID(playerID)
+	require.True(t, ok)
+	require.Equal(t, "Other", entity.(Player).Name) //nolint:forcetypeassert
+
+	nearby := overlay.Nearby(500)
+	require.Len(t, nearby, 2)
+	require.Equal(t, int32(npcID), nearby[0].ID())
+	require.Equal(t, int32(playerID), nearby[1].ID())
+}
+
+func TestOverlay_PlayerByName(t *testing.T) {
+	shared := newFedWorld(t)
+	shared.feed(fromgameserver.CharInfoID, charInfo(0, 100))
+	own := newFedWorld(t)
+	own.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	overlay := NewOverlay(own.world, func() *World { return shared.world })
+
+	self, ok := overlay.PlayerByName("self")
+	require.True(t, ok)
+	require.True(t, self.Self)
+
+	other, ok := overlay.PlayerByName("other")
+	require.True(t, ok)
+	require.False(t, other.Self)
+	require.Equal(t, int32(playerID), other.ObjectID)
+
+	_, ok = own.world.PlayerByName("other")
+	require.False(t, ok)
+	_, ok = overlay.PlayerByName("nobody")
+	require.False(t, ok)
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"fmt"
+	"math"
+)
+
+// Position is point in game world coordinates.
+type Position struct {
+	X int32
+	Y int32
+	Z int32
+}
+
+// Distance returns distance between positions on ground plane, game uses it
+// for most of range checks.
+func (p Position) Distance(other Position) float64 {
+	dx := float64(other.X) - float64(p.X)
+	dy := float64(other.Y) - float64(p.Y)
+
+	return math.Hypot(dx, dy)
+}
+
+// Distance3D returns distance between positions including height.
+func (p Position) Distance3D(other Position) float64 {
+	dz := float64(other.Z) - float64(p.Z)
+
+	return math.Hypot(p.Distance(other), dz)
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d %d %d", p.X, p.Y, p.Z)
+}
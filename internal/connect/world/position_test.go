@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition_Distance(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     Position
+		to       Position
+		expected float64
+		with3D   float64
+	}{
+		{
+			name:     "same point",
+			from:     Position{X: 1, Y: 2, Z: 3},
+			to:       Position{X: 1, Y: 2, Z: 3},
+			expected: 0,
+			with3D:   0,
+		},
+		{
+			name:     "ground",
+			from:     Position{X: 0, Y: 0, Z: 0},
+			to:       Position{X: 3, Y: -4, Z: 0},
+			expected: 5,
+			with3D:   5,
+		},
+		{
+			name:     "height",
+			from:     Position{X: 0, Y: 0, Z: 0},
+			to:       Position{X: 3, Y: 4, Z: 12},
+			expected: 5,
+			with3D:   13,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.InDelta(t, test.expected, test.from.Distance(test.to), 1e-9)
+			require.InDelta(t, test.with3D, test.from.Distance3D(test.to), 1e-9)
+		})
+	}
+}
+
+func TestPosition_String(t *testing.T) {
+	require.Equal(t, "1 -2 3", Position{X: 1, Y: -2, Z: 3}.String())
+}
@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// View is read access to world model.
+type View interface {
+	Now() time.Time
+	Self() (Player, bool)
+	ByObjectID(id int32) (Entity, bool)
+	PlayerByName(name string) (Player, bool)
+	Nearby(radius float64) []Entity
+	Around(center Position, radius float64) []Entity
+}
+
+// World is live model of surroundings of single bot. It is filled by game
+// server packets and is safe for concurrent use.
+type World struct {
+	mutex   sync.RWMutex
+	now     func() time.Time
+	selfID  int32
+	players map[int32]*Player
+	npcs    map[int32]*Npc
+	items   map[int32]*Item
+}
+
+func New() *World {
+	return &World{
+		mutex:   sync.RWMutex{},
+		now:     time.Now,
+		selfID:  0,
+		players: make(map[int32]*Player),
+		npcs:    make(map[int32]*Npc),
+		items:   make(map[int32]*Item),
+	}
+}
+
+// SetClock replaces clock of world, movement is interpolated by it.
+func (w *World) SetClock(now func() time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.now = now
+}
+
+// Now returns current time of world clock.
+func (w *World) Now() time.Time {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.now()
+}
+
+// Clear forgets everything, world is filled again after next EnterWorld.
+func (w *World) Clear() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.selfID = 0
+	clear(w.players)
+	clear(w.npcs)
+	clear(w.items)
+}
+
+// SelfID returns object id of character controlled by bot, it is zero
+// before character enters world.
+func (w *World) SelfID() int32 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.selfID
+}
+
+// Self returns character controlled by bot.
+func (w *World) Self() (Player, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	self, ok := w.players[w.selfID]
+	if !ok || w.selfID == 0 {
+		return Player{}, false //nolint:exhaustruct
+	}
+
+	return *self, true
+}
+
+func (w *World) Player(id int32) (Player, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if player, ok := w.players[id]; ok {
+		return *player, true
+	}
+
+	return Player{}, false //nolint:exhaustruct
+}
+
+// PlayerByName returns player with given name, names of characters are
+// case insensitive in game.
+func (w *World) PlayerByName(name string) (Player, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	for _, player := range w.players {
+		if strings.EqualFold(player.Name, name) {
+			return *player, true
+		}
+	}
+
+	return Player{}, false //nolint:exhaustruct
+}
+
+func (w *World) Npc(id int32) (Npc, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if npc, ok := w.npcs[id]; ok {
+		return *npc, true
+	}
+
+	return Npc{}, false //nolint:exhaustruct
+}
+
+func (w *World) Item(id int32) (Item, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if item, ok := w.items[id]; ok {
+		return *item, true
+	}
+
+	return Item{}, false //nolint:exhaustruct
+}
+
+// ByObjectID returns entity with given object id of any kind.
+func (w *World) ByObjectID(id int32) (Entity, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.byObjectID(id)
+}
+
+func (w *World) byObjectID(id int32) (Entity, bool) {
+	if player, ok := w.players[id]; ok {
+		return *player, true
+	}
+	if npc, ok := w.npcs[id]; ok {
+		return *npc, true
+	}
+	if item, ok := w.items[id]; ok {
+		return *item, true
+	}
+
+	return nil, false
+}
+
+// Nearby returns entities within radius around self sorted by distance,
+// self isn't included. Nothing is returned before self is known.
+func (w *World) Nearby(radius float64) []Entity {
+	self, ok := w.Self()
+	if !ok {
+		return nil
+	}
+
+	return w.Around(self.At(w.Now()), radius)
+}
+
+// Around returns entities within radius around center sorted by distance,
+// self isn't included.
+func (w *World) Around(center Position, radius float64) []Entity {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.around(center, radius, w.selfID)
+}
+
+// AroundExcept returns entities within radius around center sorted by
+// distance, entity with except id isn't included. Self is included as
+// regular player, so other bots can look at world through this one.
+func (w *World) AroundExcept(
+	center Position,
+	radius float64,
+	except int32,
+) []Entity {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.around(center, radius, except)
+}
+
+func (w *World) around(center Position, radius float64, except int32) []Entity {
+	now := w.now()
+	var found []Entity
+	distances := make(map[int32]float64)
+	add := func(entity Entity) {
+		if entity.ID() == except {
+			return
+		}
+		distance := center.Distance(entity.At(now))
+		if distance <= radius {
+			found = append(found, entity)
+			distances[entity.ID()] = distance
+		}
+	}
+
+	for _, player := range w.players {
+		other := *player
+		other.Self = other.ObjectID == except
+		add(other)
+	}
+	for _, npc := range w.npcs {
+		add(*npc)
+	}
+	for _, item := range w.items {
+		add(*item)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		left, right := found[i].ID(), found[j].ID()
+		if distances[left] != distances[right] {
+			return distances[left] < distances[right]
+		}
+
+		return left < right
+	})
+
+	return found
+}
+
+// Remove forgets object with given id.
+func (w *World) Remove(id int32) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.remove(id)
+}
+
+// Import replaces everything except self with copy of entities known to
+// source. Self of source becomes regular player. It is used when bot starts
+// to decode packets after it relied on world model of other bot.
+func (w *World) Import(source *World) {
+	source.mutex.RLock()
+	players := make([]Player, 0, len(source.players))
+	for _, player := range source.players {
+		players = append(players, *player)
+	}
+	npcs := make([]Npc, 0, len(source.npcs))
+	for _, npc := range source.npcs {
+		npcs = append(npcs, *npc)
+	}
+	items := make([]Item, 0, len(source.items))
+	for _, item := range source.items {
+		items = append(items, *item)
+	}
+	source.mutex.RUnlock()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	self := w.players[w.selfID]
+	clear(w.players)
+	clear(w.npcs)
+	clear(w.items)
+	if self != nil {
+		w.players[w.selfID] = self
+	}
+
+	for _, player := range players {
+		if player.ObjectID == w.selfID {
+			continue
+		}
+		player.Self = false
+		w.players[player.ObjectID] = &player
+	}
+	for _, npc := range npcs {
+		w.npcs[npc.ObjectID] = &npc
+	}
+	for _, item := range items {
+		w.items[item.ObjectID] = &item
+	}
+}
+
+// creature returns mutable creature with given object id.
+func (w *World) creature(id int32) *Creature {
+	if player, ok := w.players[id]; ok {
+		return &player.Creature
+	}
+	if npc, ok := w.npcs[id]; ok {
+		return &npc.Creature
+	}
+
+	return nil
+}
+
+func (w *World) remove(id int32) {
+	delete(w.players, id)
+	delete(w.npcs, id)
+	delete(w.items, id)
+	if id == w.selfID {
+		w.selfID = 0
+	}
+}
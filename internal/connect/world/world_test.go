@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2024 Melg Eight <public.melg8@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package world
+
+import (
+	"testing"
+
+	fromgameserver "github.com/melg8/connect/internal/connect/packets/from_game_server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorld_Nearby(t *testing.T) {
+	fed := newFedWorld(t)
+	require.Nil(t, fed.world.Nearby(1000))
+
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.CharInfoID, charInfo(300, 0))
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 100))
+	fed.feed(fromgameserver.SpawnItemID, &fromgameserver.SpawnItem{
+		ObjectID:  itemID,
+		ItemID:    57,
+		X:         0,
+		Y:         -2000,
+		Z:         0,
+		Stackable: 1,
+		Count:     1,
+	})
+
+	ids := func(entities []Entity) []int32 {
+		result := make([]int32, 0, len(entities))
+		for _, entity := range entities {
+			result = append(result, entity.ID())
+		}
+
+		return result
+	}
+
+	require.Equal(t, []int32{npcID, playerID}, ids(fed.world.Nearby(500)))
+	require.Equal(t, []int32{npcID}, ids(fed.world.Nearby(100)))
+	require.Equal(t, []int32{npcID, playerID, itemID},
+		ids(fed.world.Nearby(5000)))
+
+	around := fed.world.Around(Position{X: 0, Y: -2000, Z: 0}, 10)
+	require.Equal(t, []int32{itemID}, ids(around))
+}
+
+func TestWorld_ByObjectID(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 100))
+
+	entity, ok := fed.world.ByObjectID(npcID)
+	require.True(t, ok)
+	require.Equal(t, KindNpc, entity.Kind())
+
+	entity, ok = fed.world.ByObjectID(selfID)
+	require.True(t, ok)
+	require.Equal(t, KindPlayer, entity.Kind())
+
+	_, ok = fed.world.ByObjectID(12345)
+	require.False(t, ok)
+	_, ok = fed.world.Player(12345)
+	require.False(t, ok)
+	_, ok = fed.world.Npc(12345)
+	require.False(t, ok)
+	_, ok = fed.world.Item(12345)
+	require.False(t, ok)
+}
+
+func TestWorld_PlayerByName(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.CharInfoID, charInfo(1, 1))
+
+	player, ok := fed.world.PlayerByName("other")
+	require.True(t, ok)
+	require.Equal(t, int32(playerID), player.ObjectID)
+
+	player, ok = fed.world.PlayerByName("Self")
+	require.True(t, ok)
+	require.True(t, player.Self)
+
+	_, ok = fed.world.PlayerByName("Nobody")
+	require.False(t, ok)
+}
+
+func TestWorld_ReturnsCopies(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+
+	self, _ := fed.world.Self()
+	self.CurHP = 1
+
+	again, _ := fed.world.Self()
+	require.Equal(t, int32(50), again.CurHP)
+}
+
+func TestWorld_Clear(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 100))
+
+	fed.world.Clear()
+	_, ok := fed.world.Self()
+	require.False(t, ok)
+	_, ok = fed.world.ByObjectID(npcID)
+	require.False(t, ok)
+}
+
+func TestWorld_DeleteSelf(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.DeleteObjectID,
+		&fromgameserver.DeleteObject{ObjectID: selfID})
+
+	_, ok := fed.world.Self()
+	require.False(t, ok)
+}
+
+func TestWorld_AroundExcept(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	fed.feed(fromgameserver.CharInfoID, charInfo(100, 0))
+	require.Equal(t, int32(selfID), fed.world.SelfID())
+
+	found := fed.world.AroundExcept(Position{X: 100, Y: 0, Z: 0}, 500,
+		playerID)
+	require.Len(t, found, 1)
+	self, ok := found[0].(Player)
+	require.True(t, ok)
+	require.Equal(t, int32(selfID), self.ObjectID)
+	require.False(t, self.Self)
+}
+
+func TestWorld_Import(t *testing.T) {
+	source := newFedWorld(t)
+	source.feed(fromgameserver.UserInfoID, userInfo(0, 0))
+	source.feed(fromgameserver.NpcInfoID, npcInfo(10, 10))
+	source.feed(fromgameserver.SpawnItemID, &fromgameserver.SpawnItem{
+		ObjectID:  itemID,
+		ItemID:    57,
+		X:         0,
+		Y:         0,
+		Z:         0,
+		Stackable: 1,
+		Count:     1,
+	})
+
+	other := charInfo(50, 50)
+	other.ObjectID = selfID + 1
+	source.feed(fromgameserver.CharInfoID, other)
+
+	target := newFedWorld(t)
+	own := userInfo(50, 50)
+	own.ObjectID = selfID + 1
+	target.feed(fromgameserver.UserInfoID, own)
+	target.feed(fromgameserver.CharInfoID, charInfo(1, 1))
+
+	target.world.Import(source.world)
+
+	self, ok := target.world.Self()
+	require.True(t, ok)
+	require.Equal(t, int32(selfID+1), self.ObjectID)
+	require.Equal(t, "Self", self.Name)
+
+	_, ok = target.world.Player(playerID)
+	require.False(t, ok, "stale entities are replaced")
+
+	imported, ok := target.world.Player(selfID)
+	require.True(t, ok)
+	require.False(t, imported.Self)
+
+	_, ok = target.world.Npc(npcID)
+	require.True(t, ok)
+	_, ok = target.world.Item(itemID)
+	require.True(t, ok)
+}
+
+func TestWorld_Remove(t *testing.T) {
+	fed := newFedWorld(t)
+	fed.feed(fromgameserver.NpcInfoID, npcInfo(0, 100))
+
+	fed.world.Remove(npcID)
+	_, ok := fed.world.Npc(npcID)
+	require.False(t, ok)
+}